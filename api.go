@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"jaredpearson.com/dbweb/command"
+	"jaredpearson.com/dbweb/config"
+	"jaredpearson.com/dbweb/web"
+)
+
+func executeAPICommand(cfg *config.Config, apiCmd *command.Command, apiCheckCmd *command.Command) int {
+	if apiCheckCmd.IsSelected() {
+		if err := loadCatalog(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to load miniatures: %v\n", err)
+			return 1
+		}
+		problems := web.CheckAPI()
+		for _, problem := range problems {
+			fmt.Fprintln(os.Stdout, problem)
+		}
+		fmt.Fprintf(os.Stdout, "%d problems with the API responses\n", len(problems))
+		if len(problems) > 0 {
+			return 1
+		}
+		return 0
+	} else {
+		apiCmd.DisplayUsage()
+		return 1
+	}
+}
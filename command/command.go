@@ -8,22 +8,31 @@ like Docker or Git).
 package command
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 )
 
+// ErrHelp is returned by Parse when --help or -h is given
+var ErrHelp = errors.New("help requested")
+
 type Arg struct {
 	name        string
 	description string
 	Value       string
+	complete    CompleteFunc
 }
 
 type Command struct {
+	flagSet
 	name          string
 	description   string
 	selected      bool
+	set           *CommandSet
 	subCommandSet *CommandSet
 	args          []*Arg
+	rest          []string
 }
 
 func (command *Command) AddArg(name, description string) *Arg {
@@ -47,28 +56,59 @@ func (command *Command) GetArg(index int) (*Arg, bool) {
 	arg := command.args[index]
 	return arg, true
 }
+
+// Rest returns the values given after the command's args
+func (command *Command) Rest() []string {
+	return command.rest
+}
 func (command *Command) AddSubcommand(name, description string) *Command {
 	if command.subCommandSet == nil {
 		command.subCommandSet = NewCommandSet()
+		command.subCommandSet.parent = command
 	}
 	return command.subCommandSet.AddCommand(name, description)
 }
 func (command *Command) DisplayUsage() {
-	if command.subCommandSet == nil {
-		panic(fmt.Sprintf("Cannot displayUsage for command without subcommands: %s", command.name))
-	}
-	command.subCommandSet.DisplayUsage()
+	command.WriteUsage(os.Stderr)
 }
 func (command *Command) IsSelected() bool {
 	return command.selected
 }
+func (command *Command) Name() string {
+	return command.name
+}
+
+// Path is the name of the program followed by the names of the command and
+// its parents, such as ["dbweb", "db", "copy"]
+func (command *Command) Path() []string {
+	var path []string
+	for cmd := command; cmd != nil; cmd = cmd.set.parent {
+		path = append([]string{cmd.name}, path...)
+	}
+	return append([]string{command.root().name}, path...)
+}
+func (command *Command) root() *CommandSet {
+	set := command.set
+	for set.parent != nil {
+		set = set.parent.set
+	}
+	return set
+}
 
 type CommandSet struct {
+	flagSet
+	// name is the name of the program, only used by the top CommandSet
+	name           string
+	parent         *Command
 	commandsByName map[string]*Command
+	commands       []*Command
+	selected       *Command
 }
 
 func NewCommandSet() *CommandSet {
-	r := &CommandSet{}
+	r := &CommandSet{
+		name: filepath.Base(os.Args[0]),
+	}
 	return r
 }
 
@@ -76,63 +116,139 @@ func (commands *CommandSet) AddCommand(name, description string) *Command {
 	cmd := &Command{
 		name:        name,
 		description: description,
+		set:         commands,
 	}
 	if commands.commandsByName == nil {
 		commands.commandsByName = make(map[string]*Command)
 	}
 	commands.commandsByName[name] = cmd
+	commands.commands = append(commands.commands, cmd)
 	return cmd
 }
 
+// VisitAll calls fn for each command in the order they were added
 func (commands *CommandSet) VisitAll(fn func(*Command)) {
-	for _, cmd := range commands.commandsByName {
+	for _, cmd := range commands.commands {
 		fn(cmd)
 	}
 }
 
-func (commands *CommandSet) Parse() {
-	args := os.Args[1:]
+// Selected returns the most specific command selected by Parse or nil when
+// no command was selected
+func (commands *CommandSet) Selected() *Command {
+	return commands.selected
+}
 
-	var selected string
-	if len(args) == 0 {
-		selected = "help"
-	} else {
-		selected = args[0]
-		args = args[1:] // remove the first and continue parsing
+// lookup finds the command with the name, suggesting similar names in the
+// error when it doesn't exist
+func (commands *CommandSet) lookup(name string) (*Command, error) {
+	if cmd, exists := commands.commandsByName[name]; exists {
+		return cmd, nil
+	}
+	var names []string
+	for _, cmd := range commands.commands {
+		names = append(names, cmd.name)
 	}
+	kind := "command"
+	if commands.parent != nil {
+		kind = "subcommand for " + commands.parent.name
+	}
+	return nil, fmt.Errorf("unknown %s: %s%s", kind, name, didYouMean(name, names, ""))
+}
 
-	cmdInst, exists := commands.commandsByName[selected]
-	if !exists {
-		fmt.Fprintf(os.Stderr, "Unknown command specified: %s\n", selected)
-		os.Exit(1)
+// Parse selects the command and subcommands named in the arguments and sets
+// the values of their args and flags. Subcommands may be nested to any
+// depth. Flags of a command may be written anywhere after the command's
+// name; flags of the CommandSet may be written anywhere. Arguments after
+// "--" are never treated as flags or commands. When no command is given, the
+// "help" command is selected. ErrHelp is returned when --help or -h is given.
+func (commands *CommandSet) Parse(args []string) error {
+	scopes := [][]*Flag{commands.flags}
+	var cmdInst *Command
+	var values []string
+	terminated := false
+	helpRequested := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !terminated && arg == "--" {
+			terminated = true
+			continue
+		}
+		if !terminated && (arg == "--help" || arg == "-h") {
+			helpRequested = true
+			continue
+		}
+		if !terminated && isFlag(arg) {
+			consumed, err := parseFlag(scopes, args[i:])
+			if err != nil && !helpRequested {
+				return err
+			}
+			if consumed > 0 {
+				i += consumed - 1
+			}
+			continue
+		}
+
+		var err error
+		if cmdInst == nil {
+			cmdInst, err = commands.lookup(arg)
+		} else if cmdInst.subCommandSet != nil && len(values) == 0 {
+			// if there are subcommands, then the next value is a subcommand
+			var subInst *Command
+			subInst, err = cmdInst.subCommandSet.lookup(arg)
+			if err == nil {
+				cmdInst = subInst
+			}
+		} else {
+			values = append(values, arg)
+			continue
+		}
+		if err != nil {
+			if helpRequested {
+				return ErrHelp
+			}
+			return err
+		}
+		cmdInst.selected = true
+		commands.selected = cmdInst
+		scopes = append(scopes, cmdInst.flags)
+	}
+	if helpRequested {
+		return ErrHelp
 	}
-	cmdInst.selected = true
 
-	// if there are subcommands, then the next value is a subcommand
-	if len(args) > 0 && cmdInst.subCommandSet != nil {
-		subCommandName := args[0]
-		args = args[1:] // remove the first and continue parsing
-		subInst, exists := cmdInst.subCommandSet.commandsByName[subCommandName]
+	if cmdInst == nil {
+		helpInst, exists := commands.commandsByName["help"]
 		if !exists {
-			fmt.Fprintf(os.Stderr, "Unknown subcommand specified for %s: %s", cmdInst.name, subCommandName)
+			return fmt.Errorf("no command specified")
 		}
-		subInst.selected = true
-		cmdInst = subInst
+		helpInst.selected = true
+		commands.selected = helpInst
+		cmdInst = helpInst
 	}
 
-	// set any args. ignore if none have been setup
-	if cmdInst.args != nil {
-		for i, value := range args {
-			if i >= len(cmdInst.args) {
-				break
+	// set any args. the values without an arg are available from Rest
+	for i, value := range values {
+		if i >= len(cmdInst.args) {
+			cmdInst.rest = values[i:]
+			break
+		}
+		cmdInst.args[i].Value = value
+	}
+
+	for _, scope := range scopes {
+		for _, flag := range scope {
+			if flag.required && !flag.IsSet() {
+				return fmt.Errorf("flag --%s is required", flag.name)
 			}
-			cmdInst.args[i].Value = value
 		}
 	}
+	return nil
 }
+
+// DisplayUsage writes the usage of the selected command, or of every command
+// when none was selected, to stderr
 func (commands *CommandSet) DisplayUsage() {
-	fmt.Fprintf(os.Stderr, "Usage:\n")
-	commands.VisitAll(func(a *Command) {
-		fmt.Fprintf(os.Stderr, "\t%s\t%s\n", a.name, a.description)
-	})
+	commands.WriteUsage(os.Stderr)
 }
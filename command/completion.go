@@ -0,0 +1,231 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// CompleteFunc returns the values that complete the word being typed. The
+// values don't need to be filtered by the prefix.
+type CompleteFunc func(prefix string) []string
+
+// CompleteWith completes the value of the arg with the function
+func (arg *Arg) CompleteWith(complete CompleteFunc) *Arg {
+	arg.complete = complete
+	return arg
+}
+
+// CompleteWith completes the value of the flag with the function
+func (flag *Flag) CompleteWith(complete CompleteFunc) *Flag {
+	flag.complete = complete
+	return flag
+}
+
+// CompleteValues completes with a fixed list of values
+func CompleteValues(values ...string) CompleteFunc {
+	return func(prefix string) []string {
+		return values
+	}
+}
+
+// Complete returns the candidates for the last of the arguments, which is
+// the word being typed and may be empty. The arguments before it are used to
+// find the command and whether a flag value, arg, subcommand or flag is
+// expected. Flags are only suggested once the word starts with "-".
+func (commands *CommandSet) Complete(args []string) []string {
+	args = joinFlagValues(args)
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	word := args[len(args)-1]
+	scopes := [][]*Flag{commands.flags}
+	set := commands
+	var cmdInst *Command
+	var values []string
+	terminated := false
+
+	for i := 0; i < len(args)-1; i++ {
+		arg := args[i]
+		if !terminated && arg == "--" {
+			terminated = true
+			continue
+		}
+		if !terminated && isFlag(arg) {
+			if strings.Contains(arg, "=") {
+				continue
+			}
+			flag := findFlag(scopes, strings.TrimLeft(arg, "-"), !strings.HasPrefix(arg, "--"))
+			if flag != nil && flag.kind != boolFlag {
+				if i == len(args)-2 {
+					return filterPrefix(word, flag.completions(word))
+				}
+				i++
+			}
+			continue
+		}
+		if set != nil && len(values) == 0 {
+			if next, exists := set.commandsByName[arg]; exists {
+				cmdInst = next
+				set = next.subCommandSet
+				scopes = append(scopes, next.flags)
+				continue
+			}
+		}
+		values = append(values, arg)
+	}
+
+	if !terminated && strings.HasPrefix(word, "-") {
+		if index := strings.Index(word, "="); index >= 0 {
+			flag := findFlag(scopes, strings.TrimLeft(word[:index], "-"), !strings.HasPrefix(word, "--"))
+			if flag == nil {
+				return nil
+			}
+			var candidates []string
+			for _, value := range filterPrefix(word[index+1:], flag.completions(word[index+1:])) {
+				candidates = append(candidates, word[:index+1]+value)
+			}
+			return candidates
+		}
+		// a command's flag hides a parent's flag with the same name
+		var candidates []string
+		seen := make(map[string]bool)
+		for i := len(scopes) - 1; i >= 0; i-- {
+			for _, flag := range scopes[i] {
+				if !seen[flag.name] {
+					seen[flag.name] = true
+					candidates = append(candidates, "--"+flag.name)
+				}
+			}
+		}
+		return filterPrefix(word, append(candidates, "--help"))
+	}
+
+	// help completes the names of the commands it describes
+	if cmdInst != nil && cmdInst.name == "help" && cmdInst.set == commands {
+		set = commands
+		for _, value := range values {
+			if set == nil {
+				return nil
+			}
+			next, exists := set.commandsByName[value]
+			if !exists {
+				return nil
+			}
+			set = next.subCommandSet
+		}
+		values = nil
+		if set == nil {
+			return nil
+		}
+	}
+
+	if set != nil && len(values) == 0 {
+		var names []string
+		set.VisitAll(func(cmd *Command) {
+			names = append(names, cmd.name)
+		})
+		return filterPrefix(word, names)
+	}
+	if cmdInst != nil && len(values) < len(cmdInst.args) {
+		if arg := cmdInst.args[len(values)]; arg.complete != nil {
+			return filterPrefix(word, arg.complete(word))
+		}
+	}
+	return nil
+}
+
+// joinFlagValues joins "--name", "=", "value" into "--name=value" since bash
+// splits words at "="
+func joinFlagValues(args []string) []string {
+	var words []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "=" && len(words) > 0 && isFlag(words[len(words)-1]) {
+			words[len(words)-1] += "="
+			if i+1 < len(args) {
+				words[len(words)-1] += args[i+1]
+				i++
+			}
+			continue
+		}
+		words = append(words, args[i])
+	}
+	return words
+}
+
+func (flag *Flag) completions(prefix string) []string {
+	if flag.complete == nil {
+		return nil
+	}
+	return flag.complete(prefix)
+}
+
+func filterPrefix(prefix string, values []string) []string {
+	var matches []string
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			matches = append(matches, value)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// CompletionShells are the shells WriteCompletion supports
+var CompletionShells = []string{"bash", "zsh", "fish"}
+
+// WriteCompletion writes the completion script for the shell. The script
+// runs the program with the hidden command name followed by the words being
+// completed and offers each line printed as a candidate; see Complete. When
+// there are no candidates the shell completes file names.
+func (commands *CommandSet) WriteCompletion(w io.Writer, shell string, hiddenCommand string) error {
+	name := commands.name
+	function := "_" + strings.NewReplacer("-", "_", ".", "_").Replace(name) + "_complete"
+	switch shell {
+	case "bash":
+		fmt.Fprintf(w, `# bash completion for %[1]s
+# Load with: source <(%[1]s completion bash)
+%[2]s() {
+	local IFS=$'\n'
+	local cur="${COMP_WORDS[COMP_CWORD]}"
+	COMPREPLY=($(%[1]s %[3]s "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+	# bash splits --name=value at "=" so only the value is replaced
+	if [[ "$cur" == "=" ]]; then
+		COMPREPLY=("${COMPREPLY[@]/#--*=/=}")
+	elif [[ "$cur" != -* ]]; then
+		COMPREPLY=("${COMPREPLY[@]#--*=}")
+	fi
+}
+complete -o default -F %[2]s %[1]s
+`, name, function, hiddenCommand)
+	case "zsh":
+		fmt.Fprintf(w, `#compdef %[1]s
+# zsh completion for %[1]s
+# Load with: source <(%[1]s completion zsh)
+%[2]s() {
+	local -a candidates
+	candidates=("${(@f)$(%[1]s %[3]s "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+	candidates=(${candidates:#})
+	if (( ${#candidates} )); then
+		compadd -- $candidates
+	else
+		_files
+	fi
+}
+compdef %[2]s %[1]s
+`, name, function, hiddenCommand)
+	case "fish":
+		fmt.Fprintf(w, `# fish completion for %[1]s
+# Load with: %[1]s completion fish | source
+function %[2]s
+	set -l words (commandline -opc) (commandline -ct)
+	%[1]s %[3]s $words[2..-1] 2>/dev/null
+end
+complete -c %[1]s -f -a '(%[2]s)'
+`, name, function, hiddenCommand)
+	default:
+		return fmt.Errorf("unknown shell: %s%s", shell, didYouMean(shell, CompletionShells, ""))
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type flagKind int
+
+const (
+	stringFlag flagKind = iota
+	intFlag
+	boolFlag
+	durationFlag
+)
+
+// Flag is an option of a command, written as --name value, --name=value or
+// -s value when the flag has a short name. Boolean flags don't take a value
+// unless it's written as --name=false.
+type Flag struct {
+	name         string
+	short        string
+	description  string
+	kind         flagKind
+	defaultValue string
+	required     bool
+	repeated     bool
+	values       []string
+	complete     CompleteFunc
+}
+
+// flagSet holds the flags of a command. Flags of a CommandSet apply to every
+// command in the set.
+type flagSet struct {
+	flags []*Flag
+}
+
+func (flags *flagSet) addFlag(flag *Flag) *Flag {
+	flags.flags = append(flags.flags, flag)
+	return flag
+}
+
+// StringFlag adds a flag with a string value. The short name may be empty.
+func (flags *flagSet) StringFlag(name, short, defaultValue, description string) *Flag {
+	return flags.addFlag(&Flag{name: name, short: short, description: description, kind: stringFlag, defaultValue: defaultValue})
+}
+
+// IntFlag adds a flag with a whole number value
+func (flags *flagSet) IntFlag(name, short string, defaultValue int, description string) *Flag {
+	return flags.addFlag(&Flag{name: name, short: short, description: description, kind: intFlag, defaultValue: strconv.Itoa(defaultValue)})
+}
+
+// BoolFlag adds a flag that is false unless it's specified
+func (flags *flagSet) BoolFlag(name, short, description string) *Flag {
+	return flags.addFlag(&Flag{name: name, short: short, description: description, kind: boolFlag, defaultValue: "false"})
+}
+
+// DurationFlag adds a flag with a duration value such as 10s
+func (flags *flagSet) DurationFlag(name, short string, defaultValue time.Duration, description string) *Flag {
+	return flags.addFlag(&Flag{name: name, short: short, description: description, kind: durationFlag, defaultValue: defaultValue.String()})
+}
+
+// StringsFlag adds a string flag that can be specified more than once
+func (flags *flagSet) StringsFlag(name, short, description string) *Flag {
+	return flags.addFlag(&Flag{name: name, short: short, description: description, kind: stringFlag, repeated: true})
+}
+
+// Required causes parsing to fail when the flag isn't specified
+func (flag *Flag) Required() *Flag {
+	flag.required = true
+	return flag
+}
+
+func (flag *Flag) Name() string {
+	return flag.name
+}
+
+// IsSet determines if the flag was specified on the command line
+func (flag *Flag) IsSet() bool {
+	return len(flag.values) > 0
+}
+
+// Value is the last value specified or the default value
+func (flag *Flag) Value() string {
+	if len(flag.values) == 0 {
+		return flag.defaultValue
+	}
+	return flag.values[len(flag.values)-1]
+}
+
+// Values are every value specified for a repeated flag
+func (flag *Flag) Values() []string {
+	return flag.values
+}
+
+// Int is the value of an IntFlag. Values are checked when parsed.
+func (flag *Flag) Int() int {
+	n, _ := strconv.Atoi(flag.Value())
+	return n
+}
+
+// Bool is the value of a BoolFlag
+func (flag *Flag) Bool() bool {
+	b, _ := strconv.ParseBool(flag.Value())
+	return b
+}
+
+// Duration is the value of a DurationFlag
+func (flag *Flag) Duration() time.Duration {
+	d, _ := time.ParseDuration(flag.Value())
+	return d
+}
+
+func (flag *Flag) set(value string) error {
+	switch flag.kind {
+	case intFlag:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("invalid value for --%s: %q is not a whole number", flag.name, value)
+		}
+	case boolFlag:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("invalid value for --%s: %q is not true or false", flag.name, value)
+		}
+	case durationFlag:
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("invalid value for --%s: %q is not a duration such as 10s", flag.name, value)
+		}
+	}
+	if flag.repeated {
+		flag.values = append(flag.values, value)
+	} else {
+		flag.values = []string{value}
+	}
+	return nil
+}
+
+// usage is the flag as written on the command line, such as "-p, --port int"
+func (flag *Flag) usage() string {
+	var b strings.Builder
+	if len(flag.short) > 0 {
+		fmt.Fprintf(&b, "-%s, ", flag.short)
+	}
+	fmt.Fprintf(&b, "--%s", flag.name)
+	switch flag.kind {
+	case stringFlag:
+		b.WriteString(" string")
+	case intFlag:
+		b.WriteString(" int")
+	case durationFlag:
+		b.WriteString(" duration")
+	}
+	return b.String()
+}
+
+// isFlag determines if the argument is a flag rather than a value. Negative
+// numbers are values.
+func isFlag(arg string) bool {
+	if len(arg) < 2 || arg[0] != '-' {
+		return false
+	}
+	if arg[1] >= '0' && arg[1] <= '9' {
+		return false
+	}
+	return true
+}
+
+// findFlag looks for the flag in the scopes, from the most specific to the
+// least specific, so that a command's flag hides a flag with the same name
+// from a parent command
+func findFlag(scopes [][]*Flag, name string, short bool) *Flag {
+	for i := len(scopes) - 1; i >= 0; i-- {
+		for _, flag := range scopes[i] {
+			if (!short && flag.name == name) || (short && len(flag.short) > 0 && flag.short == name) {
+				return flag
+			}
+		}
+	}
+	return nil
+}
+
+// parseFlag parses the flag at args[0], consuming the following argument
+// when it's the value. The number of arguments consumed is returned.
+func parseFlag(scopes [][]*Flag, args []string) (int, error) {
+	arg := args[0]
+	short := !strings.HasPrefix(arg, "--")
+	name := strings.TrimLeft(arg, "-")
+	value, hasValue := "", false
+	if index := strings.Index(name, "="); index >= 0 {
+		name, value, hasValue = name[:index], name[index+1:], true
+	}
+
+	flag := findFlag(scopes, name, short)
+	if flag == nil {
+		var names []string
+		for _, scope := range scopes {
+			for _, f := range scope {
+				names = append(names, f.name)
+			}
+		}
+		return 0, fmt.Errorf("unknown flag: %s%s", arg, didYouMean(name, names, "--"))
+	}
+	consumed := 1
+	if !hasValue {
+		if flag.kind == boolFlag {
+			value = "true"
+		} else if len(args) > 1 {
+			value = args[1]
+			consumed = 2
+		} else {
+			return 0, fmt.Errorf("flag --%s requires a value", flag.name)
+		}
+	}
+	return consumed, flag.set(value)
+}
@@ -0,0 +1,171 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteUsage writes the usage line, description, args, subcommands and flags
+// of the command
+func (command *Command) WriteUsage(w io.Writer) {
+	usage := strings.Join(command.Path(), " ")
+	if command.subCommandSet != nil {
+		usage += " <command>"
+	}
+	for _, arg := range command.args {
+		usage += " <" + arg.name + ">"
+	}
+	flags := command.inheritedFlags()
+	if len(flags) > 0 {
+		usage += " [flags]"
+	}
+	fmt.Fprintf(w, "Usage: %s\n\n%s\n", usage, command.description)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if len(command.args) > 0 {
+		fmt.Fprintf(tw, "\nArguments:\n")
+		for _, arg := range command.args {
+			fmt.Fprintf(tw, "  %s\t%s\n", arg.name, arg.description)
+		}
+	}
+	if command.subCommandSet != nil {
+		fmt.Fprintf(tw, "\nCommands:\n")
+		writeCommands(tw, command.subCommandSet)
+	}
+	if len(flags) > 0 {
+		fmt.Fprintf(tw, "\nFlags:\n")
+		writeFlags(tw, flags)
+	}
+	tw.Flush()
+
+	root := command.root()
+	if len(root.flags) > 0 {
+		fmt.Fprintf(w, "\nRun '%s help' to see the global flags.\n", root.name)
+	}
+}
+
+// inheritedFlags are the flags of the command and its parents, which can all
+// be given after the command
+func (command *Command) inheritedFlags() []*Flag {
+	var flags []*Flag
+	for cmd := command; cmd != nil; cmd = cmd.set.parent {
+		flags = append(append([]*Flag(nil), cmd.flags...), flags...)
+	}
+	return flags
+}
+
+// WriteUsage writes the usage of the selected command or, when no command
+// was selected, the commands and global flags
+func (commands *CommandSet) WriteUsage(w io.Writer) {
+	if commands.selected != nil && commands.selected.name != "help" {
+		commands.selected.WriteUsage(w)
+		return
+	}
+
+	fmt.Fprintf(w, "Usage: %s <command> [flags]\n", commands.name)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "\nCommands:\n")
+	writeCommands(tw, commands)
+	if len(commands.flags) > 0 {
+		fmt.Fprintf(tw, "\nGlobal flags:\n")
+		writeFlags(tw, commands.flags)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\nRun '%s help <command>' for more information about a command.\n", commands.name)
+}
+
+// Help writes the usage of the command named by the path, such as
+// ["db", "copy"]. The usage of every command is written when the path is
+// empty.
+func (commands *CommandSet) Help(w io.Writer, path []string) error {
+	if len(path) == 0 {
+		commands.selected = nil
+		commands.WriteUsage(w)
+		return nil
+	}
+	set := commands
+	var cmd *Command
+	for _, name := range path {
+		if set == nil {
+			return fmt.Errorf("%s has no subcommands", strings.Join(cmd.Path()[1:], " "))
+		}
+		var err error
+		if cmd, err = set.lookup(name); err != nil {
+			return err
+		}
+		set = cmd.subCommandSet
+	}
+	cmd.WriteUsage(w)
+	return nil
+}
+
+func writeCommands(w io.Writer, commands *CommandSet) {
+	commands.VisitAll(func(cmd *Command) {
+		fmt.Fprintf(w, "  %s\t%s\n", cmd.name, cmd.description)
+	})
+}
+
+func writeFlags(w io.Writer, flags []*Flag) {
+	for _, flag := range flags {
+		description := flag.description
+		if flag.required {
+			description += " (required)"
+		} else if flag.kind != boolFlag && len(flag.defaultValue) > 0 {
+			description += fmt.Sprintf(" (default %s)", flag.defaultValue)
+		}
+		if flag.repeated {
+			description += " (may be repeated)"
+		}
+		fmt.Fprintf(w, "  %s\t%s\n", flag.usage(), description)
+	}
+}
+
+// didYouMean suggests the candidates that are close to the name as a
+// sentence to add to an error, or returns an empty string when none are
+// close. The prefix is written before each suggestion, such as "--" for
+// flags.
+func didYouMean(name string, candidates []string, prefix string) string {
+	maxDistance := 2
+	if len(name) <= 4 {
+		maxDistance = 1
+	}
+	var suggestions []string
+	for _, candidate := range candidates {
+		if editDistance(name, candidate) <= maxDistance ||
+			(len(name) > 1 && strings.HasPrefix(candidate, name)) {
+			suggestions = append(suggestions, prefix+candidate)
+		}
+	}
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(". Did you mean %s?", strings.Join(suggestions, " or "))
+}
+
+// editDistance is the number of insertions, deletions, substitutions and
+// transpositions of adjacent characters needed to change a into b
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,396 @@
+package command
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrInterrupt is returned by ReadLine when the line is cancelled with Ctrl-C
+var ErrInterrupt = errors.New("interrupted")
+
+// maxHistory is the number of lines kept in the history file
+const maxHistory = 1000
+
+// LineReader reads command lines from a terminal with line editing, history
+// and completion. When the input isn't a terminal, lines are read as they
+// are without a prompt.
+type LineReader struct {
+	Prompt string
+	// Complete returns the candidates for the last of the words, which is
+	// the word being typed and may be empty. See CommandSet.Complete.
+	Complete func(words []string) []string
+
+	in      *os.File
+	reader  *bufio.Reader
+	out     io.Writer
+	history []string
+}
+
+// NewLineReader reads from the file through the reader, which may be shared
+// with other code reading the same input
+func NewLineReader(in *os.File, reader *bufio.Reader, out io.Writer) *LineReader {
+	return &LineReader{
+		in:     in,
+		reader: reader,
+		out:    out,
+	}
+}
+
+// IsTerminal determines if lines are read from a terminal
+func (lr *LineReader) IsTerminal() bool {
+	return isTerminal(int(lr.in.Fd()))
+}
+
+// LoadHistory reads the history from the file. A missing file is not an
+// error.
+func (lr *LineReader) LoadHistory(path string) error {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if len(strings.TrimSpace(line)) > 0 {
+			lr.history = append(lr.history, line)
+		}
+	}
+	if len(lr.history) > maxHistory {
+		lr.history = lr.history[len(lr.history)-maxHistory:]
+	}
+	return nil
+}
+
+// SaveHistory writes the most recent lines of the history to the file
+func (lr *LineReader) SaveHistory(path string) error {
+	history := lr.history
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	content := strings.Join(history, "\n")
+	if len(content) > 0 {
+		content += "\n"
+	}
+	return os.WriteFile(path, []byte(content), 0600)
+}
+
+// AddHistory adds the line to the history unless it's blank or the same as
+// the previous line
+func (lr *LineReader) AddHistory(line string) {
+	if len(strings.TrimSpace(line)) == 0 {
+		return
+	}
+	if len(lr.history) > 0 && lr.history[len(lr.history)-1] == line {
+		return
+	}
+	lr.history = append(lr.history, line)
+}
+
+// ReadLine reads the next line. io.EOF is returned at the end of the input
+// or when Ctrl-D is pressed on an empty line.
+func (lr *LineReader) ReadLine() (string, error) {
+	if !lr.IsTerminal() {
+		return lr.readPlainLine()
+	}
+	restore, err := makeRaw(int(lr.in.Fd()))
+	if err != nil {
+		fmt.Fprint(lr.out, lr.Prompt)
+		return lr.readPlainLine()
+	}
+	defer restore()
+	return lr.edit()
+}
+
+func (lr *LineReader) readPlainLine() (string, error) {
+	line, err := lr.reader.ReadString('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// lineEditor is the state of the line being edited
+type lineEditor struct {
+	*LineReader
+	buf []rune
+	pos int
+}
+
+func (ed *lineEditor) refresh() {
+	fmt.Fprintf(ed.out, "\r%s%s\x1b[K", ed.Prompt, string(ed.buf))
+	if n := len(ed.buf) - ed.pos; n > 0 {
+		fmt.Fprintf(ed.out, "\x1b[%dD", n)
+	}
+}
+
+func (ed *lineEditor) insert(runes []rune) {
+	ed.buf = append(ed.buf[:ed.pos], append(runes, ed.buf[ed.pos:]...)...)
+	ed.pos += len(runes)
+}
+
+func (ed *lineEditor) setLine(line string) {
+	ed.buf = []rune(line)
+	ed.pos = len(ed.buf)
+}
+
+// edit reads keys from the terminal in raw mode until the line is entered
+func (lr *LineReader) edit() (string, error) {
+	ed := &lineEditor{LineReader: lr}
+	// historyIndex is the line of the history being shown. The line being
+	// typed is kept while moving through the history.
+	historyIndex := len(lr.history)
+	typed := ""
+	showHistory := func(index int) {
+		if index < 0 || index > len(lr.history) || index == historyIndex {
+			return
+		}
+		if historyIndex == len(lr.history) {
+			typed = string(ed.buf)
+		}
+		historyIndex = index
+		if index == len(lr.history) {
+			ed.setLine(typed)
+		} else {
+			ed.setLine(lr.history[index])
+		}
+	}
+
+	ed.refresh()
+	lastKeyTab := false
+	for {
+		r, _, err := lr.reader.ReadRune()
+		if err != nil {
+			if err == io.EOF && len(ed.buf) > 0 {
+				fmt.Fprint(lr.out, "\r\n")
+				return string(ed.buf), nil
+			}
+			return "", err
+		}
+		tab := false
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(lr.out, "\r\n")
+			return string(ed.buf), nil
+		case 3: // Ctrl-C
+			fmt.Fprint(lr.out, "^C\r\n")
+			return "", ErrInterrupt
+		case 4: // Ctrl-D
+			if len(ed.buf) == 0 {
+				fmt.Fprint(lr.out, "\r\n")
+				return "", io.EOF
+			}
+			if ed.pos < len(ed.buf) {
+				ed.buf = append(ed.buf[:ed.pos], ed.buf[ed.pos+1:]...)
+			}
+		case 1: // Ctrl-A
+			ed.pos = 0
+		case 5: // Ctrl-E
+			ed.pos = len(ed.buf)
+		case 2: // Ctrl-B
+			if ed.pos > 0 {
+				ed.pos--
+			}
+		case 6: // Ctrl-F
+			if ed.pos < len(ed.buf) {
+				ed.pos++
+			}
+		case 8, 127: // Backspace
+			if ed.pos > 0 {
+				ed.buf = append(ed.buf[:ed.pos-1], ed.buf[ed.pos:]...)
+				ed.pos--
+			}
+		case 11: // Ctrl-K
+			ed.buf = ed.buf[:ed.pos]
+		case 21: // Ctrl-U
+			ed.buf = ed.buf[ed.pos:]
+			ed.pos = 0
+		case 23: // Ctrl-W
+			start := ed.pos
+			for start > 0 && unicode.IsSpace(ed.buf[start-1]) {
+				start--
+			}
+			for start > 0 && !unicode.IsSpace(ed.buf[start-1]) {
+				start--
+			}
+			ed.buf = append(ed.buf[:start], ed.buf[ed.pos:]...)
+			ed.pos = start
+		case 12: // Ctrl-L
+			fmt.Fprint(lr.out, "\x1b[H\x1b[2J")
+		case 16: // Ctrl-P
+			showHistory(historyIndex - 1)
+		case 14: // Ctrl-N
+			showHistory(historyIndex + 1)
+		case '\t':
+			// a second Tab lists the candidates when the first changed nothing
+			tab = !ed.complete(lastKeyTab)
+		case 27: // escape sequences for the arrow and editing keys
+			switch lr.readEscape() {
+			case "A":
+				showHistory(historyIndex - 1)
+			case "B":
+				showHistory(historyIndex + 1)
+			case "C":
+				if ed.pos < len(ed.buf) {
+					ed.pos++
+				}
+			case "D":
+				if ed.pos > 0 {
+					ed.pos--
+				}
+			case "H", "1~", "7~":
+				ed.pos = 0
+			case "F", "4~", "8~":
+				ed.pos = len(ed.buf)
+			case "3~":
+				if ed.pos < len(ed.buf) {
+					ed.buf = append(ed.buf[:ed.pos], ed.buf[ed.pos+1:]...)
+				}
+			}
+		default:
+			if unicode.IsPrint(r) {
+				ed.insert([]rune{r})
+			}
+		}
+		lastKeyTab = tab
+		ed.refresh()
+	}
+}
+
+// readEscape reads the rest of an escape sequence such as "\x1b[A" and
+// returns the part after the "[", such as "A" or "3~"
+func (lr *LineReader) readEscape() string {
+	r, _, err := lr.reader.ReadRune()
+	if err != nil || (r != '[' && r != 'O') {
+		return ""
+	}
+	var seq []rune
+	for {
+		r, _, err := lr.reader.ReadRune()
+		if err != nil {
+			return ""
+		}
+		seq = append(seq, r)
+		if (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || r == '~' {
+			return string(seq)
+		}
+	}
+}
+
+// complete replaces the word before the cursor with the candidate when there
+// is only one, or with the prefix the candidates share. The candidates are
+// listed when Tab is pressed twice. Whether the line changed is returned.
+func (ed *lineEditor) complete(list bool) bool {
+	if ed.Complete == nil {
+		return false
+	}
+	words, start, _ := splitWords(string(ed.buf[:ed.pos]))
+	word := words[len(words)-1]
+	candidates := ed.Complete(words)
+	if len(candidates) == 0 {
+		fmt.Fprint(ed.out, "\a")
+		return false
+	}
+
+	replacement := candidates[0]
+	if len(candidates) > 1 {
+		replacement = commonPrefix(candidates)
+	}
+	if len(replacement) > len(word) || len(candidates) == 1 {
+		replacement = quoteWord(replacement)
+		if len(candidates) == 1 && !strings.HasSuffix(replacement, "=") {
+			replacement += " "
+		}
+		ed.buf = append(ed.buf[:start], ed.buf[ed.pos:]...)
+		ed.pos = start
+		ed.insert([]rune(replacement))
+		return true
+	}
+	if list {
+		fmt.Fprintf(ed.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+	} else {
+		fmt.Fprint(ed.out, "\a")
+	}
+	return false
+}
+
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// quoteWord quotes the word when it contains spaces or quotes so it's read
+// back as a single word
+func quoteWord(word string) string {
+	if !strings.ContainsAny(word, " \t'\"\\") {
+		return word
+	}
+	return strconv.Quote(word)
+}
+
+// SplitLine splits the line into words at spaces. Quotes and backslashes
+// work as they do in a Unix shell, so "Nexus Angel" is a single word.
+func SplitLine(line string) ([]string, error) {
+	words, _, quote := splitWords(line)
+	if quote != 0 {
+		return nil, fmt.Errorf("missing closing quote: %c", quote)
+	}
+	if words[len(words)-1] == "" {
+		words = words[:len(words)-1]
+	}
+	return words, nil
+}
+
+// splitWords splits the line into words. The last word is the one being
+// typed, which is empty when the line ends with a space, and start is the
+// index of the rune where it begins. quote is the open quote at the end of
+// the line, if any.
+func splitWords(line string) (words []string, start int, quote rune) {
+	var word []rune
+	inWord := false
+	escaped := false
+	for i, r := range []rune(line) {
+		switch {
+		case escaped:
+			word = append(word, r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word = append(word, r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case unicode.IsSpace(r):
+			if inWord {
+				words = append(words, string(word))
+				word = nil
+				inWord = false
+			}
+			continue
+		default:
+			word = append(word, r)
+		}
+		if !inWord {
+			inWord = true
+			start = i
+		}
+	}
+	if !inWord {
+		start = len([]rune(line))
+	}
+	return append(words, string(word)), start, quote
+}
@@ -0,0 +1,11 @@
+//go:build linux
+
+package command
+
+import "syscall"
+
+// the ioctl requests that get and set the terminal's mode
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package command
+
+import "errors"
+
+// makeRaw is only supported on Linux, macOS and the BSDs. Elsewhere lines
+// are read without editing or completion.
+func makeRaw(fd int) (restore func(), err error) {
+	return nil, errors.New("raw terminal mode is not supported")
+}
+
+func isTerminal(fd int) bool {
+	return false
+}
@@ -0,0 +1,411 @@
+/*
+Package config loads the application configuration
+
+Settings are read from, in increasing order of precedence, the defaults, a
+JSON config file, environment variables and command line flags. Each
+setting is listed in Settings with the environment variable and flag that
+override it.
+*/
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Config is the configuration of the application
+type Config struct {
+	Server  ServerConfig  `json:"server"`
+	Catalog CatalogConfig `json:"catalog"`
+	Storage StorageConfig `json:"storage"`
+	Mongo   MongoConfig   `json:"mongo"`
+	Mail    MailConfig    `json:"mail"`
+	Auth    AuthConfig    `json:"auth"`
+	API     APIConfig     `json:"api"`
+
+	// path is the config file that was loaded, if any
+	path string
+	// sources records where each setting was read from
+	sources map[string]Source
+}
+
+type ServerConfig struct {
+	Port         int    `json:"port"`
+	TemplatePath string `json:"templatePath"`
+	// SiteURL is used in links sent by email. Defaults to localhost.
+	SiteURL           string `json:"siteURL"`
+	TrustProxyHeaders bool   `json:"trustProxyHeaders"`
+	// DevMode reloads the templates when they change
+	DevMode bool `json:"devMode"`
+	// StaticPath is a directory of assets that replace the embedded ones
+	StaticPath string `json:"staticPath"`
+	// CardCachePath is the directory where stat card images are kept
+	CardCachePath string `json:"cardCachePath"`
+}
+
+type CatalogConfig struct {
+	// DataPath is the CSV file containing the miniatures
+	DataPath string `json:"dataPath"`
+}
+
+type StorageConfig struct {
+	Backend string `json:"backend"`
+	Path    string `json:"path"`
+}
+
+type MongoConfig struct {
+	URI           string   `json:"uri"`
+	Username      string   `json:"username"`
+	Password      string   `json:"password"`
+	AuthSource    string   `json:"authSource"`
+	Database      string   `json:"database"`
+	DialTimeout   Duration `json:"dialTimeout"`
+	SocketTimeout Duration `json:"socketTimeout"`
+	PoolLimit     int      `json:"poolLimit"`
+}
+
+type MailConfig struct {
+	From         string `json:"from"`
+	SMTPHost     string `json:"smtpHost"`
+	SMTPPort     int    `json:"smtpPort"`
+	SMTPUsername string `json:"smtpUsername"`
+	SMTPPassword string `json:"smtpPassword"`
+}
+
+type AuthConfig struct {
+	RegistrationMode      string               `json:"registrationMode"`
+	RequireTwoFactorRoles []string             `json:"requireTwoFactorRoles"`
+	OIDCProviders         []OIDCProviderConfig `json:"oidcProviders"`
+}
+
+type APIConfig struct {
+	// GraphQLMaxDepth is the deepest nesting of fields in a GraphQL query
+	GraphQLMaxDepth int `json:"graphqlMaxDepth"`
+	// GraphQLMaxComplexity is the most a GraphQL query can cost, counting
+	// each field once for every item of the lists it's within
+	GraphQLMaxComplexity int `json:"graphqlMaxComplexity"`
+}
+
+type OIDCProviderConfig struct {
+	Name         string   `json:"name"`
+	DisplayName  string   `json:"displayName"`
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"clientId"`
+	ClientSecret string   `json:"clientSecret"`
+	RedirectURL  string   `json:"redirectURL"`
+	Scopes       []string `json:"scopes"`
+}
+
+// Duration is a time.Duration written as a string such as "10s" in the
+// config file
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"10s\"")
+	}
+	value, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(value)
+	return nil
+}
+
+// Source is where the value of a setting was read from
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// DefaultPath is the config file loaded when no path is given and the
+// DBWEB_CONFIG environment variable is not set. It's fine for the default
+// file not to exist.
+const DefaultPath = "dbweb.json"
+
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:          8080,
+			CardCachePath: "card-cache",
+		},
+		Storage: StorageConfig{
+			Backend: "mongo",
+			Path:    "dbweb.db",
+		},
+		Mongo: MongoConfig{
+			URI:           "mongodb://127.0.0.1:27017",
+			AuthSource:    "admin",
+			Database:      "dreamblade",
+			DialTimeout:   Duration(10 * time.Second),
+			SocketTimeout: Duration(time.Minute),
+			PoolLimit:     4096,
+		},
+		Mail: MailConfig{
+			From:     "noreply@localhost",
+			SMTPPort: 25,
+		},
+		Auth: AuthConfig{
+			RegistrationMode:      "closed",
+			RequireTwoFactorRoles: []string{"admin", "moderator"},
+		},
+		API: APIConfig{
+			GraphQLMaxDepth:      15,
+			GraphQLMaxComplexity: 2000,
+		},
+		sources: make(map[string]Source),
+	}
+}
+
+// Load builds the configuration. The config file at path is read first; if
+// path is empty the DBWEB_CONFIG environment variable or DefaultPath is used.
+// Environment variables are applied next and then the flags, which are keyed
+// by the flag name of the setting. The configuration is not validated; see
+// Validate.
+func Load(path string, flags map[string]string) (*Config, error) {
+	return load(path, flags, os.LookupEnv)
+}
+
+func load(path string, flags map[string]string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	config := Default()
+
+	required := true
+	if len(path) == 0 {
+		path, required = lookupEnv("DBWEB_CONFIG")
+	}
+	if len(path) == 0 {
+		path = DefaultPath
+	}
+	if err := config.readFile(path, required); err != nil {
+		return nil, err
+	}
+
+	for _, setting := range settings {
+		value, exists := lookupEnv(setting.Env)
+		if !exists {
+			continue
+		}
+		if err := setting.set(config, value); err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %v", setting.Env, err)
+		}
+		config.sources[setting.Key] = SourceEnv
+	}
+	if err := config.readOIDCProvidersFromEnv(lookupEnv); err != nil {
+		return nil, err
+	}
+
+	for name, value := range flags {
+		setting, exists := settingByFlag(name)
+		if !exists {
+			return nil, fmt.Errorf("unknown flag --%s", name)
+		}
+		if err := setting.set(config, value); err != nil {
+			return nil, fmt.Errorf("invalid value for --%s: %v", name, err)
+		}
+		config.sources[setting.Key] = SourceFlag
+	}
+	return config, nil
+}
+
+// readFile reads the JSON config file. Unknown settings in the file are
+// reported as errors so that typos are caught.
+func (config *Config) readFile(path string, required bool) error {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !required {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to read config file: %v", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(config); err != nil {
+		return fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+
+	var present map[string]interface{}
+	if err := json.Unmarshal(b, &present); err != nil {
+		return fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+	for _, setting := range settings {
+		if hasKey(present, setting.Key) {
+			config.sources[setting.Key] = SourceFile
+		}
+	}
+	if hasKey(present, oidcProvidersKey) {
+		config.sources[oidcProvidersKey] = SourceFile
+	}
+	config.path = path
+	return nil
+}
+
+// hasKey determines if the dotted key is present in the decoded JSON
+func hasKey(values map[string]interface{}, key string) bool {
+	parts := strings.SplitN(key, ".", 2)
+	value, exists := values[parts[0]]
+	if !exists || len(parts) == 1 {
+		return exists
+	}
+	nested, ok := value.(map[string]interface{})
+	return ok && hasKey(nested, parts[1])
+}
+
+const oidcProvidersKey = "auth.oidcProviders"
+
+// readOIDCProvidersFromEnv replaces the providers with the ones listed in
+// OIDC_PROVIDERS. Each provider is configured with OIDC_<NAME>_ISSUER,
+// OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET, OIDC_<NAME>_REDIRECT_URL,
+// OIDC_<NAME>_DISPLAY_NAME and OIDC_<NAME>_SCOPES.
+func (config *Config) readOIDCProvidersFromEnv(lookupEnv func(string) (string, bool)) error {
+	names, exists := lookupEnv("OIDC_PROVIDERS")
+	if !exists {
+		return nil
+	}
+	getenv := func(name string) string {
+		value, _ := lookupEnv(name)
+		return value
+	}
+
+	var providers []OIDCProviderConfig
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if len(name) == 0 {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers = append(providers, OIDCProviderConfig{
+			Name:         name,
+			DisplayName:  getenv(prefix + "DISPLAY_NAME"),
+			Issuer:       getenv(prefix + "ISSUER"),
+			ClientID:     getenv(prefix + "CLIENT_ID"),
+			ClientSecret: getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(getenv(prefix + "SCOPES")),
+		})
+	}
+	config.Auth.OIDCProviders = providers
+	config.sources[oidcProvidersKey] = SourceEnv
+	return nil
+}
+
+// Path is the config file that was loaded or empty if there wasn't one
+func (config *Config) Path() string {
+	return config.path
+}
+
+// Source returns where the value of the setting was read from
+func (config *Config) Source(key string) Source {
+	if source, exists := config.sources[key]; exists {
+		return source
+	}
+	return SourceDefault
+}
+
+// ValidationError lists every problem found with the configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (err *ValidationError) Error() string {
+	return "invalid configuration:\n\t" + strings.Join(err.Problems, "\n\t")
+}
+
+var (
+	storageBackends   = []string{"mongo", "file"}
+	registrationModes = []string{"closed", "open", "invite"}
+)
+
+// Validate checks the values of the settings. A *ValidationError is
+// returned describing every problem.
+func (config *Config) Validate() error {
+	var problems []string
+	problem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if config.Server.Port < 1 || config.Server.Port > 65535 {
+		problem("server.port must be between 1 and 65535")
+	}
+	if len(config.Server.SiteURL) > 0 {
+		if u, err := url.Parse(config.Server.SiteURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problem("server.siteURL must be an absolute http or https URL")
+		}
+	}
+	if len(config.Server.CardCachePath) == 0 {
+		problem("server.cardCachePath is required")
+	}
+	if !contains(storageBackends, config.Storage.Backend) {
+		problem("storage.backend must be one of %s", strings.Join(storageBackends, ", "))
+	}
+	if config.Storage.Backend == "file" && len(config.Storage.Path) == 0 {
+		problem("storage.path is required when storage.backend is file")
+	}
+	if config.Storage.Backend == "mongo" {
+		if !strings.HasPrefix(config.Mongo.URI, "mongodb://") {
+			problem("mongo.uri must start with mongodb://")
+		}
+		if len(config.Mongo.Database) == 0 {
+			problem("mongo.database is required")
+		}
+		if config.Mongo.DialTimeout <= 0 || config.Mongo.SocketTimeout <= 0 {
+			problem("mongo.dialTimeout and mongo.socketTimeout must be greater than zero")
+		}
+		if config.Mongo.PoolLimit < 0 {
+			problem("mongo.poolLimit must not be negative")
+		}
+	}
+	if len(config.Mail.SMTPHost) > 0 && (config.Mail.SMTPPort < 1 || config.Mail.SMTPPort > 65535) {
+		problem("mail.smtpPort must be between 1 and 65535")
+	}
+	if !contains(registrationModes, config.Auth.RegistrationMode) {
+		problem("auth.registrationMode must be one of %s", strings.Join(registrationModes, ", "))
+	}
+	if config.API.GraphQLMaxDepth < 0 || config.API.GraphQLMaxComplexity < 0 {
+		problem("api.graphqlMaxDepth and api.graphqlMaxComplexity must not be negative")
+	}
+	names := make(map[string]bool)
+	for i, provider := range config.Auth.OIDCProviders {
+		label := fmt.Sprintf("auth.oidcProviders[%d]", i)
+		if len(provider.Name) > 0 {
+			label = fmt.Sprintf("auth.oidcProviders[%s]", provider.Name)
+		} else {
+			problem("%s.name is required", label)
+		}
+		if names[provider.Name] {
+			problem("%s is configured more than once", label)
+		}
+		names[provider.Name] = true
+		if len(provider.Issuer) == 0 || len(provider.ClientID) == 0 || len(provider.RedirectURL) == 0 {
+			problem("%s requires issuer, clientId and redirectURL", label)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
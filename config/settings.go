@@ -0,0 +1,285 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Setting is a single configuration value that can be overridden with an
+// environment variable or a flag
+type Setting struct {
+	// Key is the path of the setting in the config file, such as server.port
+	Key string
+	// Env is the environment variable that overrides the setting
+	Env         string
+	Description string
+	// Secret settings are redacted when the configuration is shown
+	Secret bool
+	// redact hides the secret parts of the value when the configuration is
+	// shown, for settings that aren't entirely secret
+	redact func(value string) string
+	// Choices are the allowed values, when the setting is limited to a few
+	Choices []string
+
+	isBool bool
+	get    func(config *Config) string
+	set    func(config *Config, value string) error
+}
+
+// Flag is the name of the command line flag for the setting, which is the
+// environment variable in lowercase with dashes, such as --mongo-uri
+func (setting Setting) Flag() string {
+	return strings.ToLower(strings.Replace(setting.Env, "_", "-", -1))
+}
+
+// IsBool determines if the setting is a boolean flag that doesn't need a
+// value
+func (setting Setting) IsBool() bool {
+	return setting.isBool
+}
+
+func stringSetting(key, env, description string, field func(*Config) *string) Setting {
+	return Setting{
+		Key:         key,
+		Env:         env,
+		Description: description,
+		get:         func(config *Config) string { return *field(config) },
+		set: func(config *Config, value string) error {
+			*field(config) = value
+			return nil
+		},
+	}
+}
+
+func choiceSetting(key, env, description string, choices []string, field func(*Config) *string) Setting {
+	setting := stringSetting(key, env, description, field)
+	setting.Choices = choices
+	return setting
+}
+
+func secretSetting(key, env, description string, field func(*Config) *string) Setting {
+	setting := stringSetting(key, env, description, field)
+	setting.Secret = true
+	return setting
+}
+
+// uriSetting is a URI that may contain a password, which is redacted when
+// the configuration is shown
+func uriSetting(key, env, description string, field func(*Config) *string) Setting {
+	setting := stringSetting(key, env, description, field)
+	setting.redact = redactURIPassword
+	return setting
+}
+
+func intSetting(key, env, description string, field func(*Config) *int) Setting {
+	return Setting{
+		Key:         key,
+		Env:         env,
+		Description: description,
+		get:         func(config *Config) string { return strconv.Itoa(*field(config)) },
+		set: func(config *Config, value string) error {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("%q is not a whole number", value)
+			}
+			*field(config) = n
+			return nil
+		},
+	}
+}
+
+func boolSetting(key, env, description string, field func(*Config) *bool) Setting {
+	return Setting{
+		Key:         key,
+		Env:         env,
+		Description: description,
+		isBool:      true,
+		get:         func(config *Config) string { return strconv.FormatBool(*field(config)) },
+		set: func(config *Config, value string) error {
+			b, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("%q is not true or false", value)
+			}
+			*field(config) = b
+			return nil
+		},
+	}
+}
+
+func durationSetting(key, env, description string, field func(*Config) *Duration) Setting {
+	return Setting{
+		Key:         key,
+		Env:         env,
+		Description: description,
+		get:         func(config *Config) string { return time.Duration(*field(config)).String() },
+		set: func(config *Config, value string) error {
+			d, err := time.ParseDuration(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("%q is not a duration such as 10s", value)
+			}
+			*field(config) = Duration(d)
+			return nil
+		},
+	}
+}
+
+func listSetting(key, env, description string, field func(*Config) *[]string) Setting {
+	return Setting{
+		Key:         key,
+		Env:         env,
+		Description: description,
+		get:         func(config *Config) string { return strings.Join(*field(config), ",") },
+		set: func(config *Config, value string) error {
+			var values []string
+			for _, v := range strings.Split(value, ",") {
+				if v = strings.TrimSpace(v); len(v) > 0 {
+					values = append(values, v)
+				}
+			}
+			*field(config) = values
+			return nil
+		},
+	}
+}
+
+var settings = []Setting{
+	intSetting("server.port", "PORT", "Port the web server listens on",
+		func(c *Config) *int { return &c.Server.Port }),
+	stringSetting("server.templatePath", "TEMPLATE_PATH", "Directory containing the templates, defaults to ./templates",
+		func(c *Config) *string { return &c.Server.TemplatePath }),
+	stringSetting("server.siteURL", "SITE_URL", "URL of the site used in emailed links, defaults to localhost",
+		func(c *Config) *string { return &c.Server.SiteURL }),
+	boolSetting("server.trustProxyHeaders", "TRUST_PROXY_HEADERS", "Use X-Forwarded-For to determine the client address",
+		func(c *Config) *bool { return &c.Server.TrustProxyHeaders }),
+	boolSetting("server.devMode", "DEV_MODE", "Reload templates when they change, for development",
+		func(c *Config) *bool { return &c.Server.DevMode }),
+	stringSetting("server.staticPath", "STATIC_PATH", "Directory of static assets that replace the embedded ones",
+		func(c *Config) *string { return &c.Server.StaticPath }),
+	stringSetting("server.cardCachePath", "CARD_CACHE_PATH", "Directory where stat card images are cached",
+		func(c *Config) *string { return &c.Server.CardCachePath }),
+	stringSetting("catalog.dataPath", "DATA", "CSV file containing the miniatures",
+		func(c *Config) *string { return &c.Catalog.DataPath }),
+	choiceSetting("storage.backend", "STORAGE", "Where site data is stored: mongo or file", storageBackends,
+		func(c *Config) *string { return &c.Storage.Backend }),
+	stringSetting("storage.path", "STORAGE_PATH", "File used by the file storage backend",
+		func(c *Config) *string { return &c.Storage.Path }),
+	uriSetting("mongo.uri", "MONGO_URI", "MongoDB connection string",
+		func(c *Config) *string { return &c.Mongo.URI }),
+	stringSetting("mongo.username", "MONGO_USERNAME", "MongoDB username",
+		func(c *Config) *string { return &c.Mongo.Username }),
+	secretSetting("mongo.password", "MONGO_PASSWORD", "MongoDB password",
+		func(c *Config) *string { return &c.Mongo.Password }),
+	stringSetting("mongo.authSource", "MONGO_AUTH_SOURCE", "Database the MongoDB credentials are defined in",
+		func(c *Config) *string { return &c.Mongo.AuthSource }),
+	stringSetting("mongo.database", "MONGO_DATABASE", "MongoDB database for the site's collections",
+		func(c *Config) *string { return &c.Mongo.Database }),
+	durationSetting("mongo.dialTimeout", "MONGO_DIAL_TIMEOUT", "Time to wait when connecting to MongoDB",
+		func(c *Config) *Duration { return &c.Mongo.DialTimeout }),
+	durationSetting("mongo.socketTimeout", "MONGO_SOCKET_TIMEOUT", "Time to wait for a MongoDB operation",
+		func(c *Config) *Duration { return &c.Mongo.SocketTimeout }),
+	intSetting("mongo.poolLimit", "MONGO_POOL_LIMIT", "Maximum MongoDB connections per server",
+		func(c *Config) *int { return &c.Mongo.PoolLimit }),
+	stringSetting("mail.from", "MAIL_FROM", "Address email is sent from",
+		func(c *Config) *string { return &c.Mail.From }),
+	stringSetting("mail.smtpHost", "SMTP_HOST", "SMTP server; email is written to stdout when empty",
+		func(c *Config) *string { return &c.Mail.SMTPHost }),
+	intSetting("mail.smtpPort", "SMTP_PORT", "SMTP server port",
+		func(c *Config) *int { return &c.Mail.SMTPPort }),
+	stringSetting("mail.smtpUsername", "SMTP_USERNAME", "SMTP username",
+		func(c *Config) *string { return &c.Mail.SMTPUsername }),
+	secretSetting("mail.smtpPassword", "SMTP_PASSWORD", "SMTP password",
+		func(c *Config) *string { return &c.Mail.SMTPPassword }),
+	choiceSetting("auth.registrationMode", "REGISTRATION_MODE", "Who can register: closed, open or invite", registrationModes,
+		func(c *Config) *string { return &c.Auth.RegistrationMode }),
+	listSetting("auth.requireTwoFactorRoles", "REQUIRE_2FA_ROLES", "Roles that must use two-factor authentication",
+		func(c *Config) *[]string { return &c.Auth.RequireTwoFactorRoles }),
+	intSetting("api.graphqlMaxDepth", "GRAPHQL_MAX_DEPTH", "Deepest nesting of fields in a GraphQL query; 0 is no limit",
+		func(c *Config) *int { return &c.API.GraphQLMaxDepth }),
+	intSetting("api.graphqlMaxComplexity", "GRAPHQL_MAX_COMPLEXITY", "Most a GraphQL query can cost; 0 is no limit",
+		func(c *Config) *int { return &c.API.GraphQLMaxComplexity }),
+}
+
+// Settings returns every setting in the order they are shown
+func Settings() []Setting {
+	return append([]Setting(nil), settings...)
+}
+
+func settingByFlag(name string) (Setting, bool) {
+	for _, setting := range settings {
+		if setting.Flag() == name {
+			return setting, true
+		}
+	}
+	return Setting{}, false
+}
+
+// Show writes every setting with its value and where it was read from.
+// Secrets are redacted.
+func (config *Config) Show(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if len(config.path) > 0 {
+		fmt.Fprintf(tw, "# config file: %s\n", config.path)
+	} else {
+		fmt.Fprintf(tw, "# config file: none\n")
+	}
+	for _, setting := range settings {
+		value := setting.get(config)
+		if setting.Secret {
+			value = redact(value)
+		} else if setting.redact != nil {
+			value = setting.redact(value)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t[%s]\t--%s, %s\n", setting.Key, quoteEmpty(value), config.Source(setting.Key), setting.Flag(), setting.Env)
+	}
+	source := config.Source(oidcProvidersKey)
+	if len(config.Auth.OIDCProviders) == 0 {
+		fmt.Fprintf(tw, "%s\t%s\t[%s]\t%s\n", oidcProvidersKey, quoteEmpty(""), source, "OIDC_PROVIDERS")
+	}
+	for _, provider := range config.Auth.OIDCProviders {
+		prefix := fmt.Sprintf("%s[%s].", oidcProvidersKey, provider.Name)
+		fmt.Fprintf(tw, "%sdisplayName\t%s\t[%s]\n", prefix, quoteEmpty(provider.DisplayName), source)
+		fmt.Fprintf(tw, "%sissuer\t%s\t[%s]\n", prefix, quoteEmpty(provider.Issuer), source)
+		fmt.Fprintf(tw, "%sclientId\t%s\t[%s]\n", prefix, quoteEmpty(provider.ClientID), source)
+		fmt.Fprintf(tw, "%sclientSecret\t%s\t[%s]\n", prefix, quoteEmpty(redact(provider.ClientSecret)), source)
+		fmt.Fprintf(tw, "%sredirectURL\t%s\t[%s]\n", prefix, quoteEmpty(provider.RedirectURL), source)
+		fmt.Fprintf(tw, "%sscopes\t%s\t[%s]\n", prefix, quoteEmpty(strings.Join(provider.Scopes, " ")), source)
+	}
+	return tw.Flush()
+}
+
+func redact(value string) string {
+	if len(value) == 0 {
+		return ""
+	}
+	return "********"
+}
+
+// redactURIPassword replaces the password in the URI's user information.
+// URIs that can't be parsed are redacted entirely when they may contain one.
+func redactURIPassword(value string) string {
+	u, err := url.Parse(value)
+	if err != nil {
+		if strings.Contains(value, "@") {
+			return redact(value)
+		}
+		return value
+	}
+	if _, hasPassword := u.User.Password(); !hasPassword {
+		return value
+	}
+	// Redacted marks the password with xxxxx; use the same mask as the
+	// secret settings
+	return strings.Replace(u.Redacted(), ":xxxxx@", ":"+redact("password")+"@", 1)
+}
+
+func quoteEmpty(value string) string {
+	if len(value) == 0 {
+		return `""`
+	}
+	return value
+}
@@ -0,0 +1,122 @@
+package data
+
+import (
+	"sort"
+	"strings"
+)
+
+// Ability is one of the abilities in the text of a miniature, such as
+// "Ravage 1 (When this creature deals damage...)". The name leaves out the
+// value and the reminder text.
+type Ability struct {
+	name string
+	text string
+}
+
+func (ability Ability) Name() string {
+	return ability.name
+}
+func (ability Ability) Text() string {
+	return ability.text
+}
+
+// AbilityList splits the ability text of the miniature into its abilities.
+// Abilities are separated by new lines or semicolons. Keywords may also be
+// listed with commas, such as "Flying, Ravage 2", but commas in reminder text
+// or after a colon don't separate abilities.
+func (mini Miniature) AbilityList() []Ability {
+	var abilities []Ability
+	for _, line := range strings.FieldsFunc(mini.abilities, func(r rune) bool {
+		return r == '\n' || r == ';'
+	}) {
+		for _, text := range splitKeywords(line) {
+			text = strings.TrimSpace(text)
+			if name := abilityName(text); len(name) > 0 {
+				abilities = append(abilities, Ability{name: name, text: text})
+			}
+		}
+	}
+	return abilities
+}
+
+// splitKeywords splits the text at the commas outside of parentheses. Text
+// with a colon is a single ability, such as "Reap: ...".
+func splitKeywords(text string) []string {
+	if strings.Contains(text, ":") {
+		return []string{text}
+	}
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range text {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth = max(depth-1, 0)
+		case ',':
+			if depth == 0 {
+				parts = append(parts, text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// abilityName is the words at the start of the text up to a number, colon or
+// reminder text
+func abilityName(text string) string {
+	end := strings.IndexAny(text, "0123456789:(+")
+	if end < 0 {
+		end = len(text)
+	}
+	return strings.TrimSpace(text[:end])
+}
+
+// GetAbilityNames returns the name of every ability in the catalog in order
+func GetAbilityNames() []string {
+	names := make(map[string]string)
+	for _, mini := range GetMiniatures() {
+		for _, ability := range mini.AbilityList() {
+			names[strings.ToLower(ability.name)] = ability.name
+		}
+	}
+	return sortedValues(names)
+}
+
+// GetLineages returns every lineage in the catalog in order
+func GetLineages() []string {
+	lineages := make(map[string]string)
+	for _, mini := range GetMiniatures() {
+		if lineage := strings.TrimSpace(mini.lineage); len(lineage) > 0 {
+			lineages[strings.ToLower(lineage)] = lineage
+		}
+	}
+	return sortedValues(lineages)
+}
+
+func sortedValues(values map[string]string) []string {
+	var sorted []string
+	for _, v := range values {
+		sorted = append(sorted, v)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i]) < strings.ToLower(sorted[j])
+	})
+	return sorted
+}
+
+// GetMiniaturesWithAbility returns the miniatures that have the ability
+// ordered by set and then collector number
+func GetMiniaturesWithAbility(name string) []*Miniature {
+	var minis []*Miniature
+	for _, mini := range GetMiniatures() {
+		for _, ability := range mini.AbilityList() {
+			if strings.EqualFold(ability.name, name) {
+				minis = append(minis, mini)
+				break
+			}
+		}
+	}
+	return minis
+}
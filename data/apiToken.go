@@ -0,0 +1,244 @@
+package data
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+)
+
+const (
+	apiTokenCollectionName    = "apiTokens"
+	apiTokenDocCurrentVersion = 1
+
+	// apiTokenPrefix is prepended to every token so they are easy to
+	// recognize when leaked in logs or source code
+	apiTokenPrefix = "dbw_"
+)
+
+// Scopes that can be granted to an API token. The catalog is public so
+// reading it doesn't need a scope.
+const (
+	ScopeCollectionRead  = "collection:read"
+	ScopeCollectionWrite = "collection:write"
+)
+
+// APITokenScopes contains all of the scopes that can be granted to an API token
+var APITokenScopes = []string{
+	ScopeCollectionRead,
+	ScopeCollectionWrite,
+}
+
+// ErrAPITokenNotFound is returned when a token is unknown or has been revoked
+var ErrAPITokenNotFound = errors.New("api token not found")
+
+// APIToken is a personal access token that allows a user to be
+// authenticated without a browser session. Only a hash of the token
+// is stored.
+type APIToken struct {
+	id       string
+	username string
+	name     string
+	scopes   []string
+	created  time.Time
+	lastUsed time.Time
+}
+
+func (token *APIToken) ID() string {
+	return token.id
+}
+func (token *APIToken) Username() string {
+	return token.username
+}
+func (token *APIToken) Name() string {
+	return token.name
+}
+func (token *APIToken) Scopes() []string {
+	return token.scopes
+}
+func (token *APIToken) Created() time.Time {
+	return token.created
+}
+
+// LastUsed is the last time the token was used. The zero time is returned
+// when the token has never been used.
+func (token *APIToken) LastUsed() time.Time {
+	return token.lastUsed
+}
+func (token *APIToken) HasScope(scope string) bool {
+	for _, s := range token.scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type apiTokenDto struct {
+	Version  int
+	ID       string `bson:"_id"`
+	Username string
+	Name     string
+	Scopes   []string
+	Hash     string
+	Created  time.Time
+	LastUsed time.Time `bson:",omitempty"`
+}
+
+func (tokenData apiTokenDto) toAPIToken() *APIToken {
+	return &APIToken{
+		id:       tokenData.ID,
+		username: tokenData.Username,
+		name:     tokenData.Name,
+		scopes:   tokenData.Scopes,
+		created:  tokenData.Created,
+		lastUsed: tokenData.LastUsed,
+	}
+}
+
+func hashAPIToken(secret string) string {
+	digest := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(digest[:])
+}
+
+func isValidScope(scope string) bool {
+	for _, s := range APITokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func getAPITokenCollection(store Store) (Collection, error) {
+	collection := store.C(apiTokenCollectionName)
+	err := collection.EnsureIndex(Index{
+		Key:    []string{"hash"},
+		Unique: true,
+	})
+	return collection, err
+}
+
+// CreateAPIToken creates a new token for the user. The secret value of the
+// token is only available from the return value; it cannot be retrieved later.
+func CreateAPIToken(username, name string, scopes []string) (secret string, token *APIToken, err error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return "", nil, errors.New("a name is required for the token")
+	}
+	if len(scopes) == 0 {
+		return "", nil, errors.New("at least one scope is required for the token")
+	}
+	for _, scope := range scopes {
+		if !isValidScope(scope) {
+			return "", nil, fmt.Errorf("unknown scope: %s", scope)
+		}
+	}
+
+	b := make([]byte, 32)
+	if _, err = io.ReadFull(rand.Reader, b); err != nil {
+		return "", nil, err
+	}
+	secret = apiTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	tokenData := apiTokenDto{
+		Version:  apiTokenDocCurrentVersion,
+		ID:       bson.NewObjectId().Hex(),
+		Username: username,
+		Name:     name,
+		Scopes:   scopes,
+		Hash:     hashAPIToken(secret),
+		Created:  time.Now().UTC(),
+	}
+
+	store, err := OpenStore()
+	if err != nil {
+		return
+	}
+	defer store.Close()
+
+	collection, err := getAPITokenCollection(store)
+	if err != nil {
+		return "", nil, err
+	}
+	if err = collection.Insert(tokenData); err != nil {
+		return "", nil, err
+	}
+	return secret, tokenData.toAPIToken(), nil
+}
+
+// GetAPITokensByUsername returns all of the active tokens for the user
+func GetAPITokensByUsername(username string) ([]*APIToken, error) {
+	store, err := OpenStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	var tokenData []apiTokenDto
+	collection := store.C(apiTokenCollectionName)
+	err = collection.Find(bson.M{"username": username}).Sort("created").All(&tokenData)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []*APIToken
+	for _, t := range tokenData {
+		tokens = append(tokens, t.toAPIToken())
+	}
+	return tokens, nil
+}
+
+// RevokeAPIToken removes the token with the given ID. The token must belong
+// to the user.
+func RevokeAPIToken(username, id string) error {
+	store, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	collection := store.C(apiTokenCollectionName)
+	err = collection.Remove(bson.M{"_id": id, "username": username})
+	if err == ErrNotFound {
+		return ErrAPITokenNotFound
+	}
+	return err
+}
+
+// AuthenticateAPIToken finds the token matching the secret value and
+// records that it has been used.
+func AuthenticateAPIToken(secret string) (*APIToken, error) {
+	if !strings.HasPrefix(secret, apiTokenPrefix) {
+		return nil, ErrAPITokenNotFound
+	}
+
+	store, err := OpenStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	var tokenData apiTokenDto
+	collection := store.C(apiTokenCollectionName)
+	err = collection.Find(bson.M{"hash": hashAPIToken(secret)}).One(&tokenData)
+	if err == ErrNotFound {
+		return nil, ErrAPITokenNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	tokenData.LastUsed = time.Now().UTC()
+	err = collection.UpdateId(tokenData.ID, bson.M{"$set": bson.M{"lastused": tokenData.LastUsed}})
+	if err != nil {
+		return nil, err
+	}
+	return tokenData.toAPIToken(), nil
+}
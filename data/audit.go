@@ -0,0 +1,88 @@
+package data
+
+import (
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+)
+
+const (
+	auditCollectionName    = "audit"
+	auditDocCurrentVersion = 1
+)
+
+// Audit events
+const (
+	AuditLoginLockout = "login.lockout"
+	AuditLoginUnlock  = "login.unlock"
+)
+
+// AuditEntry records a security relevant event
+type AuditEntry struct {
+	Time       time.Time
+	Event      string
+	Username   string
+	RemoteAddr string
+	Detail     string
+}
+
+type auditDto struct {
+	Version    int
+	ID         bson.ObjectId `bson:"_id"`
+	Time       time.Time
+	Event      string
+	Username   string `bson:",omitempty"`
+	RemoteAddr string `bson:",omitempty"`
+	Detail     string `bson:",omitempty"`
+}
+
+// AddAuditEntry stores the entry. The time is set to now when not specified.
+func AddAuditEntry(entry AuditEntry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	store, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	collection := store.C(auditCollectionName)
+	return collection.Insert(auditDto{
+		Version:    auditDocCurrentVersion,
+		ID:         bson.NewObjectId(),
+		Time:       entry.Time.UTC(),
+		Event:      entry.Event,
+		Username:   entry.Username,
+		RemoteAddr: entry.RemoteAddr,
+		Detail:     entry.Detail,
+	})
+}
+
+// GetAuditEntries returns the most recent entries with the newest first
+func GetAuditEntries(limit int) ([]AuditEntry, error) {
+	store, err := OpenStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	var auditData []auditDto
+	collection := store.C(auditCollectionName)
+	if err := collection.Find(nil).Sort("-time").Limit(limit).All(&auditData); err != nil {
+		return nil, err
+	}
+
+	var entries []AuditEntry
+	for _, a := range auditData {
+		entries = append(entries, AuditEntry{
+			Time:       a.Time,
+			Event:      a.Event,
+			Username:   a.Username,
+			RemoteAddr: a.RemoteAddr,
+			Detail:     a.Detail,
+		})
+	}
+	return entries, nil
+}
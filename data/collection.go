@@ -0,0 +1,133 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+)
+
+const (
+	collectionEntryCollectionName    = "collections"
+	collectionEntryDocCurrentVersion = 1
+
+	// MaxOwnedCount is the most copies of a miniature a collection can have
+	MaxOwnedCount = 999
+)
+
+// CollectionEntry is the number of copies of a miniature that a user owns
+type CollectionEntry struct {
+	username    string
+	miniatureID string
+	count       int
+	updated     time.Time
+}
+
+func (entry *CollectionEntry) Username() string {
+	return entry.username
+}
+func (entry *CollectionEntry) MiniatureID() string {
+	return entry.miniatureID
+}
+func (entry *CollectionEntry) Count() int {
+	return entry.count
+}
+func (entry *CollectionEntry) Updated() time.Time {
+	return entry.updated
+}
+
+type collectionEntryDto struct {
+	Version     int
+	ID          bson.ObjectId `bson:"_id,omitempty"`
+	Username    string
+	MiniatureID string
+	Count       int
+	Updated     time.Time
+}
+
+func (entryData collectionEntryDto) toCollectionEntry() *CollectionEntry {
+	return &CollectionEntry{
+		username:    entryData.Username,
+		miniatureID: entryData.MiniatureID,
+		count:       entryData.Count,
+		updated:     entryData.Updated,
+	}
+}
+
+func getCollectionEntryCollection(store Store) (Collection, error) {
+	collection := store.C(collectionEntryCollectionName)
+	err := collection.EnsureIndex(Index{
+		Key:    []string{"username", "miniatureid"},
+		Unique: true,
+	})
+	return collection, err
+}
+
+// GetCollection returns the miniatures the user owns ordered by miniature ID
+func GetCollection(username string) ([]*CollectionEntry, error) {
+	store, err := OpenStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	var entryData []collectionEntryDto
+	err = store.C(collectionEntryCollectionName).Find(bson.M{"username": username}).All(&entryData)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*CollectionEntry
+	for _, e := range entryData {
+		entries = append(entries, e.toCollectionEntry())
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].miniatureID < entries[j].miniatureID
+	})
+	return entries, nil
+}
+
+// SetOwnedCount records the number of copies of the miniature the user owns.
+// A count of zero removes the miniature from the collection.
+func SetOwnedCount(username, miniatureID string, count int) (*CollectionEntry, error) {
+	mini, err := GetMiniatureByID(miniatureID)
+	if err != nil {
+		return nil, err
+	}
+	if count < 0 || count > MaxOwnedCount {
+		return nil, fmt.Errorf("count must be from 0 to %d", MaxOwnedCount)
+	}
+
+	store, err := OpenStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	collection, err := getCollectionEntryCollection(store)
+	if err != nil {
+		return nil, err
+	}
+	entry := &CollectionEntry{
+		username:    username,
+		miniatureID: mini.ID(),
+		count:       count,
+		updated:     time.Now().UTC(),
+	}
+	selector := bson.M{"username": username, "miniatureid": mini.ID()}
+	if count == 0 {
+		if err := collection.Remove(selector); err != nil && err != ErrNotFound {
+			return nil, err
+		}
+		return entry, nil
+	}
+	err = collection.Upsert(selector, bson.M{"$set": bson.M{
+		"version": collectionEntryDocCurrentVersion,
+		"count":   count,
+		"updated": entry.updated,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,641 @@
+package data
+
+// Evaluation of MongoDB queries and updates against documents held in
+// memory. This is used by the file store and supports the operators used by
+// the data functions: $or, $and, $eq, $ne, $gt, $gte, $lt, $lte, $in, $nin,
+// $exists and $elemMatch in queries, and $set, $unset, $inc, $push,
+// $addToSet and $pull in updates. Dotted paths reach into embedded documents
+// but not into the documents of arrays.
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+)
+
+// normalizeDocument encodes the value to BSON and decodes it as a generic
+// document so that values compare the same way regardless of the Go types
+// used to build them. Structs are converted using their bson field names.
+func normalizeDocument(value interface{}) (bson.M, error) {
+	if value == nil {
+		return bson.M{}, nil
+	}
+	b, err := bson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	doc := bson.M{}
+	if err := bson.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// normalizeValue converts a single value the same way as normalizeDocument
+func normalizeValue(value interface{}) (interface{}, error) {
+	doc, err := normalizeDocument(bson.M{"v": value})
+	if err != nil {
+		return nil, err
+	}
+	return doc["v"], nil
+}
+
+func asDocument(value interface{}) (bson.M, bool) {
+	switch v := value.(type) {
+	case bson.M:
+		return v, true
+	case map[string]interface{}:
+		return bson.M(v), true
+	default:
+		return nil, false
+	}
+}
+
+func isOperatorDocument(doc bson.M) bool {
+	if len(doc) == 0 {
+		return false
+	}
+	for key := range doc {
+		if !strings.HasPrefix(key, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupPath finds the value of a dotted field path within the document
+func lookupPath(doc bson.M, path string) (interface{}, bool) {
+	var current interface{} = doc
+	for _, part := range strings.Split(path, ".") {
+		currentDoc, ok := asDocument(current)
+		if !ok {
+			return nil, false
+		}
+		current, ok = currentDoc[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setPath sets the value of a dotted field path, creating embedded documents
+// as needed
+func setPath(doc bson.M, path string, value interface{}) error {
+	parts := strings.Split(path, ".")
+	current := doc
+	for _, part := range parts[:len(parts)-1] {
+		next, exists := current[part]
+		if !exists || next == nil {
+			created := bson.M{}
+			current[part] = created
+			current = created
+			continue
+		}
+		nextDoc, ok := asDocument(next)
+		if !ok {
+			return fmt.Errorf("cannot set %s: %s is not a document", path, part)
+		}
+		current = nextDoc
+	}
+	current[parts[len(parts)-1]] = value
+	return nil
+}
+
+// unsetPath removes the dotted field path from the document
+func unsetPath(doc bson.M, path string) {
+	parts := strings.Split(path, ".")
+	current := doc
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := asDocument(current[part])
+		if !ok {
+			return
+		}
+		current = next
+	}
+	delete(current, parts[len(parts)-1])
+}
+
+// matchDocument determines if the document satisfies the normalized query
+func matchDocument(doc bson.M, query bson.M) (bool, error) {
+	for key, condition := range query {
+		switch key {
+		case "$or", "$and":
+			clauses, ok := condition.([]interface{})
+			if !ok || len(clauses) == 0 {
+				return false, fmt.Errorf("%s requires a non-empty array", key)
+			}
+			matchedAny := false
+			for _, clause := range clauses {
+				clauseDoc, ok := asDocument(clause)
+				if !ok {
+					return false, fmt.Errorf("%s requires an array of documents", key)
+				}
+				matched, err := matchDocument(doc, clauseDoc)
+				if err != nil {
+					return false, err
+				}
+				if key == "$and" && !matched {
+					return false, nil
+				}
+				matchedAny = matchedAny || matched
+			}
+			if key == "$or" && !matchedAny {
+				return false, nil
+			}
+		default:
+			if strings.HasPrefix(key, "$") {
+				return false, fmt.Errorf("unsupported query operator %s", key)
+			}
+			value, exists := lookupPath(doc, key)
+			matched, err := matchCondition(value, exists, condition)
+			if err != nil || !matched {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// matchCondition determines if a field value satisfies a query condition,
+// which is either a value to compare with or a document of operators
+func matchCondition(value interface{}, exists bool, condition interface{}) (bool, error) {
+	operators, ok := asDocument(condition)
+	if !ok || !isOperatorDocument(operators) {
+		return matchEqual(value, exists, condition), nil
+	}
+
+	for operator, operand := range operators {
+		var matched bool
+		switch operator {
+		case "$eq":
+			matched = matchEqual(value, exists, operand)
+		case "$ne":
+			matched = !matchEqual(value, exists, operand)
+		case "$gt", "$gte", "$lt", "$lte":
+			matched = matchComparison(value, exists, operator, operand)
+		case "$in", "$nin":
+			candidates, ok := operand.([]interface{})
+			if !ok {
+				return false, fmt.Errorf("%s requires an array", operator)
+			}
+			for _, candidate := range candidates {
+				if matchEqual(value, exists, candidate) {
+					matched = true
+					break
+				}
+			}
+			if operator == "$nin" {
+				matched = !matched
+			}
+		case "$exists":
+			matched = exists == isTruthy(operand)
+		case "$elemMatch":
+			elements, ok := value.([]interface{})
+			if !ok {
+				break
+			}
+			subQuery, ok := asDocument(operand)
+			if !ok {
+				return false, fmt.Errorf("$elemMatch requires a document")
+			}
+			for _, element := range elements {
+				var err error
+				matched, err = matchElement(element, subQuery)
+				if err != nil {
+					return false, err
+				}
+				if matched {
+					break
+				}
+			}
+		default:
+			return false, fmt.Errorf("unsupported query operator %s", operator)
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchElement determines if an element of an array satisfies the condition
+// of $elemMatch or $pull. A condition of fields is a query of the elements
+// that are documents.
+func matchElement(element interface{}, condition interface{}) (bool, error) {
+	if elementDoc, ok := asDocument(element); ok {
+		if query, ok := asDocument(condition); ok && !isOperatorDocument(query) {
+			return matchDocument(elementDoc, query)
+		}
+	}
+	return matchCondition(element, true, condition)
+}
+
+// matchEqual compares the field value to the operand. A missing field is
+// equal to null and an array field matches when any element is equal.
+func matchEqual(value interface{}, exists bool, operand interface{}) bool {
+	if !exists {
+		return operand == nil
+	}
+	if valuesEqual(value, operand) {
+		return true
+	}
+	if elements, ok := value.([]interface{}); ok {
+		for _, element := range elements {
+			if valuesEqual(element, operand) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchComparison compares the field value to the operand. Only values of
+// the same kind are compared, except that $gte and $lte null match a field
+// that is null or missing.
+func matchComparison(value interface{}, exists bool, operator string, operand interface{}) bool {
+	if operand == nil {
+		return (operator == "$gte" || operator == "$lte") && (!exists || value == nil)
+	}
+	if !exists {
+		return false
+	}
+	candidates := []interface{}{value}
+	if elements, ok := value.([]interface{}); ok {
+		candidates = elements
+	}
+	for _, candidate := range candidates {
+		result, comparable := compareValues(candidate, operand)
+		if !comparable {
+			continue
+		}
+		switch operator {
+		case "$gt":
+			if result > 0 {
+				return true
+			}
+		case "$gte":
+			if result >= 0 {
+				return true
+			}
+		case "$lt":
+			if result < 0 {
+				return true
+			}
+		case "$lte":
+			if result <= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case nil:
+		return false
+	default:
+		if n, ok := toFloat(v); ok {
+			return n != 0
+		}
+		return true
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// compareValues orders two values of the same kind. comparable is false when
+// the values can't be ordered, such as a string and a number.
+func compareValues(a, b interface{}) (result int, comparable bool) {
+	if af, ok := toFloat(a); ok {
+		bf, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case af < bf:
+			return -1, true
+		case af > bf:
+			return 1, true
+		}
+		return 0, true
+	}
+	switch av := a.(type) {
+	case string:
+		bv, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(av, bv), true
+	case bson.ObjectId:
+		bv, ok := b.(bson.ObjectId)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(string(av), string(bv)), true
+	case time.Time:
+		bv, ok := b.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case av.Before(bv):
+			return -1, true
+		case av.After(bv):
+			return 1, true
+		}
+		return 0, true
+	case bool:
+		bv, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case av == bv:
+			return 0, true
+		case !av:
+			return -1, true
+		}
+		return 1, true
+	}
+	return 0, false
+}
+
+func valuesEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if af, ok := toFloat(a); ok {
+		bf, ok := toFloat(b)
+		return ok && af == bf
+	}
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	if adoc, ok := asDocument(a); ok {
+		bdoc, ok := asDocument(b)
+		if !ok || len(adoc) != len(bdoc) {
+			return false
+		}
+		for key, value := range adoc {
+			other, exists := bdoc[key]
+			if !exists || !valuesEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	if aslice, ok := a.([]interface{}); ok {
+		bslice, ok := b.([]interface{})
+		if !ok || len(aslice) != len(bslice) {
+			return false
+		}
+		for i := range aslice {
+			if !valuesEqual(aslice[i], bslice[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// applyUpdate modifies the document with the normalized update. An update
+// without operators replaces the document, keeping its ID.
+func applyUpdate(doc bson.M, update bson.M) error {
+	if !isOperatorDocument(update) {
+		id, hasID := doc["_id"]
+		for key := range doc {
+			delete(doc, key)
+		}
+		for key, value := range update {
+			doc[key] = value
+		}
+		if hasID {
+			doc["_id"] = id
+		}
+		return nil
+	}
+
+	for operator, operand := range update {
+		fields, ok := asDocument(operand)
+		if !ok {
+			return fmt.Errorf("%s requires a document", operator)
+		}
+		for path, value := range fields {
+			if path == "_id" {
+				return fmt.Errorf("%s cannot modify _id", operator)
+			}
+			current, exists := lookupPath(doc, path)
+			switch operator {
+			case "$set":
+				if err := setPath(doc, path, value); err != nil {
+					return err
+				}
+			case "$unset":
+				unsetPath(doc, path)
+			case "$inc":
+				increment, ok := toFloat(value)
+				if !ok {
+					return fmt.Errorf("$inc requires a number for %s", path)
+				}
+				if !exists {
+					current = 0
+				} else if _, ok := toFloat(current); !ok {
+					return fmt.Errorf("$inc requires %s to be a number", path)
+				}
+				if err := setPath(doc, path, addNumbers(current, value, increment)); err != nil {
+					return err
+				}
+			case "$push", "$addToSet", "$pull":
+				if operator == "$pull" && !exists {
+					continue
+				}
+				var elements []interface{}
+				if exists {
+					if elements, ok = current.([]interface{}); !ok {
+						return fmt.Errorf("%s requires %s to be an array", operator, path)
+					}
+				}
+				switch operator {
+				case "$push":
+					elements = append(elements, value)
+				case "$addToSet":
+					if !containsValue(elements, value) {
+						elements = append(elements, value)
+					}
+				case "$pull":
+					var kept []interface{}
+					for _, element := range elements {
+						matched, err := matchElement(element, value)
+						if err != nil {
+							return err
+						}
+						if !matched {
+							kept = append(kept, element)
+						}
+					}
+					elements = kept
+					if elements == nil {
+						elements = []interface{}{}
+					}
+				}
+				if err := setPath(doc, path, elements); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unsupported update operator %s", operator)
+			}
+		}
+	}
+	return nil
+}
+
+func containsValue(elements []interface{}, value interface{}) bool {
+	for _, element := range elements {
+		if valuesEqual(element, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func addNumbers(current, value interface{}, increment float64) interface{} {
+	base, ok := toFloat(current)
+	if !ok {
+		base = 0
+	}
+	_, currentIsFloat := current.(float64)
+	_, valueIsFloat := value.(float64)
+	if currentIsFloat || valueIsFloat {
+		return base + increment
+	}
+	return int64(base) + int64(increment)
+}
+
+// upsertDocument creates the document inserted by an upsert when nothing
+// matches the selector. Equality conditions of the selector are copied to
+// the new document before the update is applied.
+func upsertDocument(selector bson.M, update bson.M) (bson.M, error) {
+	doc := bson.M{}
+	if isOperatorDocument(update) {
+		for key, condition := range selector {
+			if strings.HasPrefix(key, "$") {
+				continue
+			}
+			if operators, ok := asDocument(condition); ok && isOperatorDocument(operators) {
+				continue
+			}
+			if err := setPath(doc, key, condition); err != nil {
+				return nil, err
+			}
+		}
+	} else if id, ok := selector["_id"]; ok {
+		if _, isOperators := asDocument(id); !isOperators {
+			doc["_id"] = id
+		}
+	}
+	if err := applyUpdate(doc, update); err != nil {
+		return nil, err
+	}
+	if _, ok := update["_id"]; ok && !isOperatorDocument(update) {
+		doc["_id"] = update["_id"]
+	}
+	return doc, nil
+}
+
+// sortDocuments orders the documents by the fields. Fields prefixed with "-"
+// are sorted in descending order. Missing fields sort first.
+func sortDocuments(docs []bson.M, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		for _, field := range fields {
+			descending := strings.HasPrefix(field, "-")
+			field = strings.TrimPrefix(strings.TrimPrefix(field, "-"), "+")
+			a, aExists := lookupPath(docs[i], field)
+			b, bExists := lookupPath(docs[j], field)
+			var result int
+			switch {
+			case !aExists && !bExists:
+				result = 0
+			case !aExists:
+				result = -1
+			case !bExists:
+				result = 1
+			default:
+				result, _ = compareValues(a, b)
+			}
+			if result == 0 {
+				continue
+			}
+			if descending {
+				return result > 0
+			}
+			return result < 0
+		}
+		return false
+	})
+}
+
+// projectDocument applies a projection such as {"version": 1}. Inclusion
+// projections always include the _id unless it's excluded.
+func projectDocument(doc bson.M, projection bson.M) bson.M {
+	if len(projection) == 0 {
+		return doc
+	}
+	including := false
+	for key, value := range projection {
+		if key != "_id" && isTruthy(value) {
+			including = true
+		}
+	}
+
+	projected := bson.M{}
+	if including {
+		for key, value := range projection {
+			if !isTruthy(value) {
+				continue
+			}
+			if fieldValue, exists := lookupPath(doc, key); exists {
+				setPath(projected, key, fieldValue)
+			}
+		}
+		if value, exists := projection["_id"]; !exists || isTruthy(value) {
+			if id, ok := doc["_id"]; ok {
+				projected["_id"] = id
+			}
+		}
+		return projected
+	}
+
+	for key, value := range doc {
+		projected[key] = value
+	}
+	for key := range projection {
+		unsetPath(projected, key)
+	}
+	return projected
+}
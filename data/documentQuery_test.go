@@ -0,0 +1,292 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+)
+
+var testCreated = time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// testDocument is normalized the same way as the documents of the file store
+func testDocument(t *testing.T) bson.M {
+	doc, err := normalizeDocument(bson.M{
+		"_id":     "u1",
+		"name":    "ann",
+		"age":     30,
+		"score":   7.5,
+		"nothing": nil,
+		"tags":    []string{"a", "b"},
+		"scores":  []int{3, 8, 12},
+		"profile": bson.M{"city": "Oslo", "zip": "0150"},
+		"identities": []bson.M{
+			{"provider": "google", "subject": "1"},
+			{"provider": "github", "subject": "2"},
+		},
+		"created": testCreated,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// TestMatchDocument checks the query operators against what MongoDB matches
+// for the same document
+func TestMatchDocument(t *testing.T) {
+	tests := []struct {
+		query bson.M
+		match bool
+	}{
+		// equality
+		{bson.M{"name": "ann"}, true},
+		{bson.M{"name": "bob"}, false},
+		{bson.M{"age": 30.0}, true},
+		{bson.M{"missing": nil}, true},
+		{bson.M{"nothing": nil}, true},
+		{bson.M{"name": nil}, false},
+		{bson.M{"tags": "a"}, true},
+		{bson.M{"tags": []string{"a", "b"}}, true},
+		{bson.M{"tags": []string{"b", "a"}}, false},
+		{bson.M{"profile.city": "Oslo"}, true},
+		{bson.M{"profile.country": nil}, true},
+		{bson.M{"profile": bson.M{"city": "Oslo", "zip": "0150"}}, true},
+		{bson.M{"profile": bson.M{"city": "Oslo"}}, false},
+		{bson.M{"created": testCreated}, true},
+		{bson.M{"name": "ann", "age": 31}, false},
+
+		// $eq and $ne
+		{bson.M{"age": bson.M{"$eq": 30}}, true},
+		{bson.M{"age": bson.M{"$eq": 31}}, false},
+		{bson.M{"name": bson.M{"$ne": "bob"}}, true},
+		{bson.M{"name": bson.M{"$ne": "ann"}}, false},
+		{bson.M{"missing": bson.M{"$ne": 1}}, true},
+		{bson.M{"missing": bson.M{"$ne": nil}}, false},
+		{bson.M{"nothing": bson.M{"$ne": nil}}, false},
+		{bson.M{"tags": bson.M{"$ne": "a"}}, false},
+		{bson.M{"tags": bson.M{"$ne": "c"}}, true},
+
+		// comparisons only match values of the same kind
+		{bson.M{"age": bson.M{"$gt": 29}}, true},
+		{bson.M{"age": bson.M{"$gt": 30}}, false},
+		{bson.M{"age": bson.M{"$gte": 30}}, true},
+		{bson.M{"age": bson.M{"$lt": 30.5}}, true},
+		{bson.M{"age": bson.M{"$lte": 29}}, false},
+		{bson.M{"age": bson.M{"$gt": "20"}}, false},
+		{bson.M{"name": bson.M{"$gt": "alice"}}, true},
+		{bson.M{"created": bson.M{"$lt": testCreated.Add(time.Second)}}, true},
+		{bson.M{"created": bson.M{"$gt": testCreated}}, false},
+		{bson.M{"missing": bson.M{"$lt": 100}}, false},
+		{bson.M{"scores": bson.M{"$gt": 10}}, true},
+		{bson.M{"scores": bson.M{"$lt": 3}}, false},
+		{bson.M{"scores": bson.M{"$gt": 8, "$lt": 5}}, true},
+		{bson.M{"nothing": bson.M{"$gte": nil}}, true},
+		{bson.M{"missing": bson.M{"$lte": nil}}, true},
+		{bson.M{"missing": bson.M{"$gt": nil}}, false},
+		{bson.M{"age": bson.M{"$gte": nil}}, false},
+
+		// $in and $nin
+		{bson.M{"name": bson.M{"$in": []string{"bob", "ann"}}}, true},
+		{bson.M{"name": bson.M{"$in": []string{}}}, false},
+		{bson.M{"missing": bson.M{"$in": []interface{}{nil}}}, true},
+		{bson.M{"tags": bson.M{"$in": []string{"b", "z"}}}, true},
+		{bson.M{"name": bson.M{"$nin": []string{"bob"}}}, true},
+		{bson.M{"name": bson.M{"$nin": []string{"ann"}}}, false},
+		{bson.M{"tags": bson.M{"$nin": []string{"b"}}}, false},
+		{bson.M{"missing": bson.M{"$nin": []string{"x"}}}, true},
+
+		// $exists
+		{bson.M{"missing": bson.M{"$exists": false}}, true},
+		{bson.M{"nothing": bson.M{"$exists": true}}, true},
+		{bson.M{"name": bson.M{"$exists": false}}, false},
+		{bson.M{"name": bson.M{"$exists": 1}}, true},
+		{bson.M{"profile.zip": bson.M{"$exists": true}}, true},
+
+		// $elemMatch
+		{bson.M{"identities": bson.M{"$elemMatch": bson.M{"provider": "github", "subject": "2"}}}, true},
+		{bson.M{"identities": bson.M{"$elemMatch": bson.M{"provider": "github", "subject": "1"}}}, false},
+		{bson.M{"scores": bson.M{"$elemMatch": bson.M{"$gt": 5, "$lt": 10}}}, true},
+		{bson.M{"scores": bson.M{"$elemMatch": bson.M{"$gt": 8, "$lt": 12}}}, false},
+		{bson.M{"name": bson.M{"$elemMatch": bson.M{"$eq": "ann"}}}, false},
+
+		// $or and $and
+		{bson.M{"$or": []bson.M{{"name": "bob"}, {"age": 30}}}, true},
+		{bson.M{"$or": []bson.M{{"name": "bob"}, {"age": 31}}}, false},
+		{bson.M{"$and": []bson.M{{"name": "ann"}, {"age": 30}}}, true},
+		{bson.M{"$and": []bson.M{{"name": "ann"}, {"age": 31}}}, false},
+	}
+	for _, test := range tests {
+		query, err := normalizeDocument(test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		matched, err := matchDocument(testDocument(t), query)
+		if err != nil {
+			t.Errorf("%v: %v", test.query, err)
+			continue
+		}
+		if matched != test.match {
+			t.Errorf("%v: got %v, want %v", test.query, matched, test.match)
+		}
+	}
+}
+
+func TestMatchDocumentErrors(t *testing.T) {
+	tests := []bson.M{
+		{"$nor": []bson.M{{"name": "bob"}}},
+		{"$or": []bson.M{}},
+		{"$and": []string{"name"}},
+		{"name": bson.M{"$regex": "^a"}},
+		{"name": bson.M{"$in": "ann"}},
+		{"identities": bson.M{"$elemMatch": "google"}},
+	}
+	for _, test := range tests {
+		query, err := normalizeDocument(test)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := matchDocument(testDocument(t), query); err == nil {
+			t.Errorf("%v: expected an error", test)
+		}
+	}
+}
+
+// missingField is the value of a field that the update should remove
+type missingField struct{}
+
+// TestApplyUpdate checks the update operators against how MongoDB changes
+// the same document. The fields of want are checked after the update.
+func TestApplyUpdate(t *testing.T) {
+	tests := []struct {
+		update bson.M
+		want   bson.M
+	}{
+		// replacement keeps the ID
+		{bson.M{"name": "zed"}, bson.M{"_id": "u1", "name": "zed", "age": missingField{}}},
+
+		// $set and $unset
+		{bson.M{"$set": bson.M{"name": "bob"}}, bson.M{"name": "bob", "age": 30}},
+		{bson.M{"$set": bson.M{"profile.city": "Bergen"}}, bson.M{"profile.city": "Bergen", "profile.zip": "0150"}},
+		{bson.M{"$set": bson.M{"extra.deep": 1}}, bson.M{"extra": bson.M{"deep": 1}}},
+		{bson.M{"$unset": bson.M{"name": ""}}, bson.M{"name": missingField{}}},
+		{bson.M{"$unset": bson.M{"profile.zip": ""}}, bson.M{"profile": bson.M{"city": "Oslo"}}},
+		{bson.M{"$unset": bson.M{"missing.field": ""}}, bson.M{"missing": missingField{}}},
+
+		// $inc keeps integers unless either number is a float
+		{bson.M{"$inc": bson.M{"age": 2}}, bson.M{"age": 32}},
+		{bson.M{"$inc": bson.M{"age": -1.5}}, bson.M{"age": 28.5}},
+		{bson.M{"$inc": bson.M{"score": 1}}, bson.M{"score": 8.5}},
+		{bson.M{"$inc": bson.M{"count": 1}}, bson.M{"count": 1}},
+
+		// $push, $addToSet and $pull
+		{bson.M{"$push": bson.M{"tags": "c"}}, bson.M{"tags": []string{"a", "b", "c"}}},
+		{bson.M{"$push": bson.M{"list": "x"}}, bson.M{"list": []string{"x"}}},
+		{bson.M{"$addToSet": bson.M{"tags": "a"}}, bson.M{"tags": []string{"a", "b"}}},
+		{bson.M{"$addToSet": bson.M{"tags": "c"}}, bson.M{"tags": []string{"a", "b", "c"}}},
+		{bson.M{"$addToSet": bson.M{"tags": []string{"a", "b"}}}, bson.M{"tags": []interface{}{"a", "b", []string{"a", "b"}}}},
+		{bson.M{"$pull": bson.M{"tags": "a"}}, bson.M{"tags": []string{"b"}}},
+		{bson.M{"$pull": bson.M{"tags": "z"}}, bson.M{"tags": []string{"a", "b"}}},
+		{bson.M{"$pull": bson.M{"scores": bson.M{"$gte": 8}}}, bson.M{"scores": []int{3}}},
+		{bson.M{"$pull": bson.M{"identities": bson.M{"provider": "google"}}}, bson.M{"identities": []bson.M{{"provider": "github", "subject": "2"}}}},
+		{bson.M{"$pull": bson.M{"missing": "x"}}, bson.M{"missing": missingField{}}},
+	}
+	for _, test := range tests {
+		update, err := normalizeDocument(test.update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc := testDocument(t)
+		if err := applyUpdate(doc, update); err != nil {
+			t.Errorf("%v: %v", test.update, err)
+			continue
+		}
+		for path, want := range test.want {
+			value, exists := lookupPath(doc, path)
+			if _, ok := want.(missingField); ok {
+				if exists {
+					t.Errorf("%v: %s is %v, want it removed", test.update, path, value)
+				}
+				continue
+			}
+			want, err := normalizeValue(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !exists || !valuesEqual(value, want) {
+				t.Errorf("%v: %s is %v, want %v", test.update, path, value, want)
+			}
+		}
+	}
+}
+
+func TestApplyUpdateErrors(t *testing.T) {
+	tests := []bson.M{
+		{"$set": bson.M{"_id": "u2"}},
+		{"$set": bson.M{"name.first": "ann"}},
+		{"$set": "ann"},
+		{"$rename": bson.M{"name": "username"}},
+		{"$inc": bson.M{"age": "1"}},
+		{"$inc": bson.M{"name": 1}},
+		{"$inc": bson.M{"nothing": 1}},
+		{"$push": bson.M{"name": "x"}},
+		{"$push": bson.M{"nothing": "x"}},
+		{"$addToSet": bson.M{"profile": "x"}},
+		{"$pull": bson.M{"name": "x"}},
+	}
+	for _, test := range tests {
+		update, err := normalizeDocument(test)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := applyUpdate(testDocument(t), update); err == nil {
+			t.Errorf("%v: expected an error", test)
+		}
+	}
+}
+
+func TestUpsertDocument(t *testing.T) {
+	tests := []struct {
+		selector bson.M
+		update   bson.M
+		want     bson.M
+	}{
+		{
+			bson.M{"_id": "u2", "name": "bob", "age": bson.M{"$gt": 1}},
+			bson.M{"$set": bson.M{"age": 5}},
+			bson.M{"_id": "u2", "name": "bob", "age": 5},
+		},
+		{
+			bson.M{"profile.city": "Oslo", "$or": []bson.M{{"a": 1}}},
+			bson.M{"$inc": bson.M{"count": 1}},
+			bson.M{"profile": bson.M{"city": "Oslo"}, "count": 1},
+		},
+		{
+			bson.M{"_id": "u2", "name": "bob"},
+			bson.M{"name": "zed"},
+			bson.M{"_id": "u2", "name": "zed"},
+		},
+	}
+	for _, test := range tests {
+		selector, err := normalizeDocument(test.selector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		update, err := normalizeDocument(test.update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := normalizeDocument(test.want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc, err := upsertDocument(selector, update)
+		if err != nil {
+			t.Errorf("%v: %v", test.selector, err)
+			continue
+		}
+		if !valuesEqual(doc, want) {
+			t.Errorf("%v: got %v, want %v", test.selector, doc, want)
+		}
+	}
+}
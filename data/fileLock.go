@@ -0,0 +1,15 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package data
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive lock on the file that's released when the
+// file is closed. An error is returned immediately if another process holds
+// the lock.
+func lockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+}
@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package data
+
+import "os"
+
+// lockFile does nothing on platforms without flock. Only run one process
+// against a file store on these platforms.
+func lockFile(file *os.File) error {
+	return nil
+}
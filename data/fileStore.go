@@ -0,0 +1,856 @@
+package data
+
+// An embedded document store kept in a single file, for running the site
+// without MongoDB. Every document is held in memory. Changes are appended to
+// the file as BSON records that are replayed when the file is opened and the
+// file is compacted once it contains mostly replaced records.
+//
+// The file is locked while it's open so only one process can use it at a
+// time.
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+)
+
+const (
+	fileStoreMagic = "DBWEBKV1"
+	// fileStoreMaxRecordSize matches the maximum size of a MongoDB document
+	fileStoreMaxRecordSize = 16 * 1024 * 1024
+	// fileStoreCompactThreshold is the number of replaced records that must
+	// accumulate before the file is compacted
+	fileStoreCompactThreshold = 1000
+)
+
+// fileStoreRecord is written to the file each time a document is stored or
+// removed
+type fileStoreRecord struct {
+	Collection string      `bson:"c"`
+	ID         interface{} `bson:"id"`
+	Document   bson.M      `bson:"doc,omitempty"`
+	Removed    bool        `bson:"removed,omitempty"`
+}
+
+type fileStoreEntry struct {
+	seq int64
+	doc bson.M
+}
+
+type fileStoreCollection struct {
+	entries map[string]*fileStoreEntry
+	indexes []Index
+}
+
+// FileStore is a Store kept in a single file
+type FileStore struct {
+	lock        sync.RWMutex
+	path        string
+	file        *os.File
+	collections map[string]*fileStoreCollection
+	seq         int64
+	records     int
+	// failed is set when a failed write couldn't be removed from the file,
+	// after which nothing more is written until the store is opened again
+	failed error
+}
+
+var sharedFileStoreLock sync.Mutex
+var sharedFileStore *FileStore
+
+// openSharedFileStore opens the file store once and shares it between all
+// callers since the file can only be opened once
+func openSharedFileStore(path string) (*FileStore, error) {
+	sharedFileStoreLock.Lock()
+	defer sharedFileStoreLock.Unlock()
+	if sharedFileStore != nil {
+		if sharedFileStore.path != path {
+			return nil, fmt.Errorf("file store %s is already open", sharedFileStore.path)
+		}
+		return sharedFileStore, nil
+	}
+	store, err := OpenFileStore(path)
+	if err != nil {
+		return nil, err
+	}
+	sharedFileStore = store
+	return store, nil
+}
+
+// OpenFileStore opens the file store at the path, creating it if it doesn't
+// exist. The store must be closed with CloseFile to release the file.
+func OpenFileStore(path string) (*FileStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("unable to lock %s, it may be in use by another process: %v", path, err)
+	}
+
+	store := &FileStore{
+		path:        path,
+		file:        file,
+		collections: make(map[string]*fileStoreCollection),
+	}
+	if err := store.load(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("unable to read %s: %v", path, err)
+	}
+	if store.records-store.liveCount() > store.liveCount() {
+		if err := store.compact(); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// load replays the records in the file. A partially written record at the
+// end of the file, left by a crash, is discarded. A bad record anywhere else
+// is an error so that the records after it aren't lost.
+func (store *FileStore) load() error {
+	info, err := store.file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		if _, err := store.file.Write([]byte(fileStoreMagic)); err != nil {
+			return err
+		}
+		return store.file.Sync()
+	}
+
+	reader := bufio.NewReader(store.file)
+	magic := make([]byte, len(fileStoreMagic))
+	if _, err := io.ReadFull(reader, magic); err != nil || string(magic) != fileStoreMagic {
+		return errors.New("not a dbweb file store")
+	}
+
+	offset := int64(len(fileStoreMagic))
+	for {
+		record, size, err := readFileStoreRecord(reader)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			if !store.isTornRecord(offset, size, err, info.Size()) {
+				return fmt.Errorf("corrupt record at offset %d: %v", offset, err)
+			}
+			log.Printf("Discarding incomplete record at offset %d of %s\n\t%v", offset, store.path, err)
+			if err := store.file.Truncate(offset); err != nil {
+				return err
+			}
+			break
+		}
+		offset += int64(size)
+		store.records++
+		store.replay(record)
+	}
+	_, err = store.file.Seek(offset, io.SeekStart)
+	return err
+}
+
+// isTornRecord determines if the bad record at the offset was left by a write
+// that didn't finish, which is when the record runs to the end of the file
+func (store *FileStore) isTornRecord(offset int64, size int, err error, fileSize int64) bool {
+	if err == errTruncatedRecord {
+		return true
+	}
+	if size >= 5 && size <= fileStoreMaxRecordSize && offset+int64(size) == fileSize {
+		return true
+	}
+	// a crash can also leave the end of the file filled with zeros
+	rest := make([]byte, fileSize-offset)
+	if _, err := store.file.ReadAt(rest, offset); err != nil {
+		return false
+	}
+	for _, b := range rest {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+var errTruncatedRecord = errors.New("truncated record")
+
+func readFileStoreRecord(reader io.Reader) (record fileStoreRecord, size int, err error) {
+	header := make([]byte, 4)
+	if _, err = io.ReadFull(reader, header); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = errTruncatedRecord
+		}
+		return
+	}
+	size = int(binary.LittleEndian.Uint32(header))
+	if size < 5 || size > fileStoreMaxRecordSize {
+		err = fmt.Errorf("invalid record size %d", size)
+		return
+	}
+	b := make([]byte, size)
+	copy(b, header)
+	if _, err = io.ReadFull(reader, b[4:]); err != nil {
+		err = errTruncatedRecord
+		return
+	}
+	err = bson.Unmarshal(b, &record)
+	return
+}
+
+func (store *FileStore) replay(record fileStoreRecord) {
+	collection := store.collection(record.Collection)
+	key := documentKey(record.ID)
+	if record.Removed {
+		delete(collection.entries, key)
+		return
+	}
+	store.seq++
+	if existing, ok := collection.entries[key]; ok {
+		existing.doc = record.Document
+		return
+	}
+	collection.entries[key] = &fileStoreEntry{seq: store.seq, doc: record.Document}
+}
+
+func (store *FileStore) liveCount() int {
+	count := 0
+	for _, collection := range store.collections {
+		count += len(collection.entries)
+	}
+	return count
+}
+
+// compact rewrites the file with only the current documents
+func (store *FileStore) compact() error {
+	tempPath := store.path + ".compact"
+	temp, err := os.OpenFile(tempPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	records, err := writeCompacted(temp, store.collections)
+	if err == nil {
+		err = os.Rename(tempPath, store.path)
+	}
+	if err != nil {
+		temp.Close()
+		os.Remove(tempPath)
+		return err
+	}
+	store.file.Close()
+	store.file = temp
+	store.records = records
+	return nil
+}
+
+// writeCompacted writes a record for every document to the locked file
+func writeCompacted(file *os.File, collections map[string]*fileStoreCollection) (int, error) {
+	if err := lockFile(file); err != nil {
+		return 0, err
+	}
+	writer := bufio.NewWriter(file)
+	writer.WriteString(fileStoreMagic)
+	records := 0
+	for name, collection := range collections {
+		for _, entry := range collection.sortedEntries() {
+			b, err := bson.Marshal(fileStoreRecord{Collection: name, ID: entry.doc["_id"], Document: entry.doc})
+			if err != nil {
+				return 0, err
+			}
+			writer.Write(b)
+			records++
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return 0, err
+	}
+	return records, file.Sync()
+}
+
+// write appends the records to the file. When the write fails, the file is
+// truncated to where it was so that later records don't follow a partial one.
+func (store *FileStore) write(records ...fileStoreRecord) error {
+	if store.failed != nil {
+		return store.failed
+	}
+	var buffer []byte
+	for _, record := range records {
+		b, err := bson.Marshal(record)
+		if err != nil {
+			return err
+		}
+		buffer = append(buffer, b...)
+	}
+	offset, err := store.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	_, err = store.file.Write(buffer)
+	if err == nil {
+		err = store.file.Sync()
+	}
+	if err != nil {
+		if truncateErr := store.truncate(offset); truncateErr != nil {
+			store.failed = fmt.Errorf("%s needs to be opened again after a failed write: %v", store.path, truncateErr)
+			log.Printf("Unable to remove a failed write from %s\n\t%v", store.path, truncateErr)
+		}
+		return err
+	}
+	store.records += len(records)
+	return nil
+}
+
+// truncate removes everything after the offset of the file
+func (store *FileStore) truncate(offset int64) error {
+	if err := store.file.Truncate(offset); err != nil {
+		return err
+	}
+	if _, err := store.file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	return store.file.Sync()
+}
+
+// compactIfNeeded compacts the file once most of its records have been
+// replaced. This must be called after the documents in memory are updated.
+func (store *FileStore) compactIfNeeded() {
+	live := store.liveCount()
+	if replaced := store.records - live; replaced > fileStoreCompactThreshold && replaced > live {
+		if err := store.compact(); err != nil {
+			log.Printf("Unable to compact %s\n\t%v", store.path, err)
+		}
+	}
+}
+
+// CloseFile releases the file. The store can't be used afterwards.
+func (store *FileStore) CloseFile() error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	return store.file.Close()
+}
+
+func (store *FileStore) collection(name string) *fileStoreCollection {
+	collection, ok := store.collections[name]
+	if !ok {
+		collection = &fileStoreCollection{entries: make(map[string]*fileStoreEntry)}
+		store.collections[name] = collection
+	}
+	return collection
+}
+
+// existingCollection returns the collection without adding it, for reads
+// that only hold the read lock. A collection that doesn't exist is empty.
+func (store *FileStore) existingCollection(name string) *fileStoreCollection {
+	if collection, ok := store.collections[name]; ok {
+		return collection
+	}
+	return &fileStoreCollection{}
+}
+
+// documentKey converts a document ID to a map key. IDs of different types
+// have different keys, like MongoDB.
+func documentKey(id interface{}) string {
+	b, err := bson.Marshal(bson.M{"k": id})
+	if err != nil {
+		return fmt.Sprintf("%T:%v", id, id)
+	}
+	return string(b)
+}
+
+func (collection *fileStoreCollection) sortedEntries() []*fileStoreEntry {
+	entries := make([]*fileStoreEntry, 0, len(collection.entries))
+	for _, entry := range collection.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].seq < entries[j].seq
+	})
+	return entries
+}
+
+// matching returns the entries matching the query in insertion order
+func (collection *fileStoreCollection) matching(query bson.M) ([]*fileStoreEntry, error) {
+	var matches []*fileStoreEntry
+	for _, entry := range collection.sortedEntries() {
+		matched, err := matchDocument(entry.doc, query)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			matches = append(matches, entry)
+		}
+	}
+	return matches, nil
+}
+
+// firstEntry returns the first of the entries when sorted by the fields
+func firstEntry(entries []*fileStoreEntry, fields []string) *fileStoreEntry {
+	if len(fields) == 0 {
+		return entries[0]
+	}
+	docs := make([]bson.M, len(entries))
+	for i, entry := range entries {
+		docs[i] = entry.doc
+	}
+	sortDocuments(docs, fields)
+	firstKey := documentKey(docs[0]["_id"])
+	for _, entry := range entries {
+		if documentKey(entry.doc["_id"]) == firstKey {
+			return entry
+		}
+	}
+	return entries[0]
+}
+
+// checkUnique returns a duplicate key error if the document conflicts with
+// another document on the ID or a unique index
+func (collection *fileStoreCollection) checkUnique(name string, doc bson.M) error {
+	key := documentKey(doc["_id"])
+	for _, index := range collection.indexes {
+		if !index.Unique || (index.Sparse && !hasKeys(doc, index.Key)) {
+			continue
+		}
+		for otherKey, entry := range collection.entries {
+			if otherKey == key {
+				continue
+			}
+			if index.Sparse && !hasKeys(entry.doc, index.Key) {
+				continue
+			}
+			same := true
+			for _, field := range index.Key {
+				a, _ := lookupPath(doc, field)
+				b, _ := lookupPath(entry.doc, field)
+				if !valuesEqual(a, b) {
+					same = false
+					break
+				}
+			}
+			if same {
+				return &mgo.LastError{
+					Code: 11000,
+					Err:  fmt.Sprintf("E11000 duplicate key error collection: %s index: %v", name, index.Key),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// hasKeys determines if the document has every field of the index
+func hasKeys(doc bson.M, key []string) bool {
+	for _, field := range key {
+		if _, exists := lookupPath(doc, field); !exists {
+			return false
+		}
+	}
+	return true
+}
+
+// put stores the document, replacing any document with the same ID
+func (store *FileStore) put(name string, doc bson.M) error {
+	collection := store.collection(name)
+	if err := collection.checkUnique(name, doc); err != nil {
+		return err
+	}
+	if err := store.write(fileStoreRecord{Collection: name, ID: doc["_id"], Document: doc}); err != nil {
+		return err
+	}
+	key := documentKey(doc["_id"])
+	if existing, ok := collection.entries[key]; ok {
+		existing.doc = doc
+	} else {
+		store.seq++
+		collection.entries[key] = &fileStoreEntry{seq: store.seq, doc: doc}
+	}
+	store.compactIfNeeded()
+	return nil
+}
+
+func (store *FileStore) remove(name string, entries []*fileStoreEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	var records []fileStoreRecord
+	for _, entry := range entries {
+		records = append(records, fileStoreRecord{Collection: name, ID: entry.doc["_id"], Removed: true})
+	}
+	if err := store.write(records...); err != nil {
+		return err
+	}
+	collection := store.collection(name)
+	for _, entry := range entries {
+		delete(collection.entries, documentKey(entry.doc["_id"]))
+	}
+	store.compactIfNeeded()
+	return nil
+}
+
+// cloneDocument copies the document so callers can't modify stored values
+func cloneDocument(doc bson.M) bson.M {
+	clone, err := normalizeDocument(doc)
+	if err != nil {
+		panic(fmt.Sprintf("stored document can't be encoded: %v", err))
+	}
+	return clone
+}
+
+func (store *FileStore) C(name string) Collection {
+	return &fileCollection{store: store, name: name}
+}
+
+func (store *FileStore) CollectionNames() ([]string, error) {
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+	var names []string
+	for name, collection := range store.collections {
+		if len(collection.entries) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Close does nothing since the store is shared; see CloseFile
+func (store *FileStore) Close() {
+}
+
+type fileCollection struct {
+	store *FileStore
+	name  string
+}
+
+func (c *fileCollection) Find(query interface{}) Query {
+	return &fileQuery{collection: c, query: query}
+}
+
+func (c *fileCollection) FindId(id interface{}) Query {
+	return c.Find(bson.M{"_id": id})
+}
+
+func (c *fileCollection) Insert(docs ...interface{}) error {
+	c.store.lock.Lock()
+	defer c.store.lock.Unlock()
+	for _, value := range docs {
+		doc, err := normalizeDocument(value)
+		if err != nil {
+			return err
+		}
+		if _, ok := doc["_id"]; !ok {
+			doc["_id"] = bson.NewObjectId()
+		}
+		if _, exists := c.store.collection(c.name).entries[documentKey(doc["_id"])]; exists {
+			return &mgo.LastError{
+				Code: 11000,
+				Err:  fmt.Sprintf("E11000 duplicate key error collection: %s index: _id", c.name),
+			}
+		}
+		if err := c.store.put(c.name, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *fileCollection) Update(selector, update interface{}) error {
+	_, err := c.modify(selector, update, nil, false, false)
+	return err
+}
+
+func (c *fileCollection) UpdateId(id, update interface{}) error {
+	return c.Update(bson.M{"_id": id}, update)
+}
+
+func (c *fileCollection) Upsert(selector, update interface{}) error {
+	_, err := c.modify(selector, update, nil, true, false)
+	return err
+}
+
+func (c *fileCollection) UpsertId(id, update interface{}) error {
+	return c.Upsert(bson.M{"_id": id}, update)
+}
+
+// modify updates the first document matching the selector, optionally
+// inserting a document when none match, and returns the document before or
+// after the change
+func (c *fileCollection) modify(selector, update interface{}, sortFields []string, upsert, returnNew bool) (bson.M, error) {
+	query, err := normalizeDocument(selector)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := normalizeDocument(update)
+	if err != nil {
+		return nil, err
+	}
+
+	c.store.lock.Lock()
+	defer c.store.lock.Unlock()
+
+	matches, err := c.store.collection(c.name).matching(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		if !upsert {
+			return nil, ErrNotFound
+		}
+		doc, err := upsertDocument(query, changes)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := doc["_id"]; !ok {
+			doc["_id"] = bson.NewObjectId()
+		}
+		if err := c.store.put(c.name, doc); err != nil {
+			return nil, err
+		}
+		if returnNew {
+			return cloneDocument(doc), nil
+		}
+		return nil, nil
+	}
+
+	original := firstEntry(matches, sortFields).doc
+	doc := cloneDocument(original)
+	if err := applyUpdate(doc, changes); err != nil {
+		return nil, err
+	}
+	if err := c.store.put(c.name, doc); err != nil {
+		return nil, err
+	}
+	if returnNew {
+		return cloneDocument(doc), nil
+	}
+	return cloneDocument(original), nil
+}
+
+func (c *fileCollection) Remove(selector interface{}) error {
+	query, err := normalizeDocument(selector)
+	if err != nil {
+		return err
+	}
+
+	c.store.lock.Lock()
+	defer c.store.lock.Unlock()
+
+	matches, err := c.store.collection(c.name).matching(query)
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		return ErrNotFound
+	}
+	return c.store.remove(c.name, matches[:1])
+}
+
+func (c *fileCollection) RemoveAll(selector interface{}) (int, error) {
+	query, err := normalizeDocument(selector)
+	if err != nil {
+		return 0, err
+	}
+
+	c.store.lock.Lock()
+	defer c.store.lock.Unlock()
+
+	matches, err := c.store.collection(c.name).matching(query)
+	if err != nil {
+		return 0, err
+	}
+	if err := c.store.remove(c.name, matches); err != nil {
+		return 0, err
+	}
+	return len(matches), nil
+}
+
+// EnsureIndex records the index so unique indexes are enforced. Queries
+// always scan the collection so the index isn't otherwise used.
+func (c *fileCollection) EnsureIndex(index Index) error {
+	c.store.lock.Lock()
+	defer c.store.lock.Unlock()
+
+	collection := c.store.collection(c.name)
+	for _, existing := range collection.indexes {
+		if reflect.DeepEqual(existing.Key, index.Key) {
+			return nil
+		}
+	}
+	if index.Unique {
+		for _, entry := range collection.entries {
+			if err := collection.checkUnique(c.name, entry.doc); err != nil {
+				return err
+			}
+		}
+	}
+	collection.indexes = append(collection.indexes, index)
+	return nil
+}
+
+type fileQuery struct {
+	collection *fileCollection
+	query      interface{}
+	sort       []string
+	limit      int
+	projection interface{}
+}
+
+func (q *fileQuery) Sort(fields ...string) Query {
+	q.sort = fields
+	return q
+}
+
+func (q *fileQuery) Limit(n int) Query {
+	q.limit = n
+	return q
+}
+
+func (q *fileQuery) Select(selector interface{}) Query {
+	q.projection = selector
+	return q
+}
+
+// results returns copies of the matching documents
+func (q *fileQuery) results() ([]bson.M, error) {
+	query, err := normalizeDocument(q.query)
+	if err != nil {
+		return nil, err
+	}
+	projection, err := normalizeDocument(q.projection)
+	if err != nil {
+		return nil, err
+	}
+
+	store := q.collection.store
+	store.lock.RLock()
+	matches, err := store.existingCollection(q.collection.name).matching(query)
+	var docs []bson.M
+	for _, entry := range matches {
+		docs = append(docs, cloneDocument(entry.doc))
+	}
+	store.lock.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	sortDocuments(docs, q.sort)
+	if q.limit > 0 && len(docs) > q.limit {
+		docs = docs[:q.limit]
+	}
+	for i := range docs {
+		docs[i] = projectDocument(docs[i], projection)
+	}
+	return docs, nil
+}
+
+func (q *fileQuery) One(result interface{}) error {
+	docs, err := q.results()
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return ErrNotFound
+	}
+	return convertDocument(docs[0], result)
+}
+
+func (q *fileQuery) All(result interface{}) error {
+	docs, err := q.results()
+	if err != nil {
+		return err
+	}
+	resultValue := reflect.ValueOf(result)
+	if resultValue.Kind() != reflect.Ptr || resultValue.Elem().Kind() != reflect.Slice {
+		return errors.New("result argument must be a slice address")
+	}
+	sliceValue := resultValue.Elem()
+	sliceValue.Set(sliceValue.Slice(0, 0))
+	for _, doc := range docs {
+		element := reflect.New(sliceValue.Type().Elem())
+		if err := convertDocument(doc, element.Interface()); err != nil {
+			return err
+		}
+		sliceValue.Set(reflect.Append(sliceValue, element.Elem()))
+	}
+	return nil
+}
+
+func (q *fileQuery) Count() (int, error) {
+	docs, err := q.results()
+	return len(docs), err
+}
+
+func (q *fileQuery) Iter() Iter {
+	docs, err := q.results()
+	return &fileIter{docs: docs, err: err}
+}
+
+func (q *fileQuery) Apply(change Change, result interface{}) error {
+	if !change.Remove {
+		doc, err := q.collection.modify(q.query, change.Update, q.sort, change.Upsert, change.ReturnNew)
+		if err != nil {
+			return err
+		}
+		if doc == nil || result == nil {
+			return nil
+		}
+		return convertDocument(doc, result)
+	}
+
+	query, err := normalizeDocument(q.query)
+	if err != nil {
+		return err
+	}
+
+	store := q.collection.store
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	matches, err := store.collection(q.collection.name).matching(query)
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		return ErrNotFound
+	}
+	entry := firstEntry(matches, q.sort)
+	removed := cloneDocument(entry.doc)
+	if err := store.remove(q.collection.name, []*fileStoreEntry{entry}); err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return convertDocument(removed, result)
+}
+
+type fileIter struct {
+	docs []bson.M
+	err  error
+}
+
+func (iter *fileIter) Next(result interface{}) bool {
+	if iter.err != nil || len(iter.docs) == 0 {
+		return false
+	}
+	doc := iter.docs[0]
+	iter.docs = iter.docs[1:]
+	if err := convertDocument(doc, result); err != nil {
+		iter.err = err
+		return false
+	}
+	return true
+}
+
+func (iter *fileIter) Close() error {
+	return iter.err
+}
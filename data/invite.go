@@ -0,0 +1,186 @@
+package data
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+)
+
+const (
+	inviteCollectionName    = "invites"
+	inviteDocCurrentVersion = 1
+
+	// inviteCodeAlphabet excludes characters that are easily confused
+	inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	inviteCodeLength   = 10
+)
+
+// ErrInviteInvalid is returned when an invite code does not exist or has
+// already been redeemed
+var ErrInviteInvalid = errors.New("invite code is invalid or has already been used")
+
+// Invite is a single use code that allows someone to register when
+// registration is invite only
+type Invite struct {
+	code       string
+	createdBy  string
+	created    time.Time
+	redeemedBy string
+	redeemed   time.Time
+}
+
+func (invite *Invite) Code() string {
+	return invite.code
+}
+func (invite *Invite) CreatedBy() string {
+	return invite.createdBy
+}
+func (invite *Invite) Created() time.Time {
+	return invite.created
+}
+func (invite *Invite) RedeemedBy() string {
+	return invite.redeemedBy
+}
+func (invite *Invite) Redeemed() time.Time {
+	return invite.redeemed
+}
+func (invite *Invite) IsRedeemed() bool {
+	return len(invite.redeemedBy) > 0
+}
+
+type inviteDto struct {
+	Version    int
+	Code       string `bson:"_id"`
+	CreatedBy  string
+	Created    time.Time
+	RedeemedBy string    `bson:",omitempty"`
+	Redeemed   time.Time `bson:",omitempty"`
+}
+
+func (inviteData inviteDto) toInvite() *Invite {
+	return &Invite{
+		code:       inviteData.Code,
+		createdBy:  inviteData.CreatedBy,
+		created:    inviteData.Created,
+		redeemedBy: inviteData.RedeemedBy,
+		redeemed:   inviteData.Redeemed,
+	}
+}
+
+func generateInviteCode() (string, error) {
+	b := make([]byte, inviteCodeLength)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = inviteCodeAlphabet[int(b[i])%len(inviteCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+func normalizeInviteCode(code string) string {
+	return strings.ToUpper(strings.Replace(strings.TrimSpace(code), "-", "", -1))
+}
+
+// CreateInvite creates a new invite code. createdBy is the username of the
+// admin or a description of where the invite came from.
+func CreateInvite(createdBy string) (*Invite, error) {
+	code, err := generateInviteCode()
+	if err != nil {
+		return nil, err
+	}
+	inviteData := inviteDto{
+		Version:   inviteDocCurrentVersion,
+		Code:      code,
+		CreatedBy: createdBy,
+		Created:   time.Now().UTC(),
+	}
+
+	store, err := OpenStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	collection := store.C(inviteCollectionName)
+	if err = collection.Insert(inviteData); err != nil {
+		return nil, err
+	}
+	return inviteData.toInvite(), nil
+}
+
+// GetInvites returns all of the invites with the newest first
+func GetInvites() ([]*Invite, error) {
+	store, err := OpenStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	var inviteData []inviteDto
+	collection := store.C(inviteCollectionName)
+	if err := collection.Find(nil).Sort("-created").All(&inviteData); err != nil {
+		return nil, err
+	}
+
+	var invites []*Invite
+	for _, i := range inviteData {
+		invites = append(invites, i.toInvite())
+	}
+	return invites, nil
+}
+
+// IsInviteAvailable determines if the invite code can be redeemed
+func IsInviteAvailable(code string) bool {
+	store, err := OpenStore()
+	if err != nil {
+		return false
+	}
+	defer store.Close()
+
+	collection := store.C(inviteCollectionName)
+	count, err := collection.Find(bson.M{"_id": normalizeInviteCode(code), "redeemedby": bson.M{"$exists": false}}).Count()
+	return err == nil && count > 0
+}
+
+// RedeemInvite marks the invite as used by the user. ErrInviteInvalid is
+// returned if the invite has already been redeemed.
+func RedeemInvite(code, username string) error {
+	store, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	collection := store.C(inviteCollectionName)
+	err = collection.Update(
+		bson.M{"_id": normalizeInviteCode(code), "redeemedby": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"redeemedby": username, "redeemed": time.Now().UTC()}})
+	if err == ErrNotFound {
+		return ErrInviteInvalid
+	}
+	return err
+}
+
+// ReleaseInvite makes an invite redeemed by the user available again. This is
+// used when registration fails after the invite was redeemed.
+func ReleaseInvite(code, username string) error {
+	store, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	collection := store.C(inviteCollectionName)
+	err = collection.Update(
+		bson.M{"_id": normalizeInviteCode(code), "redeemedby": username},
+		bson.M{"$unset": bson.M{"redeemedby": "", "redeemed": ""}})
+	if err == ErrNotFound {
+		return ErrInviteInvalid
+	}
+	return err
+}
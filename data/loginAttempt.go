@@ -0,0 +1,92 @@
+package data
+
+import (
+	"strings"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+)
+
+const (
+	loginAttemptCollectionName    = "loginAttempts"
+	loginAttemptDocCurrentVersion = 1
+)
+
+// LoginAttempts tracks the failed login attempts for a key, such as an
+// account or an IP address
+type LoginAttempts struct {
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+type loginAttemptDto struct {
+	Version     int
+	Key         string `bson:"_id"`
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time `bson:",omitempty"`
+}
+
+// LoginAttemptsKeyForUsername is the key used to track the failed login
+// attempts against an account
+func LoginAttemptsKeyForUsername(username string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+// GetLoginAttempts retrieves the failed attempts for the key. The zero value
+// is returned when there have been no failed attempts.
+func GetLoginAttempts(key string) (LoginAttempts, error) {
+	store, err := OpenStore()
+	if err != nil {
+		return LoginAttempts{}, err
+	}
+	defer store.Close()
+
+	var attemptData loginAttemptDto
+	collection := store.C(loginAttemptCollectionName)
+	err = collection.FindId(key).One(&attemptData)
+	if err == ErrNotFound {
+		return LoginAttempts{}, nil
+	} else if err != nil {
+		return LoginAttempts{}, err
+	}
+	return LoginAttempts{
+		Failures:    attemptData.Failures,
+		LastFailure: attemptData.LastFailure,
+		LockedUntil: attemptData.LockedUntil,
+	}, nil
+}
+
+// SaveLoginAttempts stores the failed attempts for the key
+func SaveLoginAttempts(key string, attempts LoginAttempts) error {
+	store, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	collection := store.C(loginAttemptCollectionName)
+	err = collection.UpsertId(key, loginAttemptDto{
+		Version:     loginAttemptDocCurrentVersion,
+		Key:         key,
+		Failures:    attempts.Failures,
+		LastFailure: attempts.LastFailure,
+		LockedUntil: attempts.LockedUntil,
+	})
+	return err
+}
+
+// DeleteLoginAttempts removes the failed attempts for the key, which also
+// removes any lockout
+func DeleteLoginAttempts(key string) error {
+	store, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	collection := store.C(loginAttemptCollectionName)
+	_, err = collection.RemoveAll(bson.M{"_id": key})
+	return err
+}
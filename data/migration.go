@@ -0,0 +1,254 @@
+package data
+
+// Document schema migrations. Every document stores the version of its
+// schema in the "version" field. Each collection registers the steps that
+// upgrade a document from one version to the next. Documents are upgraded
+// lazily when they are read so old documents keep working during a rollout,
+// and `dbweb db migrate` upgrades all of the documents in bulk.
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/globalsign/mgo/bson"
+)
+
+// Migration upgrades a document of a collection from FromVersion to
+// FromVersion + 1. Upgrade modifies the document in place; the version
+// field is updated after Upgrade returns.
+type Migration struct {
+	Collection  string
+	FromVersion int
+	Description string
+	Upgrade     func(doc bson.M) error
+}
+
+// MigrationResult describes the outcome of migrating a collection
+type MigrationResult struct {
+	Collection string
+	Examined   int
+	Upgraded   int
+	DryRun     bool
+}
+
+// CollectionVersionStatus is the number of documents in a collection at each
+// version
+type CollectionVersionStatus struct {
+	Collection         string
+	CurrentVersion     int
+	DocumentsByVersion map[int]int
+}
+
+var migrationsLock sync.Mutex
+var migrations = make(map[string][]Migration)
+
+// RegisterMigration adds the migration to the registry. Migrations for a
+// collection must form a contiguous chain of versions.
+func RegisterMigration(migration Migration) {
+	migrationsLock.Lock()
+	defer migrationsLock.Unlock()
+
+	collectionMigrations := append(migrations[migration.Collection], migration)
+	sort.Slice(collectionMigrations, func(i, j int) bool {
+		return collectionMigrations[i].FromVersion < collectionMigrations[j].FromVersion
+	})
+	for i := 1; i < len(collectionMigrations); i++ {
+		if collectionMigrations[i].FromVersion != collectionMigrations[i-1].FromVersion+1 {
+			panic(fmt.Sprintf("migrations for %s must be contiguous: found %d after %d",
+				migration.Collection, collectionMigrations[i].FromVersion, collectionMigrations[i-1].FromVersion))
+		}
+	}
+	migrations[migration.Collection] = collectionMigrations
+}
+
+// MigrationCollections returns the names of the collections with migrations
+func MigrationCollections() []string {
+	migrationsLock.Lock()
+	defer migrationsLock.Unlock()
+
+	var names []string
+	for name := range migrations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MigrationsFor returns the registered migrations of the collection in order
+func MigrationsFor(collection string) []Migration {
+	migrationsLock.Lock()
+	defer migrationsLock.Unlock()
+	return append([]Migration(nil), migrations[collection]...)
+}
+
+// CurrentDocumentVersion is the version documents of the collection are
+// upgraded to
+func CurrentDocumentVersion(collection string) int {
+	collectionMigrations := MigrationsFor(collection)
+	if len(collectionMigrations) == 0 {
+		return 1
+	}
+	return collectionMigrations[len(collectionMigrations)-1].FromVersion + 1
+}
+
+// documentVersion reads the version field of the document. Documents without
+// a version are considered version 1.
+func documentVersion(doc bson.M) int {
+	switch v := doc["version"].(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 1
+	}
+}
+
+// UpgradeDocument applies the migrations needed to bring the document to the
+// current version of the collection. changed is true when the document was
+// modified.
+func UpgradeDocument(collection string, doc bson.M) (changed bool, err error) {
+	version := documentVersion(doc)
+	for _, migration := range MigrationsFor(collection) {
+		if migration.FromVersion < version {
+			continue
+		}
+		if migration.FromVersion != version {
+			return changed, fmt.Errorf("no migration for %s from version %d", collection, version)
+		}
+		if err = migration.Upgrade(doc); err != nil {
+			return changed, fmt.Errorf("unable to upgrade %s from version %d: %v", collection, version, err)
+		}
+		version++
+		doc["version"] = version
+		changed = true
+	}
+	return changed, nil
+}
+
+// findOneUpgraded finds a single document and upgrades it to the current
+// version before decoding it into result. Upgraded documents are written
+// back, unless another process has already changed the document.
+func findOneUpgraded(collection Collection, collectionName string, query interface{}, result interface{}) error {
+	var doc bson.M
+	if err := collection.Find(query).One(&doc); err != nil {
+		return err
+	}
+	if _, err := upgradeAndSave(collection, collectionName, doc); err != nil {
+		return err
+	}
+	return convertDocument(doc, result)
+}
+
+// upgradeAndSave upgrades the document and, when it changed, replaces the
+// stored document as long as its version hasn't changed since it was read.
+// saved is true when the stored document was replaced.
+func upgradeAndSave(collection Collection, collectionName string, doc bson.M) (saved bool, err error) {
+	originalVersion := documentVersion(doc)
+	changed, err := UpgradeDocument(collectionName, doc)
+	if err != nil || !changed {
+		return false, err
+	}
+	selector := bson.M{"_id": doc["_id"], "version": originalVersion}
+	if originalVersion == 1 {
+		selector["version"] = bson.M{"$in": []interface{}{1, nil}}
+	}
+	err = collection.Update(selector, doc)
+	if err == ErrNotFound {
+		// someone else upgraded or changed the document first
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// convertDocument decodes the generic document into a typed value
+func convertDocument(doc bson.M, result interface{}) error {
+	b, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(b, result)
+}
+
+// MigrateCollection upgrades every document of the collection that is older
+// than the current version. When dryRun is true, the documents are upgraded
+// in memory to check the migrations but are not saved.
+func MigrateCollection(name string, dryRun bool) (MigrationResult, error) {
+	result := MigrationResult{
+		Collection: name,
+		DryRun:     dryRun,
+	}
+
+	store, err := OpenStore()
+	if err != nil {
+		return result, err
+	}
+	defer store.Close()
+
+	collection := store.C(name)
+	query := bson.M{"$or": []bson.M{
+		{"version": bson.M{"$lt": CurrentDocumentVersion(name)}},
+		{"version": bson.M{"$exists": false}},
+	}}
+	iter := collection.Find(query).Iter()
+	var doc bson.M
+	for iter.Next(&doc) {
+		result.Examined++
+		if dryRun {
+			changed, err := UpgradeDocument(name, doc)
+			if err != nil {
+				iter.Close()
+				return result, err
+			}
+			if changed {
+				result.Upgraded++
+			}
+		} else {
+			saved, err := upgradeAndSave(collection, name, doc)
+			if err != nil {
+				iter.Close()
+				return result, err
+			}
+			if saved {
+				result.Upgraded++
+			}
+		}
+		doc = nil
+	}
+	return result, iter.Close()
+}
+
+// GetMigrationStatus counts the documents at each version for every
+// collection with migrations
+func GetMigrationStatus() ([]CollectionVersionStatus, error) {
+	store, err := OpenStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	var statuses []CollectionVersionStatus
+	for _, name := range MigrationCollections() {
+		collection := store.C(name)
+		status := CollectionVersionStatus{
+			Collection:         name,
+			CurrentVersion:     CurrentDocumentVersion(name),
+			DocumentsByVersion: make(map[int]int),
+		}
+
+		var docs []bson.M
+		if err := collection.Find(nil).Select(bson.M{"version": 1}).All(&docs); err != nil {
+			return nil, err
+		}
+		for _, doc := range docs {
+			status.DocumentsByVersion[documentVersion(doc)]++
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
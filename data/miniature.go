@@ -1,13 +1,16 @@
 package data
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/csv"
+	"encoding/hex"
 	"fmt"
-	"log"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 const (
@@ -141,6 +144,8 @@ func miniComparator(m1 *Miniature, m2 *Miniature) bool {
 
 // GetMiniatureByID retrieves a miniature by it's ID
 func GetMiniatureByID(id string) (*Miniature, error) {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
 	recordIndex, exists := idToIndex[strings.ToLower(id)]
 	if !exists {
 		return nil, fmt.Errorf("Unable to find miniature with ID '%s'", id)
@@ -152,6 +157,8 @@ func GetMiniatureByID(id string) (*Miniature, error) {
 // GetMiniaturesBySet retrieves all of the miniatures that correspond
 // to the given set code.
 func GetMiniaturesBySet(setCode string) ([]*Miniature, error) {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
 	set, exists := setToMinis[strings.ToLower(setCode)]
 	if !exists {
 		return nil, fmt.Errorf("Unable to find set with code '%s'", setCode)
@@ -159,28 +166,58 @@ func GetMiniaturesBySet(setCode string) ([]*Miniature, error) {
 	return set, nil
 }
 
-func loadDataFromFile() [][]string {
-	filepath := os.Getenv("DATA")
+// LoadMiniatures reads the miniatures from the CSV file, replacing any that
+// were loaded before. The current miniatures are kept if the file can't be
+// read.
+func LoadMiniatures(filepath string) error {
 	if len(filepath) == 0 {
-		log.Fatalf("Environment variable DATA not found. Please specify the location of the Dreamblade data as a CSV file.")
+		return fmt.Errorf("no data file specified")
 	}
+	rawData, version, err := loadDataFromFile(filepath)
+	if err != nil {
+		return err
+	}
+	miniatures := convertRecordToMiniature(rawData)
+	newIDToIndex, newSetToMinis := buildIDToIndex(miniatures)
+
+	catalogLock.Lock()
+	defer catalogLock.Unlock()
+	data = miniatures
+	idToIndex = newIDToIndex
+	setToMinis = newSetToMinis
+	catalogVersion = version
+	return nil
+}
+
+// CatalogVersion identifies the content of the data file the miniatures
+// were loaded from, so anything made from the catalog can be cached until
+// it changes
+func CatalogVersion() string {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
+	return catalogVersion
+}
 
-	file, err := os.Open(filepath)
+func loadDataFromFile(filepath string) ([][]string, string, error) {
+	content, err := os.ReadFile(filepath)
 	if err != nil {
-		log.Fatalf("Unable to open data file: %s\n%s", filepath, err)
+		return nil, "", fmt.Errorf("Unable to open data file: %s\n%s", filepath, err)
 	}
-	r := csv.NewReader(file)
+	r := csv.NewReader(bytes.NewReader(content))
 	d, err := r.ReadAll()
 	if err != nil {
-		log.Fatalf("Failed to read data file: %s\n%s", filepath, err)
+		return nil, "", fmt.Errorf("Failed to read data file: %s\n%s", filepath, err)
+	}
+	if len(d) == 0 {
+		return nil, "", fmt.Errorf("Data file is empty: %s", filepath)
 	}
-	return d[1:]
+	sum := sha256.Sum256(content)
+	return d[1:], hex.EncodeToString(sum[:8]), nil
 }
 
-func buildIDToIndex(miniatures []Miniature) {
+func buildIDToIndex(miniatures []Miniature) (idToIndex map[string]int, setToMinis map[string][]*Miniature) {
 	idToIndex = make(map[string]int)
 	setToMinis = make(map[string][]*Miniature)
-
 	for i := range miniatures {
 		miniature := &miniatures[i]
 		idToIndex[miniature.id] = i
@@ -207,6 +244,7 @@ func buildIDToIndex(miniatures []Miniature) {
 			}
 		}
 	}
+	return idToIndex, setToMinis
 }
 
 func createIDFromName(name string) string {
@@ -222,12 +260,9 @@ func convertRecordToMiniature(records [][]string) []Miniature {
 	return data
 }
 
-func init() {
-	rawData := loadDataFromFile()
-	data = convertRecordToMiniature(rawData)
-	buildIDToIndex(data)
-}
-
+// catalogLock guards the miniatures so they can be reloaded while serving
+var catalogLock sync.RWMutex
 var data []Miniature
 var idToIndex map[string]int
 var setToMinis map[string][]*Miniature
+var catalogVersion string
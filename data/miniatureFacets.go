@@ -0,0 +1,97 @@
+package data
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FacetValue is a value of a facet and the number of miniatures with it
+type FacetValue struct {
+	Value string
+	Count int
+}
+
+// MiniatureFacets are the values of each facet of the catalog. The count of
+// a value is the number of miniatures that would match the filter if the
+// value were the only one chosen for its facet, so choosing more values of
+// a facet broadens the search while the other facets narrow it.
+type MiniatureFacets struct {
+	Sets        []FacetValue
+	Aspects     []FacetValue
+	Lineages    []FacetValue
+	Rarities    []FacetValue
+	SpawnCosts  []FacetValue
+	AspectCosts []FacetValue
+}
+
+// GetMiniatureFacets counts the values of each facet for the filter. Sets
+// are in the order of the sets, costs are in numeric order and the others
+// are alphabetical. Values without a match are left out.
+func GetMiniatureFacets(filter MiniatureFilter) MiniatureFacets {
+	minis := GetMiniatures()
+	count := func(without func(f *MiniatureFilter), value func(mini *Miniature) string) map[string]*FacetValue {
+		facetFilter := filter
+		without(&facetFilter)
+		counts := make(map[string]*FacetValue)
+		for _, mini := range minis {
+			v := strings.TrimSpace(value(mini))
+			if len(v) == 0 || !facetFilter.Matches(mini) {
+				continue
+			}
+			key := strings.ToLower(v)
+			if counts[key] == nil {
+				counts[key] = &FacetValue{Value: v}
+			}
+			counts[key].Count++
+		}
+		return counts
+	}
+
+	setCounts := count(func(f *MiniatureFilter) { f.Sets = nil }, (*Miniature).SetCode)
+	facets := MiniatureFacets{
+		Aspects:  sortedFacetValues(count(func(f *MiniatureFilter) { f.Aspects = nil }, (*Miniature).Aspect), alphabetical),
+		Lineages: sortedFacetValues(count(func(f *MiniatureFilter) { f.Lineages = nil }, (*Miniature).Lineage), alphabetical),
+		Rarities: sortedFacetValues(count(func(f *MiniatureFilter) { f.Rarities = nil }, (*Miniature).Rarity), alphabetical),
+		SpawnCosts: sortedFacetValues(count(func(f *MiniatureFilter) { f.MinSpawnCost, f.MaxSpawnCost = 0, 0 },
+			func(mini *Miniature) string { return numberOrEmpty(mini.spawnCost) }), numeric),
+		AspectCosts: sortedFacetValues(count(func(f *MiniatureFilter) { f.MinAspectCost, f.MaxAspectCost = 0, 0 },
+			func(mini *Miniature) string { return numberOrEmpty(mini.aspectCost) }), numeric),
+	}
+	for i := range sets {
+		if value, exists := setCounts[strings.ToLower(sets[i].id)]; exists {
+			facets.Sets = append(facets.Sets, *value)
+		}
+	}
+	return facets
+}
+
+func alphabetical(a, b string) bool {
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+func numeric(a, b string) bool {
+	n1, _ := strconv.Atoi(a)
+	n2, _ := strconv.Atoi(b)
+	return n1 < n2
+}
+
+// numberOrEmpty leaves out costs that can't be chosen with a range
+func numberOrEmpty(stat string) string {
+	n, err := strconv.Atoi(strings.TrimSpace(stat))
+	if err != nil {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func sortedFacetValues(counts map[string]*FacetValue, less func(a, b string) bool) []FacetValue {
+	var values []FacetValue
+	for _, value := range counts {
+		values = append(values, *value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return less(values[i].Value, values[j].Value)
+	})
+	return values
+}
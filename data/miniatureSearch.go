@@ -0,0 +1,110 @@
+package data
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MiniatureFilter selects miniatures from the catalog. Empty fields match
+// every miniature. Values are compared without regard to case.
+type MiniatureFilter struct {
+	// Text matches miniatures with the text in their name, lineage or
+	// abilities
+	Text     string
+	Sets     []string
+	Aspects  []string
+	Lineages []string
+	Rarities []string
+	// MinSpawnCost and MaxSpawnCost limit the spawn cost. A MaxSpawnCost of
+	// zero is no limit.
+	MinSpawnCost int
+	MaxSpawnCost int
+	// MinAspectCost and MaxAspectCost limit the aspect cost the same way
+	MinAspectCost int
+	MaxAspectCost int
+}
+
+// Matches determines if the miniature is selected by the filter
+func (filter MiniatureFilter) Matches(mini *Miniature) bool {
+	if text := strings.ToLower(strings.TrimSpace(filter.Text)); len(text) > 0 {
+		if !strings.Contains(strings.ToLower(mini.name), text) &&
+			!strings.Contains(strings.ToLower(mini.lineage), text) &&
+			!strings.Contains(strings.ToLower(mini.abilities), text) {
+			return false
+		}
+	}
+	if !matchesAny(filter.Sets, mini.set) ||
+		!matchesAny(filter.Aspects, mini.aspect) ||
+		!matchesAny(filter.Lineages, mini.lineage) ||
+		!matchesAny(filter.Rarities, mini.rarity) {
+		return false
+	}
+	return inRange(mini.spawnCost, filter.MinSpawnCost, filter.MaxSpawnCost) &&
+		inRange(mini.aspectCost, filter.MinAspectCost, filter.MaxAspectCost)
+}
+
+// inRange determines if the stat is within the range. A max of zero is no
+// limit and a stat that isn't a number is only in the range when there are
+// no limits.
+func inRange(stat string, min, max int) bool {
+	if min <= 0 && max <= 0 {
+		return true
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(stat))
+	if err != nil {
+		return false
+	}
+	return n >= min && (max <= 0 || n <= max)
+}
+
+func matchesAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMiniatures returns every miniature ordered by set and then collector
+// number
+func GetMiniatures() []*Miniature {
+	return SearchMiniatures(MiniatureFilter{})
+}
+
+// SearchMiniatures returns the miniatures selected by the filter ordered by
+// set and then collector number
+func SearchMiniatures(filter MiniatureFilter) []*Miniature {
+	catalogLock.RLock()
+	defer catalogLock.RUnlock()
+
+	var minis []*Miniature
+	for i := range data {
+		if filter.Matches(&data[i]) {
+			mini := data[i]
+			minis = append(minis, &mini)
+		}
+	}
+	sort.SliceStable(minis, func(i, j int) bool {
+		if setI, setJ := setOrder(minis[i].set), setOrder(minis[j].set); setI != setJ {
+			return setI < setJ
+		}
+		return miniComparator(minis[i], minis[j])
+	})
+	return minis
+}
+
+// setOrder is the position of the set in the list of sets. Unknown sets are
+// ordered last.
+func setOrder(setCode string) int {
+	for i := range sets {
+		if strings.EqualFold(sets[i].id, setCode) {
+			return i
+		}
+	}
+	return len(sets)
+}
@@ -0,0 +1,61 @@
+package data
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MiniatureSortKeys are the orders SortMiniatures accepts. "number" is the
+// order of the catalog: by set and then collector number.
+var MiniatureSortKeys = []string{"number", "name", "spawnCost", "aspectCost", "power", "defense", "life"}
+
+// SortMiniatures orders the miniatures by the key, keeping the order of the
+// catalog for ties. Miniatures without a number for the stat are last in
+// either direction. It returns false for an unknown key.
+func SortMiniatures(minis []*Miniature, key string, descending bool) bool {
+	var stat func(mini *Miniature) string
+	switch key {
+	case "number":
+		if descending {
+			for i, j := 0, len(minis)-1; i < j; i, j = i+1, j-1 {
+				minis[i], minis[j] = minis[j], minis[i]
+			}
+		}
+		return true
+	case "name":
+		sort.SliceStable(minis, func(i, j int) bool {
+			a, b := strings.ToLower(minis[i].name), strings.ToLower(minis[j].name)
+			if descending {
+				return a > b
+			}
+			return a < b
+		})
+		return true
+	case "spawnCost":
+		stat = (*Miniature).SpawnCost
+	case "aspectCost":
+		stat = (*Miniature).AspectCost
+	case "power":
+		stat = (*Miniature).Power
+	case "defense":
+		stat = (*Miniature).Defense
+	case "life":
+		stat = (*Miniature).Life
+	default:
+		return false
+	}
+
+	sort.SliceStable(minis, func(i, j int) bool {
+		a, errA := strconv.Atoi(strings.TrimSpace(stat(minis[i])))
+		b, errB := strconv.Atoi(strings.TrimSpace(stat(minis[j])))
+		if errA != nil || errB != nil {
+			return errA == nil && errB != nil
+		}
+		if descending {
+			return a > b
+		}
+		return a < b
+	})
+	return true
+}
@@ -0,0 +1,115 @@
+package data
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CatalogProblem is a problem with a record in the catalog data file
+type CatalogProblem struct {
+	// Line is the line in the file where the record starts
+	Line    int
+	Name    string
+	Message string
+}
+
+func (problem CatalogProblem) String() string {
+	if len(problem.Name) == 0 {
+		return fmt.Sprintf("line %d: %s", problem.Line, problem.Message)
+	}
+	return fmt.Sprintf("line %d (%s): %s", problem.Line, problem.Name, problem.Message)
+}
+
+// ValidateMiniatures checks the records of the CSV file for missing fields,
+// stats that aren't numbers, unknown sets and duplicates. The number of
+// records is returned with the problems found. An error is returned when the
+// file can't be read at all.
+func ValidateMiniatures(filepath string) (records int, problems []CatalogProblem, err error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return 0, nil, fmt.Errorf("Unable to open data file: %s\n%s", filepath, err)
+	}
+	defer file.Close()
+
+	r := csv.NewReader(file)
+	// records with the wrong number of fields are reported as problems
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err == io.EOF {
+		return 0, nil, fmt.Errorf("Data file is empty: %s", filepath)
+	} else if err != nil {
+		return 0, nil, fmt.Errorf("Failed to read data file: %s\n%s", filepath, err)
+	}
+	if len(header) <= rarityIndex {
+		return 0, nil, fmt.Errorf("Data file has %d columns, expected %d: %s", len(header), rarityIndex+1, filepath)
+	}
+
+	idToLine := make(map[string]int)
+	numberToLine := make(map[string]int)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return records, problems, fmt.Errorf("Failed to read data file: %s\n%s", filepath, err)
+		}
+		records++
+		line, _ := r.FieldPos(0)
+		if len(record) != len(header) {
+			problems = append(problems, CatalogProblem{
+				Line:    line,
+				Name:    record[nameIndex],
+				Message: fmt.Sprintf("has %d fields, expected %d", len(record), len(header)),
+			})
+			continue
+		}
+		mini := newMiniature(record)
+		report := func(format string, a ...interface{}) {
+			problems = append(problems, CatalogProblem{Line: line, Name: mini.name, Message: fmt.Sprintf(format, a...)})
+		}
+
+		if strings.TrimSpace(mini.name) == "" {
+			report("name is missing")
+		} else if previous, exists := idToLine[mini.id]; exists {
+			report("same ID as line %d: %s", previous, mini.id)
+		} else {
+			idToLine[mini.id] = line
+		}
+		for _, field := range []struct{ name, value string }{
+			{"lineage", mini.lineage},
+			{"aspect", mini.aspect},
+			{"rarity", mini.rarity},
+		} {
+			if strings.TrimSpace(field.value) == "" {
+				report("%s is missing", field.name)
+			}
+		}
+		for _, field := range []struct{ name, value string }{
+			{"spawn cost", mini.spawnCost},
+			{"aspect cost", mini.aspectCost},
+			{"power", mini.power},
+			{"defense", mini.defense},
+			{"life", mini.life},
+			{"collector number", mini.collectorNumber},
+		} {
+			if _, err := strconv.Atoi(strings.TrimSpace(field.value)); err != nil {
+				report("%s is not a number: %q", field.name, field.value)
+			}
+		}
+		if _, err := GetMiniatureSetByID(strings.TrimSpace(mini.set)); err != nil {
+			report("unknown set: %q", mini.set)
+		} else if mini.CollectorNumberAsInt() >= 0 {
+			key := strings.ToUpper(strings.TrimSpace(mini.set)) + "/" + strings.TrimSpace(mini.collectorNumber)
+			if previous, exists := numberToLine[key]; exists {
+				report("same set and collector number as line %d: %s", previous, key)
+			} else {
+				numberToLine[key] = line
+			}
+		}
+	}
+	return records, problems, nil
+}
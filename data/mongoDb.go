@@ -1,33 +1,158 @@
 package data
 
 import (
+	"errors"
+	"io"
 	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/globalsign/mgo"
 )
 
-var session *mgo.Session
+// MongoConfig contains the settings used to connect to MongoDB
+type MongoConfig struct {
+	// URI is a standard MongoDB connection string
+	URI string
+	// Username and Password override the credentials in the URI when set
+	Username string
+	Password string
+	// AuthSource is the database the credentials are defined in
+	AuthSource string
+	// Database is the database the site's collections are stored in
+	Database string
+	// DialTimeout limits how long to wait when connecting and when waiting
+	// for a reachable server
+	DialTimeout time.Duration
+	// SocketTimeout limits how long to wait for a response to an operation
+	SocketTimeout time.Duration
+	// PoolLimit is the maximum number of sockets per server
+	PoolLimit int
+	// MaxBackoff is the longest time to wait between connection attempts
+	// after the connection fails
+	MaxBackoff time.Duration
+}
+
+// DefaultMongoConfig connects to a local MongoDB without credentials
+func DefaultMongoConfig() MongoConfig {
+	return MongoConfig{
+		URI:           "mongodb://127.0.0.1:27017",
+		AuthSource:    "admin",
+		Database:      "dreamblade",
+		DialTimeout:   10 * time.Second,
+		SocketTimeout: time.Minute,
+		PoolLimit:     4096,
+		MaxBackoff:    30 * time.Second,
+	}
+}
+
+// ErrDatabaseUnavailable is returned when MongoDB can't be reached. The
+// connection is retried with backoff so callers should try again later.
+var ErrDatabaseUnavailable = errors.New("database unavailable")
+
+// IsDatabaseUnavailable determines if the error was caused by MongoDB being
+// unreachable rather than a problem with the request
+func IsDatabaseUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == ErrDatabaseUnavailable || err == io.EOF {
+		return true
+	}
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	message := err.Error()
+	return strings.Contains(message, "no reachable servers") ||
+		strings.Contains(message, "Closed explicitly") ||
+		strings.Contains(message, "connection reset")
+}
+
+var mongoConfig = DefaultMongoConfig()
+
+var (
+	sessionLock   sync.Mutex
+	rootSession   *mgo.Session
+	failures      int
+	nextDialAfter time.Time
+)
+
+// ConfigureMongo replaces the connection settings. This must be called
+// before the first connection is made.
+func ConfigureMongo(config MongoConfig) {
+	sessionLock.Lock()
+	defer sessionLock.Unlock()
+	mongoConfig = config
+}
 
 func GetMongoDbName() string {
-	return "dreamblade"
+	return mongoConfig.Database
 }
 
-func GetMongoSession() *mgo.Session {
-	if session == nil {
-		var err error
-		session, err = mgo.Dial("mongodb://127.0.0.1:27017")
-		if err != nil {
-			log.Fatalf("Unable to contact MongoDB\n\t%v", err)
-		}
+// GetMongoSession returns a new session from the connection pool that the
+// caller must close. When MongoDB can't be reached, ErrDatabaseUnavailable
+// is returned and further attempts to connect are delayed with exponential
+// backoff so that requests fail fast instead of waiting on the dial timeout.
+func GetMongoSession() (*mgo.Session, error) {
+	sessionLock.Lock()
+	defer sessionLock.Unlock()
 
-		cred := mgo.Credential{
-			Username: "mongoadmin",
-			Password: "secret",
+	if rootSession == nil {
+		if time.Now().Before(nextDialAfter) {
+			return nil, ErrDatabaseUnavailable
 		}
-		err = session.Login(&cred)
+		newSession, err := dialMongo(mongoConfig)
 		if err != nil {
-			log.Fatalf("Unable to authenticate\n\t%v", err)
+			failures++
+			backoff := mongoBackoff(failures, mongoConfig.MaxBackoff)
+			nextDialAfter = time.Now().Add(backoff)
+			log.Printf("Unable to connect to MongoDB, retrying in %s\n\t%v", backoff, err)
+			return nil, ErrDatabaseUnavailable
 		}
+		if failures > 0 {
+			log.Printf("Connected to MongoDB after %d failed attempts", failures)
+		}
+		failures = 0
+		rootSession = newSession
+	}
+	return rootSession.Clone(), nil
+}
+
+func mongoBackoff(failures int, max time.Duration) time.Duration {
+	backoff := time.Second
+	for i := 1; i < failures && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		return max
+	}
+	return backoff
+}
+
+func dialMongo(config MongoConfig) (*mgo.Session, error) {
+	dialInfo, err := mgo.ParseURL(config.URI)
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Username) > 0 {
+		dialInfo.Username = config.Username
+		dialInfo.Password = config.Password
+	}
+	if len(dialInfo.Username) > 0 && len(dialInfo.Source) == 0 {
+		dialInfo.Source = config.AuthSource
+	}
+	dialInfo.Timeout = config.DialTimeout
+	if config.PoolLimit > 0 {
+		dialInfo.PoolLimit = config.PoolLimit
+	}
+
+	newSession, err := mgo.DialWithInfo(dialInfo)
+	if err != nil {
+		return nil, err
 	}
-	return session.Clone()
+	newSession.SetSyncTimeout(config.DialTimeout)
+	newSession.SetSocketTimeout(config.SocketTimeout)
+	return newSession, nil
 }
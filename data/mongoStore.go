@@ -0,0 +1,133 @@
+package data
+
+import (
+	"strings"
+
+	"github.com/globalsign/mgo"
+)
+
+// mongoStore is a Store backed by a MongoDB session
+type mongoStore struct {
+	session *mgo.Session
+}
+
+func (store *mongoStore) C(name string) Collection {
+	return &mongoCollection{collection: store.session.DB(GetMongoDbName()).C(name)}
+}
+
+func (store *mongoStore) CollectionNames() ([]string, error) {
+	names, err := store.session.DB(GetMongoDbName()).CollectionNames()
+	if err != nil {
+		return nil, err
+	}
+	var userNames []string
+	for _, name := range names {
+		if !strings.HasPrefix(name, "system.") {
+			userNames = append(userNames, name)
+		}
+	}
+	return userNames, nil
+}
+
+func (store *mongoStore) Close() {
+	store.session.Close()
+}
+
+type mongoCollection struct {
+	collection *mgo.Collection
+}
+
+func (c *mongoCollection) Find(query interface{}) Query {
+	return &mongoQuery{query: c.collection.Find(query)}
+}
+
+func (c *mongoCollection) FindId(id interface{}) Query {
+	return &mongoQuery{query: c.collection.FindId(id)}
+}
+
+func (c *mongoCollection) Insert(docs ...interface{}) error {
+	return c.collection.Insert(docs...)
+}
+
+func (c *mongoCollection) Update(selector, update interface{}) error {
+	return c.collection.Update(selector, update)
+}
+
+func (c *mongoCollection) UpdateId(id, update interface{}) error {
+	return c.collection.UpdateId(id, update)
+}
+
+func (c *mongoCollection) Upsert(selector, update interface{}) error {
+	_, err := c.collection.Upsert(selector, update)
+	return err
+}
+
+func (c *mongoCollection) UpsertId(id, update interface{}) error {
+	_, err := c.collection.UpsertId(id, update)
+	return err
+}
+
+func (c *mongoCollection) Remove(selector interface{}) error {
+	return c.collection.Remove(selector)
+}
+
+func (c *mongoCollection) RemoveAll(selector interface{}) (int, error) {
+	info, err := c.collection.RemoveAll(selector)
+	if err != nil {
+		return 0, err
+	}
+	return info.Removed, nil
+}
+
+func (c *mongoCollection) EnsureIndex(index Index) error {
+	return c.collection.EnsureIndex(mgo.Index{
+		Key:    index.Key,
+		Unique: index.Unique,
+		Sparse: index.Sparse,
+	})
+}
+
+type mongoQuery struct {
+	query *mgo.Query
+}
+
+func (q *mongoQuery) Sort(fields ...string) Query {
+	q.query.Sort(fields...)
+	return q
+}
+
+func (q *mongoQuery) Limit(n int) Query {
+	q.query.Limit(n)
+	return q
+}
+
+func (q *mongoQuery) Select(selector interface{}) Query {
+	q.query.Select(selector)
+	return q
+}
+
+func (q *mongoQuery) One(result interface{}) error {
+	return q.query.One(result)
+}
+
+func (q *mongoQuery) All(result interface{}) error {
+	return q.query.All(result)
+}
+
+func (q *mongoQuery) Count() (int, error) {
+	return q.query.Count()
+}
+
+func (q *mongoQuery) Iter() Iter {
+	return q.query.Iter()
+}
+
+func (q *mongoQuery) Apply(change Change, result interface{}) error {
+	_, err := q.query.Apply(mgo.Change{
+		Update:    change.Update,
+		Upsert:    change.Upsert,
+		Remove:    change.Remove,
+		ReturnNew: change.ReturnNew,
+	}, result)
+	return err
+}
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	passwordHashScheme     = "pbkdf2-sha256"
+	passwordHashIterations = 210000
+	passwordSaltLength     = 16
+	passwordKeyLength      = 32
+
+	// MinPasswordLength is the minimum number of characters in a password
+	MinPasswordLength = 8
+)
+
+// ErrInvalidCredentials is returned when the username or password is incorrect
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// validatePassword checks that the password meets the minimum requirements
+func validatePassword(password string) error {
+	if len([]rune(password)) < MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+	}
+	return nil
+}
+
+// hashPassword hashes the password with a random salt. The result contains
+// the scheme, iterations and salt so the parameters can be changed later
+// without invalidating existing hashes.
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordHashIterations, passwordKeyLength)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join([]string{
+		passwordHashScheme,
+		strconv.Itoa(passwordHashIterations),
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	}, "$"), nil
+}
+
+// checkPasswordHash determines if the password matches the hash created by
+// hashPassword
+func checkPasswordHash(password, hash string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != passwordHashScheme {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+	actual, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(expected))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(expected, actual) == 1
+}
@@ -0,0 +1,203 @@
+package data
+
+// Storage abstraction for the site's documents (users, sessions, tokens and
+// so on). The interfaces mirror the subset of mgo used by the data functions
+// so that documents are stored the same way in every backend: values are
+// encoded to BSON and queried with the MongoDB query and update operators.
+//
+// Two backends are available: MongoDB and an embedded file store that keeps
+// the documents in a single file. The backend is selected at startup with
+// ConfigureStorage.
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+)
+
+const (
+	// StorageMongo stores documents in MongoDB
+	StorageMongo = "mongo"
+	// StorageFile stores documents in an embedded file
+	StorageFile = "file"
+)
+
+// StorageBackends are the names of the available storage backends
+var StorageBackends = []string{StorageMongo, StorageFile}
+
+// ErrNotFound is returned when no document matches a query
+var ErrNotFound = mgo.ErrNotFound
+
+// Store is a connection to a storage backend. Close must be called when the
+// caller is finished with the store.
+type Store interface {
+	// C returns the collection with the name
+	C(name string) Collection
+	// CollectionNames returns the names of the collections with documents
+	CollectionNames() ([]string, error)
+	Close()
+}
+
+// Collection is a set of documents within a store
+type Collection interface {
+	Find(query interface{}) Query
+	FindId(id interface{}) Query
+	Insert(docs ...interface{}) error
+	// Update modifies the first document matching the selector.
+	// ErrNotFound is returned when no document matches.
+	Update(selector, update interface{}) error
+	UpdateId(id, update interface{}) error
+	// Upsert modifies the first document matching the selector or inserts a
+	// new document when none match
+	Upsert(selector, update interface{}) error
+	UpsertId(id, update interface{}) error
+	// Remove deletes the first document matching the selector.
+	// ErrNotFound is returned when no document matches.
+	Remove(selector interface{}) error
+	RemoveAll(selector interface{}) (removed int, err error)
+	EnsureIndex(index Index) error
+}
+
+// Query is a pending query against a collection
+type Query interface {
+	Sort(fields ...string) Query
+	Limit(n int) Query
+	Select(selector interface{}) Query
+	One(result interface{}) error
+	All(result interface{}) error
+	Count() (int, error)
+	Iter() Iter
+	// Apply atomically updates or removes the first document matching the
+	// query and decodes the document into result
+	Apply(change Change, result interface{}) error
+}
+
+// Iter iterates over the results of a query
+type Iter interface {
+	Next(result interface{}) bool
+	Close() error
+}
+
+// Change is the modification made by Query.Apply
+type Change struct {
+	Update    interface{}
+	Upsert    bool
+	Remove    bool
+	ReturnNew bool
+}
+
+// Index describes an index of a collection. Unique indexes are enforced by
+// every backend. Sparse indexes skip documents that don't have the keys, so
+// a unique sparse index allows any number of documents without them.
+type Index struct {
+	Key    []string
+	Unique bool
+	Sparse bool
+}
+
+// isDuplicateKey determines if the error is from a unique index rejecting a
+// document
+func isDuplicateKey(err error) bool {
+	return mgo.IsDup(err)
+}
+
+// StorageConfig selects the storage backend
+type StorageConfig struct {
+	// Backend is either StorageMongo or StorageFile
+	Backend string
+	// Path is the file used by the file backend
+	Path string
+}
+
+// DefaultStorageConfig uses MongoDB
+func DefaultStorageConfig() StorageConfig {
+	return StorageConfig{
+		Backend: StorageMongo,
+		Path:    "dbweb.db",
+	}
+}
+
+var storageLock sync.Mutex
+var storageConfig = DefaultStorageConfig()
+
+// ConfigureStorage selects the storage backend. This must be called before
+// the first store is opened.
+func ConfigureStorage(config StorageConfig) {
+	storageLock.Lock()
+	defer storageLock.Unlock()
+	storageConfig = config
+}
+
+// GetStorageConfig returns the configured storage backend
+func GetStorageConfig() StorageConfig {
+	storageLock.Lock()
+	defer storageLock.Unlock()
+	return storageConfig
+}
+
+// OpenStore opens the configured storage backend
+func OpenStore() (Store, error) {
+	return OpenStoreBackend(GetStorageConfig().Backend)
+}
+
+// OpenStoreBackend opens the named storage backend. The backend doesn't need
+// to be the configured backend, which allows copying between backends.
+func OpenStoreBackend(backend string) (Store, error) {
+	switch backend {
+	case StorageMongo:
+		mongoSession, err := GetMongoSession()
+		if err != nil {
+			return nil, err
+		}
+		return &mongoStore{session: mongoSession}, nil
+	case StorageFile:
+		fileStore, err := openSharedFileStore(GetStorageConfig().Path)
+		if err != nil {
+			return nil, err
+		}
+		return fileStore, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", backend)
+	}
+}
+
+// CopyResult is the number of documents copied from a collection
+type CopyResult struct {
+	Collection string
+	Documents  int
+}
+
+// CopyStore copies every document of every collection from one store to
+// another. Documents already in the destination with the same ID are
+// replaced, so copying again is safe.
+func CopyStore(from, to Store) ([]CopyResult, error) {
+	names, err := from.CollectionNames()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var results []CopyResult
+	for _, name := range names {
+		result := CopyResult{Collection: name}
+		destination := to.C(name)
+		iter := from.C(name).Find(nil).Iter()
+		var doc bson.M
+		for iter.Next(&doc) {
+			if err := destination.UpsertId(doc["_id"], doc); err != nil {
+				iter.Close()
+				return results, fmt.Errorf("unable to copy %s %v: %v", name, doc["_id"], err)
+			}
+			result.Documents++
+			doc = nil
+		}
+		if err := iter.Close(); err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -1,7 +1,9 @@
 package data
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/globalsign/mgo/bson"
 )
@@ -21,63 +23,421 @@ func NewErrUserNotFound(username string) *ErrUserNotFound {
 	}
 }
 
+const userCollectionName = "users"
+
+// Roles that can be granted to a user
 const (
-	mongoUserCollectionName = "users"
-	userDocCurrentVersion   = 1
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
 )
 
 type User interface {
 	Username() string
+	Email() string
+	IsVerified() bool
+	HasPassword() bool
+	HasTwoFactor() bool
+	Roles() []string
+	HasRole(role string) bool
 }
 
 type user struct {
-	username string
+	username    string
+	email       string
+	verified    bool
+	hasPassword bool
+	twoFactor   bool
+	roles       []string
 }
 
 func (u user) Username() string {
 	return u.username
 }
+func (u user) Email() string {
+	return u.email
+}
+
+// IsVerified determines if the user has verified their email address. Users
+// that are not verified are not allowed to login.
+func (u user) IsVerified() bool {
+	return u.verified
+}
+func (u user) HasPassword() bool {
+	return u.hasPassword
+}
+
+// HasTwoFactor determines if the user has enrolled in two-factor authentication
+func (u user) HasTwoFactor() bool {
+	return u.twoFactor
+}
+func (u user) Roles() []string {
+	return u.roles
+}
+func (u user) HasRole(role string) bool {
+	for _, r := range u.roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Identity is an external identity (such as an OpenID Connect subject) that
+// has been linked to a user
+type Identity struct {
+	Provider string
+	Subject  string
+}
 
 type userDto struct {
-	Version  int
-	Username string
+	Version      int
+	Username     string
+	Email        string     `bson:",omitempty"`
+	PasswordHash string     `bson:",omitempty"`
+	Verified     bool       `bson:",omitempty"`
+	Roles        []string   `bson:",omitempty"`
+	Identities   []Identity `bson:",omitempty"`
+
+	TOTPSecret      string   `bson:",omitempty"`
+	TOTPLastCounter int64    `bson:",omitempty"`
+	RecoveryCodes   []string `bson:",omitempty"`
 }
 
 func (userData userDto) toUser() User {
 	return &user{
-		username: userData.Username,
+		username:    userData.Username,
+		email:       userData.Email,
+		verified:    userData.Verified,
+		hasPassword: len(userData.PasswordHash) > 0,
+		twoFactor:   len(userData.TOTPSecret) > 0,
+		roles:       userData.Roles,
 	}
 }
 
+func init() {
+	RegisterMigration(Migration{
+		Collection:  userCollectionName,
+		FromVersion: 1,
+		Description: "users created before registration have verified email addresses",
+		Upgrade: func(doc bson.M) error {
+			doc["verified"] = true
+			return nil
+		},
+	})
+}
+
 func GetUserByUsername(username string) (user User, err error) {
-	mongoSession := GetMongoSession()
-	defer mongoSession.Close()
+	store, err := OpenStore()
+	if err != nil {
+		return
+	}
+	defer store.Close()
 
 	query := bson.M{"username": username}
 	userData := userDto{}
 
-	userCollection := session.DB(GetMongoDbName()).C(mongoUserCollectionName)
-	err = userCollection.Find(query).One(&userData)
-	if err != nil {
+	userCollection := store.C(userCollectionName)
+	err = findOneUpgraded(userCollection, userCollectionName, query, &userData)
+	if err == ErrNotFound {
 		err = NewErrUserNotFound(username)
 		return
+	} else if err != nil {
+		return
 	}
 	user = userData.toUser()
 	return
 }
 
-func AddUser(username string) (err error) {
-	mongoSession := GetMongoSession()
-	defer mongoSession.Close()
+// AddUser adds a new verified user without a password. This is used by the
+// command line and when a user logs in with an external identity. The email
+// address is optional.
+func AddUser(username, email string) (err error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if len(email) > 0 && !strings.Contains(email, "@") {
+		return errors.New("a valid email address is required")
+	}
 
-	user := userDto{
-		userDocCurrentVersion,
-		username,
+	store, err := OpenStore()
+	if err != nil {
+		return
 	}
+	defer store.Close()
 
-	// TODO make sure the username isn't already taken
+	user := userDto{
+		Version:  CurrentDocumentVersion(userCollectionName),
+		Username: username,
+		Email:    email,
+		Verified: true,
+	}
 
-	userCollection := session.DB(GetMongoDbName()).C(mongoUserCollectionName)
+	userCollection, err := getUserCollection(store)
+	if err != nil {
+		return
+	}
 	err = userCollection.Insert(user)
+	if isDuplicateKey(err) {
+		return duplicateUserError(username)
+	}
 	return
 }
+
+func getUserCollection(store Store) (Collection, error) {
+	collection := store.C(userCollectionName)
+	err := collection.EnsureIndex(Index{
+		Key:    []string{"username"},
+		Unique: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// users added from the command line may not have an email address
+	err = collection.EnsureIndex(Index{
+		Key:    []string{"email"},
+		Unique: true,
+		Sparse: true,
+	})
+	return collection, err
+}
+
+// duplicateUserError describes which of the unique fields of a user was
+// rejected when the username is taken or the email address is registered
+func duplicateUserError(username string) error {
+	if _, err := GetUserByUsername(username); err == nil {
+		return fmt.Errorf("username %s is already taken", username)
+	}
+	return errors.New("email address is already registered")
+}
+
+// GetUserByIdentity retrieves the user linked to the external identity
+func GetUserByIdentity(provider, subject string) (user User, err error) {
+	store, err := OpenStore()
+	if err != nil {
+		return
+	}
+	defer store.Close()
+
+	query := bson.M{"identities": bson.M{"$elemMatch": bson.M{"provider": provider, "subject": subject}}}
+	userData := userDto{}
+
+	userCollection := store.C(userCollectionName)
+	err = findOneUpgraded(userCollection, userCollectionName, query, &userData)
+	if err == ErrNotFound {
+		err = NewErrUserNotFound(provider + ":" + subject)
+		return
+	} else if err != nil {
+		return
+	}
+	user = userData.toUser()
+	return
+}
+
+// LinkUserIdentity links the external identity to the user. An identity can
+// only be linked to a single user.
+func LinkUserIdentity(username, provider, subject string) (err error) {
+	existing, err := GetUserByIdentity(provider, subject)
+	if err == nil {
+		if existing.Username() == username {
+			return nil
+		}
+		return fmt.Errorf("identity %s:%s is already linked to another user", provider, subject)
+	} else if _, ok := err.(*ErrUserNotFound); !ok {
+		return err
+	}
+
+	store, err := OpenStore()
+	if err != nil {
+		return
+	}
+	defer store.Close()
+
+	userCollection, err := getUserCollection(store)
+	if err != nil {
+		return
+	}
+	err = userCollection.Update(
+		bson.M{"username": username},
+		bson.M{"$push": bson.M{"identities": Identity{Provider: provider, Subject: subject}}})
+	if err != nil {
+		return fmt.Errorf("unable to link identity to %s: %v", username, err)
+	}
+	return nil
+}
+
+// GetUserByEmail retrieves the user with the email address
+func GetUserByEmail(email string) (user User, err error) {
+	store, err := OpenStore()
+	if err != nil {
+		return
+	}
+	defer store.Close()
+
+	query := bson.M{"email": strings.ToLower(strings.TrimSpace(email))}
+	userData := userDto{}
+
+	userCollection := store.C(userCollectionName)
+	err = findOneUpgraded(userCollection, userCollectionName, query, &userData)
+	if err == ErrNotFound {
+		err = NewErrUserNotFound(email)
+		return
+	} else if err != nil {
+		return
+	}
+	user = userData.toUser()
+	return
+}
+
+// RegisterUser adds a new user that has not verified their email address
+func RegisterUser(username, email, password string) error {
+	username = strings.TrimSpace(username)
+	email = strings.ToLower(strings.TrimSpace(email))
+	if len(username) == 0 {
+		return errors.New("username is required")
+	}
+	if !strings.Contains(email, "@") {
+		return errors.New("a valid email address is required")
+	}
+	if err := validatePassword(password); err != nil {
+		return err
+	}
+	if _, err := GetUserByUsername(username); err == nil {
+		return fmt.Errorf("username %s is already taken", username)
+	}
+	if _, err := GetUserByEmail(email); err == nil {
+		return errors.New("email address is already registered")
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	store, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	userCollection, err := getUserCollection(store)
+	if err != nil {
+		return err
+	}
+	// checked again by the indexes in case another registration with the
+	// same username or email address was added since
+	err = userCollection.Insert(userDto{
+		Version:      CurrentDocumentVersion(userCollectionName),
+		Username:     username,
+		Email:        email,
+		PasswordHash: passwordHash,
+	})
+	if isDuplicateKey(err) {
+		return duplicateUserError(username)
+	}
+	return err
+}
+
+// AuthenticateUser checks the password of the user. ErrInvalidCredentials is
+// returned when the user does not exist or the password is incorrect.
+func AuthenticateUser(username, password string) (User, error) {
+	store, err := OpenStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	userData := userDto{}
+	userCollection := store.C(userCollectionName)
+	err = findOneUpgraded(userCollection, userCollectionName, bson.M{"username": username}, &userData)
+	if err == ErrNotFound {
+		// hash anyway so the response time doesn't reveal which usernames exist
+		checkPasswordHash(password, dummyPasswordHash)
+		return nil, ErrInvalidCredentials
+	} else if err != nil {
+		return nil, err
+	}
+	if !checkPasswordHash(password, userData.PasswordHash) {
+		return nil, ErrInvalidCredentials
+	}
+	return userData.toUser(), nil
+}
+
+var dummyPasswordHash, _ = hashPassword("not a real password")
+
+// SetUserPassword changes the password of the user
+func SetUserPassword(username, password string) error {
+	if err := validatePassword(password); err != nil {
+		return err
+	}
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	return updateUser(username, bson.M{"$set": bson.M{"passwordhash": passwordHash}})
+}
+
+// SetUserEmail changes the email address of the user
+func SetUserEmail(username, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !strings.Contains(email, "@") {
+		return errors.New("a valid email address is required")
+	}
+	if existing, err := GetUserByEmail(email); err == nil && existing.Username() != username {
+		return errors.New("email address is already registered")
+	}
+	return updateUser(username, bson.M{"$set": bson.M{"email": email}})
+}
+
+// MarkUserVerified records that the user has verified their email address
+func MarkUserVerified(username string) error {
+	return updateUser(username, bson.M{"$set": bson.M{"verified": true}})
+}
+
+// AddUserRole grants the role to the user
+func AddUserRole(username, role string) error {
+	if role != RoleAdmin && role != RoleModerator {
+		return fmt.Errorf("unknown role: %s", role)
+	}
+	return updateUser(username, bson.M{"$addToSet": bson.M{"roles": role}})
+}
+
+// GetUsernames returns up to limit usernames that start with the prefix in
+// alphabetical order
+func GetUsernames(prefix string, limit int) ([]string, error) {
+	store, err := OpenStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	var usernames []string
+	var userData userDto
+	iter := store.C(userCollectionName).Find(nil).Select(bson.M{"username": 1}).Sort("username").Iter()
+	for len(usernames) < limit && iter.Next(&userData) {
+		if strings.HasPrefix(userData.Username, prefix) {
+			usernames = append(usernames, userData.Username)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return usernames, nil
+}
+
+func updateUser(username string, update bson.M) error {
+	store, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	userCollection, err := getUserCollection(store)
+	if err != nil {
+		return err
+	}
+	err = userCollection.Update(bson.M{"username": username}, update)
+	if err == ErrNotFound {
+		return NewErrUserNotFound(username)
+	} else if isDuplicateKey(err) {
+		return errors.New("email address is already registered")
+	}
+	return err
+}
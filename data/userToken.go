@@ -0,0 +1,125 @@
+package data
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+)
+
+const (
+	userTokenCollectionName    = "userTokens"
+	userTokenDocCurrentVersion = 1
+)
+
+// Purposes of user tokens. A token can only be consumed for the purpose it
+// was created for.
+const (
+	UserTokenVerifyEmail   = "verifyEmail"
+	UserTokenResetPassword = "resetPassword"
+)
+
+// ErrUserTokenInvalid is returned when a token does not exist, has expired or
+// has already been used
+var ErrUserTokenInvalid = errors.New("token is invalid or has expired")
+
+// userTokenDto is a single use token that is emailed to a user. Only the
+// hash of the token is stored.
+type userTokenDto struct {
+	Version  int
+	Hash     string `bson:"_id"`
+	Username string
+	Purpose  string
+	Expires  time.Time
+}
+
+func hashUserToken(secret string) string {
+	digest := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(digest[:])
+}
+
+// CreateUserToken creates a single use token for the user that expires after
+// the given duration. The returned secret is the only copy of the token.
+func CreateUserToken(username, purpose string, ttl time.Duration) (string, error) {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(b)
+
+	store, err := OpenStore()
+	if err != nil {
+		return "", err
+	}
+	defer store.Close()
+
+	collection := store.C(userTokenCollectionName)
+	err = collection.Insert(userTokenDto{
+		Version:  userTokenDocCurrentVersion,
+		Hash:     hashUserToken(secret),
+		Username: username,
+		Purpose:  purpose,
+		Expires:  time.Now().UTC().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// IsUserTokenValid determines if the token exists and has not expired
+// without consuming it
+func IsUserTokenValid(purpose, secret string) bool {
+	store, err := OpenStore()
+	if err != nil {
+		return false
+	}
+	defer store.Close()
+
+	var tokenData userTokenDto
+	collection := store.C(userTokenCollectionName)
+	err = collection.Find(bson.M{"_id": hashUserToken(secret), "purpose": purpose}).One(&tokenData)
+	return err == nil && time.Now().Before(tokenData.Expires)
+}
+
+// ConsumeUserToken removes the token and returns the username it was created
+// for. ErrUserTokenInvalid is returned if the token can't be used.
+func ConsumeUserToken(purpose, secret string) (string, error) {
+	store, err := OpenStore()
+	if err != nil {
+		return "", err
+	}
+	defer store.Close()
+
+	var tokenData userTokenDto
+	collection := store.C(userTokenCollectionName)
+	err = collection.Find(bson.M{"_id": hashUserToken(secret), "purpose": purpose}).
+		Apply(Change{Remove: true}, &tokenData)
+	if err == ErrNotFound {
+		return "", ErrUserTokenInvalid
+	} else if err != nil {
+		return "", err
+	}
+	if time.Now().After(tokenData.Expires) {
+		return "", ErrUserTokenInvalid
+	}
+	return tokenData.Username, nil
+}
+
+// DeleteUserTokens removes all of the tokens for the user with the purpose
+func DeleteUserTokens(username, purpose string) error {
+	store, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	collection := store.C(userTokenCollectionName)
+	_, err = collection.RemoveAll(bson.M{"username": username, "purpose": purpose})
+	return err
+}
@@ -0,0 +1,164 @@
+package data
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/globalsign/mgo/bson"
+
+	"jaredpearson.com/dbweb/totp"
+)
+
+const (
+	recoveryCodeCount    = 10
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz023456789"
+	recoveryCodeLength   = 10
+)
+
+// ErrInvalidTwoFactorCode is returned when a one-time code or recovery code
+// is incorrect or has already been used
+var ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.Replace(strings.TrimSpace(code), "-", "", -1))
+	digest := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(digest[:])
+}
+
+// generateRecoveryCodes creates single use codes formatted as xxxxx-xxxxx
+func generateRecoveryCodes() ([]string, error) {
+	var codes []string
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := io.ReadFull(rand.Reader, b); err != nil {
+			return nil, err
+		}
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[int(b[j])%len(recoveryCodeAlphabet)]
+		}
+		codes = append(codes, string(b[:recoveryCodeLength/2])+"-"+string(b[recoveryCodeLength/2:]))
+	}
+	return codes, nil
+}
+
+// EnableUserTwoFactor enrolls the user in two-factor authentication with the
+// TOTP secret. The code must be valid for the secret to confirm the user's
+// authenticator is set up correctly. The recovery codes are returned; only
+// their hashes are stored.
+func EnableUserTwoFactor(username, secret, code string) ([]string, error) {
+	counter, valid := totp.Validate(secret, code, time.Now())
+	if !valid {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	var hashes []string
+	for _, c := range recoveryCodes {
+		hashes = append(hashes, hashRecoveryCode(c))
+	}
+
+	err = updateUser(username, bson.M{"$set": bson.M{
+		"totpsecret":      secret,
+		"totplastcounter": counter,
+		"recoverycodes":   hashes,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// DisableUserTwoFactor removes the TOTP secret and recovery codes
+func DisableUserTwoFactor(username string) error {
+	return updateUser(username, bson.M{"$unset": bson.M{
+		"totpsecret":      "",
+		"totplastcounter": "",
+		"recoverycodes":   "",
+	}})
+}
+
+// VerifyUserTwoFactor checks the one-time code or recovery code of the user.
+// One-time codes can't be reused and recovery codes are removed once used.
+func VerifyUserTwoFactor(username, code string) error {
+	store, err := OpenStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	userData := userDto{}
+	userCollection := store.C(userCollectionName)
+	err = findOneUpgraded(userCollection, userCollectionName, bson.M{"username": username}, &userData)
+	if err == ErrNotFound {
+		return NewErrUserNotFound(username)
+	} else if err != nil {
+		return err
+	}
+	if len(userData.TOTPSecret) == 0 {
+		return ErrInvalidTwoFactorCode
+	}
+
+	if counter, valid := totp.Validate(userData.TOTPSecret, code, time.Now()); valid {
+		// only accept a counter greater than the last one used so the same
+		// code can't be replayed
+		err = userCollection.Update(
+			bson.M{"username": username, "totplastcounter": bson.M{"$lt": counter}},
+			bson.M{"$set": bson.M{"totplastcounter": counter}})
+		if err == ErrNotFound {
+			return ErrInvalidTwoFactorCode
+		}
+		return err
+	}
+
+	hash := hashRecoveryCode(code)
+	err = userCollection.Update(
+		bson.M{"username": username, "recoverycodes": hash},
+		bson.M{"$pull": bson.M{"recoverycodes": hash}})
+	if err == ErrNotFound {
+		return ErrInvalidTwoFactorCode
+	}
+	return err
+}
+
+// RegenerateUserRecoveryCodes replaces the recovery codes of the user
+func RegenerateUserRecoveryCodes(username string) ([]string, error) {
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	var hashes []string
+	for _, c := range recoveryCodes {
+		hashes = append(hashes, hashRecoveryCode(c))
+	}
+	if err = updateUser(username, bson.M{"$set": bson.M{"recoverycodes": hashes}}); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// GetUserRecoveryCodeCount returns the number of unused recovery codes
+func GetUserRecoveryCodeCount(username string) (int, error) {
+	store, err := OpenStore()
+	if err != nil {
+		return 0, err
+	}
+	defer store.Close()
+
+	userData := userDto{}
+	userCollection := store.C(userCollectionName)
+	err = findOneUpgraded(userCollection, userCollectionName, bson.M{"username": username}, &userData)
+	if err == ErrNotFound {
+		return 0, NewErrUserNotFound(username)
+	} else if err != nil {
+		return 0, err
+	}
+	return len(userData.RecoveryCodes), nil
+}
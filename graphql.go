@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"jaredpearson.com/dbweb/command"
+	"jaredpearson.com/dbweb/graphql"
+	"jaredpearson.com/dbweb/web"
+)
+
+// schemaFormats are the formats the GraphQL schema can be written in
+var schemaFormats = []string{"sdl", "json"}
+
+func executeGraphQLCommand(graphqlCmd *command.Command, graphqlSchemaCmd *command.Command, graphqlSchemaFormatFlag *command.Flag) int {
+	if graphqlSchemaCmd.IsSelected() {
+		schema, err := web.CatalogSchema()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		switch graphqlSchemaFormatFlag.Value() {
+		case "sdl":
+			fmt.Fprint(os.Stdout, graphql.PrintSchema(schema))
+		case "json":
+			// the result of the introspection query is the format read by
+			// most GraphQL tools
+			response := schema.Execute(graphql.Request{Query: graphql.IntrospectionQuery}, graphql.Limits{})
+			if len(response.Errors) > 0 {
+				fmt.Fprintf(os.Stderr, "%v\n", response.Errors[0].Message)
+				return 1
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(response); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				return 1
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown format %q\n", graphqlSchemaFormatFlag.Value())
+			return 1
+		}
+		return 0
+	} else {
+		graphqlCmd.DisplayUsage()
+		return 1
+	}
+}
@@ -0,0 +1,378 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+)
+
+// Request is a query to run against a schema
+type Request struct {
+	Query         string
+	OperationName string
+	// Variables are the values of the variables decoded from JSON
+	Variables map[string]interface{}
+	Context   context.Context
+	// QueryOnly rejects mutations, such as for requests that must not change
+	// anything
+	QueryOnly bool
+}
+
+// Response is the result of a request. Data is nil when the request was
+// rejected before it ran, in which case Errors has the reasons.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Rejected determines if the request didn't run because the query is
+// invalid or exceeds the limits
+func (response *Response) Rejected() bool {
+	return response.Data == nil && len(response.Errors) > 0
+}
+
+// orderedMap is an object in the response, which keeps the fields in the
+// order they were requested
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]interface{})}
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, _ := json.Marshal(key)
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		encodedValue, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Execute validates the query and runs the selected operation. Errors from
+// resolvers are reported in the response along with the data that could be
+// resolved.
+func (schema *Schema) Execute(request Request, limits Limits) *Response {
+	doc, syntaxErr := parse(request.Query)
+	if syntaxErr != nil {
+		return &Response{Errors: []*Error{syntaxErr}}
+	}
+	op, err := doc.operation(request.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{err}}
+	}
+
+	var root *Object
+	switch op.kind {
+	case "query":
+		root = schema.Query
+	case "mutation":
+		if request.QueryOnly {
+			return &Response{Errors: []*Error{{Message: "Mutations aren't allowed in this request.", Locations: []Location{op.loc}}}}
+		}
+		root = schema.Mutation
+	}
+	if root == nil {
+		return &Response{Errors: []*Error{{Message: fmt.Sprintf("The schema doesn't support %s operations.", op.kind), Locations: []Location{op.loc}}}}
+	}
+
+	variables, errs := schema.coerceVariables(op, request.Variables)
+	if len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+	v := &validator{schema: schema, doc: doc, variables: variables}
+	depth, complexity := v.validate(op, root)
+	if len(v.errors) > 0 {
+		return &Response{Errors: v.errors}
+	}
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return &Response{Errors: []*Error{{Message: fmt.Sprintf("The query has a depth of %d, which exceeds the maximum of %d.", depth, limits.MaxDepth), Locations: []Location{op.loc}}}}
+	}
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return &Response{Errors: []*Error{{Message: fmt.Sprintf("The query has a complexity of %d, which exceeds the maximum of %d.", complexity, limits.MaxComplexity), Locations: []Location{op.loc}}}}
+	}
+
+	ctx := request.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	e := &executor{schema: schema, doc: doc, variables: variables, ctx: ctx}
+	data, ok := e.selectionSet(root, nil, op.selectionSet, nil)
+	response := &Response{Errors: e.errors}
+	if ok {
+		response.Data = data
+	} else {
+		// every field of the operation is null
+		response.Data = json.RawMessage("null")
+	}
+	return response
+}
+
+// operation selects the operation to run. The name can be empty when the
+// document has a single operation.
+func (doc *document) operation(name string) (*operation, *Error) {
+	if len(doc.operations) == 0 {
+		return nil, &Error{Message: "The query has no operations."}
+	}
+	names := make(map[string]bool)
+	for _, op := range doc.operations {
+		if names[op.name] || (len(doc.operations) > 1 && len(op.name) == 0) {
+			return nil, &Error{Message: "Each operation must have a unique name when there is more than one.", Locations: []Location{op.loc}}
+		}
+		names[op.name] = true
+	}
+	if len(name) == 0 {
+		if len(doc.operations) > 1 {
+			return nil, &Error{Message: "An operation name is required when the query has more than one operation."}
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("Unknown operation named %q.", name)}
+}
+
+type executor struct {
+	schema    *Schema
+	doc       *document
+	variables map[string]interface{}
+	ctx       context.Context
+	errors    []*Error
+}
+
+func (e *executor) addError(message string, f *field, path []interface{}) {
+	e.errors = append(e.errors, &Error{
+		Message:   message,
+		Locations: []Location{f.loc},
+		Path:      append([]interface{}(nil), path...),
+	})
+}
+
+// collectedField is the fields of a selection set with the same response key
+type collectedField struct {
+	key    string
+	fields []*field
+}
+
+// collectFields flattens the fragments of the selection set and groups the
+// fields by response key, leaving out those that are skipped
+func (e *executor) collectFields(selections []selection, collected []*collectedField, visited map[string]bool) []*collectedField {
+	for _, s := range selections {
+		switch s := s.(type) {
+		case *field:
+			if !e.included(s.directives) {
+				continue
+			}
+			found := false
+			for _, c := range collected {
+				if c.key == s.responseKey() {
+					c.fields = append(c.fields, s)
+					found = true
+				}
+			}
+			if !found {
+				collected = append(collected, &collectedField{key: s.responseKey(), fields: []*field{s}})
+			}
+		case *fragmentSpread:
+			if !e.included(s.directives) || visited[s.name] {
+				continue
+			}
+			visited[s.name] = true
+			collected = e.collectFields(e.doc.fragments[s.name].selectionSet, collected, visited)
+		case *inlineFragment:
+			if e.included(s.directives) {
+				collected = e.collectFields(s.selectionSet, collected, visited)
+			}
+		}
+	}
+	return collected
+}
+
+// included evaluates @skip and @include
+func (e *executor) included(directives []*directive) bool {
+	for _, d := range directives {
+		args, err := coerceArguments(findDirective(d.name).Args, d.arguments, e.variables, d.loc)
+		if err != nil {
+			continue
+		}
+		condition, _ := args["if"].(bool)
+		if (d.name == "skip" && condition) || (d.name == "include" && !condition) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectionSet resolves the fields of the object. It returns false when a
+// non-null field is null, which makes the object null.
+func (e *executor) selectionSet(object *Object, source interface{}, selections []selection, path []interface{}) (*orderedMap, bool) {
+	result := newOrderedMap()
+	for _, c := range e.collectFields(selections, nil, make(map[string]bool)) {
+		value, ok := e.field(object, source, c.fields, append(path, c.key))
+		if !ok {
+			return nil, false
+		}
+		result.set(c.key, value)
+	}
+	return result, true
+}
+
+func (e *executor) field(object *Object, source interface{}, fields []*field, path []interface{}) (interface{}, bool) {
+	f := fields[0]
+	if f.name == "__typename" {
+		return object.Name, true
+	}
+	definition := e.schema.fieldDefinition(object, f.name)
+	args, argErr := coerceArguments(definition.Args, f.arguments, e.variables, f.loc)
+	if argErr != nil {
+		e.errors = append(e.errors, argErr)
+		return nil, !isNonNull(definition.Type)
+	}
+
+	var value interface{}
+	var err error
+	switch definition {
+	case schemaMetaField:
+		value = e.schema
+	case typeMetaField:
+		value = e.schema.types[args["name"].(string)]
+	default:
+		value, err = e.resolve(definition, ResolveParams{Context: e.ctx, Source: source, Args: args})
+	}
+	if err != nil {
+		e.addError(err.Error(), f, path)
+		return nil, !isNonNull(definition.Type)
+	}
+	return e.completeValue(definition.Type, fields, value, path)
+}
+
+// resolve calls the resolver of the field. A panic is reported as an error
+// so one field can't stop the rest of the query.
+func (e *executor) resolve(definition *Field, params ResolveParams) (value interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Panic resolving the %s field\n\t%v", definition.Name, r)
+			value, err = nil, fmt.Errorf("internal error resolving %s", definition.Name)
+		}
+	}()
+	if definition.Resolve == nil {
+		if m, ok := params.Source.(map[string]interface{}); ok {
+			return m[definition.Name], nil
+		}
+		return nil, fmt.Errorf("no resolver for %s", definition.Name)
+	}
+	return definition.Resolve(params)
+}
+
+func isNonNull(t Type) bool {
+	_, ok := t.(*NonNull)
+	return ok
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// completeValue converts the resolved value into the value of the type in
+// the response. It returns false when the value is null because a non-null
+// value was null, which makes the parent null. The error is reported once
+// where the null was found.
+func (e *executor) completeValue(t Type, fields []*field, value interface{}, path []interface{}) (interface{}, bool) {
+	if nonNull, ok := t.(*NonNull); ok {
+		completed, ok := e.completeNamedOrList(nonNull.OfType, fields, value, path)
+		if !ok {
+			return nil, false
+		}
+		if completed == nil {
+			e.addError(fmt.Sprintf("Cannot return null for non-nullable field %s.", fields[0].name), fields[0], path)
+			return nil, false
+		}
+		return completed, true
+	}
+	completed, ok := e.completeNamedOrList(t, fields, value, path)
+	if !ok {
+		return nil, true
+	}
+	return completed, true
+}
+
+func (e *executor) completeNamedOrList(t Type, fields []*field, value interface{}, path []interface{}) (interface{}, bool) {
+	if isNil(value) {
+		return nil, true
+	}
+	switch t := t.(type) {
+	case *List:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.addError(fmt.Sprintf("Expected a list for field %s.", fields[0].name), fields[0], path)
+			return nil, false
+		}
+		items := make([]interface{}, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item, ok := e.completeValue(t.OfType, fields, rv.Index(i).Interface(), append(path, i))
+			if !ok {
+				return nil, false
+			}
+			items = append(items, item)
+		}
+		return items, true
+	case *Object:
+		var selections []selection
+		for _, f := range fields {
+			selections = append(selections, f.selectionSet...)
+		}
+		result, ok := e.selectionSet(t, value, selections, path)
+		if !ok {
+			return nil, false
+		}
+		return result, true
+	case *Scalar:
+		serialized, ok := t.serialize(value)
+		if !ok {
+			e.addError(fmt.Sprintf("%s cannot represent the value %v.", t.Name, value), fields[0], path)
+			return nil, false
+		}
+		return serialized, true
+	case *Enum:
+		name, ok := value.(string)
+		if !ok || !t.hasValue(name) {
+			e.addError(fmt.Sprintf("Enum %s cannot represent the value %v.", t.Name, value), fields[0], path)
+			return nil, false
+		}
+		return name, true
+	}
+	return nil, false
+}
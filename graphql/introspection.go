@@ -0,0 +1,338 @@
+package graphql
+
+// The introspection types describe the schema to clients such as GraphiQL.
+// They're objects like any other, resolved from the Go values of the schema.
+
+var (
+	schemaType       = &Object{Name: "__Schema", Description: "A GraphQL Schema defines the capabilities of a GraphQL server. It exposes all available types and directives on the server, as well as the entry points for query and mutation operations."}
+	typeType         = &Object{Name: "__Type", Description: "The fundamental unit of any GraphQL Schema is the type. Wrapping types, LIST and NON_NULL, describe the type they wrap in `ofType`."}
+	fieldType        = &Object{Name: "__Field", Description: "Object types have fields, each of which has a name, potentially a list of arguments and a return type."}
+	inputValueType   = &Object{Name: "__InputValue", Description: "Arguments provided to fields or directives are represented as input values."}
+	enumValueType    = &Object{Name: "__EnumValue", Description: "One possible value for a given Enum."}
+	directiveType    = &Object{Name: "__Directive", Description: "A directive provides a way to describe alternate runtime execution and type validation behavior in a GraphQL document."}
+	typeKindType     = &Enum{Name: "__TypeKind", Description: "An enum describing what kind of type a given `__Type` is."}
+	directiveLocEnum = &Enum{Name: "__DirectiveLocation", Description: "A Directive can be adjacent to many parts of the GraphQL language, a __DirectiveLocation describes one such possible adjacencies."}
+
+	schemaMetaField = &Field{
+		Name:        "__schema",
+		Description: "Access the current type schema of this server.",
+		Type:        NewNonNull(schemaType),
+	}
+	typeMetaField = &Field{
+		Name:        "__type",
+		Description: "Request the type information of a single type.",
+		Type:        typeType,
+		Args:        []*Argument{{Name: "name", Type: NewNonNull(String)}},
+	}
+)
+
+var includeDeprecatedArg = &Argument{Name: "includeDeprecated", Type: Boolean, DefaultValue: false}
+
+func init() {
+	for _, kind := range []string{"SCALAR", "OBJECT", "INTERFACE", "UNION", "ENUM", "INPUT_OBJECT", "LIST", "NON_NULL"} {
+		typeKindType.Values = append(typeKindType.Values, &EnumValue{Name: kind})
+	}
+	for _, location := range []string{
+		"QUERY", "MUTATION", "SUBSCRIPTION", "FIELD", "FRAGMENT_DEFINITION", "FRAGMENT_SPREAD",
+		"INLINE_FRAGMENT", "VARIABLE_DEFINITION", "SCHEMA", "SCALAR", "OBJECT", "FIELD_DEFINITION",
+		"ARGUMENT_DEFINITION", "INTERFACE", "UNION", "ENUM", "ENUM_VALUE", "INPUT_OBJECT", "INPUT_FIELD_DEFINITION",
+	} {
+		directiveLocEnum.Values = append(directiveLocEnum.Values, &EnumValue{Name: location})
+	}
+
+	typeList := NewNonNull(NewList(NewNonNull(typeType)))
+	inputValueList := NewNonNull(NewList(NewNonNull(inputValueType)))
+
+	schemaType.Fields = []*Field{
+		{Name: "description", Type: String, Resolve: constant(nil)},
+		{Name: "types", Description: "A list of all types supported by this server.", Type: typeList,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				schema := p.Source.(*Schema)
+				var types []Type
+				for _, name := range schema.typeNames() {
+					types = append(types, schema.types[name])
+				}
+				return types, nil
+			}},
+		{Name: "queryType", Description: "The type that query operations will be rooted at.", Type: NewNonNull(typeType),
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return p.Source.(*Schema).Query, nil
+			}},
+		{Name: "mutationType", Description: "If this server supports mutation, the type that mutation operations will be rooted at.", Type: typeType,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				if mutation := p.Source.(*Schema).Mutation; mutation != nil {
+					return mutation, nil
+				}
+				return nil, nil
+			}},
+		{Name: "subscriptionType", Description: "If this server support subscription, the type that subscription operations will be rooted at.", Type: typeType, Resolve: constant(nil)},
+		{Name: "directives", Description: "A list of all directives supported by this server.", Type: NewNonNull(NewList(NewNonNull(directiveType))),
+			Resolve: constant(directives)},
+	}
+
+	typeType.Fields = []*Field{
+		{Name: "kind", Type: NewNonNull(typeKindType), Resolve: func(p ResolveParams) (interface{}, error) {
+			switch p.Source.(type) {
+			case *Scalar:
+				return "SCALAR", nil
+			case *Object:
+				return "OBJECT", nil
+			case *Enum:
+				return "ENUM", nil
+			case *List:
+				return "LIST", nil
+			default:
+				return "NON_NULL", nil
+			}
+		}},
+		{Name: "name", Type: String, Resolve: func(p ResolveParams) (interface{}, error) {
+			switch t := p.Source.(type) {
+			case *List, *NonNull:
+				return nil, nil
+			default:
+				return t.(Type).String(), nil
+			}
+		}},
+		{Name: "description", Type: String, Resolve: func(p ResolveParams) (interface{}, error) {
+			switch t := p.Source.(type) {
+			case *Scalar:
+				return optionalDescription(t.Description), nil
+			case *Object:
+				return optionalDescription(t.Description), nil
+			case *Enum:
+				return optionalDescription(t.Description), nil
+			}
+			return nil, nil
+		}},
+		{Name: "specifiedByURL", Type: String, Resolve: constant(nil)},
+		{Name: "fields", Type: NewList(NewNonNull(fieldType)), Args: []*Argument{includeDeprecatedArg},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				object, ok := p.Source.(*Object)
+				if !ok {
+					return nil, nil
+				}
+				fields := []*Field{}
+				for _, f := range object.Fields {
+					if len(f.DeprecationReason) == 0 || p.Args["includeDeprecated"] == true {
+						fields = append(fields, f)
+					}
+				}
+				return fields, nil
+			}},
+		{Name: "interfaces", Type: NewList(NewNonNull(typeType)), Resolve: func(p ResolveParams) (interface{}, error) {
+			if _, ok := p.Source.(*Object); ok {
+				return []Type{}, nil
+			}
+			return nil, nil
+		}},
+		{Name: "possibleTypes", Type: NewList(NewNonNull(typeType)), Resolve: constant(nil)},
+		{Name: "enumValues", Type: NewList(NewNonNull(enumValueType)), Args: []*Argument{includeDeprecatedArg},
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				enum, ok := p.Source.(*Enum)
+				if !ok {
+					return nil, nil
+				}
+				values := []*EnumValue{}
+				for _, v := range enum.Values {
+					if len(v.DeprecationReason) == 0 || p.Args["includeDeprecated"] == true {
+						values = append(values, v)
+					}
+				}
+				return values, nil
+			}},
+		{Name: "inputFields", Type: NewList(NewNonNull(inputValueType)), Args: []*Argument{includeDeprecatedArg}, Resolve: constant(nil)},
+		{Name: "ofType", Type: typeType, Resolve: func(p ResolveParams) (interface{}, error) {
+			switch t := p.Source.(type) {
+			case *List:
+				return t.OfType, nil
+			case *NonNull:
+				return t.OfType, nil
+			}
+			return nil, nil
+		}},
+		{Name: "isOneOf", Type: Boolean, Resolve: constant(nil)},
+	}
+
+	fieldType.Fields = []*Field{
+		{Name: "name", Type: NewNonNull(String), Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*Field).Name, nil
+		}},
+		{Name: "description", Type: String, Resolve: func(p ResolveParams) (interface{}, error) {
+			return optionalDescription(p.Source.(*Field).Description), nil
+		}},
+		{Name: "args", Type: inputValueList, Args: []*Argument{includeDeprecatedArg}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return append([]*Argument{}, p.Source.(*Field).Args...), nil
+		}},
+		{Name: "type", Type: NewNonNull(typeType), Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*Field).Type, nil
+		}},
+		{Name: "isDeprecated", Type: NewNonNull(Boolean), Resolve: func(p ResolveParams) (interface{}, error) {
+			return len(p.Source.(*Field).DeprecationReason) > 0, nil
+		}},
+		{Name: "deprecationReason", Type: String, Resolve: func(p ResolveParams) (interface{}, error) {
+			return optionalDescription(p.Source.(*Field).DeprecationReason), nil
+		}},
+	}
+
+	inputValueType.Fields = []*Field{
+		{Name: "name", Type: NewNonNull(String), Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*Argument).Name, nil
+		}},
+		{Name: "description", Type: String, Resolve: func(p ResolveParams) (interface{}, error) {
+			return optionalDescription(p.Source.(*Argument).Description), nil
+		}},
+		{Name: "type", Type: NewNonNull(typeType), Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*Argument).Type, nil
+		}},
+		{Name: "defaultValue", Description: "A GraphQL-formatted string representing the default value for this input value.", Type: String,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				arg := p.Source.(*Argument)
+				if arg.DefaultValue == nil {
+					return nil, nil
+				}
+				return printValue(arg.DefaultValue, arg.Type), nil
+			}},
+		{Name: "isDeprecated", Type: NewNonNull(Boolean), Resolve: constant(false)},
+		{Name: "deprecationReason", Type: String, Resolve: constant(nil)},
+	}
+
+	enumValueType.Fields = []*Field{
+		{Name: "name", Type: NewNonNull(String), Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*EnumValue).Name, nil
+		}},
+		{Name: "description", Type: String, Resolve: func(p ResolveParams) (interface{}, error) {
+			return optionalDescription(p.Source.(*EnumValue).Description), nil
+		}},
+		{Name: "isDeprecated", Type: NewNonNull(Boolean), Resolve: func(p ResolveParams) (interface{}, error) {
+			return len(p.Source.(*EnumValue).DeprecationReason) > 0, nil
+		}},
+		{Name: "deprecationReason", Type: String, Resolve: func(p ResolveParams) (interface{}, error) {
+			return optionalDescription(p.Source.(*EnumValue).DeprecationReason), nil
+		}},
+	}
+
+	directiveType.Fields = []*Field{
+		{Name: "name", Type: NewNonNull(String), Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*Directive).Name, nil
+		}},
+		{Name: "description", Type: String, Resolve: func(p ResolveParams) (interface{}, error) {
+			return optionalDescription(p.Source.(*Directive).Description), nil
+		}},
+		{Name: "isRepeatable", Type: NewNonNull(Boolean), Resolve: constant(false)},
+		{Name: "locations", Type: NewNonNull(NewList(NewNonNull(directiveLocEnum))), Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*Directive).Locations, nil
+		}},
+		{Name: "args", Type: inputValueList, Args: []*Argument{includeDeprecatedArg}, Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*Directive).Args, nil
+		}},
+	}
+}
+
+func constant(v interface{}) ResolveFunc {
+	return func(p ResolveParams) (interface{}, error) {
+		return v, nil
+	}
+}
+
+func optionalDescription(s string) interface{} {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+// IntrospectionQuery asks for everything introspection describes about a
+// schema. Its result is what tools such as GraphiQL and code generators
+// load.
+const IntrospectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types {
+      ...FullType
+    }
+    directives {
+      name
+      description
+      locations
+      args {
+        ...InputValue
+      }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args {
+      ...InputValue
+    }
+    type {
+      ...TypeRef
+    }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields {
+    ...InputValue
+  }
+  interfaces {
+    ...TypeRef
+  }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes {
+    ...TypeRef
+  }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,303 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+func (kind tokenKind) String() string {
+	switch kind {
+	case tokenEOF:
+		return "end of the query"
+	case tokenPunctuator:
+		return "punctuator"
+	case tokenName:
+		return "name"
+	case tokenInt:
+		return "integer"
+	case tokenFloat:
+		return "float"
+	default:
+		return "string"
+	}
+}
+
+type token struct {
+	kind  tokenKind
+	value string
+	loc   Location
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return t.kind.String()
+	}
+	if t.kind == tokenString {
+		return strconv.Quote(t.value)
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+// lexer splits a query into tokens. Whitespace, commas and comments are
+// skipped.
+type lexer struct {
+	source string
+	pos    int
+	line   int
+	// lineStart is the position of the first character of the line
+	lineStart int
+}
+
+func newLexer(source string) *lexer {
+	return &lexer{source: source, line: 1}
+}
+
+func (l *lexer) location() Location {
+	return Location{Line: l.line, Column: utf8.RuneCountInString(l.source[l.lineStart:l.pos]) + 1}
+}
+
+func (l *lexer) errorf(loc Location, format string, a ...interface{}) *Error {
+	return &Error{Message: "Syntax error: " + fmt.Sprintf(format, a...), Locations: []Location{loc}}
+}
+
+func (l *lexer) newline(pos int) {
+	l.line++
+	l.lineStart = pos
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.source) {
+		switch c := l.source[l.pos]; c {
+		case ' ', '\t', ',':
+			l.pos++
+		case '\n':
+			l.pos++
+			l.newline(l.pos)
+		case '\r':
+			l.pos++
+			if l.pos < len(l.source) && l.source[l.pos] == '\n' {
+				l.pos++
+			}
+			l.newline(l.pos)
+		case '#':
+			for l.pos < len(l.source) && l.source[l.pos] != '\n' && l.source[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			if strings.HasPrefix(l.source[l.pos:], "\ufeff") {
+				l.pos += len("\ufeff")
+				continue
+			}
+			return
+		}
+	}
+}
+
+// next reads the next token
+func (l *lexer) next() (token, *Error) {
+	l.skipIgnored()
+	loc := l.location()
+	if l.pos >= len(l.source) {
+		return token{kind: tokenEOF, loc: loc}, nil
+	}
+
+	c := l.source[l.pos]
+	switch {
+	case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+		l.pos++
+		return token{kind: tokenPunctuator, value: string(c), loc: loc}, nil
+	case c == '.':
+		if strings.HasPrefix(l.source[l.pos:], "...") {
+			l.pos += 3
+			return token{kind: tokenPunctuator, value: "...", loc: loc}, nil
+		}
+		return token{}, l.errorf(loc, "unexpected \".\", did you mean \"...\"?")
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.source) && (l.source[l.pos] == '_' || isLetter(l.source[l.pos]) || isDigit(l.source[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokenName, value: l.source[start:l.pos], loc: loc}, nil
+	case c == '-' || isDigit(c):
+		return l.readNumber(loc)
+	case c == '"':
+		if strings.HasPrefix(l.source[l.pos:], `"""`) {
+			return l.readBlockString(loc)
+		}
+		return l.readString(loc)
+	}
+	r, _ := utf8.DecodeRuneInString(l.source[l.pos:])
+	return token{}, l.errorf(loc, "unexpected character %q", r)
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (l *lexer) readDigits(loc Location) *Error {
+	start := l.pos
+	for l.pos < len(l.source) && isDigit(l.source[l.pos]) {
+		l.pos++
+	}
+	if l.pos == start {
+		return l.errorf(loc, "expected a digit in the number")
+	}
+	return nil
+}
+
+func (l *lexer) readNumber(loc Location) (token, *Error) {
+	start := l.pos
+	kind := tokenInt
+	if l.source[l.pos] == '-' {
+		l.pos++
+	}
+	if l.pos < len(l.source) && l.source[l.pos] == '0' {
+		l.pos++
+		if l.pos < len(l.source) && isDigit(l.source[l.pos]) {
+			return token{}, l.errorf(loc, "numbers can't start with 0")
+		}
+	} else if err := l.readDigits(loc); err != nil {
+		return token{}, err
+	}
+	if l.pos < len(l.source) && l.source[l.pos] == '.' {
+		kind = tokenFloat
+		l.pos++
+		if err := l.readDigits(loc); err != nil {
+			return token{}, err
+		}
+	}
+	if l.pos < len(l.source) && (l.source[l.pos] == 'e' || l.source[l.pos] == 'E') {
+		kind = tokenFloat
+		l.pos++
+		if l.pos < len(l.source) && (l.source[l.pos] == '+' || l.source[l.pos] == '-') {
+			l.pos++
+		}
+		if err := l.readDigits(loc); err != nil {
+			return token{}, err
+		}
+	}
+	if l.pos < len(l.source) && (l.source[l.pos] == '_' || l.source[l.pos] == '.' || isLetter(l.source[l.pos])) {
+		return token{}, l.errorf(loc, "unexpected %q after the number", l.source[l.pos])
+	}
+	return token{kind: kind, value: l.source[start:l.pos], loc: loc}, nil
+}
+
+func (l *lexer) readString(loc Location) (token, *Error) {
+	l.pos++
+	var b strings.Builder
+	for l.pos < len(l.source) {
+		c := l.source[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			return token{kind: tokenString, value: b.String(), loc: loc}, nil
+		case c == '\n' || c == '\r':
+			return token{}, l.errorf(loc, "unterminated string")
+		case c == '\\':
+			if l.pos+1 >= len(l.source) {
+				return token{}, l.errorf(loc, "unterminated string")
+			}
+			escape := l.source[l.pos+1]
+			l.pos += 2
+			switch escape {
+			case '"', '\\', '/':
+				b.WriteByte(escape)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.source) {
+					return token{}, l.errorf(loc, "invalid unicode escape in string")
+				}
+				n, err := strconv.ParseUint(l.source[l.pos:l.pos+4], 16, 32)
+				if err != nil {
+					return token{}, l.errorf(loc, "invalid unicode escape in string")
+				}
+				b.WriteRune(rune(n))
+				l.pos += 4
+			default:
+				return token{}, l.errorf(loc, "invalid escape \\%c in string", escape)
+			}
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return token{}, l.errorf(loc, "unterminated string")
+}
+
+// readBlockString reads a """ string. The indentation common to every line
+// but the first is removed along with blank lines at the start and end.
+func (l *lexer) readBlockString(loc Location) (token, *Error) {
+	l.pos += 3
+	var b strings.Builder
+	for l.pos < len(l.source) {
+		switch {
+		case strings.HasPrefix(l.source[l.pos:], `"""`):
+			l.pos += 3
+			return token{kind: tokenString, value: blockStringValue(b.String()), loc: loc}, nil
+		case strings.HasPrefix(l.source[l.pos:], `\"""`):
+			b.WriteString(`"""`)
+			l.pos += 4
+		default:
+			c := l.source[l.pos]
+			b.WriteByte(c)
+			l.pos++
+			if c == '\n' {
+				l.newline(l.pos)
+			}
+		}
+	}
+	return token{}, l.errorf(loc, "unterminated block string")
+}
+
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if len(trimmed) > 0 && (indent < 0 || len(line)-len(trimmed) < indent) {
+			indent = len(line) - len(trimmed)
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = strings.TrimLeft(lines[i], " \t")
+			}
+		}
+	}
+	for len(lines) > 0 && len(strings.TrimSpace(lines[0])) == 0 {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && len(strings.TrimSpace(lines[len(lines)-1])) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,580 @@
+package graphql
+
+import "fmt"
+
+// document is a parsed query
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	// kind is query, mutation or subscription
+	kind         string
+	name         string
+	variables    []*variableDefinition
+	directives   []*directive
+	selectionSet []selection
+	loc          Location
+}
+
+type variableDefinition struct {
+	name         string
+	typeRef      *typeRef
+	defaultValue *value
+	loc          Location
+}
+
+// typeRef is a type written in a query, such as [ID!]!
+type typeRef struct {
+	name    string
+	list    *typeRef
+	nonNull bool
+	loc     Location
+}
+
+func (ref *typeRef) String() string {
+	var s string
+	if ref.list != nil {
+		s = "[" + ref.list.String() + "]"
+	} else {
+		s = ref.name
+	}
+	if ref.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+// selection is a *field, *fragmentSpread or *inlineFragment
+type selection interface{}
+
+type field struct {
+	alias        string
+	name         string
+	arguments    []*argument
+	directives   []*directive
+	selectionSet []selection
+	loc          Location
+}
+
+// responseKey is the key of the field in the result
+func (f *field) responseKey() string {
+	if len(f.alias) > 0 {
+		return f.alias
+	}
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+	loc        Location
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []*directive
+	selectionSet  []selection
+	loc           Location
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	directives    []*directive
+	selectionSet  []selection
+	loc           Location
+}
+
+type argument struct {
+	name  string
+	value *value
+	loc   Location
+}
+
+type directive struct {
+	name      string
+	arguments []*argument
+	loc       Location
+}
+
+type valueKind int
+
+const (
+	valueVariable valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+// value is a literal or variable in a query. raw holds the text of scalars
+// and enums and the name of variables.
+type value struct {
+	kind   valueKind
+	raw    string
+	list   []*value
+	fields []*objectField
+	loc    Location
+}
+
+type objectField struct {
+	name  string
+	value *value
+}
+
+// parser reads a document with one token of lookahead
+type parser struct {
+	lexer *lexer
+	token token
+}
+
+// parse reads the query into a document
+func parse(source string) (*document, *Error) {
+	p := &parser{lexer: newLexer(source)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	doc := &document{fragments: make(map[string]*fragment)}
+	if p.token.kind == tokenEOF {
+		return nil, p.lexer.errorf(p.token.loc, "the query is empty")
+	}
+	for p.token.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			selectionSet, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{kind: "query", selectionSet: selectionSet, loc: selectionSetLocation(selectionSet)})
+		case p.peekName("query", "mutation", "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.peekName("fragment"):
+			frag, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := doc.fragments[frag.name]; exists {
+				return nil, &Error{Message: fmt.Sprintf("There can be only one fragment named %q.", frag.name), Locations: []Location{frag.loc}}
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	return doc, nil
+}
+
+func selectionSetLocation(selectionSet []selection) Location {
+	switch s := selectionSet[0].(type) {
+	case *field:
+		return s.loc
+	case *fragmentSpread:
+		return s.loc
+	case *inlineFragment:
+		return s.loc
+	}
+	return Location{}
+}
+
+func (p *parser) advance() *Error {
+	t, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.token = t
+	return nil
+}
+
+func (p *parser) unexpected() *Error {
+	return p.lexer.errorf(p.token.loc, "unexpected %s", p.token)
+}
+
+// peek determines if the current token is the punctuator
+func (p *parser) peek(punctuator string) bool {
+	return p.token.kind == tokenPunctuator && p.token.value == punctuator
+}
+
+// peekName determines if the current token is one of the names
+func (p *parser) peekName(names ...string) bool {
+	if p.token.kind != tokenName {
+		return false
+	}
+	for _, name := range names {
+		if p.token.value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// skip advances past the punctuator if it's the current token
+func (p *parser) skip(punctuator string) (bool, *Error) {
+	if !p.peek(punctuator) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(punctuator string) *Error {
+	if !p.peek(punctuator) {
+		return p.lexer.errorf(p.token.loc, "expected %q, found %s", punctuator, p.token)
+	}
+	return p.advance()
+}
+
+func (p *parser) expectName() (string, Location, *Error) {
+	if p.token.kind != tokenName {
+		return "", p.token.loc, p.lexer.errorf(p.token.loc, "expected a name, found %s", p.token)
+	}
+	name, loc := p.token.value, p.token.loc
+	return name, loc, p.advance()
+}
+
+func (p *parser) parseOperation() (*operation, *Error) {
+	op := &operation{kind: p.token.value, loc: p.token.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.token.kind == tokenName {
+		op.name = p.token.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("(") {
+		variables, err := p.parseVariableDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		op.variables = variables
+	}
+	directives, err := p.parseDirectives(false)
+	if err != nil {
+		return nil, err
+	}
+	op.directives = directives
+	if op.selectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*variableDefinition, *Error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var definitions []*variableDefinition
+	for {
+		if closed, err := p.skip(")"); err != nil || closed {
+			return definitions, err
+		}
+		definition := &variableDefinition{loc: p.token.loc}
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, _, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		definition.name = name
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if definition.typeRef, err = p.parseTypeRef(); err != nil {
+			return nil, err
+		}
+		if hasDefault, err := p.skip("="); err != nil {
+			return nil, err
+		} else if hasDefault {
+			if definition.defaultValue, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.parseDirectives(true); err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+}
+
+func (p *parser) parseTypeRef() (*typeRef, *Error) {
+	ref := &typeRef{loc: p.token.loc}
+	if listed, err := p.skip("["); err != nil {
+		return nil, err
+	} else if listed {
+		if ref.list, err = p.parseTypeRef(); err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+	} else {
+		name, _, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		ref.name = name
+	}
+	nonNull, err := p.skip("!")
+	if err != nil {
+		return nil, err
+	}
+	ref.nonNull = nonNull
+	return ref, nil
+}
+
+func (p *parser) parseDirectives(constant bool) ([]*directive, *Error) {
+	var directives []*directive
+	for p.peek("@") {
+		d := &directive{loc: p.token.loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, _, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		d.name = name
+		if d.arguments, err = p.parseArguments(constant); err != nil {
+			return nil, err
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+func (p *parser) parseArguments(constant bool) ([]*argument, *Error) {
+	if !p.peek("(") {
+		return nil, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var arguments []*argument
+	for {
+		if closed, err := p.skip(")"); err != nil || closed {
+			if err == nil && len(arguments) == 0 {
+				return nil, p.lexer.errorf(p.token.loc, "expected an argument in the parentheses")
+			}
+			return arguments, err
+		}
+		name, loc, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		v, err := p.parseValue(constant)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, &argument{name: name, value: v, loc: loc})
+	}
+}
+
+func (p *parser) parseSelectionSet() ([]selection, *Error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []selection
+	for {
+		if closed, err := p.skip("}"); err != nil || closed {
+			if err == nil && len(selections) == 0 {
+				return nil, p.lexer.errorf(p.token.loc, "expected a field in the braces")
+			}
+			return selections, err
+		}
+		s, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, s)
+	}
+}
+
+func (p *parser) parseSelection() (selection, *Error) {
+	if p.peek("...") {
+		loc := p.token.loc
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.token.kind == tokenName && p.token.value != "on" {
+			spread := &fragmentSpread{name: p.token.value, loc: loc}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			var err *Error
+			spread.directives, err = p.parseDirectives(false)
+			return spread, err
+		}
+		inline := &inlineFragment{loc: loc}
+		if p.peekName("on") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, _, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			inline.typeCondition = name
+		}
+		var err *Error
+		if inline.directives, err = p.parseDirectives(false); err != nil {
+			return nil, err
+		}
+		if inline.selectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+		return inline, nil
+	}
+
+	f := &field{}
+	name, loc, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	f.name, f.loc = name, loc
+	if aliased, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if aliased {
+		f.alias = f.name
+		if f.name, _, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+	if f.arguments, err = p.parseArguments(false); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.parseDirectives(false); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		if f.selectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) parseFragment() (*fragment, *Error) {
+	frag := &fragment{loc: p.token.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	name, _, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, p.lexer.errorf(frag.loc, "a fragment can't be named \"on\"")
+	}
+	frag.name = name
+	if !p.peekName("on") {
+		return nil, p.lexer.errorf(p.token.loc, "expected \"on\", found %s", p.token)
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if frag.typeCondition, _, err = p.expectName(); err != nil {
+		return nil, err
+	}
+	if frag.directives, err = p.parseDirectives(false); err != nil {
+		return nil, err
+	}
+	if frag.selectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return frag, nil
+}
+
+// parseValue reads a value. Variables aren't allowed in constant values,
+// such as the defaults of variables.
+func (p *parser) parseValue(constant bool) (*value, *Error) {
+	v := &value{loc: p.token.loc, raw: p.token.value}
+	switch p.token.kind {
+	case tokenInt:
+		v.kind = valueInt
+	case tokenFloat:
+		v.kind = valueFloat
+	case tokenString:
+		v.kind = valueString
+	case tokenName:
+		switch p.token.value {
+		case "true", "false":
+			v.kind = valueBoolean
+		case "null":
+			v.kind = valueNull
+		default:
+			v.kind = valueEnum
+		}
+	case tokenPunctuator:
+		switch p.token.value {
+		case "$":
+			if constant {
+				return nil, p.lexer.errorf(p.token.loc, "variables aren't allowed here")
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, _, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			v.kind, v.raw = valueVariable, name
+			return v, nil
+		case "[":
+			v.kind = valueList
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			for {
+				if closed, err := p.skip("]"); err != nil || closed {
+					return v, err
+				}
+				item, err := p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				v.list = append(v.list, item)
+			}
+		case "{":
+			v.kind = valueObject
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			for {
+				if closed, err := p.skip("}"); err != nil || closed {
+					return v, err
+				}
+				name, _, err := p.expectName()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				fieldValue, err := p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				v.fields = append(v.fields, &objectField{name: name, value: fieldValue})
+			}
+		default:
+			return nil, p.unexpected()
+		}
+	default:
+		return nil, p.unexpected()
+	}
+	return v, p.advance()
+}
@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// PrintSchema writes the schema in the GraphQL schema definition language.
+// The built-in scalars and introspection types are left out.
+func PrintSchema(schema *Schema) string {
+	var b strings.Builder
+	if schema.Query.Name != "Query" || (schema.Mutation != nil && schema.Mutation.Name != "Mutation") {
+		b.WriteString("schema {\n  query: " + schema.Query.Name + "\n")
+		if schema.Mutation != nil {
+			b.WriteString("  mutation: " + schema.Mutation.Name + "\n")
+		}
+		b.WriteString("}\n\n")
+	}
+
+	first := true
+	for _, name := range schema.typeNames() {
+		t := schema.types[name]
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		if _, builtIn := t.(*Scalar); builtIn {
+			continue
+		}
+		if !first {
+			b.WriteString("\n")
+		}
+		first = false
+
+		switch t := t.(type) {
+		case *Object:
+			printDescription(&b, t.Description, "")
+			b.WriteString("type " + t.Name + " {\n")
+			for _, f := range t.Fields {
+				printDescription(&b, f.Description, "  ")
+				b.WriteString("  " + f.Name + printArguments(f.Args) + ": " + f.Type.String())
+				printDeprecated(&b, f.DeprecationReason)
+				b.WriteString("\n")
+			}
+			b.WriteString("}\n")
+		case *Enum:
+			printDescription(&b, t.Description, "")
+			b.WriteString("enum " + t.Name + " {\n")
+			for _, v := range t.Values {
+				printDescription(&b, v.Description, "  ")
+				b.WriteString("  " + v.Name)
+				printDeprecated(&b, v.DeprecationReason)
+				b.WriteString("\n")
+			}
+			b.WriteString("}\n")
+		}
+	}
+	return b.String()
+}
+
+func printDescription(b *strings.Builder, description string, indent string) {
+	if len(description) == 0 {
+		return
+	}
+	if !strings.Contains(description, "\n") {
+		b.WriteString(indent + quote(description) + "\n")
+		return
+	}
+	b.WriteString(indent + `"""` + "\n")
+	for _, line := range strings.Split(description, "\n") {
+		b.WriteString(indent + strings.ReplaceAll(line, `"""`, `\"""`) + "\n")
+	}
+	b.WriteString(indent + `"""` + "\n")
+}
+
+func printArguments(args []*Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	var printed []string
+	for _, arg := range args {
+		s := arg.Name + ": " + arg.Type.String()
+		if arg.DefaultValue != nil {
+			s += " = " + printValue(arg.DefaultValue, arg.Type)
+		}
+		if len(arg.Description) > 0 {
+			s = quote(arg.Description) + " " + s
+		}
+		printed = append(printed, s)
+	}
+	return "(" + strings.Join(printed, ", ") + ")"
+}
+
+func printDeprecated(b *strings.Builder, reason string) {
+	if len(reason) > 0 {
+		b.WriteString(" @deprecated(reason: " + quote(reason) + ")")
+	}
+}
+
+func quote(s string) string {
+	encoded, _ := json.Marshal(s)
+	return string(encoded)
+}
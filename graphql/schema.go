@@ -0,0 +1,499 @@
+/*
+Package graphql implements enough of GraphQL to serve a schema built in Go:
+queries and mutations with variables, fragments, @include and @skip, and
+introspection. Queries are checked against the schema and against depth
+and complexity limits before anything is resolved.
+
+Schemas are made of objects, enums, lists, non-null types and the built-in
+scalars. Interfaces, unions, input objects and subscriptions aren't
+supported.
+*/
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Type is a *Scalar, *Object, *Enum, *List or *NonNull
+type Type interface {
+	// String is the type as it's written in a query, such as [ID!]!
+	String() string
+}
+
+// Scalar is a leaf value. Only the built-in scalars are supported.
+type Scalar struct {
+	Name        string
+	Description string
+	// serialize converts a resolved value into the value in the response
+	serialize func(v interface{}) (interface{}, bool)
+	// parseLiteral converts a value written in the query
+	parseLiteral func(v *value) (interface{}, bool)
+	// parseVariable converts a value of a variable decoded from JSON
+	parseVariable func(v interface{}) (interface{}, bool)
+}
+
+func (scalar *Scalar) String() string {
+	return scalar.Name
+}
+
+// Object is a type with fields
+type Object struct {
+	Name        string
+	Description string
+	Fields      []*Field
+}
+
+func (object *Object) String() string {
+	return object.Name
+}
+
+// AddField adds the field to the object. Objects that refer to each other
+// are created first and their fields are added after.
+func (object *Object) AddField(f *Field) *Object {
+	object.Fields = append(object.Fields, f)
+	return object
+}
+
+// field returns the field with the name or nil
+func (object *Object) field(name string) *Field {
+	for _, f := range object.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// ResolveParams are given to the resolver of a field
+type ResolveParams struct {
+	Context context.Context
+	// Source is the value resolved for the object the field belongs to. It's
+	// nil for the fields of the query and mutation types.
+	Source interface{}
+	// Args are the arguments of the field with the defaults filled in
+	Args map[string]interface{}
+}
+
+// ResolveFunc returns the value of a field. Lists are returned as slices,
+// objects as any value their fields can resolve, enums as strings and
+// scalars as the matching Go type.
+type ResolveFunc func(p ResolveParams) (interface{}, error)
+
+// ComplexityFunc returns the cost of a field given its arguments and the
+// cost of its selection set
+type ComplexityFunc func(args map[string]interface{}, childComplexity int) int
+
+// Field is a field of an object
+type Field struct {
+	Name              string
+	Description       string
+	Type              Type
+	Args              []*Argument
+	DeprecationReason string
+	// Resolve returns the value of the field. When nil, the source must be a
+	// map[string]interface{} with the value under the name of the field.
+	Resolve ResolveFunc
+	// Complexity returns the cost of the field. When nil, the cost is 1 plus
+	// the cost of the selection set.
+	Complexity ComplexityFunc
+}
+
+func (f *Field) argument(name string) *Argument {
+	for _, arg := range f.Args {
+		if arg.Name == name {
+			return arg
+		}
+	}
+	return nil
+}
+
+// Argument is an argument of a field or directive. Its type must be a
+// scalar, an enum or a list or non-null of one.
+type Argument struct {
+	Name        string
+	Description string
+	Type        Type
+	// DefaultValue is used when the argument isn't given. Nil means there's
+	// no default.
+	DefaultValue interface{}
+}
+
+// Enum is a leaf whose values are names. Resolvers return the names as
+// strings and arguments are given as strings.
+type Enum struct {
+	Name        string
+	Description string
+	Values      []*EnumValue
+}
+
+type EnumValue struct {
+	Name              string
+	Description       string
+	DeprecationReason string
+}
+
+func (enum *Enum) String() string {
+	return enum.Name
+}
+
+func (enum *Enum) hasValue(name string) bool {
+	for _, v := range enum.Values {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// List is a list of another type
+type List struct {
+	OfType Type
+}
+
+func (list *List) String() string {
+	return "[" + list.OfType.String() + "]"
+}
+
+// NonNull is a type that is never null
+type NonNull struct {
+	OfType Type
+}
+
+func (nonNull *NonNull) String() string {
+	return nonNull.OfType.String() + "!"
+}
+
+// NewList is shorthand for &List{OfType: t}
+func NewList(t Type) *List {
+	return &List{OfType: t}
+}
+
+// NewNonNull is shorthand for &NonNull{OfType: t}
+func NewNonNull(t Type) *NonNull {
+	return &NonNull{OfType: t}
+}
+
+// namedType removes the lists and non-nulls around a type
+func namedType(t Type) Type {
+	for {
+		switch wrapper := t.(type) {
+		case *List:
+			t = wrapper.OfType
+		case *NonNull:
+			t = wrapper.OfType
+		default:
+			return t
+		}
+	}
+}
+
+func typeName(t Type) string {
+	return namedType(t).String()
+}
+
+func isLeaf(t Type) bool {
+	switch namedType(t).(type) {
+	case *Scalar, *Enum:
+		return true
+	}
+	return false
+}
+
+func isList(t Type) bool {
+	if nonNull, ok := t.(*NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*List)
+	return ok
+}
+
+// The built-in scalars
+var (
+	Int = &Scalar{
+		Name:        "Int",
+		Description: "The `Int` scalar type represents non-fractional signed whole numeric values between -2^31 and 2^31 - 1.",
+		serialize:   serializeInt,
+		parseLiteral: func(v *value) (interface{}, bool) {
+			if v.kind != valueInt {
+				return nil, false
+			}
+			n, err := strconv.ParseInt(v.raw, 10, 32)
+			return int(n), err == nil
+		},
+		parseVariable: serializeInt,
+	}
+	Float = &Scalar{
+		Name:        "Float",
+		Description: "The `Float` scalar type represents signed double-precision fractional values.",
+		serialize:   serializeFloat,
+		parseLiteral: func(v *value) (interface{}, bool) {
+			if v.kind != valueInt && v.kind != valueFloat {
+				return nil, false
+			}
+			n, err := strconv.ParseFloat(v.raw, 64)
+			return n, err == nil && !math.IsInf(n, 0)
+		},
+		parseVariable: serializeFloat,
+	}
+	String = &Scalar{
+		Name:        "String",
+		Description: "The `String` scalar type represents textual data, represented as UTF-8 character sequences.",
+		serialize:   serializeString,
+		parseLiteral: func(v *value) (interface{}, bool) {
+			return v.raw, v.kind == valueString
+		},
+		parseVariable: serializeString,
+	}
+	Boolean = &Scalar{
+		Name:        "Boolean",
+		Description: "The `Boolean` scalar type represents `true` or `false`.",
+		serialize:   serializeBoolean,
+		parseLiteral: func(v *value) (interface{}, bool) {
+			return v.raw == "true", v.kind == valueBoolean
+		},
+		parseVariable: serializeBoolean,
+	}
+	ID = &Scalar{
+		Name:        "ID",
+		Description: "The `ID` scalar type represents a unique identifier, written as a string.",
+		serialize:   serializeID,
+		parseLiteral: func(v *value) (interface{}, bool) {
+			return v.raw, v.kind == valueString || v.kind == valueInt
+		},
+		parseVariable: serializeID,
+	}
+)
+
+var builtInScalars = []*Scalar{Int, Float, String, Boolean, ID}
+
+func serializeInt(v interface{}) (interface{}, bool) {
+	var n int64
+	switch v := v.(type) {
+	case int:
+		n = int64(v)
+	case int32:
+		n = int64(v)
+	case int64:
+		n = v
+	case float64:
+		if v != math.Trunc(v) {
+			return nil, false
+		}
+		n = int64(v)
+	default:
+		return nil, false
+	}
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		return nil, false
+	}
+	return int(n), true
+}
+
+func serializeFloat(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, !math.IsInf(v, 0) && !math.IsNaN(v)
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	}
+	return nil, false
+}
+
+func serializeString(v interface{}) (interface{}, bool) {
+	s, ok := v.(string)
+	return s, ok
+}
+
+func serializeBoolean(v interface{}) (interface{}, bool) {
+	b, ok := v.(bool)
+	return b, ok
+}
+
+func serializeID(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case int:
+		return strconv.Itoa(v), true
+	case float64:
+		if v == math.Trunc(v) {
+			return strconv.FormatInt(int64(v), 10), true
+		}
+	}
+	return nil, false
+}
+
+// Directive is a directive that queries can use. Only @include and @skip
+// are supported.
+type Directive struct {
+	Name        string
+	Description string
+	Locations   []string
+	Args        []*Argument
+}
+
+var directives = []*Directive{
+	{
+		Name:        "include",
+		Description: "Directs the executor to include this field or fragment only when the `if` argument is true.",
+		Locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		Args:        []*Argument{{Name: "if", Description: "Included when true.", Type: NewNonNull(Boolean)}},
+	},
+	{
+		Name:        "skip",
+		Description: "Directs the executor to skip this field or fragment when the `if` argument is true.",
+		Locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		Args:        []*Argument{{Name: "if", Description: "Skipped when true.", Type: NewNonNull(Boolean)}},
+	},
+	{
+		Name:        "deprecated",
+		Description: "Marks an element of a GraphQL schema as no longer supported.",
+		Locations:   []string{"FIELD_DEFINITION", "ENUM_VALUE"},
+		Args:        []*Argument{{Name: "reason", Description: "Explains why the element was deprecated.", Type: String, DefaultValue: "No longer supported"}},
+	},
+}
+
+func findDirective(name string) *Directive {
+	for _, d := range directives {
+		if d.Name == name {
+			return d
+		}
+	}
+	return nil
+}
+
+// Schema is the types that can be queried starting from the query type and,
+// optionally, the mutation type
+type Schema struct {
+	Query    *Object
+	Mutation *Object
+	// types are the named types by name, including the introspection types
+	types map[string]Type
+}
+
+// NewSchema checks the types reachable from the query and mutation types.
+// Each type must have a unique name and each object at least one field.
+func NewSchema(query, mutation *Object) (*Schema, error) {
+	if query == nil {
+		return nil, fmt.Errorf("a schema needs a query type")
+	}
+	schema := &Schema{
+		Query:    query,
+		Mutation: mutation,
+		types:    make(map[string]Type),
+	}
+	for _, scalar := range builtInScalars {
+		schema.types[scalar.Name] = scalar
+	}
+	roots := []Type{query, schemaType, typeType}
+	if mutation != nil {
+		roots = append(roots, mutation)
+	}
+	for _, root := range roots {
+		if err := schema.addType(root); err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+func (schema *Schema) addType(t Type) error {
+	t = namedType(t)
+	name := t.String()
+	if existing, exists := schema.types[name]; exists {
+		if existing != t {
+			return fmt.Errorf("schema has more than one type named %s", name)
+		}
+		return nil
+	}
+	if !isValidName(name) {
+		return fmt.Errorf("invalid type name %q", name)
+	}
+	schema.types[name] = t
+
+	switch t := t.(type) {
+	case *Scalar:
+		return fmt.Errorf("custom scalars aren't supported: %s", name)
+	case *Enum:
+		if len(t.Values) == 0 {
+			return fmt.Errorf("enum %s has no values", name)
+		}
+	case *Object:
+		if len(t.Fields) == 0 {
+			return fmt.Errorf("object %s has no fields", name)
+		}
+		names := make(map[string]bool)
+		for _, f := range t.Fields {
+			if names[f.Name] {
+				return fmt.Errorf("object %s has more than one field named %s", name, f.Name)
+			}
+			names[f.Name] = true
+			if err := schema.addType(f.Type); err != nil {
+				return err
+			}
+			for _, arg := range f.Args {
+				if _, isObject := namedType(arg.Type).(*Object); isObject {
+					return fmt.Errorf("argument %s of %s.%s must be a scalar or an enum", arg.Name, name, f.Name)
+				}
+				if err := schema.addType(arg.Type); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func isValidName(name string) bool {
+	if len(name) == 0 || isDigit(name[0]) {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] != '_' && !isLetter(name[i]) && !isDigit(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Type returns the named type or nil
+func (schema *Schema) Type(name string) Type {
+	return schema.types[name]
+}
+
+// typeNames returns the names of the types in order
+func (schema *Schema) typeNames() []string {
+	var names []string
+	for name := range schema.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Location is a line and column in a query, both starting at 1
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error is an error in the response. Errors found before the query runs
+// have no path.
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (err *Error) Error() string {
+	if len(err.Locations) > 0 {
+		return fmt.Sprintf("%s (line %d, column %d)", err.Message, err.Locations[0].Line, err.Locations[0].Column)
+	}
+	return err.Message
+}
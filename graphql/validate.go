@@ -0,0 +1,252 @@
+package graphql
+
+import "fmt"
+
+// Limits bound the cost of a query so a single request can't ask for the
+// whole catalog many times over. Zero means no limit.
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields. The fields of the operation
+	// are at depth 1.
+	MaxDepth int
+	// MaxComplexity is the most the fields can cost. See Field.Complexity.
+	MaxComplexity int
+}
+
+// complexityCap is where complexity stops growing so that deeply nested
+// lists and fragments can't overflow it
+const complexityCap = 1 << 40
+
+// fragmentCost is the depth and complexity of a fragment's selection set,
+// relative to where it's spread
+type fragmentCost struct {
+	depth      int
+	complexity int
+}
+
+// validator checks an operation against the schema and measures its depth
+// and complexity. Every problem is collected so they can all be reported.
+type validator struct {
+	schema        *Schema
+	doc           *document
+	variables     map[string]interface{}
+	variableTypes map[string]*variableDefinition
+	errors        []*Error
+	// spreading holds the fragments being spread to find cycles
+	spreading map[string]bool
+	// fragmentCosts holds fragments that have been checked so that spreading
+	// one many times doesn't check it again each time
+	fragmentCosts map[string]fragmentCost
+	usedFragments map[string]bool
+	usedVariables map[string]bool
+}
+
+func (v *validator) errorf(loc Location, format string, a ...interface{}) {
+	v.errors = append(v.errors, &Error{Message: fmt.Sprintf(format, a...), Locations: []Location{loc}})
+}
+
+// validate checks the operation and returns its depth and complexity
+func (v *validator) validate(op *operation, root *Object) (depth int, complexity int) {
+	v.spreading = make(map[string]bool)
+	v.fragmentCosts = make(map[string]fragmentCost)
+	v.usedFragments = make(map[string]bool)
+	v.usedVariables = make(map[string]bool)
+	v.variableTypes = make(map[string]*variableDefinition)
+	for _, definition := range op.variables {
+		if _, exists := v.variableTypes[definition.name]; exists {
+			v.errorf(definition.loc, "There can be only one variable named $%s.", definition.name)
+		}
+		v.variableTypes[definition.name] = definition
+	}
+	v.directives(op.directives)
+
+	depth, complexity = v.selectionSet(root, op.selectionSet, 1)
+
+	for _, definition := range op.variables {
+		if !v.usedVariables[definition.name] {
+			v.errorf(definition.loc, "Variable $%s is never used.", definition.name)
+		}
+	}
+	for name, frag := range v.doc.fragments {
+		if !v.usedFragments[name] && len(v.doc.operations) == 1 {
+			v.errorf(frag.loc, "Fragment %q is never used.", name)
+		}
+	}
+	return depth, complexity
+}
+
+func (v *validator) selectionSet(object *Object, selections []selection, depth int) (maxDepth int, complexity int) {
+	maxDepth = depth
+	for _, s := range selections {
+		var selectionDepth, selectionComplexity int
+		switch s := s.(type) {
+		case *field:
+			selectionDepth, selectionComplexity = v.field(object, s, depth)
+		case *fragmentSpread:
+			v.directives(s.directives)
+			frag, exists := v.doc.fragments[s.name]
+			if !exists {
+				v.errorf(s.loc, "Unknown fragment %q.", s.name)
+				continue
+			}
+			v.usedFragments[s.name] = true
+			if v.spreading[s.name] {
+				v.errorf(s.loc, "Cannot spread fragment %q within itself.", s.name)
+				continue
+			}
+			cost, checked := v.fragmentCosts[s.name]
+			if !checked {
+				if !v.typeCondition(object, frag.typeCondition, s.loc) {
+					continue
+				}
+				v.spreading[s.name] = true
+				fragmentDepth, fragmentComplexity := v.selectionSet(object, frag.selectionSet, depth)
+				delete(v.spreading, s.name)
+				cost = fragmentCost{depth: fragmentDepth - depth, complexity: fragmentComplexity}
+				v.fragmentCosts[s.name] = cost
+			} else if Type(object) != v.schema.types[frag.typeCondition] {
+				v.typeCondition(object, frag.typeCondition, s.loc)
+				continue
+			}
+			selectionDepth, selectionComplexity = depth+cost.depth, 1+cost.complexity
+		case *inlineFragment:
+			v.directives(s.directives)
+			if len(s.typeCondition) > 0 && !v.typeCondition(object, s.typeCondition, s.loc) {
+				continue
+			}
+			selectionDepth, selectionComplexity = v.selectionSet(object, s.selectionSet, depth)
+		}
+		maxDepth = max(maxDepth, selectionDepth)
+		complexity = min(complexityCap, complexity+selectionComplexity)
+	}
+	return maxDepth, complexity
+}
+
+// typeCondition determines if a fragment on the type can be spread within
+// the object
+func (v *validator) typeCondition(object *Object, name string, loc Location) bool {
+	t, exists := v.schema.types[name]
+	if !exists {
+		v.errorf(loc, "Unknown type %q.", name)
+		return false
+	}
+	if t != Type(object) {
+		v.errorf(loc, "Fragment cannot be spread here as objects of type %q can never be of type %q.", object.Name, name)
+		return false
+	}
+	return true
+}
+
+func (v *validator) field(object *Object, f *field, depth int) (int, int) {
+	v.directives(f.directives)
+	if f.name == "__typename" {
+		if len(f.selectionSet) > 0 {
+			v.errorf(f.loc, "Field \"__typename\" must not have a selection since type \"String!\" has no subfields.")
+		}
+		return depth, 1
+	}
+
+	definition := v.schema.fieldDefinition(object, f.name)
+	if definition == nil {
+		v.errorf(f.loc, "Cannot query field %q on type %q.", f.name, object.Name)
+		return depth, 0
+	}
+	for _, arg := range f.arguments {
+		if definition.argument(arg.name) == nil {
+			v.errorf(arg.loc, "Unknown argument %q on field \"%s.%s\".", arg.name, object.Name, f.name)
+		}
+		v.variableUsages(arg.value, definition.argument(arg.name))
+	}
+	args, err := coerceArguments(definition.Args, f.arguments, v.variables, f.loc)
+	if err != nil {
+		v.errors = append(v.errors, err)
+	}
+
+	fieldDepth, childComplexity := depth, 0
+	if isLeaf(definition.Type) {
+		if len(f.selectionSet) > 0 {
+			v.errorf(f.loc, "Field %q must not have a selection since type %q has no subfields.", f.name, definition.Type)
+		}
+	} else if len(f.selectionSet) == 0 {
+		v.errorf(f.loc, "Field %q of type %q must have a selection of subfields.", f.name, definition.Type)
+	} else {
+		fieldDepth, childComplexity = v.selectionSet(namedType(definition.Type).(*Object), f.selectionSet, depth+1)
+	}
+
+	if definition.Complexity != nil && args != nil {
+		return fieldDepth, min(complexityCap, definition.Complexity(args, childComplexity))
+	}
+	return fieldDepth, min(complexityCap, 1+childComplexity)
+}
+
+// variableUsages checks that the variables in the value are defined with a
+// type that can be used for the argument
+func (v *validator) variableUsages(value *value, arg *Argument) {
+	switch value.kind {
+	case valueVariable:
+		v.usedVariables[value.raw] = true
+		definition, defined := v.variableTypes[value.raw]
+		if !defined {
+			v.errorf(value.loc, "Variable $%s is not defined.", value.raw)
+			return
+		}
+		if arg == nil {
+			return
+		}
+		locationType := arg.Type
+		if nonNull, ok := locationType.(*NonNull); ok && (definition.defaultValue != nil || arg.DefaultValue != nil) {
+			locationType = nonNull.OfType
+		}
+		if !isTypeRefCompatible(definition.typeRef, locationType) {
+			v.errorf(value.loc, "Variable $%s of type %q used in position expecting type %q.", value.raw, definition.typeRef, arg.Type)
+		}
+	case valueList:
+		for _, item := range value.list {
+			v.variableUsages(item, nil)
+		}
+	}
+}
+
+// isTypeRefCompatible determines if a variable of the type can be used where
+// the type is expected
+func isTypeRefCompatible(ref *typeRef, t Type) bool {
+	if nonNull, ok := t.(*NonNull); ok {
+		if !ref.nonNull {
+			return false
+		}
+		t = nonNull.OfType
+	}
+	if list, ok := t.(*List); ok {
+		return ref.list != nil && isTypeRefCompatible(ref.list, list.OfType)
+	}
+	return ref.list == nil && ref.name == t.String()
+}
+
+func (v *validator) directives(directives []*directive) {
+	for _, d := range directives {
+		definition := findDirective(d.name)
+		if definition == nil || d.name == "deprecated" {
+			v.errorf(d.loc, "Unknown directive \"@%s\".", d.name)
+			continue
+		}
+		for _, arg := range d.arguments {
+			v.variableUsages(arg.value, nil)
+		}
+		if _, err := coerceArguments(definition.Args, d.arguments, v.variables, d.loc); err != nil {
+			v.errors = append(v.errors, err)
+		}
+	}
+}
+
+// fieldDefinition finds the field of the object, including the
+// introspection fields of the query type
+func (schema *Schema) fieldDefinition(object *Object, name string) *Field {
+	if object == schema.Query {
+		switch name {
+		case "__schema":
+			return schemaMetaField
+		case "__type":
+			return typeMetaField
+		}
+	}
+	return object.field(name)
+}
@@ -0,0 +1,167 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testSchema has an Item that refers to itself so queries can nest as deep
+// as needed. The items fields cost their selection set for each item.
+func testSchema(t *testing.T) *Schema {
+	item := &Object{Name: "Item"}
+	resolveItem := func(p ResolveParams) (interface{}, error) {
+		return map[string]interface{}{"name": "item"}, nil
+	}
+	resolveItems := func(p ResolveParams) (interface{}, error) {
+		return []interface{}{map[string]interface{}{"name": "item"}}, nil
+	}
+	itemsComplexity := func(args map[string]interface{}, childComplexity int) int {
+		limit, _ := args["limit"].(int)
+		return 1 + limit*childComplexity
+	}
+	limitArgs := []*Argument{{Name: "limit", Type: Int, DefaultValue: 10}}
+	item.
+		AddField(&Field{Name: "name", Type: String}).
+		AddField(&Field{Name: "child", Type: item, Resolve: resolveItem}).
+		AddField(&Field{Name: "items", Type: NewList(item), Args: limitArgs, Complexity: itemsComplexity, Resolve: resolveItems})
+	query := (&Object{Name: "Query"}).
+		AddField(&Field{Name: "item", Type: item, Resolve: resolveItem}).
+		AddField(&Field{Name: "items", Type: NewList(item), Args: limitArgs, Complexity: itemsComplexity, Resolve: resolveItems})
+	schema, err := NewSchema(query, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+// measure validates the query and returns its depth, complexity and the
+// messages of the validation errors
+func measure(t *testing.T, schema *Schema, query string) (int, int, []string) {
+	doc, syntaxErr := parse(query)
+	if syntaxErr != nil {
+		t.Fatalf("%s: %v", query, syntaxErr)
+	}
+	op, err := doc.operation("")
+	if err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+	v := &validator{schema: schema, doc: doc, variables: map[string]interface{}{}}
+	depth, complexity := v.validate(op, schema.Query)
+	var messages []string
+	for _, err := range v.errors {
+		messages = append(messages, err.Message)
+	}
+	return depth, complexity, messages
+}
+
+func TestDepthAndComplexity(t *testing.T) {
+	schema := testSchema(t)
+	tests := []struct {
+		name       string
+		query      string
+		depth      int
+		complexity int
+	}{
+		{"field", `{ item { name } }`, 2, 2},
+		{"nested", `{ item { child { child { name } } } }`, 4, 4},
+		{"typename", `{ item { __typename } }`, 2, 2},
+		{"list", `{ items(limit: 5) { name } }`, 2, 6},
+		{"default limit", `{ items { name } }`, 2, 11},
+		{"nested lists", `{ items(limit: 5) { name items(limit: 3) { name } } }`, 3, 26},
+		{"fragment", `{ item { ...F } } fragment F on Item { name child { name } }`, 3, 5},
+		{"fragment spread twice", `{ a: item { ...F } b: item { ...F } } fragment F on Item { name }`, 2, 6},
+		{"fragment in a list", `{ items(limit: 4) { ...F } } fragment F on Item { name }`, 2, 9},
+		{"nested fragments", `{ item { ...A } } fragment A on Item { child { ...B } } fragment B on Item { name }`, 3, 5},
+		{"inline fragment", `{ item { ... on Item { name } } }`, 2, 2},
+	}
+	for _, test := range tests {
+		depth, complexity, errs := measure(t, schema, test.query)
+		if len(errs) > 0 {
+			t.Errorf("%s: unexpected errors %v", test.name, errs)
+			continue
+		}
+		if depth != test.depth || complexity != test.complexity {
+			t.Errorf("%s: got depth %d and complexity %d, want %d and %d", test.name, depth, complexity, test.depth, test.complexity)
+		}
+	}
+}
+
+// TestFragmentComplexityIsCapped spreads each fragment twice in the next
+// one, which doubles the cost at each level
+func TestFragmentComplexityIsCapped(t *testing.T) {
+	const levels = 60
+	var query strings.Builder
+	query.WriteString(`{ item { ...F59 } } fragment F0 on Item { name }`)
+	for i := 1; i < levels; i++ {
+		fmt.Fprintf(&query, ` fragment F%d on Item { a: child { ...F%d } b: child { ...F%d } }`, i, i-1, i-1)
+	}
+	depth, complexity, errs := measure(t, testSchema(t), query.String())
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	if depth != levels+1 {
+		t.Errorf("got depth %d, want %d", depth, levels+1)
+	}
+	if complexity != complexityCap {
+		t.Errorf("got complexity %d, want %d", complexity, complexityCap)
+	}
+}
+
+func TestFragmentErrors(t *testing.T) {
+	schema := testSchema(t)
+	tests := []struct {
+		name  string
+		query string
+		error string
+	}{
+		{"spread within itself", `{ item { ...A } } fragment A on Item { name ...A }`, `Cannot spread fragment "A" within itself.`},
+		{"cycle", `{ item { ...A } } fragment A on Item { child { ...B } } fragment B on Item { name ...A }`, `Cannot spread fragment "A" within itself.`},
+		{"cycle through an inline fragment", `{ item { ...A } } fragment A on Item { ... on Item { ...A } }`, `Cannot spread fragment "A" within itself.`},
+		{"unknown fragment", `{ item { ...A } }`, `Unknown fragment "A".`},
+		{"unused fragment", `{ item { name } } fragment A on Item { name }`, `Fragment "A" is never used.`},
+		{"unknown type", `{ item { ...A } } fragment A on Thing { name }`, `Unknown type "Thing".`},
+		{"wrong type", `{ ...A } fragment A on Item { name }`, `Fragment cannot be spread here as objects of type "Query" can never be of type "Item".`},
+	}
+	for _, test := range tests {
+		_, _, errs := measure(t, schema, test.query)
+		found := false
+		for _, message := range errs {
+			found = found || message == test.error
+		}
+		if !found {
+			t.Errorf("%s: got errors %v, want %q", test.name, errs, test.error)
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	schema := testSchema(t)
+	const deep = `{ item { child { child { name } } } }`
+	const costly = `{ items(limit: 5) { name items(limit: 3) { name } } }`
+	tests := []struct {
+		name   string
+		query  string
+		limits Limits
+		error  string
+	}{
+		{"no limits", deep, Limits{}, ""},
+		{"at the maximum depth", deep, Limits{MaxDepth: 4}, ""},
+		{"deeper than the maximum", deep, Limits{MaxDepth: 3}, "The query has a depth of 4, which exceeds the maximum of 3."},
+		{"at the maximum complexity", costly, Limits{MaxComplexity: 26}, ""},
+		{"more complex than the maximum", costly, Limits{MaxComplexity: 25}, "The query has a complexity of 26, which exceeds the maximum of 25."},
+		{"both limits", costly, Limits{MaxDepth: 2, MaxComplexity: 10}, "The query has a depth of 3, which exceeds the maximum of 2."},
+	}
+	for _, test := range tests {
+		response := schema.Execute(Request{Query: test.query}, test.limits)
+		if test.error == "" {
+			if response.Rejected() {
+				t.Errorf("%s: unexpected errors %v", test.name, response.Errors)
+			}
+			continue
+		}
+		if !response.Rejected() || len(response.Errors) != 1 || response.Errors[0].Message != test.error {
+			t.Errorf("%s: got errors %v, want %q", test.name, response.Errors, test.error)
+		}
+	}
+}
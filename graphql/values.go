@@ -0,0 +1,273 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// coerceLiteral converts a value in the query into a value of the type.
+// Variables must already be coerced. The bool is false when the value is a
+// variable that wasn't given, which leaves the argument out.
+func coerceLiteral(v *value, t Type, variables map[string]interface{}) (interface{}, bool, error) {
+	if v.kind == valueVariable {
+		variable, given := variables[v.raw]
+		if !given {
+			return nil, false, nil
+		}
+		if _, nonNull := t.(*NonNull); nonNull && variable == nil {
+			return nil, false, fmt.Errorf("expected a value of type %s but $%s is null", t, v.raw)
+		}
+		return variable, true, nil
+	}
+
+	if nonNull, ok := t.(*NonNull); ok {
+		if v.kind == valueNull {
+			return nil, false, fmt.Errorf("expected a value of type %s but found null", t)
+		}
+		return coerceLiteral(v, nonNull.OfType, variables)
+	}
+	if v.kind == valueNull {
+		return nil, true, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		if v.kind != valueList {
+			item, given, err := coerceLiteral(v, t.OfType, variables)
+			if err != nil || !given {
+				return nil, given, err
+			}
+			return []interface{}{item}, true, nil
+		}
+		items := []interface{}{}
+		for _, itemValue := range v.list {
+			item, given, err := coerceLiteral(itemValue, t.OfType, variables)
+			if err != nil {
+				return nil, false, err
+			}
+			if !given {
+				if _, nonNull := t.OfType.(*NonNull); nonNull {
+					return nil, false, fmt.Errorf("expected a value of type %s but $%s wasn't given", t.OfType, itemValue.raw)
+				}
+			}
+			items = append(items, item)
+		}
+		return items, true, nil
+	case *Scalar:
+		if parsed, ok := t.parseLiteral(v); ok {
+			return parsed, true, nil
+		}
+	case *Enum:
+		if v.kind == valueEnum && t.hasValue(v.raw) {
+			return v.raw, true, nil
+		}
+	}
+	return nil, false, fmt.Errorf("expected a value of type %s but found %s", t, printLiteral(v))
+}
+
+// coerceVariable converts the value of a variable, as decoded from JSON, into
+// a value of the type
+func coerceVariable(v interface{}, t Type) (interface{}, error) {
+	if nonNull, ok := t.(*NonNull); ok {
+		if v == nil {
+			return nil, fmt.Errorf("expected a value of type %s but found null", t)
+		}
+		return coerceVariable(v, nonNull.OfType)
+	}
+	if v == nil {
+		return nil, nil
+	}
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("expected a value of type %s but found %s", t, n)
+		}
+		v = f
+	}
+
+	switch t := t.(type) {
+	case *List:
+		values, isList := v.([]interface{})
+		if !isList {
+			item, err := coerceVariable(v, t.OfType)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{item}, nil
+		}
+		items := []interface{}{}
+		for _, value := range values {
+			item, err := coerceVariable(value, t.OfType)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case *Scalar:
+		if parsed, ok := t.parseVariable(v); ok {
+			return parsed, nil
+		}
+	case *Enum:
+		if name, ok := v.(string); ok && t.hasValue(name) {
+			return name, nil
+		}
+	}
+	encoded, _ := json.Marshal(v)
+	return nil, fmt.Errorf("expected a value of type %s but found %s", t, encoded)
+}
+
+// coerceVariables converts the variables given with the query into the
+// types declared by the operation. Variables that aren't given use their
+// default.
+func (schema *Schema) coerceVariables(op *operation, inputs map[string]interface{}) (map[string]interface{}, []*Error) {
+	coerced := make(map[string]interface{})
+	var errs []*Error
+	for _, definition := range op.variables {
+		t, err := schema.inputType(definition.typeRef)
+		if err != nil {
+			errs = append(errs, &Error{Message: fmt.Sprintf("Variable $%s: %v", definition.name, err), Locations: []Location{definition.loc}})
+			continue
+		}
+		input, given := inputs[definition.name]
+		if !given {
+			if definition.defaultValue != nil {
+				value, _, err := coerceLiteral(definition.defaultValue, t, nil)
+				if err != nil {
+					errs = append(errs, &Error{Message: fmt.Sprintf("Variable $%s has an invalid default: %v", definition.name, err), Locations: []Location{definition.loc}})
+				} else {
+					coerced[definition.name] = value
+				}
+			} else if _, nonNull := t.(*NonNull); nonNull {
+				errs = append(errs, &Error{Message: fmt.Sprintf("Variable $%s of required type %s was not provided.", definition.name, t), Locations: []Location{definition.loc}})
+			}
+			continue
+		}
+		value, err := coerceVariable(input, t)
+		if err != nil {
+			errs = append(errs, &Error{Message: fmt.Sprintf("Variable $%s got an invalid value: %v", definition.name, err), Locations: []Location{definition.loc}})
+			continue
+		}
+		coerced[definition.name] = value
+	}
+	return coerced, errs
+}
+
+// inputType finds the type written in the query. Only scalars and enums can
+// be the types of variables.
+func (schema *Schema) inputType(ref *typeRef) (Type, error) {
+	var t Type
+	if ref.list != nil {
+		ofType, err := schema.inputType(ref.list)
+		if err != nil {
+			return nil, err
+		}
+		t = NewList(ofType)
+	} else {
+		t = schema.types[ref.name]
+		switch t.(type) {
+		case nil:
+			return nil, fmt.Errorf("unknown type %s", ref.name)
+		case *Object:
+			return nil, fmt.Errorf("%s is not an input type", ref.name)
+		}
+	}
+	if ref.nonNull {
+		t = NewNonNull(t)
+	}
+	return t, nil
+}
+
+// coerceArguments converts the arguments of a field or directive and fills
+// in the defaults
+func coerceArguments(definitions []*Argument, args []*argument, variables map[string]interface{}, loc Location) (map[string]interface{}, *Error) {
+	coerced := make(map[string]interface{})
+	for _, definition := range definitions {
+		var given *argument
+		for _, arg := range args {
+			if arg.name == definition.Name {
+				given = arg
+			}
+		}
+		if given != nil {
+			value, exists, err := coerceLiteral(given.value, definition.Type, variables)
+			if err != nil {
+				return nil, &Error{Message: fmt.Sprintf("Argument %q has an invalid value: %v.", definition.Name, err), Locations: []Location{given.loc}}
+			}
+			if exists {
+				coerced[definition.Name] = value
+				continue
+			}
+		}
+		if definition.DefaultValue != nil {
+			coerced[definition.Name] = definition.DefaultValue
+		} else if _, nonNull := definition.Type.(*NonNull); nonNull {
+			return nil, &Error{Message: fmt.Sprintf("Argument %q of type %s is required but not provided.", definition.Name, definition.Type), Locations: []Location{loc}}
+		}
+	}
+	return coerced, nil
+}
+
+// printLiteral writes the value as it appears in a query
+func printLiteral(v *value) string {
+	switch v.kind {
+	case valueVariable:
+		return "$" + v.raw
+	case valueString:
+		return strconv.Quote(v.raw)
+	case valueNull:
+		return "null"
+	case valueList:
+		var items []string
+		for _, item := range v.list {
+			items = append(items, printLiteral(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case valueObject:
+		var fields []string
+		for _, f := range v.fields {
+			fields = append(fields, f.name+": "+printLiteral(f.value))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	default:
+		return v.raw
+	}
+}
+
+// printValue writes a Go value of the type as it would appear in a query,
+// such as the default of an argument
+func printValue(v interface{}, t Type) string {
+	if nonNull, ok := t.(*NonNull); ok {
+		t = nonNull.OfType
+	}
+	if v == nil {
+		return "null"
+	}
+	switch t := t.(type) {
+	case *List:
+		var items []string
+		switch values := v.(type) {
+		case []interface{}:
+			for _, item := range values {
+				items = append(items, printValue(item, t.OfType))
+			}
+		case []string:
+			for _, item := range values {
+				items = append(items, printValue(item, t.OfType))
+			}
+		default:
+			return "[" + printValue(v, t.OfType) + "]"
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case *Enum:
+		return fmt.Sprint(v)
+	}
+	switch v := v.(type) {
+	case string:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,123 @@
+/*
+Package mail sends email from the site.
+
+Messages are sent with SMTP when an SMTP host is configured. Otherwise the messages
+are written to standard output so the site can be used locally without a
+mail server. For exercising the SMTP path locally, StartStubServer runs a
+minimal SMTP server that prints every message it receives.
+*/
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers messages
+type Sender interface {
+	Send(message Message) error
+}
+
+// SMTPSender delivers messages to an SMTP server. PLAIN authentication is
+// used when a username is given.
+type SMTPSender struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (sender *SMTPSender) Send(message Message) error {
+	var auth smtp.Auth
+	if len(sender.Username) > 0 {
+		auth = smtp.PlainAuth("", sender.Username, sender.Password, sender.Host)
+	}
+	addr := net.JoinHostPort(sender.Host, sender.Port)
+	return smtp.SendMail(addr, auth, sender.From, []string{message.To}, formatMessage(sender.From, message))
+}
+
+// LogSender writes messages to a writer instead of delivering them
+type LogSender struct {
+	lock   sync.Mutex
+	Writer io.Writer
+	From   string
+}
+
+func (sender *LogSender) Send(message Message) error {
+	sender.lock.Lock()
+	defer sender.lock.Unlock()
+	_, err := fmt.Fprintf(sender.Writer, "----- email -----\n%s\n-----------------\n", formatMessage(sender.From, message))
+	return err
+}
+
+// formatMessage creates the RFC 5322 representation of the message
+func formatMessage(from string, message Message) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", stripNewlines(message.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", stripNewlines(message.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.Replace(message.Body, "\n", "\r\n", -1))
+	return b.Bytes()
+}
+
+// stripNewlines prevents header injection from user supplied values
+func stripNewlines(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+// Config contains the settings used to send email
+type Config struct {
+	From string
+	// SMTPHost is the SMTP server. Email is written to stdout when empty.
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+}
+
+// NewSender creates the sender for the configuration
+func NewSender(config Config) Sender {
+	from := config.From
+	if len(from) == 0 {
+		from = "noreply@localhost"
+	}
+
+	if len(config.SMTPHost) == 0 {
+		return &LogSender{
+			Writer: os.Stdout,
+			From:   from,
+		}
+	}
+
+	port := config.SMTPPort
+	if port == 0 {
+		port = 25
+	}
+	return &SMTPSender{
+		Host:     config.SMTPHost,
+		Port:     strconv.Itoa(port),
+		Username: config.SMTPUsername,
+		Password: config.SMTPPassword,
+		From:     from,
+	}
+}
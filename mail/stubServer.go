@@ -0,0 +1,122 @@
+package mail
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"sync"
+)
+
+// StubServer is a minimal SMTP server that accepts every message and writes
+// it to Writer. It's intended for local development only; it does not
+// support authentication or TLS.
+type StubServer struct {
+	lock   sync.Mutex
+	Writer io.Writer
+}
+
+// Serve accepts connections until the listener is closed
+func (server *StubServer) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go server.handle(conn)
+	}
+}
+
+func (server *StubServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		fmt.Fprintf(conn, "%s\r\n", line)
+	}
+
+	var from string
+	var recipients []string
+	reply("220 localhost dbweb stub SMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(line)
+		if i := strings.IndexAny(verb, " :"); i >= 0 {
+			verb = verb[:i]
+		}
+
+		switch verb {
+		case "HELO", "EHLO":
+			reply("250 localhost")
+		case "MAIL":
+			from = smtpAddress(line)
+			recipients = nil
+			reply("250 OK")
+		case "RCPT":
+			recipients = append(recipients, smtpAddress(line))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			body, err := readSMTPData(reader)
+			if err != nil {
+				return
+			}
+			server.lock.Lock()
+			fmt.Fprintf(server.Writer, "----- email from %s to %s -----\n%s\n-----------------\n", from, strings.Join(recipients, ", "), body)
+			server.lock.Unlock()
+			reply("250 OK")
+		case "RSET":
+			from = ""
+			recipients = nil
+			reply("250 OK")
+		case "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func smtpAddress(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.LastIndex(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+func readSMTPData(reader *bufio.Reader) (string, error) {
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "." {
+			return strings.Join(lines, "\n"), nil
+		}
+		// remove dot stuffing
+		lines = append(lines, strings.TrimPrefix(line, "."))
+	}
+}
+
+// StartStubServer runs a stub SMTP server on the port until the process exits
+func StartStubServer(port string, writer io.Writer) {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("Unable to start stub SMTP server\n\t%v", err)
+	}
+	log.Printf("Stub SMTP server started on %s", port)
+	server := &StubServer{Writer: writer}
+	log.Fatal(server.Serve(listener))
+}
@@ -1,67 +1,668 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"log"
 	"os"
+	"os/signal"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"jaredpearson.com/dbweb/command"
+	"jaredpearson.com/dbweb/config"
 	"jaredpearson.com/dbweb/data"
+	"jaredpearson.com/dbweb/graphql"
+	"jaredpearson.com/dbweb/mail"
 	"jaredpearson.com/dbweb/web"
 )
 
-func executeUserCommand(userCmd *command.Command, usersAddCmd *command.Command) {
+// stdin is shared by the shell and the commands that read from the input so
+// neither loses what the other has buffered
+var stdin = bufio.NewReader(os.Stdin)
+
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := stdin.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func executeUserCommand(
+	userCmd *command.Command,
+	usersAddCmd *command.Command,
+	usersAddEmailFlag *command.Flag,
+	usersPasswordCmd *command.Command,
+	usersGrantCmd *command.Command,
+	usersUnlockCmd *command.Command) int {
 	if usersAddCmd.IsSelected() {
 		usernameArg, _ := usersAddCmd.GetArg(0)
 		username := strings.Trim(usernameArg.Value, " ")
 		if username == "" {
 			fmt.Fprint(os.Stderr, "Username is required when adding a new user\n")
-			os.Exit(1)
+			return 1
 		}
 
 		_, err := data.GetUserByUsername(username)
 		// we expect a ErrUserNotFound
 		if err == nil {
 			fmt.Fprintf(os.Stderr, "User already exists with username %s\n", username)
-			os.Exit(1)
-		} else if ae, ok := err.(*data.ErrUserNotFound); !ok {
-			fmt.Fprintf(os.Stderr, "Unable to add user: %s\n%v\n", username, ae)
-			os.Exit(1)
+			return 1
+		} else if _, ok := err.(*data.ErrUserNotFound); !ok {
+			fmt.Fprintf(os.Stderr, "Unable to add user: %s\n%v\n", username, err)
+			return 1
 		}
 
-		err = data.AddUser(username)
+		err = data.AddUser(username, strings.Trim(usersAddEmailFlag.Value(), " "))
 		if err != nil {
 			fmt.Printf("Error adding user: %v\n", err)
-			os.Exit(1)
+			return 1
 		}
 		fmt.Fprintf(os.Stdout, "Added new user %s\n", username)
-		os.Exit(0)
+		return 0
+	} else if usersPasswordCmd.IsSelected() {
+		usernameArg, _ := usersPasswordCmd.GetArg(0)
+		username := strings.Trim(usernameArg.Value, " ")
+		if username == "" {
+			fmt.Fprint(os.Stderr, "Username is required when setting a password\n")
+			return 1
+		}
+		password, err := readPassword()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to read password: %v\n", err)
+			return 1
+		}
+		if err := data.SetUserPassword(username, password); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to set password for %s: %v\n", username, err)
+			return 1
+		}
+		fmt.Fprintf(os.Stdout, "Password updated for %s\n", username)
+		return 0
+	} else if usersGrantCmd.IsSelected() {
+		usernameArg, _ := usersGrantCmd.GetArg(0)
+		roleArg, _ := usersGrantCmd.GetArg(1)
+		username := strings.Trim(usernameArg.Value, " ")
+		role := strings.Trim(roleArg.Value, " ")
+		if username == "" || role == "" {
+			fmt.Fprint(os.Stderr, "Username and role are required when granting a role\n")
+			return 1
+		}
+		if err := data.AddUserRole(username, role); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to grant %s to %s: %v\n", role, username, err)
+			return 1
+		}
+		fmt.Fprintf(os.Stdout, "Granted %s to %s\n", role, username)
+		return 0
+	} else if usersUnlockCmd.IsSelected() {
+		usernameArg, _ := usersUnlockCmd.GetArg(0)
+		username := strings.Trim(usernameArg.Value, " ")
+		if username == "" {
+			fmt.Fprint(os.Stderr, "Username is required when unlocking a user\n")
+			return 1
+		}
+		if err := data.DeleteLoginAttempts(data.LoginAttemptsKeyForUsername(username)); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to unlock %s: %v\n", username, err)
+			return 1
+		}
+		err := data.AddAuditEntry(data.AuditEntry{
+			Event:    data.AuditLoginUnlock,
+			Username: username,
+			Detail:   "unlocked from the command line",
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to add audit entry: %v\n", err)
+		}
+		fmt.Fprintf(os.Stdout, "Unlocked %s\n", username)
+		return 0
 	} else {
 		userCmd.DisplayUsage()
+		return 1
+	}
+}
+
+func executeInvitesCommand(invitesCmd *command.Command, invitesCreateCmd *command.Command, invitesCountFlag *command.Flag, invitesListCmd *command.Command) int {
+	if invitesCreateCmd.IsSelected() {
+		count := invitesCountFlag.Int()
+		if count < 1 {
+			fmt.Fprintf(os.Stderr, "Invalid count: %d\n", count)
+			return 1
+		}
+		for i := 0; i < count; i++ {
+			invite, err := data.CreateInvite("command line")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Unable to create invite: %v\n", err)
+				return 1
+			}
+			fmt.Fprintln(os.Stdout, invite.Code())
+		}
+		return 0
+	} else if invitesListCmd.IsSelected() {
+		invites, err := data.GetInvites()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to list invites: %v\n", err)
+			return 1
+		}
+		for _, invite := range invites {
+			redeemedBy := "-"
+			if invite.IsRedeemed() {
+				redeemedBy = invite.RedeemedBy()
+			}
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\n", invite.Code(), invite.Created().Format("2006-01-02"), redeemedBy)
+		}
+		return 0
+	} else {
+		invitesCmd.DisplayUsage()
+		return 1
+	}
+}
+
+func executeAuditCommand(auditCmd *command.Command, auditListCmd *command.Command, auditCountFlag *command.Flag) int {
+	if auditListCmd.IsSelected() {
+		count := auditCountFlag.Int()
+		if count < 1 {
+			fmt.Fprintf(os.Stderr, "Invalid count: %d\n", count)
+			return 1
+		}
+		entries, err := data.GetAuditEntries(count)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to list audit entries: %v\n", err)
+			return 1
+		}
+		for _, entry := range entries {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s\n",
+				entry.Time.Format(time.RFC3339), entry.Event, entry.Username, entry.RemoteAddr, entry.Detail)
+		}
+		return 0
+	} else {
+		auditCmd.DisplayUsage()
+		return 1
+	}
+}
+
+func executeDbCommand(
+	dbCmd *command.Command,
+	dbMigrateCmd *command.Command,
+	dbMigrateDryRunFlag *command.Flag,
+	dbStatusCmd *command.Command,
+	dbCopyCmd *command.Command,
+	dbCopyFromFlag *command.Flag,
+	dbCopyToFlag *command.Flag) int {
+	if dbMigrateCmd.IsSelected() {
+		dryRun := dbMigrateDryRunFlag.Bool()
+		for _, name := range data.MigrationCollections() {
+			result, err := data.MigrateCollection(name, dryRun)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Unable to migrate %s: %v\n", name, err)
+				return 1
+			}
+			verb := "upgraded"
+			if dryRun {
+				verb = "would upgrade"
+			}
+			fmt.Fprintf(os.Stdout, "%s: %s %d of %d documents to version %d\n",
+				name, verb, result.Upgraded, result.Examined, data.CurrentDocumentVersion(name))
+		}
+		return 0
+	} else if dbStatusCmd.IsSelected() {
+		statuses, err := data.GetMigrationStatus()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to determine migration status: %v\n", err)
+			return 1
+		}
+		for _, status := range statuses {
+			fmt.Fprintf(os.Stdout, "%s (current version %d)\n", status.Collection, status.CurrentVersion)
+			for _, migration := range data.MigrationsFor(status.Collection) {
+				fmt.Fprintf(os.Stdout, "\tv%d -> v%d\t%s\n", migration.FromVersion, migration.FromVersion+1, migration.Description)
+			}
+			var versions []int
+			for version := range status.DocumentsByVersion {
+				versions = append(versions, version)
+			}
+			sort.Ints(versions)
+			for _, version := range versions {
+				pending := ""
+				if version < status.CurrentVersion {
+					pending = " (pending)"
+				}
+				fmt.Fprintf(os.Stdout, "\tv%d: %d documents%s\n", version, status.DocumentsByVersion[version], pending)
+			}
+		}
+		return 0
+	} else if dbCopyCmd.IsSelected() {
+		from := strings.Trim(dbCopyFromFlag.Value(), " ")
+		to := strings.Trim(dbCopyToFlag.Value(), " ")
+		if from == to {
+			fmt.Fprintf(os.Stderr, "Two different storage backends are required: %s\n", strings.Join(data.StorageBackends, ", "))
+			return 1
+		}
+
+		fromStore, err := data.OpenStoreBackend(from)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to open %s: %v\n", from, err)
+			return 1
+		}
+		defer fromStore.Close()
+		toStore, err := data.OpenStoreBackend(to)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to open %s: %v\n", to, err)
+			return 1
+		}
+		defer toStore.Close()
+
+		results, err := data.CopyStore(fromStore, toStore)
+		for _, result := range results {
+			fmt.Fprintf(os.Stdout, "%s: copied %d documents\n", result.Collection, result.Documents)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to copy from %s to %s: %v\n", from, to, err)
+			return 1
+		}
+		return 0
+	} else {
+		dbCmd.DisplayUsage()
+		return 1
+	}
+}
+
+func executeMailCommand(mailCmd *command.Command, mailStubCmd *command.Command, mailStubPortFlag *command.Flag) int {
+	if mailStubCmd.IsSelected() {
+		mail.StartStubServer(strconv.Itoa(mailStubPortFlag.Int()), os.Stdout)
+		return 0
+	} else {
+		mailCmd.DisplayUsage()
+		return 1
+	}
+}
+
+func executeOIDCCommand(oidcCmd *command.Command, oidcStubCmd *command.Command, oidcStubPortFlag *command.Flag) int {
+	if oidcStubCmd.IsSelected() {
+		web.StartOIDCStubIssuer(strconv.Itoa(oidcStubPortFlag.Int()))
+		return 0
+	} else {
+		oidcCmd.DisplayUsage()
+		return 1
+	}
+}
+
+// addConfigFlags adds the --config flag and a flag for each setting that
+// apply to every command
+func addConfigFlags(commands *command.CommandSet) (configFlag *command.Flag, settingFlags map[string]*command.Flag) {
+	configFlag = commands.StringFlag("config", "", "", "The config file, defaults to "+config.DefaultPath)
+	settingFlags = make(map[string]*command.Flag)
+	for _, setting := range config.Settings() {
+		if setting.IsBool() {
+			settingFlags[setting.Flag()] = commands.BoolFlag(setting.Flag(), "", setting.Description)
+		} else {
+			flag := commands.StringFlag(setting.Flag(), "", "", setting.Description)
+			if len(setting.Choices) > 0 {
+				flag.CompleteWith(command.CompleteValues(setting.Choices...))
+			}
+			settingFlags[setting.Flag()] = flag
+		}
+	}
+	return configFlag, settingFlags
+}
+
+// loadConfig reads the configuration, applying the setting flags that were
+// specified
+func loadConfig(configFlag *command.Flag, settingFlags map[string]*command.Flag) *config.Config {
+	overrides := make(map[string]string)
+	for name, flag := range settingFlags {
+		if flag.IsSet() {
+			overrides[name] = flag.Value()
+		}
+	}
+	cfg, err := config.Load(configFlag.Value(), overrides)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+// applyConfig configures the storage backend used by every command
+func applyConfig(cfg *config.Config) {
+	data.ConfigureStorage(data.StorageConfig{
+		Backend: cfg.Storage.Backend,
+		Path:    cfg.Storage.Path,
+	})
+	mongoConfig := data.DefaultMongoConfig()
+	mongoConfig.URI = cfg.Mongo.URI
+	mongoConfig.Username = cfg.Mongo.Username
+	mongoConfig.Password = cfg.Mongo.Password
+	mongoConfig.AuthSource = cfg.Mongo.AuthSource
+	mongoConfig.Database = cfg.Mongo.Database
+	mongoConfig.DialTimeout = time.Duration(cfg.Mongo.DialTimeout)
+	mongoConfig.SocketTimeout = time.Duration(cfg.Mongo.SocketTimeout)
+	mongoConfig.PoolLimit = cfg.Mongo.PoolLimit
+	data.ConfigureMongo(mongoConfig)
+}
+
+func executeStartCommand(cfg *config.Config) {
+	if err := loadCatalog(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load miniatures: %v\n", err)
 		os.Exit(1)
 	}
+	go reloadCatalogOnHangup(cfg)
+
+	var providers []web.OIDCProviderConfig
+	for _, provider := range cfg.Auth.OIDCProviders {
+		providers = append(providers, web.OIDCProviderConfig{
+			Name:         provider.Name,
+			DisplayName:  provider.DisplayName,
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  provider.RedirectURL,
+			Scopes:       provider.Scopes,
+		})
+	}
+	web.ServerStart(web.ServerConfig{
+		Port:                  cfg.Server.Port,
+		TemplatePath:          cfg.Server.TemplatePath,
+		SiteURL:               cfg.Server.SiteURL,
+		TrustProxyHeaders:     cfg.Server.TrustProxyHeaders,
+		DevMode:               cfg.Server.DevMode,
+		StaticPath:            cfg.Server.StaticPath,
+		CardCachePath:         cfg.Server.CardCachePath,
+		RegistrationMode:      web.RegistrationMode(cfg.Auth.RegistrationMode),
+		RequireTwoFactorRoles: cfg.Auth.RequireTwoFactorRoles,
+		OIDCProviders:         providers,
+		Mail: mail.Config{
+			From:         cfg.Mail.From,
+			SMTPHost:     cfg.Mail.SMTPHost,
+			SMTPPort:     cfg.Mail.SMTPPort,
+			SMTPUsername: cfg.Mail.SMTPUsername,
+			SMTPPassword: cfg.Mail.SMTPPassword,
+		},
+		GraphQLLimits: graphql.Limits{
+			MaxDepth:      cfg.API.GraphQLMaxDepth,
+			MaxComplexity: cfg.API.GraphQLMaxComplexity,
+		},
+	})
+}
+
+// reloadCatalogOnHangup reads the data file again whenever the process gets
+// SIGHUP, so the catalog can be updated without restarting the server. The
+// current miniatures are kept if the file can't be read.
+func reloadCatalogOnHangup(cfg *config.Config) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	for range hangups {
+		if err := data.LoadMiniatures(cfg.Catalog.DataPath); err != nil {
+			log.Printf("Unable to reload the miniatures\n\t%v", err)
+			continue
+		}
+		log.Printf("Reloaded the miniatures from %s", cfg.Catalog.DataPath)
+	}
+}
+
+func executeConfigCommand(cfg *config.Config, configCmd *command.Command, configShowCmd *command.Command) int {
+	if configShowCmd.IsSelected() {
+		cfg.Show(os.Stdout)
+		if err := cfg.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		return 0
+	} else {
+		configCmd.DisplayUsage()
+		return 1
+	}
+}
+
+// completeCommand is the hidden command run by the shell completion scripts
+const completeCommand = "__complete"
+
+// completionConfig is the configuration used by completion. The shell's
+// configuration is used when completing in the shell. Otherwise the config
+// file and environment are read, giving up quickly when the database can't
+// be reached.
+func completionConfig() (*config.Config, error) {
+	if shellConfig != nil {
+		return shellConfig, nil
+	}
+	cfg, err := config.Load("", nil)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Mongo.DialTimeout = config.Duration(2 * time.Second)
+	applyConfig(cfg)
+	return cfg, nil
+}
+
+// completeUsernames completes with the users in the store
+func completeUsernames(prefix string) []string {
+	if _, err := completionConfig(); err != nil {
+		return nil
+	}
+	usernames, err := data.GetUsernames(prefix, 100)
+	if err != nil {
+		return nil
+	}
+	return usernames
+}
+
+func executeCompletionCommand(commands *command.CommandSet, shellArg *command.Arg) int {
+	shell := strings.Trim(shellArg.Value, " ")
+	if shell == "" {
+		fmt.Fprintf(os.Stderr, "A shell is required: %s\n", strings.Join(command.CompletionShells, ", "))
+		return 1
+	}
+	if err := commands.WriteCompletion(os.Stdout, shell, completeCommand); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// cli is the tree of commands with the flags and args needed to run them.
+// Parsing records the values in the tree, so the shell builds a new one for
+// each line.
+type cli struct {
+	commands     *command.CommandSet
+	configFlag   *command.Flag
+	settingFlags map[string]*command.Flag
+
+	helpCmd            *command.Command
+	startCmd           *command.Command
+	shellCmd           *command.Command
+	userCmd            *command.Command
+	usersAddCmd        *command.Command
+	usersAddEmailFlag  *command.Flag
+	usersPasswordCmd   *command.Command
+	usersGrantCmd      *command.Command
+	usersUnlockCmd     *command.Command
+	auditCmd           *command.Command
+	auditListCmd       *command.Command
+	auditCountFlag     *command.Flag
+	invitesCmd         *command.Command
+	invitesCreateCmd   *command.Command
+	invitesCountFlag   *command.Flag
+	invitesListCmd     *command.Command
+	dbCmd              *command.Command
+	dbMigrateCmd       *command.Command
+	dbMigrateDryRun    *command.Flag
+	dbStatusCmd        *command.Command
+	dbCopyCmd          *command.Command
+	dbCopyFromFlag     *command.Flag
+	dbCopyToFlag       *command.Flag
+	dataCmd            *command.Command
+	dataValidateCmd    *command.Command
+	mailCmd            *command.Command
+	mailStubCmd        *command.Command
+	mailStubPortFlag   *command.Flag
+	oidcCmd            *command.Command
+	oidcStubCmd        *command.Command
+	oidcStubPortFlag   *command.Flag
+	configCmd          *command.Command
+	configShowCmd      *command.Command
+	minisCmd           *command.Command
+	minisFormatFlag    *command.Flag
+	minisSetsCmd       *command.Command
+	minisListCmd       *command.Command
+	minisListFilter    miniatureFilterFlags
+	minisSearchCmd     *command.Command
+	minisSearchFilter  miniatureFilterFlags
+	minisShowCmd       *command.Command
+	apiCmd             *command.Command
+	apiCheckCmd        *command.Command
+	graphqlCmd         *command.Command
+	graphqlSchemaCmd   *command.Command
+	graphqlFormatFlag  *command.Flag
+	completionCmd      *command.Command
+	completionShellArg *command.Arg
+}
+
+func newCLI() *cli {
+	c := &cli{commands: command.NewCommandSet()}
+	c.configFlag, c.settingFlags = addConfigFlags(c.commands)
+	c.helpCmd = c.commands.AddCommand("help", "Displays the help information for a command, such as help db copy")
+	c.startCmd = c.commands.AddCommand("start", "Starts the web server")
+	c.shellCmd = c.commands.AddCommand("shell", "Starts an interactive prompt that runs these commands")
+	c.userCmd = c.commands.AddCommand("users", "Manage users")
+	c.usersAddCmd = c.userCmd.AddSubcommand("add", "Adds a new user")
+	c.usersAddCmd.AddArg("username", "The username of the new user")
+	c.usersAddEmailFlag = c.usersAddCmd.StringFlag("email", "e", "", "The email address of the new user")
+	c.usersPasswordCmd = c.userCmd.AddSubcommand("passwd", "Sets the password of a user, read from stdin")
+	c.usersPasswordCmd.AddArg("username", "The username of the user").CompleteWith(completeUsernames)
+	c.usersGrantCmd = c.userCmd.AddSubcommand("grant", "Grants a role (admin or moderator) to a user")
+	c.usersGrantCmd.AddArg("username", "The username of the user").CompleteWith(completeUsernames)
+	c.usersGrantCmd.AddArg("role", "The role to grant").CompleteWith(command.CompleteValues(data.RoleAdmin, data.RoleModerator))
+	c.usersUnlockCmd = c.userCmd.AddSubcommand("unlock", "Removes a lockout caused by failed login attempts")
+	c.usersUnlockCmd.AddArg("username", "The username of the user").CompleteWith(completeUsernames)
+	c.auditCmd = c.commands.AddCommand("audit", "View the audit log")
+	c.auditListCmd = c.auditCmd.AddSubcommand("list", "Lists the most recent audit entries")
+	c.auditCountFlag = c.auditListCmd.IntFlag("count", "n", 50, "The number of entries to list")
+	c.invitesCmd = c.commands.AddCommand("invites", "Manage registration invite codes")
+	c.invitesCreateCmd = c.invitesCmd.AddSubcommand("create", "Creates new invite codes")
+	c.invitesCountFlag = c.invitesCreateCmd.IntFlag("count", "n", 1, "The number of codes to create")
+	c.invitesListCmd = c.invitesCmd.AddSubcommand("list", "Lists all invite codes")
+	c.dbCmd = c.commands.AddCommand("db", "Manage the database")
+	c.dbMigrateCmd = c.dbCmd.AddSubcommand("migrate", "Upgrades all documents to the current schema version")
+	c.dbMigrateDryRun = c.dbMigrateCmd.BoolFlag("dry-run", "", "Checks the migrations without saving")
+	c.dbStatusCmd = c.dbCmd.AddSubcommand("status", "Shows the number of documents at each schema version")
+	c.dbCopyCmd = c.dbCmd.AddSubcommand("copy", "Copies every document from one storage backend to another")
+	c.dbCopyFromFlag = c.dbCopyCmd.StringFlag("from", "", "", "The backend to copy from (mongo or file)").
+		Required().CompleteWith(command.CompleteValues(data.StorageBackends...))
+	c.dbCopyToFlag = c.dbCopyCmd.StringFlag("to", "", "", "The backend to copy to (mongo or file)").
+		Required().CompleteWith(command.CompleteValues(data.StorageBackends...))
+	c.dataCmd = c.commands.AddCommand("data", "Manage the catalog data file")
+	c.dataValidateCmd = c.dataCmd.AddSubcommand("validate", "Checks the data file for missing fields, bad stats, unknown sets and duplicates")
+	c.dataValidateCmd.AddArg("file", "The data file, defaults to catalog.dataPath")
+	c.mailCmd = c.commands.AddCommand("mail", "Email utilities")
+	c.mailStubCmd = c.mailCmd.AddSubcommand("stub", "Runs a local stub SMTP server that prints received email")
+	c.mailStubPortFlag = c.mailStubCmd.IntFlag("port", "p", 2525, "The port to listen on")
+	c.oidcCmd = c.commands.AddCommand("oidc", "OpenID Connect utilities")
+	c.oidcStubCmd = c.oidcCmd.AddSubcommand("stub", "Runs a local stub OpenID Connect issuer for development")
+	c.oidcStubPortFlag = c.oidcStubCmd.IntFlag("port", "p", 9000, "The port to listen on")
+	c.configCmd = c.commands.AddCommand("config", "View the configuration")
+	c.configShowCmd = c.configCmd.AddSubcommand("show", "Shows every setting and where it was read from, with secrets redacted")
+	c.minisCmd = c.commands.AddCommand("minis", "Look up miniatures in the catalog")
+	c.minisFormatFlag = c.minisCmd.StringFlag("format", "f", "table", "The output format: "+strings.Join(outputFormats, ", ")).
+		CompleteWith(command.CompleteValues(outputFormats...))
+	c.minisSetsCmd = c.minisCmd.AddSubcommand("sets", "Lists the sets")
+	c.minisListCmd = c.minisCmd.AddSubcommand("list", "Lists the miniatures")
+	c.minisListFilter = addMiniatureFilterFlags(c.minisListCmd)
+	c.minisSearchCmd = c.minisCmd.AddSubcommand("search", "Searches the names, lineages and abilities of the miniatures")
+	c.minisSearchCmd.AddArg("text", "The text to search for")
+	c.minisSearchFilter = addMiniatureFilterFlags(c.minisSearchCmd)
+	c.minisShowCmd = c.minisCmd.AddSubcommand("show", "Shows the stats and abilities of a miniature")
+	c.minisShowCmd.AddArg("id", "The ID of the miniature, which is the name in lowercase with underscores").
+		CompleteWith(completeMiniatureIDs)
+	c.apiCmd = c.commands.AddCommand("api", "HTTP API utilities")
+	c.apiCheckCmd = c.apiCmd.AddSubcommand("check", "Requests each API route and compares the responses with the OpenAPI document")
+	c.graphqlCmd = c.commands.AddCommand("graphql", "GraphQL API utilities")
+	c.graphqlSchemaCmd = c.graphqlCmd.AddSubcommand("schema", "Writes the GraphQL schema for client tooling")
+	c.graphqlFormatFlag = c.graphqlSchemaCmd.StringFlag("format", "f", "sdl", "The output format: sdl or json (the introspection result)").
+		CompleteWith(command.CompleteValues(schemaFormats...))
+	c.completionCmd = c.commands.AddCommand("completion", "Generates the shell completion script")
+	c.completionShellArg = c.completionCmd.AddArg("shell", "The shell: "+strings.Join(command.CompletionShells, ", ")).
+		CompleteWith(command.CompleteValues(command.CompletionShells...))
+	return c
+}
+
+// execute runs the selected command that needs the configuration and
+// returns the exit code
+func (c *cli) execute(cfg *config.Config) int {
+	if c.userCmd.IsSelected() {
+		return executeUserCommand(c.userCmd, c.usersAddCmd, c.usersAddEmailFlag, c.usersPasswordCmd, c.usersGrantCmd, c.usersUnlockCmd)
+	} else if c.auditCmd.IsSelected() {
+		return executeAuditCommand(c.auditCmd, c.auditListCmd, c.auditCountFlag)
+	} else if c.invitesCmd.IsSelected() {
+		return executeInvitesCommand(c.invitesCmd, c.invitesCreateCmd, c.invitesCountFlag, c.invitesListCmd)
+	} else if c.dbCmd.IsSelected() {
+		return executeDbCommand(c.dbCmd, c.dbMigrateCmd, c.dbMigrateDryRun, c.dbStatusCmd, c.dbCopyCmd, c.dbCopyFromFlag, c.dbCopyToFlag)
+	} else if c.dataCmd.IsSelected() {
+		return executeDataCommand(cfg, c.dataCmd, c.dataValidateCmd)
+	} else if c.mailCmd.IsSelected() {
+		return executeMailCommand(c.mailCmd, c.mailStubCmd, c.mailStubPortFlag)
+	} else if c.oidcCmd.IsSelected() {
+		return executeOIDCCommand(c.oidcCmd, c.oidcStubCmd, c.oidcStubPortFlag)
+	} else if c.minisCmd.IsSelected() {
+		return executeMinisCommand(cfg, c.minisCmd, c.minisFormatFlag, c.minisSetsCmd, c.minisListCmd, c.minisListFilter,
+			c.minisSearchCmd, c.minisSearchFilter, c.minisShowCmd)
+	} else if c.apiCmd.IsSelected() {
+		return executeAPICommand(cfg, c.apiCmd, c.apiCheckCmd)
+	} else if c.graphqlCmd.IsSelected() {
+		return executeGraphQLCommand(c.graphqlCmd, c.graphqlSchemaCmd, c.graphqlFormatFlag)
+	} else if c.configCmd.IsSelected() {
+		return executeConfigCommand(cfg, c.configCmd, c.configShowCmd)
+	}
+	fmt.Fprint(os.Stderr, "Invalid or unknown command specified\n")
+	c.commands.DisplayUsage()
+	return 1
 }
 
 func main() {
-	var commands = command.NewCommandSet()
-	helpCmd := commands.AddCommand("help", "Displays the help information")
-	startCmd := commands.AddCommand("start", "Starts the web server")
-	userCmd := commands.AddCommand("users", "Manage users")
-	usersAddCmd := userCmd.AddSubcommand("add", "Adds a new user")
-	usersAddCmd.AddArg("username", "The username of the new user")
+	c := newCLI()
 
-	commands.Parse()
+	if len(os.Args) > 1 && os.Args[1] == completeCommand {
+		for _, candidate := range c.commands.Complete(os.Args[2:]) {
+			fmt.Fprintln(os.Stdout, candidate)
+		}
+		os.Exit(0)
+	}
 
-	if helpCmd.IsSelected() {
-		commands.DisplayUsage()
+	if err := c.commands.Parse(os.Args[1:]); err == command.ErrHelp {
+		c.commands.WriteUsage(os.Stdout)
 		os.Exit(0)
-	} else if startCmd.IsSelected() {
-		web.ServerStart()
-	} else if userCmd.IsSelected() {
-		executeUserCommand(userCmd, usersAddCmd)
-	} else {
-		fmt.Fprint(os.Stderr, "Invalid or unknown command specified\n")
-		commands.DisplayUsage()
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n\n", err)
+		c.commands.DisplayUsage()
 		os.Exit(1)
 	}
+
+	if c.completionCmd.IsSelected() {
+		os.Exit(executeCompletionCommand(c.commands, c.completionShellArg))
+	}
+	if c.helpCmd.IsSelected() {
+		if err := c.commands.Help(os.Stdout, c.helpCmd.Rest()); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	cfg := loadConfig(c.configFlag, c.settingFlags)
+	if !c.configCmd.IsSelected() {
+		if err := cfg.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+	applyConfig(cfg)
+
+	if c.startCmd.IsSelected() {
+		executeStartCommand(cfg)
+	} else if c.shellCmd.IsSelected() {
+		os.Exit(executeShellCommand(cfg))
+	}
+	os.Exit(c.execute(cfg))
 }
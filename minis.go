@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"jaredpearson.com/dbweb/command"
+	"jaredpearson.com/dbweb/config"
+	"jaredpearson.com/dbweb/data"
+)
+
+// miniatureColumns are the columns used when listing miniatures
+var miniatureColumns = []column{
+	{"id", "ID", false},
+	{"name", "Name", false},
+	{"set", "Set", false},
+	{"number", "#", true},
+	{"rarity", "Rarity", false},
+	{"aspect", "Aspect", false},
+	{"lineage", "Lineage", false},
+	{"spawnCost", "Spawn", true},
+	{"aspectCost", "Aspect Cost", true},
+	{"power", "Power", true},
+	{"defense", "Defense", true},
+	{"life", "Life", true},
+}
+
+// miniatureDetailColumns are the columns used when showing a miniature
+var miniatureDetailColumns = append(append([]column(nil), miniatureColumns...),
+	column{"abilities", "Abilities", false},
+	column{"flavorText", "Flavor Text", false},
+)
+
+func miniatureRow(mini *data.Miniature) []string {
+	return []string{
+		mini.ID(),
+		mini.Name(),
+		mini.SetCode(),
+		mini.CollectorNumber(),
+		mini.Rarity(),
+		mini.Aspect(),
+		mini.Lineage(),
+		mini.SpawnCost(),
+		mini.AspectCost(),
+		mini.Power(),
+		mini.Defense(),
+		mini.Life(),
+	}
+}
+
+// miniatureFilterFlags are the flags that filter the miniatures
+type miniatureFilterFlags struct {
+	sets     *command.Flag
+	aspects  *command.Flag
+	lineages *command.Flag
+	rarities *command.Flag
+	minCost  *command.Flag
+	maxCost  *command.Flag
+}
+
+func addMiniatureFilterFlags(cmd *command.Command) miniatureFilterFlags {
+	return miniatureFilterFlags{
+		sets:     cmd.StringsFlag("set", "s", "Only miniatures in the set, by code").CompleteWith(completeSetCodes),
+		aspects:  cmd.StringsFlag("aspect", "a", "Only miniatures with the aspect"),
+		lineages: cmd.StringsFlag("lineage", "l", "Only miniatures with the lineage"),
+		rarities: cmd.StringsFlag("rarity", "r", "Only miniatures with the rarity"),
+		minCost:  cmd.IntFlag("min-cost", "", 0, "Only miniatures with at least the spawn cost"),
+		maxCost:  cmd.IntFlag("max-cost", "", 0, "Only miniatures with at most the spawn cost, 0 for no limit"),
+	}
+}
+
+func (flags miniatureFilterFlags) filter(text string) data.MiniatureFilter {
+	return data.MiniatureFilter{
+		Text:         text,
+		Sets:         flags.sets.Values(),
+		Aspects:      flags.aspects.Values(),
+		Lineages:     flags.lineages.Values(),
+		Rarities:     flags.rarities.Values(),
+		MinSpawnCost: flags.minCost.Int(),
+		MaxSpawnCost: flags.maxCost.Int(),
+	}
+}
+
+// loadedCatalogPath is the data file the miniatures were loaded from so the
+// shell reads it only once
+var loadedCatalogPath string
+
+// loadCatalog loads the miniatures from the configured data file
+func loadCatalog(cfg *config.Config) error {
+	if len(cfg.Catalog.DataPath) == 0 {
+		return fmt.Errorf("catalog.dataPath is required. Set it in the config file, with DATA or with --data")
+	}
+	if cfg.Catalog.DataPath == loadedCatalogPath {
+		return nil
+	}
+	if err := data.LoadMiniatures(cfg.Catalog.DataPath); err != nil {
+		return err
+	}
+	loadedCatalogPath = cfg.Catalog.DataPath
+	return nil
+}
+
+func completeSetCodes(prefix string) []string {
+	sets, _ := data.GetMiniatureSets()
+	var codes []string
+	for _, miniSet := range sets {
+		codes = append(codes, miniSet.ID())
+	}
+	return codes
+}
+
+// completeMiniatureIDs completes with the miniatures in the configured
+// catalog
+func completeMiniatureIDs(prefix string) []string {
+	cfg, err := completionConfig()
+	if err != nil || loadCatalog(cfg) != nil {
+		return nil
+	}
+	var ids []string
+	for _, mini := range data.GetMiniatures() {
+		ids = append(ids, mini.ID())
+	}
+	return ids
+}
+
+// writeOutput writes the table to stdout and returns the exit code
+func writeOutput(t *table, format string) int {
+	if err := t.write(os.Stdout, format); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func executeMinisCommand(
+	cfg *config.Config,
+	minisCmd *command.Command,
+	formatFlag *command.Flag,
+	minisSetsCmd *command.Command,
+	minisListCmd *command.Command,
+	minisListFilter miniatureFilterFlags,
+	minisSearchCmd *command.Command,
+	minisSearchFilter miniatureFilterFlags,
+	minisShowCmd *command.Command) int {
+	if !minisSetsCmd.IsSelected() && !minisListCmd.IsSelected() && !minisSearchCmd.IsSelected() && !minisShowCmd.IsSelected() {
+		minisCmd.DisplayUsage()
+		return 1
+	}
+	if err := loadCatalog(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load miniatures: %v\n", err)
+		return 1
+	}
+	format := strings.ToLower(strings.Trim(formatFlag.Value(), " "))
+
+	if minisSetsCmd.IsSelected() {
+		sets, _ := data.GetMiniatureSets()
+		t := &table{columns: []column{{"code", "Code", false}, {"name", "Name", false}, {"miniatures", "Miniatures", true}}}
+		for _, miniSet := range sets {
+			minis, _ := data.GetMiniaturesBySet(miniSet.ID())
+			t.addRow(miniSet.ID(), miniSet.Name(), fmt.Sprint(len(minis)))
+		}
+		return writeOutput(t, format)
+	} else if minisListCmd.IsSelected() || minisSearchCmd.IsSelected() {
+		var minis []*data.Miniature
+		if minisListCmd.IsSelected() {
+			minis = data.SearchMiniatures(minisListFilter.filter(""))
+		} else {
+			textArg, _ := minisSearchCmd.GetArg(0)
+			text := strings.Trim(strings.Join(append([]string{textArg.Value}, minisSearchCmd.Rest()...), " "), " ")
+			minis = data.SearchMiniatures(minisSearchFilter.filter(text))
+		}
+		t := &table{columns: miniatureColumns}
+		for _, mini := range minis {
+			t.addRow(miniatureRow(mini)...)
+		}
+		if code := writeOutput(t, format); code != 0 {
+			return code
+		}
+		if len(minis) == 0 && format == "table" {
+			fmt.Fprintln(os.Stderr, "No miniatures found")
+		}
+		return 0
+	} else if minisShowCmd.IsSelected() {
+		idArg, _ := minisShowCmd.GetArg(0)
+		id := strings.Trim(strings.Join(append([]string{idArg.Value}, minisShowCmd.Rest()...), " "), " ")
+		if id == "" {
+			fmt.Fprint(os.Stderr, "A miniature ID is required\n")
+			return 1
+		}
+		mini, err := data.GetMiniatureByID(strings.Replace(id, " ", "_", -1))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to find miniature: %s\n", id)
+			if similar := data.SearchMiniatures(data.MiniatureFilter{Text: id}); len(similar) > 0 {
+				fmt.Fprint(os.Stderr, "Did you mean:\n")
+				for i, m := range similar {
+					if i == 5 {
+						break
+					}
+					fmt.Fprintf(os.Stderr, "\t%s\t%s\n", m.ID(), m.Name())
+				}
+			}
+			return 1
+		}
+
+		t := &table{columns: miniatureDetailColumns, single: true}
+		t.addRow(append(miniatureRow(mini), mini.Abilities(), mini.FlavorText())...)
+		if format == "table" || format == "markdown" {
+			t = t.transposed()
+		}
+		return writeOutput(t, format)
+	}
+	return 1
+}
+
+func executeDataCommand(cfg *config.Config, dataCmd *command.Command, dataValidateCmd *command.Command) int {
+	if dataValidateCmd.IsSelected() {
+		fileArg, _ := dataValidateCmd.GetArg(0)
+		path := strings.Trim(fileArg.Value, " ")
+		if path == "" {
+			path = cfg.Catalog.DataPath
+		}
+		if path == "" {
+			fmt.Fprint(os.Stderr, "A data file is required. Set catalog.dataPath or specify the file\n")
+			return 1
+		}
+		records, problems, err := data.ValidateMiniatures(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		for _, problem := range problems {
+			fmt.Fprintln(os.Stdout, problem)
+		}
+		fmt.Fprintf(os.Stdout, "Checked %d miniatures in %s: %d problems\n", records, path, len(problems))
+		if len(problems) > 0 {
+			return 1
+		}
+		return 0
+	} else {
+		dataCmd.DisplayUsage()
+		return 1
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// outputFormats are the values of the --format flag
+var outputFormats = []string{"table", "json", "csv", "markdown"}
+
+type column struct {
+	// key is the name of the field in JSON
+	key    string
+	header string
+	// number writes the values as numbers in JSON, or null when a value
+	// isn't a number
+	number bool
+}
+
+// table is command output that can be written in each of the output formats
+type table struct {
+	columns []column
+	rows    [][]string
+	// single writes JSON as an object rather than an array of objects
+	single bool
+}
+
+func (t *table) addRow(values ...string) {
+	t.rows = append(t.rows, values)
+}
+
+func (t *table) write(w io.Writer, format string) error {
+	switch format {
+	case "table":
+		return t.writeAligned(w)
+	case "json":
+		return t.writeJSON(w)
+	case "csv":
+		return t.writeCSV(w)
+	case "markdown":
+		return t.writeMarkdown(w)
+	default:
+		return fmt.Errorf("unknown format: %s. Use one of %s", format, strings.Join(outputFormats, ", "))
+	}
+}
+
+func (t *table) writeAligned(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	var headers []string
+	for _, c := range t.columns {
+		headers = append(headers, strings.ToUpper(c.header))
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range t.rows {
+		var cells []string
+		for _, value := range row {
+			cells = append(cells, strings.Replace(value, "\n", " ", -1))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (t *table) writeJSON(w io.Writer) error {
+	var objects []map[string]interface{}
+	for _, row := range t.rows {
+		object := make(map[string]interface{})
+		for i, c := range t.columns {
+			object[c.key] = jsonValue(c, row[i])
+		}
+		objects = append(objects, object)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if t.single {
+		if len(objects) == 0 {
+			return encoder.Encode(nil)
+		}
+		return encoder.Encode(objects[0])
+	}
+	if objects == nil {
+		objects = []map[string]interface{}{}
+	}
+	return encoder.Encode(objects)
+}
+
+// jsonValue is the value of the column in JSON
+func jsonValue(c column, value string) interface{} {
+	if !c.number {
+		return value
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+	return n
+}
+
+func (t *table) writeCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	var headers []string
+	for _, c := range t.columns {
+		headers = append(headers, c.key)
+	}
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(t.rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func (t *table) writeMarkdown(w io.Writer) error {
+	escape := strings.NewReplacer("|", `\|`, "\r", "", "\n", "<br>")
+	var headers, separators []string
+	for _, c := range t.columns {
+		headers = append(headers, escape.Replace(c.header))
+		separators = append(separators, "---")
+	}
+	fmt.Fprintf(w, "| %s |\n", strings.Join(headers, " | "))
+	fmt.Fprintf(w, "| %s |\n", strings.Join(separators, " | "))
+	for _, row := range t.rows {
+		var cells []string
+		for _, value := range row {
+			cells = append(cells, escape.Replace(value))
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+	}
+	return nil
+}
+
+// transposed returns a table with a row for each column, which is easier to
+// read for a single record
+func (t *table) transposed() *table {
+	transposed := &table{
+		columns: []column{{"field", "Field", false}, {"value", "Value", false}},
+	}
+	for i, c := range t.columns {
+		for _, row := range t.rows {
+			transposed.addRow(c.header, row[i])
+		}
+	}
+	return transposed
+}
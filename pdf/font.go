@@ -0,0 +1,145 @@
+package pdf
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Font is one of the standard PDF fonts, which every reader has so they
+// aren't embedded in the document
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+	HelveticaOblique
+)
+
+var fontNames = []string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique"}
+
+// helveticaWidths are the widths of the characters from space to tilde in
+// thousandths of the font size, from the font's metrics
+var helveticaWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = []int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// winAnsi are the characters outside of ASCII and Latin-1 that the
+// documents' encoding has, such as curly quotes
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, '‰': 0x89,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'™': 0x99,
+}
+
+// encode converts the text to the documents' encoding. Characters it
+// doesn't have become question marks.
+func encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r >= ' ' && r <= '~', r >= 0xa0 && r <= 0xff:
+			encoded = append(encoded, byte(r))
+		case winAnsi[r] != 0:
+			encoded = append(encoded, winAnsi[r])
+		case r == '\t' || r == '\n':
+			encoded = append(encoded, ' ')
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return encoded
+}
+
+// Width is the width of the text in points at the font size
+func (font Font) Width(text string, size float64) float64 {
+	widths := helveticaWidths
+	if font == HelveticaBold {
+		widths = helveticaBoldWidths
+	}
+	total := 0
+	for _, c := range encode(text) {
+		if c >= ' ' && c <= '~' {
+			total += widths[c-' ']
+		} else {
+			// the other characters are mostly accented letters
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Wrap breaks the text into lines no wider than the width. Words longer
+// than a line are broken where they reach the edge.
+func Wrap(text string, font Font, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if len(line) > 0 {
+				candidate = line + " " + word
+			}
+			if font.Width(candidate, size) <= width {
+				line = candidate
+				continue
+			}
+			if len(line) > 0 {
+				lines = append(lines, line)
+			}
+			for font.Width(word, size) > width {
+				split := fit(word, font, size, width)
+				lines = append(lines, word[:split])
+				word = word[split:]
+			}
+			line = word
+		}
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// fit returns the length of the longest start of the word that fits in the
+// width, which is at least one character
+func fit(word string, font Font, size, width float64) int {
+	_, first := utf8.DecodeRuneInString(word)
+	end := first
+	for end < len(word) {
+		_, n := utf8.DecodeRuneInString(word[end:])
+		if font.Width(word[:end+n], size) > width {
+			break
+		}
+		end += n
+	}
+	return end
+}
+
+// Truncate shortens the text to fit in the width, ending it with an
+// ellipsis when anything is removed
+func Truncate(text string, font Font, size, width float64) string {
+	if font.Width(text, size) <= width {
+		return text
+	}
+	const ellipsis = "…"
+	text = strings.TrimRight(text, " ")
+	for len(text) > 0 && font.Width(text+ellipsis, size) > width {
+		_, n := utf8.DecodeLastRuneInString(text)
+		text = strings.TrimRight(text[:len(text)-n], " ")
+	}
+	return text + ellipsis
+}
@@ -0,0 +1,203 @@
+/*
+Package pdf writes simple PDF documents of text, lines and rectangles. Text
+uses the standard Helvetica fonts, which aren't embedded, so documents are
+small and need nothing outside the standard library. Coordinates are in
+points from the bottom left corner of the page.
+*/
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Size is the width and height of a page in points
+type Size struct {
+	Width  float64
+	Height float64
+}
+
+var (
+	// Letter is US letter paper, 8.5 by 11 inches
+	Letter = Size{612, 792}
+	// A4 is ISO A4 paper, 210 by 297 millimetres
+	A4 = Size{595.28, 841.89}
+)
+
+// Color is a color with red, green and blue from 0 to 1
+type Color struct {
+	R, G, B float64
+}
+
+var (
+	Black = Color{0, 0, 0}
+	White = Color{1, 1, 1}
+)
+
+// Document is a PDF with pages of the same size
+type Document struct {
+	size  Size
+	title string
+	pages []*Page
+}
+
+// New creates a document without any pages
+func New(size Size, title string) *Document {
+	return &Document{size: size, title: title}
+}
+
+// Size is the size of the document's pages
+func (doc *Document) Size() Size {
+	return doc.size
+}
+
+// AddPage adds a blank page to the end of the document
+func (doc *Document) AddPage() *Page {
+	page := &Page{}
+	doc.pages = append(doc.pages, page)
+	return page
+}
+
+// Page is the drawing operations of a page
+type Page struct {
+	content bytes.Buffer
+}
+
+// number formats the coordinate or size to a hundredth of a point
+func number(n float64) string {
+	return strconv.FormatFloat(math.Round(n*100)/100, 'f', -1, 64)
+}
+
+func (page *Page) op(format string, args ...float64) {
+	var values []interface{}
+	for _, arg := range args {
+		values = append(values, number(arg))
+	}
+	fmt.Fprintf(&page.content, format+"\n", values...)
+}
+
+// Text draws the text with its baseline starting at x and y
+func (page *Page) Text(x, y float64, font Font, size float64, color Color, text string) {
+	page.op("%s %s %s rg", color.R, color.G, color.B)
+	page.content.WriteString("BT\n")
+	fmt.Fprintf(&page.content, "/F%d %s Tf\n", font+1, number(size))
+	page.op("%s %s Td", x, y)
+	fmt.Fprintf(&page.content, "%s Tj\nET\n", pdfString(text))
+}
+
+// Line draws a line from x1 and y1 to x2 and y2
+func (page *Page) Line(x1, y1, x2, y2, width float64, color Color) {
+	page.op("%s %s %s RG", color.R, color.G, color.B)
+	page.op("%s w", width)
+	page.op("%s %s m %s %s l S", x1, y1, x2, y2)
+}
+
+// Rect draws the outline of a rectangle with its bottom left corner at x
+// and y
+func (page *Page) Rect(x, y, width, height, lineWidth float64, color Color) {
+	page.op("%s %s %s RG", color.R, color.G, color.B)
+	page.op("%s w", lineWidth)
+	page.op("%s %s %s %s re S", x, y, width, height)
+}
+
+// FillRect draws a filled rectangle with its bottom left corner at x and y
+func (page *Page) FillRect(x, y, width, height float64, color Color) {
+	page.op("%s %s %s rg", color.R, color.G, color.B)
+	page.op("%s %s %s %s re f", x, y, width, height)
+}
+
+// pdfString quotes the text as a PDF string
+func pdfString(text string) string {
+	var quoted bytes.Buffer
+	quoted.WriteByte('(')
+	for _, c := range encode(text) {
+		if c == '(' || c == ')' || c == '\\' {
+			quoted.WriteByte('\\')
+		}
+		quoted.WriteByte(c)
+	}
+	quoted.WriteByte(')')
+	return quoted.String()
+}
+
+// WriteTo writes the document as a PDF
+func (doc *Document) WriteTo(w io.Writer) (int64, error) {
+	out := &countingWriter{w: bufio.NewWriter(w)}
+	var offsets []int64
+	// objects are numbered from 1 in the order they're written
+	object := func(body string, stream []byte) {
+		offsets = append(offsets, out.n)
+		fmt.Fprintf(out, "%d 0 obj\n%s", len(offsets), body)
+		if stream != nil {
+			fmt.Fprintf(out, "\nstream\n")
+			out.Write(stream)
+			fmt.Fprintf(out, "\nendstream")
+		}
+		fmt.Fprintf(out, "\nendobj\n")
+	}
+
+	fmt.Fprintf(out, "%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	// the catalog, page tree, info and fonts come first so the pages can
+	// refer to them by number
+	const pagesObject, firstFontObject = 2, 4
+	firstPageObject := firstFontObject + len(fontNames)
+	var kids bytes.Buffer
+	for i := range doc.pages {
+		fmt.Fprintf(&kids, "%d 0 R ", firstPageObject+2*i)
+	}
+	object(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObject), nil)
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %s %s] >>",
+		bytes.TrimSpace(kids.Bytes()), len(doc.pages), number(doc.size.Width), number(doc.size.Height)), nil)
+	object(fmt.Sprintf("<< /Title %s /Producer (dbweb) >>", pdfString(doc.title)), nil)
+	var fonts bytes.Buffer
+	for i, name := range fontNames {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name), nil)
+		fmt.Fprintf(&fonts, "/F%d %d 0 R ", i+1, firstFontObject+i)
+	}
+
+	for i, page := range doc.pages {
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		zw.Write(page.content.Bytes())
+		zw.Close()
+		object(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			pagesObject, fonts.String(), firstPageObject+2*i+1), nil)
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>", compressed.Len()), compressed.Bytes())
+	}
+
+	xref := out.n
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	if out.err != nil {
+		return out.n, out.err
+	}
+	return out.n, out.w.Flush()
+}
+
+// countingWriter counts the bytes written for the cross-reference table and
+// keeps the first error so each write doesn't need to be checked
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
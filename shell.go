@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"jaredpearson.com/dbweb/command"
+	"jaredpearson.com/dbweb/config"
+	"jaredpearson.com/dbweb/data"
+)
+
+// shellConfig is the configuration of the running shell, which completion
+// uses instead of reading the config file again
+var shellConfig *config.Config
+
+// shellUnavailableCommands are the commands that run until they're stopped
+// or only make sense from the system shell
+var shellUnavailableCommands = map[string]bool{
+	"start":      true,
+	"shell":      true,
+	"completion": true,
+	"mail":       true,
+	"oidc":       true,
+}
+
+// shellExitCommands end the shell
+var shellExitCommands = []string{"exit", "quit"}
+
+// shellHistoryFile is the file in the home directory where the lines entered
+// in the shell are kept
+const shellHistoryFile = ".dbweb_history"
+
+// executeShellCommand reads command lines until the input ends or exit is
+// entered. The catalog is loaded once and the configuration given when
+// starting the shell is used by every command. The exit code of the last
+// command is returned so scripts piped into the shell can check it.
+func executeShellCommand(cfg *config.Config) int {
+	if err := loadCatalog(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load miniatures: %v\n", err)
+		return 1
+	}
+	shellConfig = cfg
+
+	reader := command.NewLineReader(os.Stdin, stdin, os.Stdout)
+	reader.Prompt = "dbweb> "
+	reader.Complete = completeShellLine
+	historyPath := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		historyPath = filepath.Join(home, shellHistoryFile)
+		if err := reader.LoadHistory(historyPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to read history: %v\n", err)
+		}
+	}
+	if reader.IsTerminal() {
+		fmt.Fprintf(os.Stdout, "Loaded %d miniatures from %s. Type help for the commands or exit to quit.\n",
+			len(data.GetMiniatures()), cfg.Catalog.DataPath)
+	}
+
+	code := 0
+	for {
+		line, err := reader.ReadLine()
+		if err == command.ErrInterrupt {
+			continue
+		} else if err == io.EOF {
+			break
+		} else if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to read command: %v\n", err)
+			code = 1
+			break
+		}
+		reader.AddHistory(line)
+
+		args, err := command.SplitLine(line)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			code = 1
+			continue
+		}
+		if len(args) == 0 {
+			continue
+		}
+		if isShellExitCommand(args[0]) {
+			break
+		}
+		code = runShellLine(cfg, args)
+	}
+
+	if historyPath != "" {
+		if err := reader.SaveHistory(historyPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to save history: %v\n", err)
+		}
+	}
+	return code
+}
+
+// runShellLine runs the command in the args and returns the exit code
+func runShellLine(cfg *config.Config, args []string) int {
+	c := newCLI()
+	if err := c.commands.Parse(args); err == command.ErrHelp {
+		c.commands.WriteUsage(os.Stdout)
+		return 0
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	if c.helpCmd.IsSelected() {
+		if err := c.commands.Help(os.Stdout, c.helpCmd.Rest()); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		return 0
+	}
+	if selected := c.commands.Selected(); selected != nil && shellUnavailableCommands[selected.Path()[1]] {
+		fmt.Fprintf(os.Stderr, "%s is not available in the shell\n", selected.Path()[1])
+		return 1
+	}
+	configFlagSet := c.configFlag.IsSet()
+	for _, flag := range c.settingFlags {
+		configFlagSet = configFlagSet || flag.IsSet()
+	}
+	if configFlagSet {
+		fmt.Fprint(os.Stderr, "Configuration flags can't be changed in the shell. Give them when starting the shell, such as dbweb shell --data minis.csv\n")
+		return 1
+	}
+	return c.execute(cfg)
+}
+
+// completeShellLine completes the words with the same command tree as the
+// command line, leaving out the commands that aren't available in the shell
+func completeShellLine(words []string) []string {
+	candidates := newCLI().commands.Complete(words)
+	if len(words) != 1 {
+		return candidates
+	}
+	var names []string
+	for _, name := range candidates {
+		if !shellUnavailableCommands[name] {
+			names = append(names, name)
+		}
+	}
+	for _, name := range shellExitCommands {
+		if strings.HasPrefix(name, words[0]) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func isShellExitCommand(name string) bool {
+	for _, exit := range shellExitCommands {
+		if name == exit {
+			return true
+		}
+	}
+	return false
+}
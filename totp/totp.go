@@ -0,0 +1,101 @@
+/*
+Package totp implements time-based one-time passwords (RFC 6238) compatible
+with authenticator apps such as Google Authenticator. Codes are 6 digits
+using HMAC-SHA1 with a 30 second period.
+*/
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is the number of seconds each code is valid for
+	Period = 30
+	// Digits is the number of digits in a code
+	Digits = 6
+	// Skew is the number of periods before and after the current period that
+	// are accepted to allow for clock drift
+	Skew = 1
+
+	secretLength = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret creates a random base32 encoded secret
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// ProvisioningURI creates the otpauth URI that authenticator apps use to add
+// an account, usually by scanning it as a QR code
+func ProvisioningURI(issuer, accountName, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(Period))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Counter returns the period counter for the time
+func Counter(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// CodeAt returns the code for the period counter
+func CodeAt(secret string, counter int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.Replace(secret, " ", "", -1)))
+	if err != nil {
+		return "", fmt.Errorf("invalid secret: %v", err)
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation from RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", Digits, value%1000000), nil
+}
+
+// Validate checks the code against the periods around the time. The counter
+// of the matching period is returned so callers can reject a code that has
+// already been used.
+func Validate(secret, code string, t time.Time) (counter int64, valid bool) {
+	code = strings.Replace(strings.TrimSpace(code), " ", "", -1)
+	if len(code) != Digits {
+		return 0, false
+	}
+	current := Counter(t)
+	for c := current - Skew; c <= current+Skew; c++ {
+		expected, err := CodeAt(secret, c)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return c, true
+		}
+	}
+	return 0, false
+}
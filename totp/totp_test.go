@@ -0,0 +1,85 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 key of the RFC 6238 test vectors,
+// "12345678901234567890", in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// TestCodeAt checks the SHA1 test vectors of RFC 6238. The RFC's codes have 8
+// digits, so the expected codes are their last 6 digits.
+func TestCodeAt(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, test := range tests {
+		code, err := CodeAt(rfcSecret, Counter(time.Unix(test.unix, 0)))
+		if err != nil {
+			t.Errorf("%d: %v", test.unix, err)
+			continue
+		}
+		if code != test.code {
+			t.Errorf("%d: got %s, want %s", test.unix, code, test.code)
+		}
+	}
+}
+
+func TestCodeAtSecretFormatting(t *testing.T) {
+	code, err := CodeAt("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", Counter(time.Unix(59, 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != "287082" {
+		t.Errorf("got %s, want 287082", code)
+	}
+	if _, err := CodeAt("not base32!", 1); err == nil {
+		t.Error("expected an error for an invalid secret")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Counter(now)
+	tests := []struct {
+		name    string
+		code    string
+		counter int64
+		valid   bool
+	}{
+		{"current period", "050471", current, true},
+		{"spaces", " 050 471 ", current, true},
+		{"previous period", mustCode(t, current-1), current - 1, true},
+		{"next period", mustCode(t, current+1), current + 1, true},
+		{"two periods ago", mustCode(t, current-2), 0, false},
+		{"two periods ahead", mustCode(t, current+2), 0, false},
+		{"wrong code", "000000", 0, false},
+		{"too short", "50471", 0, false},
+		{"too long", "0050471", 0, false},
+		{"empty", "", 0, false},
+	}
+	for _, test := range tests {
+		counter, valid := Validate(rfcSecret, test.code, now)
+		if valid != test.valid || counter != test.counter {
+			t.Errorf("%s: got (%d, %v), want (%d, %v)", test.name, counter, valid, test.counter, test.valid)
+		}
+	}
+}
+
+func mustCode(t *testing.T, counter int64) string {
+	code, err := CodeAt(rfcSecret, counter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
@@ -0,0 +1,420 @@
+// Code generated by gen.go from the DejaVu Sans fonts; DO NOT EDIT.
+// The fonts' license is in LICENSE-DejaVu.
+
+package typeface
+
+// Each contour is the x, y and on-curve flag of its points in font units
+
+var Regular = &Face{
+	unitsPerEm: 2048,
+	ascent:     1901,
+	descent:    -483,
+	glyphs: map[rune]*glyph{
+		' ':      {advance: 651},
+		'!':      {advance: 821, contours: [][]int16{{309, 254, 1, 512, 254, 1, 512, 0, 1, 309, 0, 1}, {309, 1493, 1, 512, 1493, 1, 512, 838, 1, 492, 481, 1, 330, 481, 1, 309, 838, 1}}},
+		'"':      {advance: 942, contours: [][]int16{{367, 1493, 1, 367, 938, 1, 197, 938, 1, 197, 1493, 1}, {745, 1493, 1, 745, 938, 1, 575, 938, 1, 575, 1493, 1}}},
+		'#':      {advance: 1716, contours: [][]int16{{1047, 901, 1, 756, 901, 1, 672, 567, 1, 965, 567, 1}, {897, 1470, 1, 793, 1055, 1, 1085, 1055, 1, 1190, 1470, 1, 1350, 1470, 1, 1247, 1055, 1, 1559, 1055, 1, 1559, 901, 1, 1208, 901, 1, 1126, 567, 1, 1444, 567, 1, 1444, 414, 1, 1087, 414, 1, 983, 0, 1, 823, 0, 1, 926, 414, 1, 633, 414, 1, 530, 0, 1, 369, 0, 1, 473, 414, 1, 158, 414, 1, 158, 567, 1, 510, 567, 1, 594, 901, 1, 272, 901, 1, 272, 1055, 1, 633, 1055, 1, 735, 1470, 1}}},
+		'$':      {advance: 1303, contours: [][]int16{{692, -301, 1, 592, -301, 1, 591, 0, 1, 486, 2, 0, 276, 47, 0, 170, 92, 1, 170, 272, 1, 272, 208, 0, 481, 143, 0, 592, 142, 1, 592, 598, 1, 371, 634, 0, 170, 806, 0, 170, 956, 1, 170, 1119, 0, 388, 1307, 0, 592, 1321, 1, 592, 1556, 1, 692, 1556, 1, 692, 1324, 1, 785, 1320, 0, 959, 1289, 0, 1042, 1262, 1, 1042, 1087, 1, 959, 1129, 0, 784, 1175, 0, 692, 1179, 1, 692, 752, 1, 919, 717, 0, 1133, 537, 0, 1133, 381, 1, 1133, 212, 0, 906, 17, 0, 692, 2, 1}, {592, 770, 1, 592, 1180, 1, 476, 1167, 0, 354, 1061, 0, 354, 973, 1, 354, 887, 0, 467, 791, 0}, {692, 578, 1, 692, 145, 1, 819, 162, 0, 948, 272, 0, 948, 362, 1, 948, 450, 0, 825, 554, 0}}},
+		'%':      {advance: 1946, contours: [][]int16{{1489, 657, 1, 1402, 657, 0, 1303, 509, 0, 1303, 377, 1, 1303, 247, 0, 1402, 98, 0, 1489, 98, 1, 1574, 98, 0, 1673, 247, 0, 1673, 377, 1, 1673, 508, 0, 1574, 657, 0}, {1489, 784, 1, 1647, 784, 0, 1833, 564, 0, 1833, 377, 1, 1833, 190, 0, 1646, -29, 0, 1489, -29, 1, 1329, -29, 0, 1143, 190, 0, 1143, 377, 1, 1143, 565, 0, 1330, 784, 0}, {457, 1393, 1, 371, 1393, 0, 272, 1244, 0, 272, 1114, 1, 272, 982, 0, 370, 834, 0, 457, 834, 1, 544, 834, 0, 643, 982, 0, 643, 1114, 1, 643, 1243, 0, 543, 1393, 0}, {1360, 1520, 1, 1520, 1520, 1, 586, -29, 1, 426, -29, 1}, {457, 1520, 1, 615, 1520, 0, 803, 1301, 0, 803, 1114, 1, 803, 925, 0, 616, 707, 0, 457, 707, 1, 298, 707, 0, 113, 926, 0, 113, 1114, 1, 113, 1300, 0, 299, 1520, 0}}},
+		'&':      {advance: 1597, contours: [][]int16{{498, 803, 1, 407, 722, 0, 322, 561, 0, 322, 473, 1, 322, 327, 0, 534, 133, 0, 694, 133, 1, 789, 133, 0, 955, 196, 0, 1028, 260, 1}, {639, 915, 1, 1147, 395, 1, 1206, 484, 0, 1272, 687, 0, 1278, 801, 1, 1464, 801, 1, 1452, 669, 0, 1348, 411, 0, 1255, 285, 1, 1534, 0, 1, 1282, 0, 1, 1139, 147, 1, 1035, 58, 0, 807, -29, 0, 676, -29, 1, 435, -29, 0, 129, 246, 0, 129, 461, 1, 129, 589, 0, 263, 814, 0, 397, 913, 1, 349, 976, 0, 299, 1101, 0, 299, 1161, 1, 299, 1323, 0, 521, 1520, 0, 705, 1520, 1, 788, 1520, 0, 953, 1484, 0, 1038, 1448, 1, 1038, 1266, 1, 951, 1313, 0, 793, 1362, 0, 725, 1362, 1, 620, 1362, 0, 489, 1251, 0, 489, 1163, 1, 489, 1112, 0, 548, 1009, 0}}},
+		'\'':     {advance: 563, contours: [][]int16{{367, 1493, 1, 367, 938, 1, 197, 938, 1, 197, 1493, 1}}},
+		'(':      {advance: 799, contours: [][]int16{{635, 1554, 1, 501, 1324, 0, 371, 874, 0, 371, 643, 1, 371, 412, 0, 502, -41, 0, 635, -270, 1, 475, -270, 1, 325, -35, 0, 176, 419, 0, 176, 643, 1, 176, 866, 0, 324, 1318, 0, 475, 1554, 1}}},
+		')':      {advance: 799, contours: [][]int16{{164, 1554, 1, 324, 1554, 1, 474, 1318, 0, 623, 866, 0, 623, 643, 1, 623, 419, 0, 474, -35, 0, 324, -270, 1, 164, -270, 1, 297, -41, 0, 428, 412, 0, 428, 643, 1, 428, 874, 0, 297, 1324, 0}}},
+		'*':      {advance: 1024, contours: [][]int16{{963, 1247, 1, 604, 1053, 1, 963, 858, 1, 905, 760, 1, 569, 963, 1, 569, 586, 1, 455, 586, 1, 455, 963, 1, 119, 760, 1, 61, 858, 1, 420, 1053, 1, 61, 1247, 1, 119, 1346, 1, 455, 1143, 1, 455, 1520, 1, 569, 1520, 1, 569, 1143, 1, 905, 1346, 1}}},
+		'+':      {advance: 1716, contours: [][]int16{{942, 1284, 1, 942, 727, 1, 1499, 727, 1, 1499, 557, 1, 942, 557, 1, 942, 0, 1, 774, 0, 1, 774, 557, 1, 217, 557, 1, 217, 727, 1, 774, 727, 1, 774, 1284, 1}}},
+		',':      {advance: 651, contours: [][]int16{{240, 254, 1, 451, 254, 1, 451, 82, 1, 287, -238, 1, 158, -238, 1, 240, 82, 1}}},
+		'-':      {advance: 739, contours: [][]int16{{100, 643, 1, 639, 643, 1, 639, 479, 1, 100, 479, 1}}},
+		'.':      {advance: 651, contours: [][]int16{{219, 254, 1, 430, 254, 1, 430, 0, 1, 219, 0, 1}}},
+		'/':      {advance: 690, contours: [][]int16{{520, 1493, 1, 690, 1493, 1, 170, -190, 1, 0, -190, 1}}},
+		'0':      {advance: 1303, contours: [][]int16{{651, 1360, 1, 495, 1360, 0, 338, 1053, 0, 338, 745, 1, 338, 438, 0, 495, 131, 0, 651, 131, 1, 808, 131, 0, 965, 438, 0, 965, 745, 1, 965, 1053, 0, 808, 1360, 0}, {651, 1520, 1, 902, 1520, 0, 1167, 1123, 0, 1167, 745, 1, 1167, 368, 0, 902, -29, 0, 651, -29, 1, 400, -29, 0, 135, 368, 0, 135, 745, 1, 135, 1123, 0, 400, 1520, 0}}},
+		'1':      {advance: 1303, contours: [][]int16{{254, 170, 1, 584, 170, 1, 584, 1309, 1, 225, 1237, 1, 225, 1421, 1, 582, 1493, 1, 784, 1493, 1, 784, 170, 1, 1114, 170, 1, 1114, 0, 1, 254, 0, 1}}},
+		'2':      {advance: 1303, contours: [][]int16{{393, 170, 1, 1098, 170, 1, 1098, 0, 1, 150, 0, 1, 150, 170, 1, 265, 289, 0, 662, 690, 0, 713, 748, 1, 810, 857, 0, 887, 1008, 0, 887, 1081, 1, 887, 1200, 0, 720, 1350, 0, 586, 1350, 1, 491, 1350, 0, 280, 1284, 0, 160, 1217, 1, 160, 1421, 1, 282, 1470, 0, 494, 1520, 0, 582, 1520, 1, 814, 1520, 0, 1090, 1288, 0, 1090, 1094, 1, 1090, 1002, 0, 1021, 837, 0, 930, 725, 1, 905, 696, 0, 637, 419, 0}}},
+		'3':      {advance: 1303, contours: [][]int16{{831, 805, 1, 976, 774, 0, 1139, 578, 0, 1139, 434, 1, 1139, 213, 0, 835, -29, 0, 555, -29, 1, 461, -29, 0, 262, 8, 0, 156, 45, 1, 156, 240, 1, 240, 191, 0, 440, 141, 0, 549, 141, 1, 739, 141, 0, 938, 291, 0, 938, 434, 1, 938, 566, 0, 753, 715, 0, 588, 715, 1, 414, 715, 1, 414, 881, 1, 596, 881, 1, 745, 881, 0, 903, 1000, 0, 903, 1112, 1, 903, 1227, 0, 740, 1350, 0, 588, 1350, 1, 505, 1350, 0, 315, 1314, 0, 201, 1276, 1, 201, 1456, 1, 316, 1488, 0, 517, 1520, 0, 606, 1520, 1, 836, 1520, 0, 1104, 1311, 0, 1104, 1133, 1, 1104, 1009, 0, 962, 838, 0}}},
+		'4':      {advance: 1303, contours: [][]int16{{774, 1317, 1, 264, 520, 1, 774, 520, 1}, {721, 1493, 1, 975, 1493, 1, 975, 520, 1, 1188, 520, 1, 1188, 352, 1, 975, 352, 1, 975, 0, 1, 774, 0, 1, 774, 352, 1, 100, 352, 1, 100, 547, 1}}},
+		'5':      {advance: 1303, contours: [][]int16{{221, 1493, 1, 1014, 1493, 1, 1014, 1323, 1, 406, 1323, 1, 406, 957, 1, 450, 972, 0, 538, 987, 0, 582, 987, 1, 832, 987, 0, 1124, 713, 0, 1124, 479, 1, 1124, 238, 0, 824, -29, 0, 551, -29, 1, 457, -29, 0, 262, 3, 0, 158, 35, 1, 158, 238, 1, 248, 189, 0, 440, 141, 0, 547, 141, 1, 720, 141, 0, 922, 323, 0, 922, 479, 1, 922, 635, 0, 720, 817, 0, 547, 817, 1, 466, 817, 0, 305, 781, 0, 221, 743, 1}}},
+		'6':      {advance: 1303, contours: [][]int16{{676, 827, 1, 540, 827, 0, 381, 641, 0, 381, 479, 1, 381, 318, 0, 540, 131, 0, 676, 131, 1, 812, 131, 0, 971, 318, 0, 971, 479, 1, 971, 641, 0, 812, 827, 0}, {1077, 1460, 1, 1077, 1276, 1, 1001, 1312, 0, 846, 1350, 0, 770, 1350, 1, 570, 1350, 0, 359, 1080, 0, 344, 807, 1, 403, 894, 0, 581, 987, 0, 688, 987, 1, 913, 987, 0, 1174, 714, 0, 1174, 479, 1, 1174, 249, 0, 902, -29, 0, 676, -29, 1, 417, -29, 0, 143, 368, 0, 143, 745, 1, 143, 1099, 0, 479, 1520, 0, 762, 1520, 1, 838, 1520, 0, 993, 1490, 0}}},
+		'7':      {advance: 1303, contours: [][]int16{{168, 1493, 1, 1128, 1493, 1, 1128, 1407, 1, 586, 0, 1, 375, 0, 1, 885, 1323, 1, 168, 1323, 1}}},
+		'8':      {advance: 1303, contours: [][]int16{{651, 709, 1, 507, 709, 0, 342, 555, 0, 342, 420, 1, 342, 285, 0, 507, 131, 0, 651, 131, 1, 795, 131, 0, 961, 286, 0, 961, 420, 1, 961, 555, 0, 796, 709, 0}, {449, 795, 1, 319, 827, 0, 174, 1005, 0, 174, 1133, 1, 174, 1312, 0, 429, 1520, 0, 651, 1520, 1, 874, 1520, 0, 1128, 1312, 0, 1128, 1133, 1, 1128, 1005, 0, 983, 827, 0, 854, 795, 1, 1000, 761, 0, 1163, 563, 0, 1163, 420, 1, 1163, 203, 0, 898, -29, 0, 651, -29, 1, 404, -29, 0, 139, 203, 0, 139, 420, 1, 139, 563, 0, 303, 761, 0}, {375, 1114, 1, 375, 998, 0, 520, 868, 0, 651, 868, 1, 781, 868, 0, 928, 998, 0, 928, 1114, 1, 928, 1230, 0, 781, 1360, 0, 651, 1360, 1, 520, 1360, 0, 375, 1230, 0}}},
+		'9':      {advance: 1303, contours: [][]int16{{225, 31, 1, 225, 215, 1, 301, 179, 0, 457, 141, 0, 532, 141, 1, 732, 141, 0, 943, 410, 0, 958, 684, 1, 900, 598, 0, 722, 506, 0, 614, 506, 1, 390, 506, 0, 129, 777, 0, 129, 1012, 1, 129, 1242, 0, 401, 1520, 0, 627, 1520, 1, 886, 1520, 0, 1159, 1123, 0, 1159, 745, 1, 1159, 392, 0, 824, -29, 0, 541, -29, 1, 465, -29, 0, 309, 1, 0}, {627, 664, 1, 763, 664, 0, 922, 850, 0, 922, 1012, 1, 922, 1173, 0, 763, 1360, 0, 627, 1360, 1, 491, 1360, 0, 332, 1173, 0, 332, 1012, 1, 332, 850, 0, 491, 664, 0}}},
+		':':      {advance: 690, contours: [][]int16{{240, 254, 1, 451, 254, 1, 451, 0, 1, 240, 0, 1}, {240, 1059, 1, 451, 1059, 1, 451, 805, 1, 240, 805, 1}}},
+		';':      {advance: 690, contours: [][]int16{{240, 1059, 1, 451, 1059, 1, 451, 805, 1, 240, 805, 1}, {240, 254, 1, 451, 254, 1, 451, 82, 1, 287, -238, 1, 158, -238, 1, 240, 82, 1}}},
+		'<':      {advance: 1716, contours: [][]int16{{1499, 1008, 1, 467, 641, 1, 1499, 276, 1, 1499, 94, 1, 217, 559, 1, 217, 725, 1, 1499, 1190, 1}}},
+		'=':      {advance: 1716, contours: [][]int16{{217, 930, 1, 1499, 930, 1, 1499, 762, 1, 217, 762, 1}, {217, 522, 1, 1499, 522, 1, 1499, 352, 1, 217, 352, 1}}},
+		'>':      {advance: 1716, contours: [][]int16{{217, 1008, 1, 217, 1190, 1, 1499, 725, 1, 1499, 559, 1, 217, 94, 1, 217, 276, 1, 1247, 641, 1}}},
+		'?':      {advance: 1087, contours: [][]int16{{391, 254, 1, 594, 254, 1, 594, 0, 1, 391, 0, 1}, {588, 401, 1, 397, 401, 1, 397, 555, 1, 397, 656, 0, 453, 786, 0, 543, 872, 1, 633, 961, 1, 690, 1014, 0, 741, 1108, 0, 741, 1157, 1, 741, 1246, 0, 610, 1356, 0, 502, 1356, 1, 423, 1356, 0, 244, 1286, 0, 147, 1219, 1, 147, 1407, 1, 241, 1464, 0, 434, 1520, 0, 537, 1520, 1, 721, 1520, 0, 944, 1326, 0, 944, 1167, 1, 944, 1091, 0, 872, 954, 0, 782, 868, 1, 694, 782, 1, 647, 735, 0, 608, 682, 0, 600, 657, 1, 594, 636, 0, 588, 576, 0, 588, 524, 1}}},
+		'@':      {advance: 2048, contours: [][]int16{{762, 537, 1, 762, 394, 0, 904, 231, 0, 1028, 231, 1, 1151, 231, 0, 1292, 395, 0, 1292, 537, 1, 1292, 677, 0, 1148, 842, 0, 1026, 842, 1, 905, 842, 0, 762, 678, 0}, {1307, 238, 1, 1247, 161, 0, 1092, 88, 0, 989, 88, 1, 817, 88, 0, 602, 337, 0, 602, 537, 1, 602, 737, 0, 818, 987, 0, 989, 987, 1, 1092, 987, 0, 1248, 912, 0, 1307, 836, 1, 1307, 967, 1, 1450, 967, 1, 1450, 231, 1, 1596, 253, 0, 1761, 476, 0, 1761, 653, 1, 1761, 760, 0, 1698, 948, 0, 1634, 1028, 1, 1530, 1159, 0, 1231, 1298, 0, 1055, 1298, 1, 932, 1298, 0, 706, 1233, 0, 610, 1169, 1, 453, 1067, 0, 276, 736, 0, 276, 543, 1, 276, 384, 0, 391, 106, 0, 500, 0, 1, 605, -104, 0, 881, -213, 0, 1038, -213, 1, 1167, -213, 0, 1416, -126, 0, 1520, -45, 1, 1610, -156, 1, 1485, -253, 0, 1190, -356, 0, 1038, -356, 1, 853, -356, 0, 525, -225, 0, 397, -100, 1, 269, 25, 0, 135, 354, 0, 135, 543, 1, 135, 725, 0, 271, 1055, 0, 397, 1180, 1, 526, 1307, 0, 864, 1442, 0, 1053, 1442, 1, 1265, 1442, 0, 1628, 1268, 0, 1751, 1108, 1, 1826, 1010, 0, 1905, 780, 0, 1905, 657, 1, 1905, 394, 0, 1587, 90, 0, 1307, 84, 1}}},
+		'A':      {advance: 1401, contours: [][]int16{{700, 1294, 1, 426, 551, 1, 975, 551, 1}, {586, 1493, 1, 815, 1493, 1, 1384, 0, 1, 1174, 0, 1, 1038, 383, 1, 365, 383, 1, 229, 0, 1, 16, 0, 1}}},
+		'B':      {advance: 1405, contours: [][]int16{{403, 713, 1, 403, 166, 1, 727, 166, 1, 890, 166, 0, 1047, 301, 0, 1047, 440, 1, 1047, 580, 0, 890, 713, 0, 727, 713, 1}, {403, 1327, 1, 403, 877, 1, 702, 877, 1, 850, 877, 0, 995, 988, 0, 995, 1102, 1, 995, 1215, 0, 850, 1327, 0, 702, 1327, 1}, {201, 1493, 1, 717, 1493, 1, 948, 1493, 0, 1198, 1301, 0, 1198, 1124, 1, 1198, 987, 0, 1070, 825, 0, 946, 805, 1, 1095, 773, 0, 1260, 570, 0, 1260, 418, 1, 1260, 218, 0, 988, 0, 0, 737, 0, 1, 201, 0, 1}}},
+		'C':      {advance: 1430, contours: [][]int16{{1319, 1378, 1, 1319, 1165, 1, 1217, 1260, 0, 986, 1354, 0, 856, 1354, 1, 600, 1354, 0, 328, 1041, 0, 328, 745, 1, 328, 450, 0, 600, 137, 0, 856, 137, 1, 986, 137, 0, 1217, 231, 0, 1319, 326, 1, 1319, 115, 1, 1213, 43, 0, 976, -29, 0, 844, -29, 1, 505, -29, 0, 115, 386, 0, 115, 745, 1, 115, 1105, 0, 505, 1520, 0, 844, 1520, 1, 978, 1520, 0, 1215, 1449, 0}}},
+		'D':      {advance: 1577, contours: [][]int16{{403, 1327, 1, 403, 166, 1, 647, 166, 1, 956, 166, 0, 1243, 446, 0, 1243, 748, 1, 1243, 1048, 0, 956, 1327, 0, 647, 1327, 1}, {201, 1493, 1, 616, 1493, 1, 1050, 1493, 0, 1456, 1132, 0, 1456, 748, 1, 1456, 362, 0, 1048, 0, 0, 616, 0, 1, 201, 0, 1}}},
+		'E':      {advance: 1294, contours: [][]int16{{201, 1493, 1, 1145, 1493, 1, 1145, 1323, 1, 403, 1323, 1, 403, 881, 1, 1114, 881, 1, 1114, 711, 1, 403, 711, 1, 403, 170, 1, 1163, 170, 1, 1163, 0, 1, 201, 0, 1}}},
+		'F':      {advance: 1178, contours: [][]int16{{201, 1493, 1, 1059, 1493, 1, 1059, 1323, 1, 403, 1323, 1, 403, 883, 1, 995, 883, 1, 995, 713, 1, 403, 713, 1, 403, 0, 1, 201, 0, 1}}},
+		'G':      {advance: 1587, contours: [][]int16{{1219, 213, 1, 1219, 614, 1, 889, 614, 1, 889, 780, 1, 1419, 780, 1, 1419, 139, 1, 1302, 56, 0, 1020, -29, 0, 860, -29, 1, 510, -29, 0, 115, 380, 0, 115, 745, 1, 115, 1111, 0, 510, 1520, 0, 860, 1520, 1, 1006, 1520, 0, 1269, 1448, 0, 1380, 1378, 1, 1380, 1163, 1, 1268, 1258, 0, 1016, 1354, 0, 877, 1354, 1, 603, 1354, 0, 328, 1048, 0, 328, 745, 1, 328, 443, 0, 603, 137, 0, 877, 137, 1, 984, 137, 0, 1152, 174, 0}}},
+		'H':      {advance: 1540, contours: [][]int16{{201, 1493, 1, 403, 1493, 1, 403, 881, 1, 1137, 881, 1, 1137, 1493, 1, 1339, 1493, 1, 1339, 0, 1, 1137, 0, 1, 1137, 711, 1, 403, 711, 1, 403, 0, 1, 201, 0, 1}}},
+		'I':      {advance: 604, contours: [][]int16{{201, 1493, 1, 403, 1493, 1, 403, 0, 1, 201, 0, 1}}},
+		'J':      {advance: 604, contours: [][]int16{{201, 1493, 1, 403, 1493, 1, 403, 104, 1, 403, -166, 0, 198, -410, 0, -29, -410, 1, -106, -410, 1, -106, -240, 1, -43, -240, 1, 91, -240, 0, 201, -90, 0, 201, 104, 1}}},
+		'K':      {advance: 1343, contours: [][]int16{{201, 1493, 1, 403, 1493, 1, 403, 862, 1, 1073, 1493, 1, 1333, 1493, 1, 592, 797, 1, 1386, 0, 1, 1120, 0, 1, 403, 719, 1, 403, 0, 1, 201, 0, 1}}},
+		'L':      {advance: 1141, contours: [][]int16{{201, 1493, 1, 403, 1493, 1, 403, 170, 1, 1130, 170, 1, 1130, 0, 1, 201, 0, 1}}},
+		'M':      {advance: 1767, contours: [][]int16{{201, 1493, 1, 502, 1493, 1, 883, 477, 1, 1266, 1493, 1, 1567, 1493, 1, 1567, 0, 1, 1370, 0, 1, 1370, 1311, 1, 985, 287, 1, 782, 287, 1, 397, 1311, 1, 397, 0, 1, 201, 0, 1}}},
+		'N':      {advance: 1532, contours: [][]int16{{201, 1493, 1, 473, 1493, 1, 1135, 244, 1, 1135, 1493, 1, 1331, 1493, 1, 1331, 0, 1, 1059, 0, 1, 397, 1249, 1, 397, 0, 1, 201, 0, 1}}},
+		'O':      {advance: 1612, contours: [][]int16{{807, 1356, 1, 587, 1356, 0, 328, 1028, 0, 328, 745, 1, 328, 463, 0, 587, 135, 0, 807, 135, 1, 1027, 135, 0, 1284, 463, 0, 1284, 745, 1, 1284, 1028, 0, 1027, 1356, 0}, {807, 1520, 1, 1121, 1520, 0, 1497, 1099, 0, 1497, 745, 1, 1497, 392, 0, 1121, -29, 0, 807, -29, 1, 492, -29, 0, 115, 391, 0, 115, 745, 1, 115, 1099, 0, 492, 1520, 0}}},
+		'P':      {advance: 1235, contours: [][]int16{{403, 1327, 1, 403, 766, 1, 657, 766, 1, 798, 766, 0, 952, 912, 0, 952, 1047, 1, 952, 1181, 0, 798, 1327, 0, 657, 1327, 1}, {201, 1493, 1, 657, 1493, 1, 908, 1493, 0, 1165, 1266, 0, 1165, 1047, 1, 1165, 826, 0, 908, 600, 0, 657, 600, 1, 403, 600, 1, 403, 0, 1, 201, 0, 1}}},
+		'Q':      {advance: 1612, contours: [][]int16{{807, 1356, 1, 587, 1356, 0, 328, 1028, 0, 328, 745, 1, 328, 463, 0, 587, 135, 0, 807, 135, 1, 1027, 135, 0, 1284, 463, 0, 1284, 745, 1, 1284, 1028, 0, 1027, 1356, 0}, {1090, 27, 1, 1356, -264, 1, 1112, -264, 1, 891, -25, 1, 858, -27, 0, 823, -29, 0, 807, -29, 1, 492, -29, 0, 115, 392, 0, 115, 745, 1, 115, 1099, 0, 492, 1520, 0, 807, 1520, 1, 1121, 1520, 0, 1497, 1099, 0, 1497, 745, 1, 1497, 485, 0, 1288, 115, 0}}},
+		'R':      {advance: 1423, contours: [][]int16{{909, 700, 1, 974, 678, 0, 1097, 534, 0, 1159, 408, 1, 1364, 0, 1, 1147, 0, 1, 956, 383, 1, 882, 533, 0, 743, 631, 0, 623, 631, 1, 403, 631, 1, 403, 0, 1, 201, 0, 1, 201, 1493, 1, 657, 1493, 1, 913, 1493, 0, 1165, 1279, 0, 1165, 1063, 1, 1165, 922, 0, 1034, 736, 0}, {403, 1327, 1, 403, 797, 1, 657, 797, 1, 803, 797, 0, 952, 932, 0, 952, 1063, 1, 952, 1194, 0, 803, 1327, 0, 657, 1327, 1}}},
+		'S':      {advance: 1300, contours: [][]int16{{1096, 1444, 1, 1096, 1247, 1, 981, 1302, 0, 777, 1356, 0, 682, 1356, 1, 517, 1356, 0, 338, 1228, 0, 338, 1110, 1, 338, 1011, 0, 457, 910, 0, 623, 879, 1, 745, 854, 1, 971, 811, 0, 1186, 594, 0, 1186, 412, 1, 1186, 195, 0, 895, -29, 0, 614, -29, 1, 508, -29, 0, 269, 19, 0, 141, 66, 1, 141, 274, 1, 264, 205, 0, 500, 135, 0, 614, 135, 1, 787, 135, 0, 975, 271, 0, 975, 397, 1, 975, 507, 0, 840, 631, 0, 686, 662, 1, 563, 686, 1, 337, 731, 0, 135, 923, 0, 135, 1094, 1, 135, 1292, 0, 414, 1520, 0, 659, 1520, 1, 764, 1520, 0, 982, 1482, 0}}},
+		'T':      {advance: 1251, contours: [][]int16{{-6, 1493, 1, 1257, 1493, 1, 1257, 1323, 1, 727, 1323, 1, 727, 0, 1, 524, 0, 1, 524, 1323, 1, -6, 1323, 1}}},
+		'U':      {advance: 1499, contours: [][]int16{{178, 1493, 1, 381, 1493, 1, 381, 586, 1, 381, 346, 0, 555, 135, 0, 750, 135, 1, 944, 135, 0, 1118, 346, 0, 1118, 586, 1, 1118, 1493, 1, 1321, 1493, 1, 1321, 561, 1, 1321, 269, 0, 1032, -29, 0, 750, -29, 1, 467, -29, 0, 178, 269, 0, 178, 561, 1}}},
+		'V':      {advance: 1401, contours: [][]int16{{586, 0, 1, 16, 1493, 1, 227, 1493, 1, 700, 236, 1, 1174, 1493, 1, 1384, 1493, 1, 815, 0, 1}}},
+		'W':      {advance: 2025, contours: [][]int16{{68, 1493, 1, 272, 1493, 1, 586, 231, 1, 899, 1493, 1, 1126, 1493, 1, 1440, 231, 1, 1753, 1493, 1, 1958, 1493, 1, 1583, 0, 1, 1329, 0, 1, 1014, 1296, 1, 696, 0, 1, 442, 0, 1}}},
+		'X':      {advance: 1403, contours: [][]int16{{129, 1493, 1, 346, 1493, 1, 717, 938, 1, 1090, 1493, 1, 1307, 1493, 1, 827, 776, 1, 1339, 0, 1, 1122, 0, 1, 702, 635, 1, 279, 0, 1, 61, 0, 1, 594, 797, 1}}},
+		'Y':      {advance: 1251, contours: [][]int16{{-4, 1493, 1, 213, 1493, 1, 627, 879, 1, 1038, 1493, 1, 1255, 1493, 1, 727, 711, 1, 727, 0, 1, 524, 0, 1, 524, 711, 1}}},
+		'Z':      {advance: 1403, contours: [][]int16{{115, 1493, 1, 1288, 1493, 1, 1288, 1339, 1, 344, 170, 1, 1311, 170, 1, 1311, 0, 1, 92, 0, 1, 92, 154, 1, 1036, 1323, 1, 115, 1323, 1}}},
+		'[':      {advance: 799, contours: [][]int16{{176, 1556, 1, 600, 1556, 1, 600, 1413, 1, 360, 1413, 1, 360, -127, 1, 600, -127, 1, 600, -270, 1, 176, -270, 1}}},
+		'\\':     {advance: 690, contours: [][]int16{{170, 1493, 1, 690, -190, 1, 520, -190, 1, 0, 1493, 1}}},
+		']':      {advance: 799, contours: [][]int16{{623, 1556, 1, 623, -270, 1, 199, -270, 1, 199, -127, 1, 438, -127, 1, 438, 1413, 1, 199, 1413, 1, 199, 1556, 1}}},
+		'^':      {advance: 1716, contours: [][]int16{{956, 1493, 1, 1499, 936, 1, 1298, 936, 1, 858, 1331, 1, 418, 936, 1, 217, 936, 1, 760, 1493, 1}}},
+		'_':      {advance: 1024, contours: [][]int16{{1044, -340, 1, 1044, -483, 1, -20, -483, 1, -20, -340, 1}}},
+		'`':      {advance: 1024, contours: [][]int16{{367, 1638, 1, 649, 1264, 1, 496, 1264, 1, 170, 1638, 1}}},
+		'a':      {advance: 1255, contours: [][]int16{{702, 563, 1, 479, 563, 0, 307, 461, 0, 307, 338, 1, 307, 240, 0, 436, 125, 0, 547, 125, 1, 700, 125, 0, 885, 342, 0, 885, 522, 1, 885, 563, 1}, {1069, 639, 1, 1069, 0, 1, 885, 0, 1, 885, 170, 1, 822, 68, 0, 634, -29, 0, 498, -29, 1, 326, -29, 0, 123, 164, 0, 123, 326, 1, 123, 515, 0, 376, 707, 0, 627, 707, 1, 885, 707, 1, 885, 725, 1, 885, 852, 0, 718, 991, 0, 567, 991, 1, 471, 991, 0, 289, 945, 0, 205, 899, 1, 205, 1069, 1, 306, 1108, 0, 496, 1147, 0, 586, 1147, 1, 829, 1147, 0, 1069, 895, 0}}},
+		'b':      {advance: 1300, contours: [][]int16{{997, 559, 1, 997, 762, 0, 830, 993, 0, 684, 993, 1, 538, 993, 0, 371, 762, 0, 371, 559, 1, 371, 356, 0, 538, 125, 0, 684, 125, 1, 830, 125, 0, 997, 356, 0}, {371, 950, 1, 429, 1050, 0, 606, 1147, 0, 729, 1147, 1, 933, 1147, 0, 1188, 823, 0, 1188, 559, 1, 1188, 295, 0, 933, -29, 0, 729, -29, 1, 606, -29, 0, 429, 68, 0, 371, 168, 1, 371, 0, 1, 186, 0, 1, 186, 1556, 1, 371, 1556, 1}}},
+		'c':      {advance: 1126, contours: [][]int16{{999, 1077, 1, 999, 905, 1, 921, 948, 0, 764, 991, 0, 684, 991, 1, 505, 991, 0, 307, 764, 0, 307, 559, 1, 307, 354, 0, 505, 127, 0, 684, 127, 1, 764, 127, 0, 921, 170, 0, 999, 213, 1, 999, 43, 1, 922, 7, 0, 757, -29, 0, 664, -29, 1, 411, -29, 0, 113, 289, 0, 113, 559, 1, 113, 833, 0, 414, 1147, 0, 676, 1147, 1, 761, 1147, 0, 923, 1112, 0}}},
+		'd':      {advance: 1300, contours: [][]int16{{930, 950, 1, 930, 1556, 1, 1114, 1556, 1, 1114, 0, 1, 930, 0, 1, 930, 168, 1, 872, 68, 0, 695, -29, 0, 571, -29, 1, 368, -29, 0, 113, 295, 0, 113, 559, 1, 113, 823, 0, 368, 1147, 0, 571, 1147, 1, 695, 1147, 0, 872, 1050, 0}, {303, 559, 1, 303, 356, 0, 470, 125, 0, 616, 125, 1, 762, 125, 0, 930, 356, 0, 930, 559, 1, 930, 762, 0, 762, 993, 0, 616, 993, 1, 470, 993, 0, 303, 762, 0}}},
+		'e':      {advance: 1260, contours: [][]int16{{1151, 606, 1, 1151, 516, 1, 305, 516, 1, 317, 326, 0, 522, 127, 0, 705, 127, 1, 811, 127, 0, 1010, 179, 0, 1108, 231, 1, 1108, 57, 1, 1009, 15, 0, 801, -29, 0, 694, -29, 1, 426, -29, 0, 113, 283, 0, 113, 549, 1, 113, 824, 0, 410, 1147, 0, 662, 1147, 1, 888, 1147, 0, 1151, 856, 0}, {967, 660, 1, 965, 811, 0, 800, 991, 0, 664, 991, 1, 510, 991, 0, 325, 817, 0, 311, 659, 1}}},
+		'f':      {advance: 721, contours: [][]int16{{760, 1556, 1, 760, 1403, 1, 584, 1403, 1, 485, 1403, 0, 408, 1323, 0, 408, 1219, 1, 408, 1120, 1, 711, 1120, 1, 711, 977, 1, 408, 977, 1, 408, 0, 1, 223, 0, 1, 223, 977, 1, 47, 977, 1, 47, 1120, 1, 223, 1120, 1, 223, 1198, 1, 223, 1385, 0, 397, 1556, 0, 586, 1556, 1}}},
+		'g':      {advance: 1300, contours: [][]int16{{930, 573, 1, 930, 773, 0, 765, 993, 0, 616, 993, 1, 468, 993, 0, 303, 773, 0, 303, 573, 1, 303, 374, 0, 468, 154, 0, 616, 154, 1, 765, 154, 0, 930, 374, 0}, {1114, 139, 1, 1114, -147, 0, 860, -426, 0, 598, -426, 1, 501, -426, 0, 329, -397, 0, 248, -367, 1, 248, -188, 1, 329, -232, 0, 487, -274, 0, 569, -274, 1, 750, -274, 0, 930, -85, 0, 930, 106, 1, 930, 197, 1, 873, 98, 0, 695, 0, 0, 571, 0, 1, 365, 0, 0, 113, 314, 0, 113, 573, 1, 113, 833, 0, 365, 1147, 0, 571, 1147, 1, 695, 1147, 0, 873, 1049, 0, 930, 950, 1, 930, 1120, 1, 1114, 1120, 1}}},
+		'h':      {advance: 1298, contours: [][]int16{{1124, 676, 1, 1124, 0, 1, 940, 0, 1, 940, 670, 1, 940, 829, 0, 816, 987, 0, 692, 987, 1, 543, 987, 0, 371, 797, 0, 371, 633, 1, 371, 0, 1, 186, 0, 1, 186, 1556, 1, 371, 1556, 1, 371, 946, 1, 437, 1047, 0, 616, 1147, 0, 733, 1147, 1, 926, 1147, 0, 1124, 908, 0}}},
+		'i':      {advance: 569, contours: [][]int16{{193, 1120, 1, 377, 1120, 1, 377, 0, 1, 193, 0, 1}, {193, 1556, 1, 377, 1556, 1, 377, 1323, 1, 193, 1323, 1}}},
+		'j':      {advance: 569, contours: [][]int16{{193, 1120, 1, 377, 1120, 1, 377, -20, 1, 377, -234, 0, 214, -426, 0, 33, -426, 1, -37, -426, 1, -37, -270, 1, 12, -270, 1, 117, -270, 0, 193, -173, 0, 193, -20, 1}, {193, 1556, 1, 377, 1556, 1, 377, 1323, 1, 193, 1323, 1}}},
+		'k':      {advance: 1186, contours: [][]int16{{186, 1556, 1, 371, 1556, 1, 371, 637, 1, 920, 1120, 1, 1155, 1120, 1, 561, 596, 1, 1180, 0, 1, 940, 0, 1, 371, 547, 1, 371, 0, 1, 186, 0, 1}}},
+		'l':      {advance: 569, contours: [][]int16{{193, 1556, 1, 377, 1556, 1, 377, 0, 1, 193, 0, 1}}},
+		'm':      {advance: 1995, contours: [][]int16{{1065, 905, 1, 1134, 1029, 0, 1326, 1147, 0, 1456, 1147, 1, 1631, 1147, 0, 1821, 902, 0, 1821, 676, 1, 1821, 0, 1, 1636, 0, 1, 1636, 670, 1, 1636, 831, 0, 1522, 987, 0, 1405, 987, 1, 1262, 987, 0, 1096, 797, 0, 1096, 633, 1, 1096, 0, 1, 911, 0, 1, 911, 670, 1, 911, 832, 0, 797, 987, 0, 678, 987, 1, 537, 987, 0, 371, 796, 0, 371, 633, 1, 371, 0, 1, 186, 0, 1, 186, 1120, 1, 371, 1120, 1, 371, 946, 1, 434, 1049, 0, 610, 1147, 0, 731, 1147, 1, 853, 1147, 0, 1024, 1023, 0}}},
+		'n':      {advance: 1298, contours: [][]int16{{1124, 676, 1, 1124, 0, 1, 940, 0, 1, 940, 670, 1, 940, 829, 0, 816, 987, 0, 692, 987, 1, 543, 987, 0, 371, 797, 0, 371, 633, 1, 371, 0, 1, 186, 0, 1, 186, 1120, 1, 371, 1120, 1, 371, 946, 1, 437, 1047, 0, 616, 1147, 0, 733, 1147, 1, 926, 1147, 0, 1124, 908, 0}}},
+		'o':      {advance: 1253, contours: [][]int16{{627, 991, 1, 479, 991, 0, 307, 760, 0, 307, 559, 1, 307, 358, 0, 478, 127, 0, 627, 127, 1, 774, 127, 0, 946, 359, 0, 946, 559, 1, 946, 758, 0, 774, 991, 0}, {627, 1147, 1, 867, 1147, 0, 1141, 835, 0, 1141, 559, 1, 1141, 284, 0, 867, -29, 0, 627, -29, 1, 386, -29, 0, 113, 284, 0, 113, 559, 1, 113, 835, 0, 386, 1147, 0}}},
+		'p':      {advance: 1300, contours: [][]int16{{371, 168, 1, 371, -426, 1, 186, -426, 1, 186, 1120, 1, 371, 1120, 1, 371, 950, 1, 429, 1050, 0, 606, 1147, 0, 729, 1147, 1, 933, 1147, 0, 1188, 823, 0, 1188, 559, 1, 1188, 295, 0, 933, -29, 0, 729, -29, 1, 606, -29, 0, 429, 68, 0}, {997, 559, 1, 997, 762, 0, 830, 993, 0, 684, 993, 1, 538, 993, 0, 371, 762, 0, 371, 559, 1, 371, 356, 0, 538, 125, 0, 684, 125, 1, 830, 125, 0, 997, 356, 0}}},
+		'q':      {advance: 1300, contours: [][]int16{{303, 559, 1, 303, 356, 0, 470, 125, 0, 616, 125, 1, 762, 125, 0, 930, 356, 0, 930, 559, 1, 930, 762, 0, 762, 993, 0, 616, 993, 1, 470, 993, 0, 303, 762, 0}, {930, 168, 1, 872, 68, 0, 695, -29, 0, 571, -29, 1, 368, -29, 0, 113, 295, 0, 113, 559, 1, 113, 823, 0, 368, 1147, 0, 571, 1147, 1, 695, 1147, 0, 872, 1050, 0, 930, 950, 1, 930, 1120, 1, 1114, 1120, 1, 1114, -426, 1, 930, -426, 1}}},
+		'r':      {advance: 842, contours: [][]int16{{842, 948, 1, 811, 966, 0, 738, 983, 0, 694, 983, 1, 538, 983, 0, 371, 780, 0, 371, 590, 1, 371, 0, 1, 186, 0, 1, 186, 1120, 1, 371, 1120, 1, 371, 946, 1, 429, 1048, 0, 615, 1147, 0, 748, 1147, 1, 767, 1147, 0, 813, 1142, 0, 841, 1137, 1}}},
+		's':      {advance: 1067, contours: [][]int16{{907, 1087, 1, 907, 913, 1, 829, 953, 0, 661, 993, 0, 571, 993, 1, 434, 993, 0, 297, 909, 0, 297, 825, 1, 297, 761, 0, 395, 688, 0, 543, 655, 1, 606, 641, 1, 802, 599, 0, 967, 446, 0, 967, 309, 1, 967, 153, 0, 720, -29, 0, 504, -29, 1, 414, -29, 0, 219, 6, 0, 111, 41, 1, 111, 231, 1, 213, 178, 0, 411, 125, 0, 508, 125, 1, 638, 125, 0, 778, 214, 0, 778, 295, 1, 778, 370, 0, 677, 450, 0, 506, 487, 1, 442, 502, 1, 271, 538, 0, 119, 687, 0, 119, 817, 1, 119, 975, 0, 343, 1147, 0, 549, 1147, 1, 651, 1147, 0, 831, 1117, 0}}},
+		't':      {advance: 803, contours: [][]int16{{375, 1438, 1, 375, 1120, 1, 754, 1120, 1, 754, 977, 1, 375, 977, 1, 375, 369, 1, 375, 232, 0, 450, 154, 0, 565, 154, 1, 754, 154, 1, 754, 0, 1, 565, 0, 1, 352, 0, 0, 190, 159, 0, 190, 369, 1, 190, 977, 1, 55, 977, 1, 55, 1120, 1, 190, 1120, 1, 190, 1438, 1}}},
+		'u':      {advance: 1298, contours: [][]int16{{174, 442, 1, 174, 1120, 1, 358, 1120, 1, 358, 449, 1, 358, 290, 0, 482, 131, 0, 606, 131, 1, 755, 131, 0, 928, 321, 0, 928, 485, 1, 928, 1120, 1, 1112, 1120, 1, 1112, 0, 1, 928, 0, 1, 928, 172, 1, 861, 70, 0, 684, -29, 0, 567, -29, 1, 374, -29, 0, 174, 211, 0}, {637, 1147, 1}}},
+		'v':      {advance: 1212, contours: [][]int16{{61, 1120, 1, 256, 1120, 1, 606, 180, 1, 956, 1120, 1, 1151, 1120, 1, 731, 0, 1, 481, 0, 1}}},
+		'w':      {advance: 1675, contours: [][]int16{{86, 1120, 1, 270, 1120, 1, 500, 246, 1, 729, 1120, 1, 946, 1120, 1, 1176, 246, 1, 1405, 1120, 1, 1589, 1120, 1, 1296, 0, 1, 1079, 0, 1, 838, 918, 1, 596, 0, 1, 379, 0, 1}}},
+		'x':      {advance: 1212, contours: [][]int16{{1124, 1120, 1, 719, 575, 1, 1145, 0, 1, 928, 0, 1, 602, 440, 1, 276, 0, 1, 59, 0, 1, 494, 586, 1, 96, 1120, 1, 313, 1120, 1, 610, 721, 1, 907, 1120, 1}}},
+		'y':      {advance: 1212, contours: [][]int16{{659, -104, 1, 581, -304, 0, 433, -426, 0, 309, -426, 1, 162, -426, 1, 162, -272, 1, 270, -272, 1, 346, -272, 0, 430, -200, 0, 481, -66, 1, 514, 18, 1, 61, 1120, 1, 256, 1120, 1, 606, 244, 1, 956, 1120, 1, 1151, 1120, 1}}},
+		'z':      {advance: 1075, contours: [][]int16{{113, 1120, 1, 987, 1120, 1, 987, 952, 1, 295, 147, 1, 987, 147, 1, 987, 0, 1, 88, 0, 1, 88, 168, 1, 780, 973, 1, 113, 973, 1}}},
+		'{':      {advance: 1303, contours: [][]int16{{1047, -190, 1, 1047, -334, 1, 985, -334, 1, 736, -334, 0, 567, -186, 0, 567, 35, 1, 567, 274, 1, 567, 425, 0, 459, 541, 0, 317, 541, 1, 256, 541, 1, 256, 684, 1, 317, 684, 1, 460, 684, 0, 567, 799, 0, 567, 948, 1, 567, 1188, 1, 567, 1409, 0, 736, 1556, 0, 985, 1556, 1, 1047, 1556, 1, 1047, 1413, 1, 979, 1413, 1, 838, 1413, 0, 752, 1325, 0, 752, 1184, 1, 752, 936, 1, 752, 779, 0, 661, 637, 0, 551, 612, 1, 662, 585, 0, 752, 443, 0, 752, 287, 1, 752, 39, 1, 752, -102, 0, 838, -190, 0, 979, -190, 1}}},
+		'|':      {advance: 690, contours: [][]int16{{430, 1565, 1, 430, -483, 1, 260, -483, 1, 260, 1565, 1}}},
+		'}':      {advance: 1303, contours: [][]int16{{256, -190, 1, 326, -190, 1, 466, -190, 0, 551, -104, 0, 551, 39, 1, 551, 287, 1, 551, 443, 0, 641, 585, 0, 752, 612, 1, 641, 637, 0, 551, 779, 0, 551, 936, 1, 551, 1184, 1, 551, 1326, 0, 466, 1413, 0, 326, 1413, 1, 256, 1413, 1, 256, 1556, 1, 319, 1556, 1, 568, 1556, 0, 735, 1409, 0, 735, 1188, 1, 735, 948, 1, 735, 799, 0, 843, 684, 0, 985, 684, 1, 1047, 684, 1, 1047, 541, 1, 985, 541, 1, 843, 541, 0, 735, 425, 0, 735, 274, 1, 735, 35, 1, 735, -186, 0, 568, -334, 0, 319, -334, 1, 256, -334, 1}}},
+		'~':      {advance: 1716, contours: [][]int16{{1499, 817, 1, 1499, 639, 1, 1394, 560, 0, 1215, 492, 0, 1118, 492, 1, 1008, 492, 0, 862, 551, 1, 851, 555, 0, 846, 557, 1, 839, 560, 0, 824, 565, 1, 669, 627, 0, 575, 627, 1, 487, 627, 0, 315, 550, 0, 217, 467, 1, 217, 645, 1, 322, 724, 0, 501, 793, 0, 598, 793, 1, 708, 793, 0, 855, 733, 1, 865, 729, 0, 870, 727, 1, 878, 724, 0, 892, 719, 1, 1047, 657, 0, 1141, 657, 1, 1227, 657, 0, 1396, 733, 0}}},
+		'\u00a0': {advance: 651},
+		'¡':      {advance: 821, contours: [][]int16{{512, 866, 1, 309, 866, 1, 309, 1120, 1, 512, 1120, 1}, {512, -373, 1, 309, -373, 1, 309, 282, 1, 330, 639, 1, 492, 639, 1, 512, 282, 1}}},
+		'¢':      {advance: 1303, contours: [][]int16{{678, 131, 1, 678, 987, 1, 531, 969, 0, 367, 743, 0, 367, 559, 1, 367, 374, 0, 531, 148, 0}, {1059, 1077, 1, 1059, 905, 1, 985, 946, 0, 849, 988, 0, 781, 991, 1, 780, 127, 1, 850, 132, 0, 987, 174, 0, 1059, 213, 1, 1059, 43, 1, 994, 13, 0, 857, -22, 0, 780, -29, 1, 780, -313, 1, 678, -313, 1, 678, -25, 1, 437, -5, 0, 172, 302, 0, 172, 559, 1, 172, 817, 0, 437, 1123, 0, 678, 1145, 1, 678, 1432, 1, 780, 1432, 1, 781, 1145, 1, 854, 1141, 0, 991, 1108, 0}}},
+		'£':      {advance: 1303, contours: [][]int16{{1102, 1460, 1, 1102, 1278, 1, 1026, 1319, 0, 890, 1360, 0, 829, 1360, 1, 681, 1360, 0, 565, 1205, 0, 565, 993, 1, 565, 778, 1, 956, 778, 1, 956, 635, 1, 565, 635, 1, 565, 170, 1, 1122, 170, 1, 1122, 0, 1, 129, 0, 1, 129, 170, 1, 365, 170, 1, 365, 635, 1, 166, 635, 1, 166, 778, 1, 365, 778, 1, 365, 1016, 1, 365, 1277, 0, 579, 1520, 0, 811, 1520, 1, 872, 1520, 0, 1023, 1489, 0}}},
+		'¤':      {advance: 1303, contours: [][]int16{{891, 993, 1, 1098, 1202, 1, 1212, 1087, 1, 1006, 881, 1, 1043, 822, 0, 1079, 703, 0, 1079, 641, 1, 1079, 578, 0, 1041, 463, 0, 1001, 406, 1, 1210, 199, 1, 1096, 86, 1, 889, 293, 1, 830, 253, 0, 714, 215, 0, 653, 215, 1, 595, 215, 0, 475, 252, 0, 414, 289, 1, 207, 82, 1, 94, 197, 1, 301, 403, 1, 264, 465, 0, 227, 583, 0, 227, 641, 1, 227, 705, 0, 265, 821, 0, 303, 877, 1, 96, 1083, 1, 211, 1198, 1, 418, 991, 1, 473, 1030, 0, 589, 1067, 0, 653, 1067, 1, 713, 1067, 0, 830, 1031, 0}, {922, 643, 1, 922, 755, 0, 767, 909, 0, 653, 909, 1, 541, 909, 0, 383, 755, 0, 383, 643, 1, 383, 529, 0, 540, 373, 0, 653, 373, 1, 766, 373, 0, 922, 530, 0}}},
+		'¥':      {advance: 1303, contours: [][]int16{{1165, 455, 1, 752, 455, 1, 752, 0, 1, 551, 0, 1, 551, 455, 1, 135, 455, 1, 135, 578, 1, 551, 578, 1, 551, 629, 1, 467, 784, 1, 135, 784, 1, 135, 907, 1, 399, 907, 1, 82, 1493, 1, 272, 1493, 1, 651, 793, 1, 1028, 1493, 1, 1219, 1493, 1, 901, 907, 1, 1165, 907, 1, 1165, 784, 1, 834, 784, 1, 750, 629, 1, 750, 578, 1, 1165, 578, 1}}},
+		'¦':      {advance: 690, contours: [][]int16{{430, 408, 1, 430, -350, 1, 260, -350, 1, 260, 408, 1}, {430, 1432, 1, 430, 674, 1, 260, 674, 1, 260, 1432, 1}}},
+		'§':      {advance: 1024, contours: [][]int16{{379, 936, 1, 316, 890, 0, 254, 800, 0, 254, 754, 1, 254, 678, 0, 393, 545, 0, 643, 410, 1, 706, 455, 0, 768, 546, 0, 768, 592, 1, 768, 667, 0, 625, 803, 0}, {829, 1462, 1, 829, 1298, 1, 746, 1337, 0, 603, 1376, 0, 547, 1376, 1, 450, 1376, 0, 342, 1296, 0, 342, 1225, 1, 342, 1135, 0, 548, 1020, 1, 574, 1005, 0, 588, 997, 1, 799, 878, 0, 930, 724, 0, 930, 623, 1, 930, 533, 0, 838, 393, 0, 745, 340, 1, 807, 288, 0, 864, 179, 0, 864, 115, 1, 864, -27, 0, 660, -195, 0, 487, -195, 1, 414, -195, 0, 260, -166, 0, 172, -137, 1, 172, 27, 1, 259, -12, 0, 407, -51, 0, 465, -51, 1, 567, -51, 0, 680, 33, 0, 680, 109, 1, 680, 211, 0, 459, 334, 1, 434, 348, 1, 220, 468, 0, 92, 621, 0, 92, 723, 1, 92, 814, 0, 185, 957, 0, 276, 1006, 1, 217, 1050, 0, 158, 1162, 0, 158, 1231, 1, 158, 1361, 0, 358, 1520, 0, 524, 1520, 1, 597, 1520, 0, 750, 1491, 0}}},
+		'¨':      {advance: 1024, contours: [][]int16{{606, 1552, 1, 809, 1552, 1, 809, 1350, 1, 606, 1350, 1}, {215, 1552, 1, 418, 1552, 1, 418, 1350, 1, 215, 1350, 1}}},
+		'©':      {advance: 2048, contours: [][]int16{{1024, 1485, 1, 1176, 1485, 0, 1439, 1375, 0, 1548, 1266, 1, 1657, 1157, 0, 1765, 895, 0, 1765, 741, 1, 1765, 589, 0, 1657, 328, 0, 1548, 219, 1, 1439, 110, 0, 1176, 0, 0, 1024, 0, 1, 872, 0, 0, 609, 110, 0, 500, 219, 1, 391, 328, 0, 283, 589, 0, 283, 741, 1, 283, 895, 0, 391, 1157, 0, 500, 1266, 1, 609, 1375, 0, 872, 1485, 0}, {1024, 1382, 1, 893, 1382, 0, 667, 1288, 0, 573, 1194, 1, 479, 1100, 0, 383, 871, 0, 383, 741, 1, 383, 612, 0, 479, 385, 0, 573, 291, 1, 667, 197, 0, 893, 102, 0, 1024, 102, 1, 1156, 102, 0, 1383, 197, 0, 1477, 291, 1, 1570, 384, 0, 1663, 610, 0, 1663, 741, 1, 1663, 874, 0, 1569, 1101, 0, 1477, 1194, 1, 1383, 1288, 0, 1156, 1382, 0}, {1323, 1137, 1, 1323, 1008, 1, 1257, 1041, 0, 1127, 1073, 0, 1061, 1073, 1, 912, 1073, 0, 745, 898, 0, 745, 741, 1, 745, 582, 0, 916, 408, 0, 1071, 408, 1, 1135, 408, 0, 1257, 439, 0, 1323, 473, 1, 1323, 346, 1, 1256, 317, 0, 1119, 289, 0, 1049, 289, 1, 833, 289, 0, 582, 533, 0, 582, 741, 1, 582, 950, 0, 833, 1192, 0, 1049, 1192, 1, 1122, 1192, 0, 1258, 1164, 0}}},
+		'ª':      {advance: 965, contours: [][]int16{{139, 592, 1, 827, 592, 1, 827, 469, 1, 139, 469, 1}, {825, 1165, 1, 825, 717, 1, 676, 717, 1, 676, 829, 1, 632, 766, 0, 488, 698, 0, 395, 698, 1, 267, 698, 0, 115, 833, 0, 115, 946, 1, 115, 1081, 0, 306, 1219, 0, 494, 1219, 1, 676, 1219, 1, 676, 1223, 1, 676, 1314, 0, 559, 1405, 0, 442, 1405, 1, 380, 1405, 0, 244, 1371, 0, 176, 1337, 1, 176, 1464, 1, 249, 1492, 0, 394, 1520, 0, 463, 1520, 1, 646, 1520, 0, 825, 1344, 0}, {549, 1104, 1, 388, 1104, 0, 262, 1037, 0, 262, 958, 1, 262, 894, 0, 360, 817, 0, 442, 817, 1, 546, 817, 0, 676, 961, 0, 676, 1075, 1, 676, 1104, 1}}},
+		'«':      {advance: 1253, contours: [][]int16{{1061, 1059, 1, 1061, 868, 1, 760, 600, 1, 1061, 332, 1, 1061, 141, 1, 592, 559, 1, 592, 641, 1}, {627, 1059, 1, 627, 868, 1, 326, 600, 1, 627, 332, 1, 627, 141, 1, 158, 559, 1, 158, 641, 1}}},
+		'¬':      {advance: 1716, contours: [][]int16{{217, 862, 1, 1499, 862, 1, 1499, 287, 1, 1331, 287, 1, 1331, 692, 1, 217, 692, 1}}},
+		'\u00ad': {advance: 739, contours: [][]int16{{100, 643, 1, 639, 643, 1, 639, 479, 1, 100, 479, 1}}},
+		'®':      {advance: 2048, contours: [][]int16{{1024, 1382, 1, 893, 1382, 0, 667, 1288, 0, 573, 1194, 1, 479, 1100, 0, 383, 871, 0, 383, 741, 1, 383, 612, 0, 479, 385, 0, 573, 291, 1, 667, 197, 0, 893, 102, 0, 1024, 102, 1, 1156, 102, 0, 1383, 197, 0, 1477, 291, 1, 1570, 384, 0, 1663, 610, 0, 1663, 741, 1, 1663, 874, 0, 1569, 1101, 0, 1477, 1194, 1, 1383, 1288, 0, 1156, 1382, 0}, {1024, 1485, 1, 1176, 1485, 0, 1439, 1375, 0, 1548, 1266, 1, 1657, 1157, 0, 1765, 895, 0, 1765, 741, 1, 1765, 589, 0, 1657, 328, 0, 1548, 219, 1, 1439, 110, 0, 1176, 0, 0, 1024, 0, 1, 872, 0, 0, 609, 110, 0, 500, 219, 1, 391, 328, 0, 283, 589, 0, 283, 741, 1, 283, 895, 0, 391, 1157, 0, 500, 1266, 1, 609, 1375, 0, 872, 1485, 0}, {997, 1071, 1, 874, 1071, 1, 874, 795, 1, 997, 795, 1, 1107, 795, 0, 1194, 857, 0, 1194, 932, 1, 1194, 1008, 0, 1106, 1071, 0}, {1004, 1174, 1, 1180, 1174, 0, 1354, 1055, 0, 1354, 934, 1, 1354, 848, 0, 1249, 736, 0, 1153, 719, 1, 1177, 711, 0, 1244, 634, 0, 1290, 561, 1, 1427, 338, 1, 1255, 338, 1, 1126, 547, 1, 1067, 643, 0, 994, 694, 0, 940, 694, 1, 874, 694, 1, 874, 338, 1, 719, 338, 1, 719, 1174, 1}}},
+		'¯':      {advance: 1024, contours: [][]int16{{213, 1526, 1, 811, 1526, 1, 811, 1378, 1, 213, 1378, 1}}},
+		'°':      {advance: 1024, contours: [][]int16{{512, 1391, 1, 432, 1391, 0, 322, 1280, 0, 322, 1200, 1, 322, 1121, 0, 432, 1012, 0, 512, 1012, 1, 592, 1012, 0, 702, 1121, 0, 702, 1200, 1, 702, 1279, 0, 591, 1391, 0}, {512, 1520, 1, 576, 1520, 0, 694, 1471, 0, 737, 1425, 1, 783, 1380, 0, 829, 1266, 0, 829, 1200, 1, 829, 1068, 0, 644, 885, 0, 510, 885, 1, 375, 885, 0, 195, 1065, 0, 195, 1200, 1, 195, 1334, 0, 379, 1520, 0}}},
+		'±':      {advance: 1716, contours: [][]int16{{942, 1284, 1, 942, 897, 1, 1499, 897, 1, 1499, 727, 1, 942, 727, 1, 942, 340, 1, 774, 340, 1, 774, 727, 1, 217, 727, 1, 217, 897, 1, 774, 897, 1, 774, 1284, 1}, {217, 170, 1, 1499, 170, 1, 1499, 0, 1, 217, 0, 1}}},
+		'²':      {advance: 821, contours: [][]int16{{268, 782, 1, 692, 782, 1, 692, 668, 1, 94, 668, 1, 94, 778, 1, 128, 809, 0, 191, 865, 1, 535, 1170, 0, 535, 1264, 1, 535, 1330, 0, 431, 1411, 0, 346, 1411, 1, 294, 1411, 0, 172, 1376, 0, 100, 1341, 1, 100, 1464, 1, 177, 1492, 0, 310, 1520, 0, 367, 1520, 1, 512, 1520, 0, 686, 1388, 0, 686, 1280, 1, 686, 1141, 0, 355, 857, 1, 299, 809, 0}}},
+		'³':      {advance: 821, contours: [][]int16{{524, 1120, 1, 616, 1102, 0, 717, 993, 0, 717, 911, 1, 717, 787, 0, 527, 653, 0, 350, 653, 1, 293, 653, 0, 168, 674, 0, 98, 694, 1, 98, 815, 1, 150, 788, 0, 269, 762, 0, 336, 762, 1, 445, 762, 0, 565, 841, 0, 565, 911, 1, 565, 985, 0, 454, 1061, 0, 346, 1061, 1, 260, 1061, 1, 260, 1169, 1, 354, 1169, 1, 448, 1169, 0, 545, 1232, 0, 545, 1292, 1, 545, 1350, 0, 445, 1411, 0, 350, 1411, 1, 310, 1411, 0, 208, 1393, 0, 127, 1370, 1, 127, 1485, 1, 200, 1502, 0, 328, 1520, 0, 383, 1520, 1, 527, 1520, 0, 696, 1402, 0, 696, 1303, 1, 696, 1234, 0, 606, 1138, 0}}},
+		'´':      {advance: 1024, contours: [][]int16{{651, 1638, 1, 850, 1638, 1, 524, 1262, 1, 371, 1262, 1}}},
+		'µ':      {advance: 1303, contours: [][]int16{{174, -426, 1, 174, 1120, 1, 358, 1120, 1, 358, 424, 1, 358, 279, 0, 496, 131, 0, 631, 131, 1, 779, 131, 0, 928, 299, 0, 928, 467, 1, 928, 1120, 1, 1112, 1120, 1, 1112, 258, 1, 1112, 198, 0, 1147, 141, 0, 1184, 141, 1, 1193, 141, 0, 1225, 152, 0, 1253, 164, 1, 1253, 16, 1, 1212, -7, 0, 1139, -29, 0, 1104, -29, 1, 1035, -29, 0, 953, 49, 0, 938, 129, 1, 888, 50, 0, 743, -29, 0, 645, -29, 1, 543, -29, 0, 400, 49, 0, 358, 127, 1, 358, -426, 1}}},
+		'¶':      {advance: 1303, contours: [][]int16{{633, 1493, 1, 1081, 1493, 1, 1081, -197, 1, 940, -197, 1, 940, 1370, 1, 750, 1370, 1, 750, -197, 1, 608, -197, 1, 608, 649, 1, 393, 666, 0, 158, 887, 0, 158, 1071, 1, 158, 1261, 0, 418, 1493, 0}}},
+		'·':      {advance: 651, contours: [][]int16{{219, 838, 1, 430, 838, 1, 430, 584, 1, 219, 584, 1}}},
+		'¸':      {advance: 1024, contours: [][]int16{{596, 0, 1, 651, -62, 0, 705, -167, 0, 705, -215, 1, 705, -304, 0, 585, -395, 0, 467, -395, 1, 421, -395, 0, 334, -383, 0, 291, -371, 1, 291, -240, 1, 325, -257, 0, 399, -272, 0, 446, -272, 1, 505, -272, 0, 565, -224, 0, 565, -178, 1, 565, -148, 0, 522, -61, 0, 477, 0, 1}}},
+		'¹':      {advance: 821, contours: [][]int16{{156, 778, 1, 360, 778, 1, 360, 1389, 1, 137, 1348, 1, 137, 1464, 1, 367, 1503, 1, 504, 1503, 1, 504, 778, 1, 709, 778, 1, 709, 668, 1, 156, 668, 1}}},
+		'º':      {advance: 965, contours: [][]int16{{139, 592, 1, 827, 592, 1, 827, 469, 1, 139, 469, 1}, {483, 1520, 1, 662, 1520, 0, 868, 1299, 0, 868, 1108, 1, 868, 917, 0, 662, 698, 0, 483, 698, 1, 304, 698, 0, 96, 918, 0, 96, 1108, 1, 96, 1299, 0, 304, 1520, 0}, {483, 1405, 1, 378, 1405, 0, 252, 1244, 0, 252, 1108, 1, 252, 975, 0, 379, 815, 0, 483, 815, 1, 588, 815, 0, 713, 975, 0, 713, 1108, 1, 713, 1245, 0, 589, 1405, 0}}},
+		'»':      {advance: 1253, contours: [][]int16{{193, 1059, 1, 662, 641, 1, 662, 559, 1, 193, 141, 1, 193, 332, 1, 494, 600, 1, 193, 868, 1}, {627, 1059, 1, 1096, 641, 1, 1096, 559, 1, 627, 141, 1, 627, 332, 1, 928, 600, 1, 627, 868, 1}}},
+		'¼':      {advance: 1985, contours: [][]int16{{156, 778, 1, 360, 778, 1, 360, 1389, 1, 137, 1348, 1, 137, 1464, 1, 367, 1503, 1, 504, 1503, 1, 504, 778, 1, 709, 778, 1, 709, 668, 1, 156, 668, 1}, {1640, 714, 1, 1331, 295, 1, 1640, 295, 1}, {1618, 835, 1, 1784, 835, 1, 1784, 295, 1, 1919, 295, 1, 1919, 186, 1, 1784, 186, 1, 1784, 0, 1, 1640, 0, 1, 1640, 186, 1, 1226, 186, 1, 1226, 307, 1}, {1378, 1520, 1, 1538, 1520, 1, 606, -29, 1, 446, -29, 1}}},
+		'½':      {advance: 1985, contours: [][]int16{{156, 778, 1, 360, 778, 1, 360, 1389, 1, 137, 1348, 1, 137, 1464, 1, 367, 1503, 1, 504, 1503, 1, 504, 778, 1, 709, 778, 1, 709, 668, 1, 156, 668, 1}, {1431, 114, 1, 1855, 114, 1, 1855, 0, 1, 1257, 0, 1, 1257, 110, 1, 1291, 141, 0, 1354, 197, 1, 1698, 502, 0, 1698, 596, 1, 1698, 662, 0, 1594, 743, 0, 1509, 743, 1, 1457, 743, 0, 1335, 708, 0, 1263, 673, 1, 1263, 796, 1, 1340, 824, 0, 1473, 852, 0, 1530, 852, 1, 1675, 852, 0, 1849, 720, 0, 1849, 612, 1, 1849, 473, 0, 1518, 189, 1, 1462, 141, 0}, {1378, 1520, 1, 1538, 1520, 1, 606, -29, 1, 446, -29, 1}}},
+		'¾':      {advance: 1985, contours: [][]int16{{524, 1120, 1, 616, 1102, 0, 717, 993, 0, 717, 911, 1, 717, 787, 0, 527, 653, 0, 350, 653, 1, 293, 653, 0, 168, 674, 0, 98, 694, 1, 98, 815, 1, 150, 788, 0, 269, 762, 0, 336, 762, 1, 445, 762, 0, 565, 841, 0, 565, 911, 1, 565, 985, 0, 454, 1061, 0, 346, 1061, 1, 260, 1061, 1, 260, 1169, 1, 354, 1169, 1, 448, 1169, 0, 545, 1232, 0, 545, 1292, 1, 545, 1350, 0, 445, 1411, 0, 350, 1411, 1, 310, 1411, 0, 208, 1393, 0, 127, 1370, 1, 127, 1485, 1, 200, 1502, 0, 328, 1520, 0, 383, 1520, 1, 527, 1520, 0, 696, 1402, 0, 696, 1303, 1, 696, 1234, 0, 606, 1138, 0}, {1640, 714, 1, 1331, 295, 1, 1640, 295, 1}, {1618, 835, 1, 1784, 835, 1, 1784, 295, 1, 1919, 295, 1, 1919, 186, 1, 1784, 186, 1, 1784, 0, 1, 1640, 0, 1, 1640, 186, 1, 1226, 186, 1, 1226, 307, 1}, {1378, 1520, 1, 1538, 1520, 1, 606, -29, 1, 446, -29, 1}}},
+		'¿':      {advance: 1087, contours: [][]int16{{500, 719, 1, 690, 719, 1, 690, 563, 1, 690, 462, 0, 635, 332, 0, 545, 245, 1, 455, 157, 1, 397, 104, 0, 346, 10, 0, 346, -39, 1, 346, -128, 0, 477, -238, 0, 586, -238, 1, 664, -238, 0, 844, -168, 0, 940, -101, 1, 940, -289, 1, 846, -346, 0, 654, -402, 0, 551, -402, 1, 367, -402, 0, 143, -208, 0, 143, -49, 1, 143, 27, 0, 216, 164, 0, 305, 250, 1, 393, 336, 1, 441, 383, 0, 479, 436, 0, 487, 461, 1, 494, 482, 0, 500, 542, 0, 500, 596, 1}, {696, 866, 1, 494, 866, 1, 494, 1120, 1, 696, 1120, 1}}},
+		'À':      {advance: 1401, contours: [][]int16{{700, 1294, 1, 426, 551, 1, 975, 551, 1}, {586, 1493, 1, 815, 1493, 1, 1384, 0, 1, 1174, 0, 1, 1038, 383, 1, 365, 383, 1, 229, 0, 1, 16, 0, 1}, {643, 1899, 1, 839, 1635, 1, 686, 1635, 1, 456, 1899, 1}}},
+		'Á':      {advance: 1401, contours: [][]int16{{700, 1294, 1, 426, 551, 1, 975, 551, 1}, {586, 1493, 1, 815, 1493, 1, 1384, 0, 1, 1174, 0, 1, 1038, 383, 1, 365, 383, 1, 229, 0, 1, 16, 0, 1}, {755, 1899, 1, 940, 1899, 1, 712, 1635, 1, 559, 1635, 1}}},
+		'Â':      {advance: 1401, contours: [][]int16{{700, 1294, 1, 426, 551, 1, 975, 551, 1}, {586, 1493, 1, 815, 1493, 1, 1384, 0, 1, 1174, 0, 1, 1038, 383, 1, 365, 383, 1, 229, 0, 1, 16, 0, 1}, {606, 1901, 1, 794, 1901, 1, 1005, 1635, 1, 866, 1635, 1, 700, 1813, 1, 534, 1635, 1, 395, 1635, 1}}},
+		'Ã':      {advance: 1401, contours: [][]int16{{700, 1294, 1, 426, 551, 1, 975, 551, 1}, {586, 1493, 1, 815, 1493, 1, 1384, 0, 1, 1174, 0, 1, 1038, 383, 1, 365, 383, 1, 229, 0, 1, 16, 0, 1}, {696, 1710, 1, 639, 1743, 1, 614, 1757, 0, 583, 1768, 0, 571, 1768, 1, 535, 1768, 0, 495, 1718, 0, 495, 1673, 1, 495, 1667, 1, 370, 1667, 1, 370, 1768, 0, 473, 1886, 0, 559, 1886, 1, 595, 1886, 0, 656, 1870, 0, 704, 1843, 1, 761, 1813, 1, 784, 1800, 0, 818, 1788, 0, 833, 1788, 1, 865, 1788, 0, 905, 1839, 0, 905, 1880, 1, 905, 1886, 1, 1030, 1886, 1, 1028, 1786, 0, 925, 1667, 0, 841, 1667, 1, 807, 1667, 0, 748, 1683, 0}}},
+		'Ä':      {advance: 1401, contours: [][]int16{{700, 1294, 1, 426, 551, 1, 975, 551, 1}, {586, 1493, 1, 815, 1493, 1, 1384, 0, 1, 1174, 0, 1, 1038, 383, 1, 365, 383, 1, 229, 0, 1, 16, 0, 1}, {794, 1870, 1, 997, 1870, 1, 997, 1667, 1, 794, 1667, 1}, {403, 1870, 1, 606, 1870, 1, 606, 1667, 1, 403, 1667, 1}}},
+		'Å':      {advance: 1401, contours: [][]int16{{852, 1626, 1, 852, 1689, 0, 763, 1778, 0, 700, 1778, 1, 636, 1778, 0, 549, 1691, 0, 549, 1626, 1, 549, 1563, 0, 637, 1475, 0, 700, 1475, 1, 763, 1475, 0, 852, 1563, 0}, {700, 1294, 1, 428, 551, 1, 973, 551, 1}, {549, 1397, 1, 488, 1438, 0, 426, 1553, 0, 426, 1626, 1, 426, 1741, 0, 585, 1901, 0, 700, 1901, 1, 814, 1901, 0, 975, 1740, 0, 975, 1626, 1, 975, 1556, 0, 912, 1438, 0, 852, 1397, 1, 1384, 0, 1, 1174, 0, 1, 1038, 383, 1, 365, 383, 1, 229, 0, 1, 16, 0, 1}}},
+		'Æ':      {advance: 1995, contours: [][]int16{{1845, 1493, 1, 1845, 1323, 1, 1104, 1323, 1, 1104, 881, 1, 1815, 881, 1, 1815, 711, 1, 1104, 711, 1, 1104, 170, 1, 1864, 170, 1, 1864, 0, 1, 901, 0, 1, 901, 383, 1, 373, 383, 1, 213, 0, 1, 8, 0, 1, 633, 1493, 1}, {772, 1335, 1, 442, 551, 1, 901, 551, 1, 901, 1335, 1}}},
+		'Ç':      {advance: 1430, contours: [][]int16{{1319, 1378, 1, 1319, 1165, 1, 1217, 1260, 0, 986, 1354, 0, 856, 1354, 1, 600, 1354, 0, 328, 1041, 0, 328, 745, 1, 328, 450, 0, 600, 137, 0, 856, 137, 1, 986, 137, 0, 1217, 231, 0, 1319, 326, 1, 1319, 115, 1, 1213, 43, 0, 976, -29, 0, 844, -29, 1, 505, -29, 0, 115, 386, 0, 115, 745, 1, 115, 1105, 0, 505, 1520, 0, 844, 1520, 1, 978, 1520, 0, 1215, 1449, 0}, {897, 0, 1, 952, -62, 0, 1006, -167, 0, 1006, -215, 1, 1006, -304, 0, 886, -395, 0, 768, -395, 1, 722, -395, 0, 635, -383, 0, 592, -371, 1, 592, -240, 1, 626, -257, 0, 700, -272, 0, 747, -272, 1, 806, -272, 0, 866, -224, 0, 866, -178, 1, 866, -148, 0, 823, -61, 0, 778, 0, 1}}},
+		'È':      {advance: 1294, contours: [][]int16{{201, 1493, 1, 1145, 1493, 1, 1145, 1323, 1, 403, 1323, 1, 403, 881, 1, 1114, 881, 1, 1114, 711, 1, 403, 711, 1, 403, 170, 1, 1163, 170, 1, 1163, 0, 1, 201, 0, 1}, {613, 1899, 1, 809, 1635, 1, 656, 1635, 1, 426, 1899, 1}}},
+		'É':      {advance: 1294, contours: [][]int16{{201, 1493, 1, 1145, 1493, 1, 1145, 1323, 1, 403, 1323, 1, 403, 881, 1, 1114, 881, 1, 1114, 711, 1, 403, 711, 1, 403, 170, 1, 1163, 170, 1, 1163, 0, 1, 201, 0, 1}, {725, 1899, 1, 910, 1899, 1, 682, 1635, 1, 529, 1635, 1}}},
+		'Ê':      {advance: 1294, contours: [][]int16{{201, 1493, 1, 1145, 1493, 1, 1145, 1323, 1, 403, 1323, 1, 403, 881, 1, 1114, 881, 1, 1114, 711, 1, 403, 711, 1, 403, 170, 1, 1163, 170, 1, 1163, 0, 1, 201, 0, 1}, {576, 1901, 1, 764, 1901, 1, 975, 1635, 1, 836, 1635, 1, 670, 1813, 1, 504, 1635, 1, 365, 1635, 1}}},
+		'Ë':      {advance: 1294, contours: [][]int16{{201, 1493, 1, 1145, 1493, 1, 1145, 1323, 1, 403, 1323, 1, 403, 881, 1, 1114, 881, 1, 1114, 711, 1, 403, 711, 1, 403, 170, 1, 1163, 170, 1, 1163, 0, 1, 201, 0, 1}, {764, 1870, 1, 967, 1870, 1, 967, 1667, 1, 764, 1667, 1}, {373, 1870, 1, 576, 1870, 1, 576, 1667, 1, 373, 1667, 1}}},
+		'Ì':      {advance: 604, contours: [][]int16{{201, 1493, 1, 403, 1493, 1, 403, 0, 1, 201, 0, 1}, {246, 1899, 1, 442, 1635, 1, 289, 1635, 1, 59, 1899, 1}}},
+		'Í':      {advance: 604, contours: [][]int16{{201, 1493, 1, 403, 1493, 1, 403, 0, 1, 201, 0, 1}, {358, 1899, 1, 543, 1899, 1, 315, 1635, 1, 162, 1635, 1}}},
+		'Î':      {advance: 604, contours: [][]int16{{201, 1493, 1, 403, 1493, 1, 403, 0, 1, 201, 0, 1}, {209, 1901, 1, 397, 1901, 1, 608, 1635, 1, 469, 1635, 1, 303, 1813, 1, 137, 1635, 1, -2, 1635, 1}}},
+		'Ï':      {advance: 604, contours: [][]int16{{201, 1493, 1, 403, 1493, 1, 403, 0, 1, 201, 0, 1}, {397, 1870, 1, 600, 1870, 1, 600, 1667, 1, 397, 1667, 1}, {6, 1870, 1, 209, 1870, 1, 209, 1667, 1, 6, 1667, 1}}},
+		'Ð':      {advance: 1587, contours: [][]int16{{211, 1493, 1, 627, 1493, 1, 1060, 1493, 0, 1466, 1132, 0, 1466, 748, 1, 1466, 362, 0, 1059, 0, 0, 627, 0, 1, 211, 0, 1, 211, 700, 1, 10, 700, 1, 10, 844, 1, 211, 844, 1}, {414, 1327, 1, 414, 844, 1, 750, 844, 1, 750, 700, 1, 414, 700, 1, 414, 166, 1, 657, 166, 1, 966, 166, 0, 1253, 446, 0, 1253, 748, 1, 1253, 1048, 0, 966, 1327, 0, 657, 1327, 1}}},
+		'Ñ':      {advance: 1532, contours: [][]int16{{201, 1493, 1, 473, 1493, 1, 1135, 244, 1, 1135, 1493, 1, 1331, 1493, 1, 1331, 0, 1, 1059, 0, 1, 397, 1249, 1, 397, 0, 1, 201, 0, 1}, {762, 1710, 1, 705, 1743, 1, 680, 1757, 0, 649, 1768, 0, 637, 1768, 1, 601, 1768, 0, 561, 1718, 0, 561, 1673, 1, 561, 1667, 1, 436, 1667, 1, 436, 1768, 0, 539, 1886, 0, 625, 1886, 1, 661, 1886, 0, 722, 1870, 0, 770, 1843, 1, 827, 1813, 1, 850, 1800, 0, 884, 1788, 0, 899, 1788, 1, 931, 1788, 0, 971, 1839, 0, 971, 1880, 1, 971, 1886, 1, 1096, 1886, 1, 1094, 1786, 0, 991, 1667, 0, 907, 1667, 1, 873, 1667, 0, 814, 1683, 0}}},
+		'Ò':      {advance: 1612, contours: [][]int16{{807, 1356, 1, 587, 1356, 0, 328, 1028, 0, 328, 745, 1, 328, 463, 0, 587, 135, 0, 807, 135, 1, 1027, 135, 0, 1284, 463, 0, 1284, 745, 1, 1284, 1028, 0, 1027, 1356, 0}, {807, 1520, 1, 1121, 1520, 0, 1497, 1099, 0, 1497, 745, 1, 1497, 392, 0, 1121, -29, 0, 807, -29, 1, 492, -29, 0, 115, 391, 0, 115, 745, 1, 115, 1099, 0, 492, 1520, 0}, {750, 1899, 1, 946, 1635, 1, 793, 1635, 1, 563, 1899, 1}}},
+		'Ó':      {advance: 1612, contours: [][]int16{{807, 1356, 1, 587, 1356, 0, 328, 1028, 0, 328, 745, 1, 328, 463, 0, 587, 135, 0, 807, 135, 1, 1027, 135, 0, 1284, 463, 0, 1284, 745, 1, 1284, 1028, 0, 1027, 1356, 0}, {807, 1520, 1, 1121, 1520, 0, 1497, 1099, 0, 1497, 745, 1, 1497, 392, 0, 1121, -29, 0, 807, -29, 1, 492, -29, 0, 115, 391, 0, 115, 745, 1, 115, 1099, 0, 492, 1520, 0}, {862, 1899, 1, 1047, 1899, 1, 819, 1635, 1, 666, 1635, 1}}},
+		'Ô':      {advance: 1612, contours: [][]int16{{807, 1356, 1, 587, 1356, 0, 328, 1028, 0, 328, 745, 1, 328, 463, 0, 587, 135, 0, 807, 135, 1, 1027, 135, 0, 1284, 463, 0, 1284, 745, 1, 1284, 1028, 0, 1027, 1356, 0}, {807, 1520, 1, 1121, 1520, 0, 1497, 1099, 0, 1497, 745, 1, 1497, 392, 0, 1121, -29, 0, 807, -29, 1, 492, -29, 0, 115, 391, 0, 115, 745, 1, 115, 1099, 0, 492, 1520, 0}, {713, 1901, 1, 901, 1901, 1, 1112, 1635, 1, 973, 1635, 1, 807, 1813, 1, 641, 1635, 1, 502, 1635, 1}}},
+		'Õ':      {advance: 1612, contours: [][]int16{{807, 1356, 1, 587, 1356, 0, 328, 1028, 0, 328, 745, 1, 328, 463, 0, 587, 135, 0, 807, 135, 1, 1027, 135, 0, 1284, 463, 0, 1284, 745, 1, 1284, 1028, 0, 1027, 1356, 0}, {807, 1520, 1, 1121, 1520, 0, 1497, 1099, 0, 1497, 745, 1, 1497, 392, 0, 1121, -29, 0, 807, -29, 1, 492, -29, 0, 115, 391, 0, 115, 745, 1, 115, 1099, 0, 492, 1520, 0}, {803, 1710, 1, 746, 1743, 1, 721, 1757, 0, 690, 1768, 0, 678, 1768, 1, 642, 1768, 0, 602, 1718, 0, 602, 1673, 1, 602, 1667, 1, 477, 1667, 1, 477, 1768, 0, 580, 1886, 0, 666, 1886, 1, 702, 1886, 0, 763, 1870, 0, 811, 1843, 1, 868, 1813, 1, 891, 1800, 0, 925, 1788, 0, 940, 1788, 1, 972, 1788, 0, 1012, 1839, 0, 1012, 1880, 1, 1012, 1886, 1, 1137, 1886, 1, 1135, 1786, 0, 1032, 1667, 0, 948, 1667, 1, 914, 1667, 0, 855, 1683, 0}}},
+		'Ö':      {advance: 1612, contours: [][]int16{{807, 1356, 1, 587, 1356, 0, 328, 1028, 0, 328, 745, 1, 328, 463, 0, 587, 135, 0, 807, 135, 1, 1027, 135, 0, 1284, 463, 0, 1284, 745, 1, 1284, 1028, 0, 1027, 1356, 0}, {807, 1520, 1, 1121, 1520, 0, 1497, 1099, 0, 1497, 745, 1, 1497, 392, 0, 1121, -29, 0, 807, -29, 1, 492, -29, 0, 115, 391, 0, 115, 745, 1, 115, 1099, 0, 492, 1520, 0}, {901, 1870, 1, 1104, 1870, 1, 1104, 1667, 1, 901, 1667, 1}, {510, 1870, 1, 713, 1870, 1, 713, 1667, 1, 510, 1667, 1}}},
+		'×':      {advance: 1716, contours: [][]int16{{1436, 1100, 1, 979, 641, 1, 1436, 184, 1, 1317, 63, 1, 858, 522, 1, 399, 63, 1, 281, 184, 1, 737, 641, 1, 281, 1100, 1, 399, 1221, 1, 858, 762, 1, 1317, 1221, 1}}},
+		'Ø':      {advance: 1612, contours: [][]int16{{1206, 1112, 1, 489, 266, 1, 551, 202, 0, 712, 135, 0, 807, 135, 1, 1027, 135, 0, 1284, 463, 0, 1284, 745, 1, 1284, 857, 0, 1245, 1041, 0}, {1124, 1225, 1, 1063, 1289, 0, 902, 1356, 0, 807, 1356, 1, 587, 1356, 0, 328, 1028, 0, 328, 745, 1, 328, 633, 0, 367, 445, 0, 406, 377, 1}, {272, 219, 1, 194, 321, 0, 115, 585, 0, 115, 745, 1, 115, 1099, 0, 492, 1520, 0, 807, 1520, 1, 937, 1520, 0, 1158, 1443, 0, 1245, 1368, 1, 1407, 1559, 1, 1509, 1470, 1, 1339, 1272, 1, 1417, 1169, 0, 1497, 903, 0, 1497, 745, 1, 1497, 392, 0, 1121, -29, 0, 807, -29, 1, 679, -29, 0, 458, 46, 0, 367, 121, 1, 205, -70, 1, 102, 18, 1}}},
+		'Ù':      {advance: 1499, contours: [][]int16{{178, 1493, 1, 381, 1493, 1, 381, 586, 1, 381, 346, 0, 555, 135, 0, 750, 135, 1, 944, 135, 0, 1118, 346, 0, 1118, 586, 1, 1118, 1493, 1, 1321, 1493, 1, 1321, 561, 1, 1321, 269, 0, 1032, -29, 0, 750, -29, 1, 467, -29, 0, 178, 269, 0, 178, 561, 1}, {693, 1899, 1, 889, 1635, 1, 736, 1635, 1, 506, 1899, 1}}},
+		'Ú':      {advance: 1499, contours: [][]int16{{178, 1493, 1, 381, 1493, 1, 381, 586, 1, 381, 346, 0, 555, 135, 0, 750, 135, 1, 944, 135, 0, 1118, 346, 0, 1118, 586, 1, 1118, 1493, 1, 1321, 1493, 1, 1321, 561, 1, 1321, 269, 0, 1032, -29, 0, 750, -29, 1, 467, -29, 0, 178, 269, 0, 178, 561, 1}, {805, 1899, 1, 990, 1899, 1, 762, 1635, 1, 609, 1635, 1}}},
+		'Û':      {advance: 1499, contours: [][]int16{{178, 1493, 1, 381, 1493, 1, 381, 586, 1, 381, 346, 0, 555, 135, 0, 750, 135, 1, 944, 135, 0, 1118, 346, 0, 1118, 586, 1, 1118, 1493, 1, 1321, 1493, 1, 1321, 561, 1, 1321, 269, 0, 1032, -29, 0, 750, -29, 1, 467, -29, 0, 178, 269, 0, 178, 561, 1}, {656, 1901, 1, 844, 1901, 1, 1055, 1635, 1, 916, 1635, 1, 750, 1813, 1, 584, 1635, 1, 445, 1635, 1}}},
+		'Ü':      {advance: 1499, contours: [][]int16{{178, 1493, 1, 381, 1493, 1, 381, 586, 1, 381, 346, 0, 555, 135, 0, 750, 135, 1, 944, 135, 0, 1118, 346, 0, 1118, 586, 1, 1118, 1493, 1, 1321, 1493, 1, 1321, 561, 1, 1321, 269, 0, 1032, -29, 0, 750, -29, 1, 467, -29, 0, 178, 269, 0, 178, 561, 1}, {844, 1870, 1, 1047, 1870, 1, 1047, 1667, 1, 844, 1667, 1}, {453, 1870, 1, 656, 1870, 1, 656, 1667, 1, 453, 1667, 1}}},
+		'Ý':      {advance: 1251, contours: [][]int16{{-4, 1493, 1, 213, 1493, 1, 627, 879, 1, 1038, 1493, 1, 1255, 1493, 1, 727, 711, 1, 727, 0, 1, 524, 0, 1, 524, 711, 1}, {682, 1899, 1, 867, 1899, 1, 639, 1635, 1, 486, 1635, 1}}},
+		'Þ':      {advance: 1239, contours: [][]int16{{201, 1493, 1, 403, 1493, 1, 403, 1229, 1, 657, 1229, 1, 908, 1229, 0, 1165, 1004, 0, 1165, 784, 1, 1165, 564, 0, 908, 338, 0, 657, 338, 1, 403, 338, 1, 403, 0, 1, 201, 0, 1}, {403, 1063, 1, 403, 504, 1, 657, 504, 1, 798, 504, 0, 952, 650, 0, 952, 784, 1, 952, 918, 0, 799, 1063, 0, 657, 1063, 1}}},
+		'ß':      {advance: 1290, contours: [][]int16{{186, 1137, 1, 186, 1337, 0, 425, 1556, 0, 643, 1556, 1, 851, 1556, 0, 1070, 1324, 0, 1073, 1100, 1, 922, 1092, 0, 754, 977, 0, 754, 881, 1, 754, 834, 0, 812, 753, 0, 877, 711, 1, 934, 674, 1, 1100, 568, 0, 1196, 426, 0, 1196, 326, 1, 1196, 154, 0, 971, -29, 0, 760, -29, 1, 696, -29, 0, 560, -4, 0, 487, 20, 1, 487, 184, 1, 567, 154, 0, 707, 125, 0, 772, 125, 1, 888, 125, 0, 1008, 220, 0, 1008, 311, 1, 1008, 374, 0, 949, 458, 0, 848, 520, 1, 756, 575, 1, 660, 634, 0, 573, 769, 0, 573, 860, 1, 573, 987, 0, 740, 1159, 0, 891, 1188, 1, 883, 1291, 0, 752, 1403, 0, 639, 1403, 1, 509, 1403, 0, 373, 1264, 0, 373, 1133, 1, 373, 0, 1, 186, 0, 1}}},
+		'à':      {advance: 1255, contours: [][]int16{{702, 563, 1, 479, 563, 0, 307, 461, 0, 307, 338, 1, 307, 240, 0, 436, 125, 0, 547, 125, 1, 700, 125, 0, 885, 342, 0, 885, 522, 1, 885, 563, 1}, {1069, 639, 1, 1069, 0, 1, 885, 0, 1, 885, 170, 1, 822, 68, 0, 634, -29, 0, 498, -29, 1, 326, -29, 0, 123, 164, 0, 123, 326, 1, 123, 515, 0, 376, 707, 0, 627, 707, 1, 885, 707, 1, 885, 725, 1, 885, 852, 0, 718, 991, 0, 567, 991, 1, 471, 991, 0, 289, 945, 0, 205, 899, 1, 205, 1069, 1, 306, 1108, 0, 496, 1147, 0, 586, 1147, 1, 829, 1147, 0, 1069, 895, 0}, {449, 1638, 1, 731, 1264, 1, 578, 1264, 1, 252, 1638, 1}}},
+		'á':      {advance: 1255, contours: [][]int16{{702, 563, 1, 479, 563, 0, 307, 461, 0, 307, 338, 1, 307, 240, 0, 436, 125, 0, 547, 125, 1, 700, 125, 0, 885, 342, 0, 885, 522, 1, 885, 563, 1}, {1069, 639, 1, 1069, 0, 1, 885, 0, 1, 885, 170, 1, 822, 68, 0, 634, -29, 0, 498, -29, 1, 326, -29, 0, 123, 164, 0, 123, 326, 1, 123, 515, 0, 376, 707, 0, 627, 707, 1, 885, 707, 1, 885, 725, 1, 885, 852, 0, 718, 991, 0, 567, 991, 1, 471, 991, 0, 289, 945, 0, 205, 899, 1, 205, 1069, 1, 306, 1108, 0, 496, 1147, 0, 586, 1147, 1, 829, 1147, 0, 1069, 895, 0}, {733, 1638, 1, 932, 1638, 1, 606, 1262, 1, 453, 1262, 1}}},
+		'â':      {advance: 1255, contours: [][]int16{{702, 563, 1, 479, 563, 0, 307, 461, 0, 307, 338, 1, 307, 240, 0, 436, 125, 0, 547, 125, 1, 700, 125, 0, 885, 342, 0, 885, 522, 1, 885, 563, 1}, {1069, 639, 1, 1069, 0, 1, 885, 0, 1, 885, 170, 1, 822, 68, 0, 634, -29, 0, 498, -29, 1, 326, -29, 0, 123, 164, 0, 123, 326, 1, 123, 515, 0, 376, 707, 0, 627, 707, 1, 885, 707, 1, 885, 725, 1, 885, 852, 0, 718, 991, 0, 567, 991, 1, 471, 991, 0, 289, 945, 0, 205, 899, 1, 205, 1069, 1, 306, 1108, 0, 496, 1147, 0, 586, 1147, 1, 829, 1147, 0, 1069, 895, 0}, {520, 1638, 1, 668, 1638, 1, 913, 1262, 1, 774, 1262, 1, 594, 1507, 1, 414, 1262, 1, 275, 1262, 1}}},
+		'ã':      {advance: 1255, contours: [][]int16{{702, 563, 1, 479, 563, 0, 307, 461, 0, 307, 338, 1, 307, 240, 0, 436, 125, 0, 547, 125, 1, 700, 125, 0, 885, 342, 0, 885, 522, 1, 885, 563, 1}, {1069, 639, 1, 1069, 0, 1, 885, 0, 1, 885, 170, 1, 822, 68, 0, 634, -29, 0, 498, -29, 1, 326, -29, 0, 123, 164, 0, 123, 326, 1, 123, 515, 0, 376, 707, 0, 627, 707, 1, 885, 707, 1, 885, 725, 1, 885, 852, 0, 718, 991, 0, 567, 991, 1, 471, 991, 0, 289, 945, 0, 205, 899, 1, 205, 1069, 1, 306, 1108, 0, 496, 1147, 0, 586, 1147, 1, 829, 1147, 0, 1069, 895, 0}, {590, 1370, 1, 533, 1425, 1, 511, 1445, 0, 478, 1464, 0, 465, 1464, 1, 427, 1464, 0, 391, 1391, 0, 389, 1309, 1, 264, 1309, 1, 266, 1444, 0, 368, 1591, 0, 459, 1591, 1, 497, 1591, 0, 561, 1563, 0, 598, 1530, 1, 655, 1475, 1, 677, 1455, 0, 710, 1436, 0, 723, 1436, 1, 761, 1436, 0, 797, 1509, 0, 799, 1591, 1, 924, 1591, 1, 922, 1456, 0, 820, 1309, 0, 729, 1309, 1, 691, 1309, 0, 627, 1337, 0}}},
+		'ä':      {advance: 1255, contours: [][]int16{{702, 563, 1, 479, 563, 0, 307, 461, 0, 307, 338, 1, 307, 240, 0, 436, 125, 0, 547, 125, 1, 700, 125, 0, 885, 342, 0, 885, 522, 1, 885, 563, 1}, {1069, 639, 1, 1069, 0, 1, 885, 0, 1, 885, 170, 1, 822, 68, 0, 634, -29, 0, 498, -29, 1, 326, -29, 0, 123, 164, 0, 123, 326, 1, 123, 515, 0, 376, 707, 0, 627, 707, 1, 885, 707, 1, 885, 725, 1, 885, 852, 0, 718, 991, 0, 567, 991, 1, 471, 991, 0, 289, 945, 0, 205, 899, 1, 205, 1069, 1, 306, 1108, 0, 496, 1147, 0, 586, 1147, 1, 829, 1147, 0, 1069, 895, 0}, {688, 1552, 1, 891, 1552, 1, 891, 1350, 1, 688, 1350, 1}, {297, 1552, 1, 500, 1552, 1, 500, 1350, 1, 297, 1350, 1}}},
+		'å':      {advance: 1255, contours: [][]int16{{702, 563, 1, 479, 563, 0, 307, 461, 0, 307, 338, 1, 307, 240, 0, 436, 125, 0, 547, 125, 1, 700, 125, 0, 885, 342, 0, 885, 522, 1, 885, 563, 1}, {1069, 639, 1, 1069, 0, 1, 885, 0, 1, 885, 170, 1, 822, 68, 0, 634, -29, 0, 498, -29, 1, 326, -29, 0, 123, 164, 0, 123, 326, 1, 123, 515, 0, 376, 707, 0, 627, 707, 1, 885, 707, 1, 885, 725, 1, 885, 852, 0, 718, 991, 0, 567, 991, 1, 471, 991, 0, 289, 945, 0, 205, 899, 1, 205, 1069, 1, 306, 1108, 0, 496, 1147, 0, 586, 1147, 1, 829, 1147, 0, 1069, 895, 0}, {746, 1524, 1, 746, 1587, 0, 658, 1675, 0, 594, 1675, 1, 529, 1675, 0, 442, 1588, 0, 442, 1524, 1, 442, 1459, 0, 529, 1372, 0, 594, 1372, 1, 658, 1372, 0, 746, 1460, 0}, {868, 1524, 1, 868, 1409, 0, 709, 1249, 0, 594, 1249, 1, 479, 1249, 0, 320, 1409, 0, 320, 1524, 1, 320, 1639, 0, 479, 1798, 0, 594, 1798, 1, 709, 1798, 0, 868, 1639, 0}}},
+		'æ':      {advance: 2011, contours: [][]int16{{1718, 660, 1, 1717, 811, 0, 1552, 991, 0, 1415, 991, 1, 1262, 991, 0, 1077, 817, 0, 1063, 659, 1}, {995, 963, 1, 1069, 1053, 0, 1281, 1147, 0, 1413, 1147, 1, 1639, 1147, 0, 1903, 856, 0, 1903, 606, 1, 1903, 516, 1, 1057, 516, 1, 1069, 325, 0, 1273, 125, 0, 1456, 125, 1, 1560, 125, 0, 1760, 178, 0, 1860, 231, 1, 1860, 57, 1, 1760, 15, 0, 1552, -29, 0, 1446, -29, 1, 1279, -29, 0, 1031, 92, 0, 954, 211, 1, 881, 91, 0, 665, -29, 0, 522, -29, 1, 333, -29, 0, 123, 158, 0, 123, 326, 1, 123, 515, 0, 376, 707, 0, 627, 707, 1, 885, 707, 1, 885, 725, 1, 885, 852, 0, 718, 991, 0, 567, 991, 1, 471, 991, 0, 289, 945, 0, 205, 899, 1, 205, 1069, 1, 306, 1108, 0, 496, 1147, 0, 586, 1147, 1, 728, 1147, 0, 941, 1051, 0}, {702, 563, 1, 479, 563, 0, 307, 461, 0, 307, 338, 1, 307, 240, 0, 436, 125, 0, 547, 125, 1, 700, 125, 0, 885, 342, 0, 885, 522, 1, 885, 563, 1}}},
+		'ç':      {advance: 1126, contours: [][]int16{{999, 1077, 1, 999, 905, 1, 921, 948, 0, 764, 991, 0, 684, 991, 1, 505, 991, 0, 307, 764, 0, 307, 559, 1, 307, 354, 0, 505, 127, 0, 684, 127, 1, 764, 127, 0, 921, 170, 0, 999, 213, 1, 999, 43, 1, 922, 7, 0, 757, -29, 0, 664, -29, 1, 411, -29, 0, 113, 289, 0, 113, 559, 1, 113, 833, 0, 414, 1147, 0, 676, 1147, 1, 761, 1147, 0, 923, 1112, 0}, {739, 0, 1, 794, -62, 0, 848, -167, 0, 848, -215, 1, 848, -304, 0, 728, -395, 0, 610, -395, 1, 564, -395, 0, 477, -383, 0, 434, -371, 1, 434, -240, 1, 468, -257, 0, 542, -272, 0, 589, -272, 1, 648, -272, 0, 708, -224, 0, 708, -178, 1, 708, -148, 0, 665, -61, 0, 620, 0, 1}}},
+		'è':      {advance: 1260, contours: [][]int16{{1151, 606, 1, 1151, 516, 1, 305, 516, 1, 317, 326, 0, 522, 127, 0, 705, 127, 1, 811, 127, 0, 1010, 179, 0, 1108, 231, 1, 1108, 57, 1, 1009, 15, 0, 801, -29, 0, 694, -29, 1, 426, -29, 0, 113, 283, 0, 113, 549, 1, 113, 824, 0, 410, 1147, 0, 662, 1147, 1, 888, 1147, 0, 1151, 856, 0}, {967, 660, 1, 965, 811, 0, 800, 991, 0, 664, 991, 1, 510, 991, 0, 325, 817, 0, 311, 659, 1}, {506, 1638, 1, 788, 1264, 1, 635, 1264, 1, 309, 1638, 1}}},
+		'é':      {advance: 1260, contours: [][]int16{{1151, 606, 1, 1151, 516, 1, 305, 516, 1, 317, 326, 0, 522, 127, 0, 705, 127, 1, 811, 127, 0, 1010, 179, 0, 1108, 231, 1, 1108, 57, 1, 1009, 15, 0, 801, -29, 0, 694, -29, 1, 426, -29, 0, 113, 283, 0, 113, 549, 1, 113, 824, 0, 410, 1147, 0, 662, 1147, 1, 888, 1147, 0, 1151, 856, 0}, {967, 660, 1, 965, 811, 0, 800, 991, 0, 664, 991, 1, 510, 991, 0, 325, 817, 0, 311, 659, 1}, {790, 1638, 1, 989, 1638, 1, 663, 1262, 1, 510, 1262, 1}}},
+		'ê':      {advance: 1260, contours: [][]int16{{1151, 606, 1, 1151, 516, 1, 305, 516, 1, 317, 326, 0, 522, 127, 0, 705, 127, 1, 811, 127, 0, 1010, 179, 0, 1108, 231, 1, 1108, 57, 1, 1009, 15, 0, 801, -29, 0, 694, -29, 1, 426, -29, 0, 113, 283, 0, 113, 549, 1, 113, 824, 0, 410, 1147, 0, 662, 1147, 1, 888, 1147, 0, 1151, 856, 0}, {967, 660, 1, 965, 811, 0, 800, 991, 0, 664, 991, 1, 510, 991, 0, 325, 817, 0, 311, 659, 1}, {577, 1638, 1, 725, 1638, 1, 970, 1262, 1, 831, 1262, 1, 651, 1507, 1, 471, 1262, 1, 332, 1262, 1}}},
+		'ë':      {advance: 1260, contours: [][]int16{{1151, 606, 1, 1151, 516, 1, 305, 516, 1, 317, 326, 0, 522, 127, 0, 705, 127, 1, 811, 127, 0, 1010, 179, 0, 1108, 231, 1, 1108, 57, 1, 1009, 15, 0, 801, -29, 0, 694, -29, 1, 426, -29, 0, 113, 283, 0, 113, 549, 1, 113, 824, 0, 410, 1147, 0, 662, 1147, 1, 888, 1147, 0, 1151, 856, 0}, {967, 660, 1, 965, 811, 0, 800, 991, 0, 664, 991, 1, 510, 991, 0, 325, 817, 0, 311, 659, 1}, {745, 1552, 1, 948, 1552, 1, 948, 1350, 1, 745, 1350, 1}, {354, 1552, 1, 557, 1552, 1, 557, 1350, 1, 354, 1350, 1}}},
+		'ì':      {advance: 569, contours: [][]int16{{140, 1638, 1, 422, 1264, 1, 269, 1264, 1, -57, 1638, 1}, {193, 1120, 1, 377, 1120, 1, 377, 0, 1, 193, 0, 1}, {285, 1147, 1}}},
+		'í':      {advance: 569, contours: [][]int16{{424, 1638, 1, 623, 1638, 1, 297, 1262, 1, 144, 1262, 1}, {193, 1120, 1, 377, 1120, 1, 377, 0, 1, 193, 0, 1}, {285, 1147, 1}}},
+		'î':      {advance: 569, contours: [][]int16{{193, 1120, 1, 377, 1120, 1, 377, 0, 1, 193, 0, 1}, {285, 1147, 1}, {211, 1638, 1, 359, 1638, 1, 604, 1262, 1, 465, 1262, 1, 285, 1507, 1, 105, 1262, 1, -34, 1262, 1}}},
+		'ï':      {advance: 569, contours: [][]int16{{193, 1120, 1, 377, 1120, 1, 377, 0, 1, 193, 0, 1}, {285, 1147, 1}, {379, 1552, 1, 582, 1552, 1, 582, 1350, 1, 379, 1350, 1}, {-12, 1552, 1, 191, 1552, 1, 191, 1350, 1, -12, 1350, 1}}},
+		'ð':      {advance: 1253, contours: [][]int16{{838, 915, 1, 788, 932, 0, 700, 948, 0, 659, 948, 1, 492, 948, 0, 307, 732, 0, 307, 537, 1, 307, 349, 0, 481, 127, 0, 627, 127, 1, 772, 127, 0, 946, 349, 0, 946, 537, 1, 946, 659, 0, 892, 847, 0}, {901, 1141, 1, 1027, 998, 0, 1141, 710, 0, 1141, 537, 1, 1141, 282, 0, 857, -29, 0, 627, -29, 1, 396, -29, 0, 113, 282, 0, 113, 537, 1, 113, 787, 0, 389, 1098, 0, 610, 1098, 1, 628, 1098, 0, 680, 1093, 0, 722, 1088, 1, 563, 1268, 1, 244, 1161, 1, 211, 1260, 1, 492, 1352, 1, 311, 1556, 1, 539, 1556, 1, 666, 1411, 1, 999, 1522, 1, 1032, 1425, 1, 737, 1327, 1}}},
+		'ñ':      {advance: 1298, contours: [][]int16{{1124, 676, 1, 1124, 0, 1, 940, 0, 1, 940, 670, 1, 940, 829, 0, 816, 987, 0, 692, 987, 1, 543, 987, 0, 371, 797, 0, 371, 633, 1, 371, 0, 1, 186, 0, 1, 186, 1120, 1, 371, 1120, 1, 371, 946, 1, 437, 1047, 0, 616, 1147, 0, 733, 1147, 1, 926, 1147, 0, 1124, 908, 0}, {660, 1370, 1, 603, 1425, 1, 581, 1445, 0, 548, 1464, 0, 535, 1464, 1, 497, 1464, 0, 461, 1391, 0, 459, 1309, 1, 334, 1309, 1, 336, 1444, 0, 438, 1591, 0, 529, 1591, 1, 567, 1591, 0, 631, 1563, 0, 668, 1530, 1, 725, 1475, 1, 747, 1455, 0, 780, 1436, 0, 793, 1436, 1, 831, 1436, 0, 867, 1509, 0, 869, 1591, 1, 994, 1591, 1, 992, 1456, 0, 890, 1309, 0, 799, 1309, 1, 761, 1309, 0, 697, 1337, 0}}},
+		'ò':      {advance: 1253, contours: [][]int16{{627, 991, 1, 479, 991, 0, 307, 760, 0, 307, 559, 1, 307, 358, 0, 478, 127, 0, 627, 127, 1, 774, 127, 0, 946, 359, 0, 946, 559, 1, 946, 758, 0, 774, 991, 0}, {627, 1147, 1, 867, 1147, 0, 1141, 835, 0, 1141, 559, 1, 1141, 284, 0, 867, -29, 0, 627, -29, 1, 386, -29, 0, 113, 284, 0, 113, 559, 1, 113, 835, 0, 386, 1147, 0}, {482, 1638, 1, 764, 1264, 1, 611, 1264, 1, 285, 1638, 1}}},
+		'ó':      {advance: 1253, contours: [][]int16{{627, 991, 1, 479, 991, 0, 307, 760, 0, 307, 559, 1, 307, 358, 0, 478, 127, 0, 627, 127, 1, 774, 127, 0, 946, 359, 0, 946, 559, 1, 946, 758, 0, 774, 991, 0}, {627, 1147, 1, 867, 1147, 0, 1141, 835, 0, 1141, 559, 1, 1141, 284, 0, 867, -29, 0, 627, -29, 1, 386, -29, 0, 113, 284, 0, 113, 559, 1, 113, 835, 0, 386, 1147, 0}, {766, 1638, 1, 965, 1638, 1, 639, 1262, 1, 486, 1262, 1}}},
+		'ô':      {advance: 1253, contours: [][]int16{{627, 991, 1, 479, 991, 0, 307, 760, 0, 307, 559, 1, 307, 358, 0, 478, 127, 0, 627, 127, 1, 774, 127, 0, 946, 359, 0, 946, 559, 1, 946, 758, 0, 774, 991, 0}, {627, 1147, 1, 867, 1147, 0, 1141, 835, 0, 1141, 559, 1, 1141, 284, 0, 867, -29, 0, 627, -29, 1, 386, -29, 0, 113, 284, 0, 113, 559, 1, 113, 835, 0, 386, 1147, 0}, {553, 1638, 1, 701, 1638, 1, 946, 1262, 1, 807, 1262, 1, 627, 1507, 1, 447, 1262, 1, 308, 1262, 1}}},
+		'õ':      {advance: 1253, contours: [][]int16{{627, 991, 1, 479, 991, 0, 307, 760, 0, 307, 559, 1, 307, 358, 0, 478, 127, 0, 627, 127, 1, 774, 127, 0, 946, 359, 0, 946, 559, 1, 946, 758, 0, 774, 991, 0}, {627, 1147, 1, 867, 1147, 0, 1141, 835, 0, 1141, 559, 1, 1141, 284, 0, 867, -29, 0, 627, -29, 1, 386, -29, 0, 113, 284, 0, 113, 559, 1, 113, 835, 0, 386, 1147, 0}, {623, 1370, 1, 566, 1425, 1, 544, 1445, 0, 511, 1464, 0, 498, 1464, 1, 460, 1464, 0, 424, 1391, 0, 422, 1309, 1, 297, 1309, 1, 299, 1444, 0, 401, 1591, 0, 492, 1591, 1, 530, 1591, 0, 594, 1563, 0, 631, 1530, 1, 688, 1475, 1, 710, 1455, 0, 743, 1436, 0, 756, 1436, 1, 794, 1436, 0, 830, 1509, 0, 832, 1591, 1, 957, 1591, 1, 955, 1456, 0, 853, 1309, 0, 762, 1309, 1, 724, 1309, 0, 660, 1337, 0}}},
+		'ö':      {advance: 1253, contours: [][]int16{{627, 991, 1, 479, 991, 0, 307, 760, 0, 307, 559, 1, 307, 358, 0, 478, 127, 0, 627, 127, 1, 774, 127, 0, 946, 359, 0, 946, 559, 1, 946, 758, 0, 774, 991, 0}, {627, 1147, 1, 867, 1147, 0, 1141, 835, 0, 1141, 559, 1, 1141, 284, 0, 867, -29, 0, 627, -29, 1, 386, -29, 0, 113, 284, 0, 113, 559, 1, 113, 835, 0, 386, 1147, 0}, {721, 1552, 1, 924, 1552, 1, 924, 1350, 1, 721, 1350, 1}, {330, 1552, 1, 533, 1552, 1, 533, 1350, 1, 330, 1350, 1}}},
+		'÷':      {advance: 1716, contours: [][]int16{{735, 1135, 1, 981, 1135, 1, 981, 889, 1, 735, 889, 1}, {735, 395, 1, 981, 395, 1, 981, 150, 1, 735, 150, 1}, {217, 727, 1, 1499, 727, 1, 1499, 557, 1, 217, 557, 1}}},
+		'ø':      {advance: 1253, contours: [][]int16{{905, 801, 1, 418, 209, 1, 459, 167, 0, 562, 127, 0, 627, 127, 1, 774, 127, 0, 946, 359, 0, 946, 559, 1, 946, 638, 0, 926, 755, 0}, {834, 909, 1, 792, 950, 0, 689, 991, 0, 627, 991, 1, 476, 991, 0, 307, 756, 0, 307, 545, 1, 307, 473, 0, 326, 363, 0, 346, 317, 1}, {221, 166, 1, 167, 243, 0, 113, 440, 0, 113, 559, 1, 113, 835, 0, 386, 1147, 0, 627, 1147, 1, 720, 1147, 0, 879, 1096, 0, 946, 1044, 1, 1085, 1212, 1, 1180, 1133, 1, 1034, 954, 1, 1087, 877, 0, 1141, 679, 0, 1141, 559, 1, 1141, 284, 0, 867, -29, 0, 627, -29, 1, 531, -29, 0, 370, 23, 0, 307, 74, 1, 168, -94, 1, 72, -16, 1}}},
+		'ù':      {advance: 1298, contours: [][]int16{{174, 442, 1, 174, 1120, 1, 358, 1120, 1, 358, 449, 1, 358, 290, 0, 482, 131, 0, 606, 131, 1, 755, 131, 0, 928, 321, 0, 928, 485, 1, 928, 1120, 1, 1112, 1120, 1, 1112, 0, 1, 928, 0, 1, 928, 172, 1, 861, 70, 0, 684, -29, 0, 567, -29, 1, 374, -29, 0, 174, 211, 0}, {637, 1147, 1}, {490, 1638, 1, 772, 1264, 1, 619, 1264, 1, 293, 1638, 1}}},
+		'ú':      {advance: 1298, contours: [][]int16{{174, 442, 1, 174, 1120, 1, 358, 1120, 1, 358, 449, 1, 358, 290, 0, 482, 131, 0, 606, 131, 1, 755, 131, 0, 928, 321, 0, 928, 485, 1, 928, 1120, 1, 1112, 1120, 1, 1112, 0, 1, 928, 0, 1, 928, 172, 1, 861, 70, 0, 684, -29, 0, 567, -29, 1, 374, -29, 0, 174, 211, 0}, {637, 1147, 1}, {774, 1638, 1, 973, 1638, 1, 647, 1262, 1, 494, 1262, 1}}},
+		'û':      {advance: 1298, contours: [][]int16{{174, 442, 1, 174, 1120, 1, 358, 1120, 1, 358, 449, 1, 358, 290, 0, 482, 131, 0, 606, 131, 1, 755, 131, 0, 928, 321, 0, 928, 485, 1, 928, 1120, 1, 1112, 1120, 1, 1112, 0, 1, 928, 0, 1, 928, 172, 1, 861, 70, 0, 684, -29, 0, 567, -29, 1, 374, -29, 0, 174, 211, 0}, {637, 1147, 1}, {561, 1638, 1, 709, 1638, 1, 954, 1262, 1, 815, 1262, 1, 635, 1507, 1, 455, 1262, 1, 316, 1262, 1}}},
+		'ü':      {advance: 1298, contours: [][]int16{{174, 442, 1, 174, 1120, 1, 358, 1120, 1, 358, 449, 1, 358, 290, 0, 482, 131, 0, 606, 131, 1, 755, 131, 0, 928, 321, 0, 928, 485, 1, 928, 1120, 1, 1112, 1120, 1, 1112, 0, 1, 928, 0, 1, 928, 172, 1, 861, 70, 0, 684, -29, 0, 567, -29, 1, 374, -29, 0, 174, 211, 0}, {637, 1147, 1}, {729, 1552, 1, 932, 1552, 1, 932, 1350, 1, 729, 1350, 1}, {338, 1552, 1, 541, 1552, 1, 541, 1350, 1, 338, 1350, 1}}},
+		'ý':      {advance: 1212, contours: [][]int16{{659, -104, 1, 581, -304, 0, 433, -426, 0, 309, -426, 1, 162, -426, 1, 162, -272, 1, 270, -272, 1, 346, -272, 0, 430, -200, 0, 481, -66, 1, 514, 18, 1, 61, 1120, 1, 256, 1120, 1, 606, 244, 1, 956, 1120, 1, 1151, 1120, 1}, {745, 1638, 1, 944, 1638, 1, 618, 1262, 1, 465, 1262, 1}}},
+		'þ':      {advance: 1300, contours: [][]int16{{371, 168, 1, 371, -426, 1, 186, -426, 1, 186, 1556, 1, 371, 1556, 1, 371, 950, 1, 429, 1050, 0, 606, 1147, 0, 729, 1147, 1, 933, 1147, 0, 1188, 823, 0, 1188, 559, 1, 1188, 295, 0, 933, -29, 0, 729, -29, 1, 606, -29, 0, 429, 68, 0}, {997, 559, 1, 997, 762, 0, 830, 993, 0, 684, 993, 1, 538, 993, 0, 371, 762, 0, 371, 559, 1, 371, 356, 0, 538, 125, 0, 684, 125, 1, 830, 125, 0, 997, 356, 0}}},
+		'ÿ':      {advance: 1212, contours: [][]int16{{659, -104, 1, 581, -304, 0, 433, -426, 0, 309, -426, 1, 162, -426, 1, 162, -272, 1, 270, -272, 1, 346, -272, 0, 430, -200, 0, 481, -66, 1, 514, 18, 1, 61, 1120, 1, 256, 1120, 1, 606, 244, 1, 956, 1120, 1, 1151, 1120, 1}, {700, 1552, 1, 903, 1552, 1, 903, 1350, 1, 700, 1350, 1}, {309, 1552, 1, 512, 1552, 1, 512, 1350, 1, 309, 1350, 1}}},
+		'–':      {advance: 1024, contours: [][]int16{{100, 633, 1, 924, 633, 1, 924, 489, 1, 100, 489, 1}}},
+		'—':      {advance: 2048, contours: [][]int16{{100, 633, 1, 1948, 633, 1, 1948, 489, 1, 100, 489, 1}}},
+		'‘':      {advance: 651, contours: [][]int16{{385, 1001, 1, 174, 1001, 1, 174, 1174, 1, 338, 1493, 1, 467, 1493, 1, 385, 1174, 1}}},
+		'’':      {advance: 651, contours: [][]int16{{260, 1493, 1, 471, 1493, 1, 471, 1341, 1, 307, 1022, 1, 178, 1022, 1, 260, 1341, 1}}},
+		'“':      {advance: 1061, contours: [][]int16{{385, 1001, 1, 174, 1001, 1, 174, 1174, 1, 338, 1493, 1, 467, 1493, 1, 385, 1174, 1}, {795, 1001, 1, 584, 1001, 1, 584, 1174, 1, 748, 1493, 1, 877, 1493, 1, 795, 1174, 1}}},
+		'”':      {advance: 1061, contours: [][]int16{{256, 1493, 1, 467, 1493, 1, 467, 1321, 1, 303, 1001, 1, 174, 1001, 1, 256, 1321, 1}, {666, 1493, 1, 877, 1493, 1, 877, 1321, 1, 713, 1001, 1, 584, 1001, 1, 666, 1321, 1}}},
+		'•':      {advance: 1208, contours: [][]int16{{307, 762, 1, 307, 886, 0, 480, 1057, 0, 606, 1057, 1, 730, 1057, 0, 901, 886, 0, 901, 762, 1, 901, 637, 0, 729, 465, 0, 604, 465, 1, 479, 465, 0, 307, 637, 0}}},
+		'…':      {advance: 2048, contours: [][]int16{{918, 254, 1, 1130, 254, 1, 1130, 0, 1, 918, 0, 1}, {1599, 254, 1, 1812, 254, 1, 1812, 0, 1, 1599, 0, 1}, {236, 254, 1, 449, 254, 1, 449, 0, 1, 236, 0, 1}}},
+	},
+}
+
+var Bold = &Face{
+	unitsPerEm: 2048,
+	ascent:     1901,
+	descent:    -483,
+	glyphs: map[rune]*glyph{
+		' ':      {advance: 713},
+		'!':      {advance: 934, contours: [][]int16{{287, 1493, 1, 647, 1493, 1, 647, 920, 1, 596, 502, 1, 338, 502, 1, 287, 920, 1}, {287, 356, 1, 647, 356, 1, 647, 0, 1, 287, 0, 1}}},
+		'"':      {advance: 1067, contours: [][]int16{{872, 1493, 1, 872, 938, 1, 635, 938, 1, 635, 1493, 1}, {432, 1493, 1, 432, 938, 1, 195, 938, 1, 195, 1493, 1}}},
+		'#':      {advance: 1716, contours: [][]int16{{911, 1470, 1, 815, 1085, 1, 1079, 1085, 1, 1176, 1470, 1, 1397, 1470, 1, 1300, 1085, 1, 1577, 1085, 1, 1577, 872, 1, 1247, 872, 1, 1178, 598, 1, 1462, 598, 1, 1462, 383, 1, 1126, 383, 1, 1030, 0, 1, 809, 0, 1, 905, 383, 1, 641, 383, 1, 545, 0, 1, 322, 0, 1, 418, 383, 1, 139, 383, 1, 139, 598, 1, 467, 598, 1, 537, 872, 1, 254, 872, 1, 254, 1085, 1, 592, 1085, 1, 688, 1470, 1}, {1024, 872, 1, 760, 872, 1, 690, 598, 1, 954, 598, 1}}},
+		'$':      {advance: 1425, contours: [][]int16{{795, -301, 1, 633, -301, 1, 632, 0, 1, 507, 5, 0, 273, 51, 0, 162, 92, 1, 162, 354, 1, 277, 295, 0, 512, 232, 0, 633, 228, 1, 633, 539, 1, 600, 545, 1, 361, 587, 0, 160, 767, 0, 160, 936, 1, 160, 1115, 0, 405, 1316, 0, 632, 1325, 1, 633, 1556, 1, 795, 1556, 1, 795, 1329, 1, 895, 1321, 0, 1095, 1287, 0, 1196, 1260, 1, 1196, 1006, 1, 1096, 1048, 0, 896, 1095, 0, 795, 1100, 1, 795, 813, 1, 827, 807, 1, 1081, 767, 0, 1286, 580, 0, 1286, 397, 1, 1286, 213, 0, 1042, 16, 0, 795, 2, 1}, {633, 836, 1, 633, 1097, 1, 562, 1093, 0, 477, 1024, 0, 477, 971, 1, 477, 912, 0, 555, 845, 0}, {795, 510, 1, 795, 232, 1, 882, 233, 0, 969, 299, 0, 969, 365, 1, 969, 433, 0, 889, 500, 0}}},
+		'%':      {advance: 2052, contours: [][]int16{{1587, 616, 1, 1516, 616, 0, 1438, 493, 0, 1438, 379, 1, 1438, 264, 0, 1515, 141, 0, 1587, 141, 1, 1659, 141, 0, 1735, 264, 0, 1735, 379, 1, 1735, 493, 0, 1658, 616, 0}, {1587, 784, 1, 1773, 784, 0, 1987, 568, 0, 1987, 379, 1, 1987, 190, 0, 1773, -29, 0, 1587, -29, 1, 1401, -29, 0, 1186, 190, 0, 1186, 379, 1, 1186, 567, 0, 1401, 784, 0}, {670, -29, 1, 449, -29, 1, 1382, 1520, 1, 1604, 1520, 1}, {465, 1520, 1, 651, 1520, 0, 864, 1303, 0, 864, 1114, 1, 864, 925, 0, 651, 707, 0, 465, 707, 1, 279, 707, 0, 66, 925, 0, 66, 1114, 1, 66, 1303, 0, 279, 1520, 0}, {465, 1352, 1, 393, 1352, 0, 315, 1228, 0, 315, 1114, 1, 315, 999, 0, 393, 874, 0, 465, 874, 1, 537, 874, 0, 614, 999, 0, 614, 1114, 1, 614, 1228, 0, 536, 1352, 0}}},
+		'&':      {advance: 1786, contours: [][]int16{{799, 991, 1, 1208, 541, 1, 1261, 611, 0, 1316, 785, 0, 1321, 895, 1, 1632, 895, 1, 1617, 713, 0, 1506, 429, 0, 1407, 322, 1, 1700, 0, 1, 1276, 0, 1, 1178, 109, 1, 1073, 39, 0, 841, -29, 0, 711, -29, 1, 448, -29, 0, 123, 248, 0, 123, 467, 1, 123, 613, 0, 266, 838, 0, 428, 944, 1, 386, 997, 0, 346, 1103, 0, 346, 1161, 1, 346, 1324, 0, 600, 1520, 0, 811, 1520, 1, 902, 1520, 0, 1099, 1491, 0, 1206, 1462, 1, 1206, 1184, 1, 1112, 1232, 0, 944, 1278, 0, 864, 1278, 1, 787, 1278, 0, 702, 1219, 0, 702, 1165, 1, 702, 1131, 0, 751, 1044, 0}, {600, 743, 1, 535, 696, 0, 469, 577, 0, 469, 506, 1, 469, 391, 0, 639, 229, 0, 758, 229, 1, 825, 229, 0, 941, 270, 0, 991, 311, 1}}},
+		'\'':     {advance: 627, contours: [][]int16{{432, 1493, 1, 432, 938, 1, 195, 938, 1, 195, 1493, 1}}},
+		'(':      {advance: 936, contours: [][]int16{{772, -270, 1, 475, -270, 1, 322, -23, 0, 176, 422, 0, 176, 641, 1, 176, 860, 0, 323, 1309, 0, 475, 1554, 1, 772, 1554, 1, 644, 1317, 0, 516, 864, 0, 516, 643, 1, 516, 422, 0, 643, -32, 0}}},
+		')':      {advance: 936, contours: [][]int16{{164, -270, 1, 292, -32, 0, 420, 422, 0, 420, 643, 1, 420, 864, 0, 292, 1317, 0, 164, 1554, 1, 461, 1554, 1, 613, 1309, 0, 760, 860, 0, 760, 641, 1, 760, 422, 0, 614, -23, 0, 461, -270, 1}}},
+		'*':      {advance: 1071, contours: [][]int16{{1030, 1217, 1, 700, 1044, 1, 1030, 870, 1, 954, 729, 1, 621, 913, 1, 621, 569, 1, 451, 569, 1, 451, 913, 1, 117, 729, 1, 41, 870, 1, 375, 1044, 1, 41, 1217, 1, 117, 1358, 1, 451, 1176, 1, 451, 1520, 1, 621, 1520, 1, 621, 1176, 1, 954, 1358, 1}}},
+		'+':      {advance: 1716, contours: [][]int16{{977, 1284, 1, 977, 760, 1, 1499, 760, 1, 1499, 524, 1, 977, 524, 1, 977, 0, 1, 739, 0, 1, 739, 524, 1, 217, 524, 1, 217, 760, 1, 739, 760, 1, 739, 1284, 1}}},
+		',':      {advance: 778, contours: [][]int16{{209, 387, 1, 569, 387, 1, 569, 82, 1, 322, -291, 1, 109, -291, 1, 209, 82, 1}}},
+		'-':      {advance: 850, contours: [][]int16{{111, 735, 1, 739, 735, 1, 739, 444, 1, 111, 444, 1}}},
+		'.':      {advance: 778, contours: [][]int16{{209, 387, 1, 569, 387, 1, 569, 0, 1, 209, 0, 1}}},
+		'/':      {advance: 748, contours: [][]int16{{526, 1493, 1, 748, 1493, 1, 221, -190, 1, 0, -190, 1}}},
+		'0':      {advance: 1425, contours: [][]int16{{942, 748, 1, 942, 1028, 0, 837, 1257, 0, 713, 1257, 1, 589, 1257, 0, 483, 1028, 0, 483, 748, 1, 483, 465, 0, 589, 233, 0, 713, 233, 1, 836, 233, 0, 942, 465, 0}, {1327, 745, 1, 1327, 374, 0, 1007, -29, 0, 713, -29, 1, 418, -29, 0, 98, 374, 0, 98, 745, 1, 98, 1117, 0, 418, 1520, 0, 713, 1520, 1, 1007, 1520, 0, 1327, 1117, 0}}},
+		'1':      {advance: 1425, contours: [][]int16{{240, 266, 1, 580, 266, 1, 580, 1231, 1, 231, 1159, 1, 231, 1421, 1, 578, 1493, 1, 944, 1493, 1, 944, 266, 1, 1284, 266, 1, 1284, 0, 1, 240, 0, 1}}},
+		'2':      {advance: 1425, contours: [][]int16{{590, 283, 1, 1247, 283, 1, 1247, 0, 1, 162, 0, 1, 162, 283, 1, 707, 764, 1, 780, 830, 0, 850, 956, 0, 850, 1024, 1, 850, 1129, 0, 709, 1257, 0, 592, 1257, 1, 502, 1257, 0, 288, 1180, 0, 166, 1104, 1, 166, 1432, 1, 296, 1475, 0, 550, 1520, 0, 672, 1520, 1, 940, 1520, 0, 1237, 1284, 0, 1237, 1073, 1, 1237, 951, 0, 1111, 740, 0, 909, 563, 1}}},
+		'3':      {advance: 1425, contours: [][]int16{{954, 805, 1, 1105, 766, 0, 1262, 573, 0, 1262, 424, 1, 1262, 202, 0, 922, -29, 0, 596, -29, 1, 481, -29, 0, 250, 8, 0, 137, 45, 1, 137, 342, 1, 245, 288, 0, 458, 233, 0, 561, 233, 1, 714, 233, 0, 877, 339, 0, 877, 438, 1, 877, 540, 0, 710, 645, 0, 547, 645, 1, 393, 645, 1, 393, 893, 1, 555, 893, 1, 700, 893, 0, 842, 984, 0, 842, 1077, 1, 842, 1163, 0, 704, 1257, 0, 578, 1257, 1, 485, 1257, 0, 295, 1215, 0, 201, 1174, 1, 201, 1456, 1, 315, 1488, 0, 539, 1520, 0, 647, 1520, 1, 938, 1520, 0, 1227, 1329, 0, 1227, 1137, 1, 1227, 1006, 0, 1089, 839, 0}}},
+		'4':      {advance: 1425, contours: [][]int16{{754, 1176, 1, 332, 551, 1, 754, 551, 1}, {690, 1493, 1, 1118, 1493, 1, 1118, 551, 1, 1331, 551, 1, 1331, 272, 1, 1118, 272, 1, 1118, 0, 1, 754, 0, 1, 754, 272, 1, 92, 272, 1, 92, 602, 1}}},
+		'5':      {advance: 1425, contours: [][]int16{{217, 1493, 1, 1174, 1493, 1, 1174, 1210, 1, 524, 1210, 1, 524, 979, 1, 568, 991, 0, 657, 1004, 0, 705, 1004, 1, 978, 1004, 0, 1282, 731, 0, 1282, 487, 1, 1282, 245, 0, 951, -29, 0, 657, -29, 1, 530, -29, 0, 281, 20, 0, 158, 70, 1, 158, 373, 1, 280, 303, 0, 499, 233, 0, 596, 233, 1, 736, 233, 0, 897, 370, 0, 897, 487, 1, 897, 605, 0, 736, 741, 0, 596, 741, 1, 513, 741, 0, 325, 698, 0, 217, 653, 1}}},
+		'6':      {advance: 1425, contours: [][]int16{{741, 737, 1, 640, 737, 0, 539, 606, 0, 539, 475, 1, 539, 344, 0, 640, 213, 0, 741, 213, 1, 843, 213, 0, 944, 344, 0, 944, 475, 1, 944, 606, 0, 843, 737, 0}, {1217, 1454, 1, 1217, 1178, 1, 1122, 1223, 0, 954, 1266, 0, 874, 1266, 1, 702, 1266, 0, 510, 1075, 0, 494, 887, 1, 560, 936, 0, 714, 985, 0, 805, 985, 1, 1034, 985, 0, 1315, 717, 0, 1315, 500, 1, 1315, 260, 0, 1001, -29, 0, 737, -29, 1, 446, -29, 0, 127, 364, 0, 127, 725, 1, 127, 1095, 0, 500, 1518, 0, 825, 1518, 1, 928, 1518, 0, 1122, 1486, 0}}},
+		'7':      {advance: 1425, contours: [][]int16{{137, 1493, 1, 1262, 1493, 1, 1262, 1276, 1, 680, 0, 1, 305, 0, 1, 856, 1210, 1, 137, 1210, 1}}},
+		'8':      {advance: 1425, contours: [][]int16{{713, 668, 1, 605, 668, 0, 489, 550, 0, 489, 440, 1, 489, 330, 0, 605, 213, 0, 713, 213, 1, 820, 213, 0, 934, 330, 0, 934, 440, 1, 934, 551, 0, 820, 668, 0}, {432, 795, 1, 296, 836, 0, 158, 1006, 0, 158, 1133, 1, 158, 1322, 0, 440, 1520, 0, 713, 1520, 1, 984, 1520, 0, 1266, 1323, 0, 1266, 1133, 1, 1266, 1006, 0, 1127, 836, 0, 991, 795, 1, 1143, 753, 0, 1298, 564, 0, 1298, 420, 1, 1298, 198, 0, 1003, -29, 0, 713, -29, 1, 422, -29, 0, 125, 198, 0, 125, 420, 1, 125, 564, 0, 280, 753, 0}, {522, 1094, 1, 522, 1005, 0, 621, 909, 0, 713, 909, 1, 803, 909, 0, 901, 1005, 0, 901, 1094, 1, 901, 1183, 0, 803, 1278, 0, 713, 1278, 1, 621, 1278, 0, 522, 1182, 0}}},
+		'9':      {advance: 1425, contours: [][]int16{{205, 33, 1, 205, 309, 1, 297, 266, 0, 465, 223, 0, 547, 223, 1, 719, 223, 0, 911, 414, 0, 928, 602, 1, 860, 552, 0, 706, 502, 0, 616, 502, 1, 387, 502, 0, 106, 769, 0, 106, 987, 1, 106, 1228, 0, 419, 1518, 0, 682, 1518, 1, 974, 1518, 0, 1294, 1124, 0, 1294, 764, 1, 1294, 394, 0, 920, -29, 0, 594, -29, 1, 489, -29, 0, 297, 2, 0}, {680, 752, 1, 781, 752, 0, 883, 883, 0, 883, 1014, 1, 883, 1144, 0, 781, 1276, 0, 680, 1276, 1, 579, 1276, 0, 477, 1144, 0, 477, 1014, 1, 477, 883, 0, 579, 752, 0}}},
+		':':      {advance: 819, contours: [][]int16{{229, 1120, 1, 590, 1120, 1, 590, 733, 1, 229, 733, 1}, {229, 387, 1, 590, 387, 1, 590, 0, 1, 229, 0, 1}}},
+		';':      {advance: 819, contours: [][]int16{{229, 387, 1, 590, 387, 1, 590, 82, 1, 342, -291, 1, 129, -291, 1, 229, 82, 1}, {229, 1120, 1, 590, 1120, 1, 590, 733, 1, 229, 733, 1}}},
+		'<':      {advance: 1716, contours: [][]int16{{1499, 973, 1, 535, 641, 1, 1499, 311, 1, 1499, 61, 1, 217, 524, 1, 217, 760, 1, 1499, 1223, 1}}},
+		'=':      {advance: 1716, contours: [][]int16{{217, 987, 1, 1499, 987, 1, 1499, 752, 1, 217, 752, 1}, {217, 532, 1, 1499, 532, 1, 1499, 295, 1, 217, 295, 1}}},
+		'>':      {advance: 1716, contours: [][]int16{{217, 973, 1, 217, 1223, 1, 1499, 760, 1, 1499, 524, 1, 217, 61, 1, 217, 311, 1, 1182, 641, 1}}},
+		'?':      {advance: 1188, contours: [][]int16{{709, 504, 1, 348, 504, 1, 348, 553, 1, 348, 635, 0, 414, 762, 0, 520, 860, 1, 584, 918, 1, 641, 970, 0, 694, 1062, 0, 694, 1108, 1, 694, 1178, 0, 598, 1257, 0, 512, 1257, 1, 431, 1257, 0, 243, 1190, 0, 141, 1124, 1, 141, 1438, 1, 262, 1480, 0, 462, 1520, 0, 555, 1520, 1, 799, 1520, 0, 1055, 1321, 0, 1055, 1130, 1, 1055, 1032, 0, 977, 877, 0, 883, 788, 1, 819, 731, 1, 751, 669, 0, 709, 594, 0, 709, 549, 1}, {348, 356, 1, 709, 356, 1, 709, 0, 1, 348, 0, 1}}},
+		'@':      {advance: 2048, contours: [][]int16{{831, 539, 1, 831, 416, 0, 936, 274, 0, 1026, 274, 1, 1115, 274, 0, 1221, 417, 0, 1221, 539, 1, 1221, 660, 0, 1114, 801, 0, 1024, 801, 1, 936, 801, 0, 831, 660, 0}, {1241, 238, 1, 1211, 167, 0, 1078, 88, 0, 989, 88, 1, 817, 88, 0, 602, 337, 0, 602, 537, 1, 602, 737, 0, 818, 987, 0, 989, 987, 1, 1078, 987, 0, 1211, 907, 0, 1241, 836, 1, 1241, 967, 1, 1450, 967, 1, 1450, 274, 1, 1574, 293, 0, 1716, 494, 0, 1716, 651, 1, 1716, 751, 0, 1658, 926, 0, 1599, 999, 1, 1504, 1121, 0, 1219, 1253, 0, 1053, 1253, 1, 937, 1253, 0, 725, 1192, 0, 635, 1133, 1, 487, 1035, 0, 322, 724, 0, 322, 543, 1, 322, 394, 0, 429, 133, 0, 530, 33, 1, 630, -65, 0, 889, -168, 0, 1036, -168, 1, 1162, -168, 0, 1414, -74, 0, 1503, 6, 1, 1610, -156, 1, 1485, -253, 0, 1190, -356, 0, 1038, -356, 1, 853, -356, 0, 525, -225, 0, 397, -100, 1, 269, 25, 0, 135, 354, 0, 135, 543, 1, 135, 725, 0, 271, 1055, 0, 397, 1180, 1, 523, 1304, 0, 858, 1440, 0, 1038, 1440, 1, 1262, 1440, 0, 1628, 1269, 0, 1751, 1108, 1, 1826, 1010, 0, 1903, 781, 0, 1903, 655, 1, 1903, 384, 0, 1577, 84, 0, 1280, 84, 1, 1241, 84, 1}}},
+		'A':      {advance: 1585, contours: [][]int16{{1094, 272, 1, 492, 272, 1, 397, 0, 1, 10, 0, 1, 563, 1493, 1, 1022, 1493, 1, 1575, 0, 1, 1188, 0, 1}, {588, 549, 1, 997, 549, 1, 793, 1143, 1}}},
+		'B':      {advance: 1561, contours: [][]int16{{786, 915, 1, 877, 915, 0, 971, 995, 0, 971, 1073, 1, 971, 1150, 0, 877, 1231, 0, 786, 1231, 1, 573, 1231, 1, 573, 915, 1}, {799, 262, 1, 915, 262, 0, 1032, 360, 0, 1032, 459, 1, 1032, 556, 0, 916, 653, 0, 799, 653, 1, 573, 653, 1, 573, 262, 1}, {1157, 799, 1, 1281, 763, 0, 1417, 569, 0, 1417, 428, 1, 1417, 212, 0, 1125, 0, 0, 827, 0, 1, 188, 0, 1, 188, 1493, 1, 766, 1493, 1, 1077, 1493, 0, 1356, 1305, 0, 1356, 1098, 1, 1356, 989, 0, 1254, 836, 0}}},
+		'C':      {advance: 1503, contours: [][]int16{{1372, 82, 1, 1266, 27, 0, 1036, -29, 0, 911, -29, 1, 538, -29, 0, 102, 388, 0, 102, 745, 1, 102, 1103, 0, 538, 1520, 0, 911, 1520, 1, 1036, 1520, 0, 1266, 1464, 0, 1372, 1409, 1, 1372, 1100, 1, 1265, 1173, 0, 1057, 1241, 0, 942, 1241, 1, 736, 1241, 0, 500, 977, 0, 500, 745, 1, 500, 514, 0, 736, 250, 0, 942, 250, 1, 1057, 250, 0, 1265, 318, 0, 1372, 391, 1}}},
+		'D':      {advance: 1700, contours: [][]int16{{573, 1202, 1, 573, 291, 1, 711, 291, 1, 947, 291, 0, 1196, 525, 0, 1196, 748, 1, 1196, 970, 0, 948, 1202, 0, 711, 1202, 1}, {188, 1493, 1, 594, 1493, 1, 934, 1493, 0, 1267, 1396, 0, 1386, 1280, 1, 1491, 1179, 0, 1593, 915, 0, 1593, 748, 1, 1593, 579, 0, 1491, 314, 0, 1386, 213, 1, 1266, 97, 0, 930, 0, 0, 594, 0, 1, 188, 0, 1}}},
+		'E':      {advance: 1399, contours: [][]int16{{188, 1493, 1, 1227, 1493, 1, 1227, 1202, 1, 573, 1202, 1, 573, 924, 1, 1188, 924, 1, 1188, 633, 1, 573, 633, 1, 573, 291, 1, 1249, 291, 1, 1249, 0, 1, 188, 0, 1}}},
+		'F':      {advance: 1399, contours: [][]int16{{188, 1493, 1, 1227, 1493, 1, 1227, 1202, 1, 573, 1202, 1, 573, 924, 1, 1188, 924, 1, 1188, 633, 1, 573, 633, 1, 573, 0, 1, 188, 0, 1}}},
+		'G':      {advance: 1681, contours: [][]int16{{1530, 111, 1, 1386, 41, 0, 1076, -29, 0, 911, -29, 1, 538, -29, 0, 102, 388, 0, 102, 745, 1, 102, 1106, 0, 546, 1520, 0, 932, 1520, 1, 1081, 1520, 0, 1354, 1464, 0, 1475, 1409, 1, 1475, 1100, 1, 1350, 1171, 0, 1103, 1241, 0, 979, 1241, 1, 749, 1241, 0, 500, 984, 0, 500, 745, 1, 500, 508, 0, 740, 250, 0, 961, 250, 1, 1021, 250, 0, 1124, 265, 0, 1165, 281, 1, 1165, 571, 1, 930, 571, 1, 930, 829, 1, 1530, 829, 1}}},
+		'H':      {advance: 1714, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 924, 1, 1141, 924, 1, 1141, 1493, 1, 1526, 1493, 1, 1526, 0, 1, 1141, 0, 1, 1141, 633, 1, 573, 633, 1, 573, 0, 1, 188, 0, 1}}},
+		'I':      {advance: 762, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 0, 1, 188, 0, 1}}},
+		'J':      {advance: 762, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 145, 1, 573, -134, 0, 270, -410, 0, -37, -410, 1, -115, -410, 1, -115, -119, 1, -55, -119, 1, 65, -119, 0, 188, 15, 0, 188, 145, 1}}},
+		'K':      {advance: 1587, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 948, 1, 1128, 1493, 1, 1575, 1493, 1, 856, 786, 1, 1649, 0, 1, 1167, 0, 1, 573, 588, 1, 573, 0, 1, 188, 0, 1}}},
+		'L':      {advance: 1305, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 291, 1, 1249, 291, 1, 1249, 0, 1, 188, 0, 1}}},
+		'M':      {advance: 2038, contours: [][]int16{{188, 1493, 1, 678, 1493, 1, 1018, 694, 1, 1360, 1493, 1, 1849, 1493, 1, 1849, 0, 1, 1485, 0, 1, 1485, 1092, 1, 1141, 287, 1, 897, 287, 1, 553, 1092, 1, 553, 0, 1, 188, 0, 1}}},
+		'N':      {advance: 1714, contours: [][]int16{{188, 1493, 1, 618, 1493, 1, 1161, 469, 1, 1161, 1493, 1, 1526, 1493, 1, 1526, 0, 1, 1096, 0, 1, 553, 1024, 1, 553, 0, 1, 188, 0, 1}}},
+		'O':      {advance: 1741, contours: [][]int16{{870, 1241, 1, 694, 1241, 0, 500, 981, 0, 500, 745, 1, 500, 510, 0, 694, 250, 0, 870, 250, 1, 1047, 250, 0, 1241, 510, 0, 1241, 745, 1, 1241, 981, 0, 1047, 1241, 0}, {870, 1520, 1, 1230, 1520, 0, 1638, 1108, 0, 1638, 745, 1, 1638, 383, 0, 1230, -29, 0, 870, -29, 1, 511, -29, 0, 102, 383, 0, 102, 745, 1, 102, 1108, 0, 511, 1520, 0}}},
+		'P':      {advance: 1501, contours: [][]int16{{188, 1493, 1, 827, 1493, 1, 1112, 1493, 0, 1417, 1240, 0, 1417, 1006, 1, 1417, 771, 0, 1112, 518, 0, 827, 518, 1, 573, 518, 1, 573, 0, 1, 188, 0, 1}, {573, 1214, 1, 573, 797, 1, 786, 797, 1, 898, 797, 0, 1020, 906, 0, 1020, 1006, 1, 1020, 1106, 0, 898, 1214, 0, 786, 1214, 1}}},
+		'Q':      {advance: 1741, contours: [][]int16{{911, -27, 1, 881, -27, 1, 512, -27, 0, 102, 381, 0, 102, 745, 1, 102, 1108, 0, 511, 1520, 0, 870, 1520, 1, 1233, 1520, 0, 1638, 1112, 0, 1638, 745, 1, 1638, 493, 0, 1423, 129, 0, 1221, 37, 1, 1522, -299, 1, 1155, -299, 1}, {870, 1241, 1, 694, 1241, 0, 500, 981, 0, 500, 745, 1, 500, 505, 0, 690, 250, 0, 870, 250, 1, 1047, 250, 0, 1241, 510, 0, 1241, 745, 1, 1241, 981, 0, 1047, 1241, 0}}},
+		'R':      {advance: 1577, contours: [][]int16{{735, 831, 1, 856, 831, 0, 961, 921, 0, 961, 1024, 1, 961, 1126, 0, 856, 1214, 0, 735, 1214, 1, 573, 1214, 1, 573, 831, 1}, {573, 565, 1, 573, 0, 1, 188, 0, 1, 188, 1493, 1, 776, 1493, 1, 1071, 1493, 0, 1346, 1295, 0, 1346, 1081, 1, 1346, 933, 0, 1203, 743, 0, 1059, 698, 1, 1138, 680, 0, 1263, 553, 0, 1327, 424, 1, 1536, 0, 1, 1126, 0, 1, 944, 371, 1, 889, 483, 0, 776, 565, 0, 682, 565, 1}}},
+		'S':      {advance: 1475, contours: [][]int16{{1227, 1446, 1, 1227, 1130, 1, 1104, 1185, 0, 870, 1241, 0, 766, 1241, 1, 628, 1241, 0, 496, 1165, 0, 496, 1085, 1, 496, 1025, 0, 585, 958, 0, 702, 934, 1, 866, 901, 1, 1115, 851, 0, 1325, 647, 0, 1325, 459, 1, 1325, 212, 0, 1032, -29, 0, 731, -29, 1, 589, -29, 0, 303, 25, 0, 160, 78, 1, 160, 403, 1, 303, 327, 0, 570, 250, 0, 694, 250, 1, 820, 250, 0, 954, 334, 0, 954, 412, 1, 954, 482, 0, 863, 558, 0, 727, 588, 1, 578, 621, 1, 354, 669, 0, 147, 879, 0, 147, 1057, 1, 147, 1280, 0, 435, 1520, 0, 705, 1520, 1, 828, 1520, 0, 1088, 1483, 0}}},
+		'T':      {advance: 1397, contours: [][]int16{{10, 1493, 1, 1386, 1493, 1, 1386, 1202, 1, 891, 1202, 1, 891, 0, 1, 506, 0, 1, 506, 1202, 1, 10, 1202, 1}}},
+		'U':      {advance: 1663, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 598, 1, 573, 413, 0, 694, 254, 0, 831, 254, 1, 969, 254, 0, 1090, 413, 0, 1090, 598, 1, 1090, 1493, 1, 1475, 1493, 1, 1475, 598, 1, 1475, 281, 0, 1157, -29, 0, 831, -29, 1, 506, -29, 0, 188, 281, 0, 188, 598, 1}}},
+		'V':      {advance: 1585, contours: [][]int16{{10, 1493, 1, 397, 1493, 1, 793, 391, 1, 1188, 1493, 1, 1575, 1493, 1, 1022, 0, 1, 563, 0, 1}}},
+		'W':      {advance: 2259, contours: [][]int16{{61, 1493, 1, 430, 1493, 1, 688, 408, 1, 944, 1493, 1, 1315, 1493, 1, 1571, 408, 1, 1829, 1493, 1, 2195, 1493, 1, 1843, 0, 1, 1399, 0, 1, 1128, 1135, 1, 860, 0, 1, 416, 0, 1}}},
+		'X':      {advance: 1579, contours: [][]int16{{1020, 762, 1, 1538, 0, 1, 1137, 0, 1, 788, 510, 1, 442, 0, 1, 39, 0, 1, 557, 762, 1, 59, 1493, 1, 461, 1493, 1, 788, 1012, 1, 1114, 1493, 1, 1518, 1493, 1}}},
+		'Y':      {advance: 1483, contours: [][]int16{{-20, 1493, 1, 401, 1493, 1, 741, 961, 1, 1081, 1493, 1, 1503, 1493, 1, 934, 629, 1, 934, 0, 1, 549, 0, 1, 549, 629, 1}}},
+		'Z':      {advance: 1485, contours: [][]int16{{115, 1493, 1, 1370, 1493, 1, 1370, 1260, 1, 569, 291, 1, 1393, 291, 1, 1393, 0, 1, 92, 0, 1, 92, 233, 1, 893, 1202, 1, 115, 1202, 1}}},
+		'[':      {advance: 936, contours: [][]int16{{176, 1556, 1, 797, 1556, 1, 797, 1331, 1, 516, 1331, 1, 516, -45, 1, 797, -45, 1, 797, -270, 1, 176, -270, 1}}},
+		'\\':     {advance: 748, contours: [][]int16{{526, -190, 1, 0, 1493, 1, 221, 1493, 1, 748, -190, 1}}},
+		']':      {advance: 936, contours: [][]int16{{760, -270, 1, 139, -270, 1, 139, -45, 1, 420, -45, 1, 420, 1331, 1, 139, 1331, 1, 139, 1556, 1, 760, 1556, 1}}},
+		'^':      {advance: 1716, contours: [][]int16{{981, 1493, 1, 1509, 936, 1, 1268, 936, 1, 858, 1237, 1, 449, 936, 1, 207, 936, 1, 735, 1493, 1}}},
+		'_':      {advance: 1024, contours: [][]int16{{1024, -293, 1, 1024, -483, 1, 0, -483, 1, 0, -293, 1}}},
+		'`':      {advance: 1024, contours: [][]int16{{377, 1638, 1, 659, 1262, 1, 463, 1262, 1, 94, 1638, 1}}},
+		'a':      {advance: 1382, contours: [][]int16{{674, 504, 1, 562, 504, 0, 449, 428, 0, 449, 354, 1, 449, 286, 0, 540, 209, 0, 621, 209, 1, 722, 209, 0, 860, 354, 0, 860, 463, 1, 860, 504, 1}, {1221, 639, 1, 1221, 0, 1, 860, 0, 1, 860, 166, 1, 788, 64, 0, 608, -29, 0, 479, -29, 1, 305, -29, 0, 88, 174, 0, 88, 336, 1, 88, 533, 0, 359, 717, 0, 649, 717, 1, 860, 717, 1, 860, 745, 1, 860, 830, 0, 726, 909, 0, 584, 909, 1, 469, 909, 0, 271, 863, 0, 186, 817, 1, 186, 1090, 1, 301, 1118, 0, 533, 1147, 0, 649, 1147, 1, 952, 1147, 0, 1221, 908, 0}}},
+		'b':      {advance: 1466, contours: [][]int16{{768, 231, 1, 883, 231, 0, 1004, 399, 0, 1004, 559, 1, 1004, 719, 0, 883, 887, 0, 768, 887, 1, 653, 887, 0, 530, 718, 0, 530, 559, 1, 530, 400, 0, 653, 231, 0}, {530, 956, 1, 604, 1054, 0, 784, 1147, 0, 901, 1147, 1, 1108, 1147, 0, 1374, 818, 0, 1374, 559, 1, 1374, 300, 0, 1108, -29, 0, 901, -29, 1, 784, -29, 0, 604, 64, 0, 530, 162, 1, 530, 0, 1, 172, 0, 1, 172, 1556, 1, 530, 1556, 1}}},
+		'c':      {advance: 1214, contours: [][]int16{{1077, 1085, 1, 1077, 793, 1, 1004, 843, 0, 857, 891, 0, 778, 891, 1, 628, 891, 0, 461, 716, 0, 461, 559, 1, 461, 402, 0, 628, 227, 0, 778, 227, 1, 862, 227, 0, 1013, 277, 0, 1077, 326, 1, 1077, 33, 1, 993, 2, 0, 820, -29, 0, 733, -29, 1, 430, -29, 0, 88, 282, 0, 88, 559, 1, 88, 836, 0, 430, 1147, 0, 733, 1147, 1, 821, 1147, 0, 992, 1116, 0}}},
+		'd':      {advance: 1466, contours: [][]int16{{934, 956, 1, 934, 1556, 1, 1294, 1556, 1, 1294, 0, 1, 934, 0, 1, 934, 162, 1, 860, 63, 0, 682, -29, 0, 565, -29, 1, 358, -29, 0, 92, 300, 0, 92, 559, 1, 92, 818, 0, 358, 1147, 0, 565, 1147, 1, 681, 1147, 0, 860, 1054, 0}, {698, 231, 1, 813, 231, 0, 934, 399, 0, 934, 559, 1, 934, 719, 0, 813, 887, 0, 698, 887, 1, 584, 887, 0, 463, 719, 0, 463, 559, 1, 463, 399, 0, 584, 231, 0}}},
+		'e':      {advance: 1389, contours: [][]int16{{1290, 563, 1, 1290, 461, 1, 453, 461, 1, 466, 335, 0, 622, 209, 0, 762, 209, 1, 875, 209, 0, 1112, 276, 0, 1237, 344, 1, 1237, 68, 1, 1110, 20, 0, 856, -29, 0, 729, -29, 1, 425, -29, 0, 88, 280, 0, 88, 559, 1, 88, 833, 0, 419, 1147, 0, 709, 1147, 1, 973, 1147, 0, 1290, 829, 0}, {922, 682, 1, 922, 784, 0, 803, 909, 0, 707, 909, 1, 603, 909, 0, 473, 792, 0, 457, 682, 1}}},
+		'f':      {advance: 891, contours: [][]int16{{909, 1556, 1, 909, 1321, 1, 711, 1321, 1, 635, 1321, 0, 575, 1266, 0, 575, 1198, 1, 575, 1120, 1, 881, 1120, 1, 881, 864, 1, 575, 864, 1, 575, 0, 1, 217, 0, 1, 217, 864, 1, 39, 864, 1, 39, 1120, 1, 217, 1120, 1, 217, 1198, 1, 217, 1381, 0, 421, 1556, 0, 635, 1556, 1}}},
+		'g':      {advance: 1466, contours: [][]int16{{934, 190, 1, 860, 92, 0, 682, 0, 0, 565, 0, 1, 360, 0, 0, 92, 323, 0, 92, 573, 1, 92, 824, 0, 360, 1145, 0, 565, 1145, 1, 682, 1145, 0, 860, 1053, 0, 934, 954, 1, 934, 1120, 1, 1294, 1120, 1, 1294, 113, 1, 1294, -157, 0, 953, -442, 0, 629, -442, 1, 524, -442, 0, 328, -410, 0, 229, -377, 1, 229, -98, 1, 323, -152, 0, 503, -205, 0, 594, -205, 1, 770, -205, 0, 934, -51, 0, 934, 113, 1}, {698, 887, 1, 587, 887, 0, 463, 723, 0, 463, 573, 1, 463, 419, 0, 583, 260, 0, 698, 260, 1, 810, 260, 0, 934, 424, 0, 934, 573, 1, 934, 723, 0, 810, 887, 0}}},
+		'h':      {advance: 1458, contours: [][]int16{{1298, 682, 1, 1298, 0, 1, 938, 0, 1, 938, 111, 1, 938, 520, 1, 938, 667, 0, 925, 777, 0, 909, 803, 1, 888, 838, 0, 816, 877, 0, 770, 877, 1, 658, 877, 0, 530, 704, 0, 530, 551, 1, 530, 0, 1, 172, 0, 1, 172, 1556, 1, 530, 1556, 1, 530, 956, 1, 611, 1054, 0, 793, 1147, 0, 903, 1147, 1, 1097, 1147, 0, 1298, 909, 0}}},
+		'i':      {advance: 702, contours: [][]int16{{172, 1120, 1, 530, 1120, 1, 530, 0, 1, 172, 0, 1}, {172, 1556, 1, 530, 1556, 1, 530, 1264, 1, 172, 1264, 1}}},
+		'j':      {advance: 702, contours: [][]int16{{172, 1120, 1, 530, 1120, 1, 530, 20, 1, 530, -205, 0, 314, -442, 0, 109, -442, 1, -68, -442, 1, -68, -207, 1, -6, -207, 1, 96, -207, 0, 172, -115, 0, 172, 20, 1}, {172, 1556, 1, 530, 1556, 1, 530, 1264, 1, 172, 1264, 1}}},
+		'k':      {advance: 1362, contours: [][]int16{{172, 1556, 1, 530, 1556, 1, 530, 709, 1, 942, 1120, 1, 1358, 1120, 1, 811, 606, 1, 1401, 0, 1, 967, 0, 1, 530, 467, 1, 530, 0, 1, 172, 0, 1}}},
+		'l':      {advance: 702, contours: [][]int16{{172, 1556, 1, 530, 1556, 1, 530, 0, 1, 172, 0, 1}}},
+		'm':      {advance: 2134, contours: [][]int16{{1210, 934, 1, 1278, 1038, 0, 1465, 1147, 0, 1577, 1147, 1, 1770, 1147, 0, 1972, 909, 0, 1972, 682, 1, 1972, 0, 1, 1612, 0, 1, 1612, 584, 1, 1613, 597, 0, 1614, 625, 0, 1614, 651, 1, 1614, 770, 0, 1544, 877, 0, 1466, 877, 1, 1364, 877, 0, 1253, 709, 0, 1251, 550, 1, 1251, 0, 1, 891, 0, 1, 891, 584, 1, 891, 770, 0, 827, 877, 0, 745, 877, 1, 642, 877, 0, 530, 708, 0, 530, 551, 1, 530, 0, 1, 170, 0, 1, 170, 1120, 1, 530, 1120, 1, 530, 956, 1, 596, 1051, 0, 767, 1147, 0, 870, 1147, 1, 986, 1147, 0, 1164, 1035, 0}}},
+		'n':      {advance: 1458, contours: [][]int16{{1298, 682, 1, 1298, 0, 1, 938, 0, 1, 938, 111, 1, 938, 522, 1, 938, 667, 0, 925, 777, 0, 909, 803, 1, 888, 838, 0, 816, 877, 0, 770, 877, 1, 658, 877, 0, 530, 704, 0, 530, 551, 1, 530, 0, 1, 172, 0, 1, 172, 1120, 1, 530, 1120, 1, 530, 956, 1, 611, 1054, 0, 793, 1147, 0, 903, 1147, 1, 1097, 1147, 0, 1298, 909, 0}}},
+		'o':      {advance: 1407, contours: [][]int16{{705, 891, 1, 586, 891, 0, 461, 720, 0, 461, 559, 1, 461, 398, 0, 586, 227, 0, 705, 227, 1, 822, 227, 0, 946, 398, 0, 946, 559, 1, 946, 720, 0, 822, 891, 0}, {705, 1147, 1, 994, 1147, 0, 1319, 835, 0, 1319, 559, 1, 1319, 283, 0, 994, -29, 0, 705, -29, 1, 415, -29, 0, 88, 283, 0, 88, 559, 1, 88, 835, 0, 415, 1147, 0}}},
+		'p':      {advance: 1466, contours: [][]int16{{530, 162, 1, 530, -426, 1, 172, -426, 1, 172, 1120, 1, 530, 1120, 1, 530, 956, 1, 604, 1054, 0, 784, 1147, 0, 901, 1147, 1, 1108, 1147, 0, 1374, 818, 0, 1374, 559, 1, 1374, 300, 0, 1108, -29, 0, 901, -29, 1, 784, -29, 0, 604, 64, 0}, {768, 887, 1, 653, 887, 0, 530, 718, 0, 530, 559, 1, 530, 400, 0, 653, 231, 0, 768, 231, 1, 883, 231, 0, 1004, 399, 0, 1004, 559, 1, 1004, 719, 0, 883, 887, 0}}},
+		'q':      {advance: 1466, contours: [][]int16{{698, 887, 1, 584, 887, 0, 463, 719, 0, 463, 559, 1, 463, 399, 0, 584, 231, 0, 698, 231, 1, 813, 231, 0, 934, 399, 0, 934, 559, 1, 934, 719, 0, 813, 887, 0}, {934, 162, 1, 860, 63, 0, 682, -29, 0, 565, -29, 1, 358, -29, 0, 92, 300, 0, 92, 559, 1, 92, 818, 0, 358, 1145, 0, 565, 1145, 1, 682, 1145, 0, 860, 1053, 0, 934, 954, 1, 934, 1120, 1, 1294, 1120, 1, 1294, -426, 1, 934, -426, 1}}},
+		'r':      {advance: 1010, contours: [][]int16{{1004, 815, 1, 957, 837, 0, 864, 858, 0, 817, 858, 1, 679, 858, 0, 530, 681, 0, 530, 516, 1, 530, 0, 1, 172, 0, 1, 172, 1120, 1, 530, 1120, 1, 530, 936, 1, 599, 1046, 0, 778, 1147, 0, 903, 1147, 1, 921, 1147, 0, 963, 1144, 0, 1003, 1139, 1}}},
+		's':      {advance: 1219, contours: [][]int16{{1047, 1085, 1, 1047, 813, 1, 932, 861, 0, 718, 909, 0, 623, 909, 1, 521, 909, 0, 422, 858, 0, 422, 805, 1, 422, 762, 0, 497, 716, 0, 594, 705, 1, 657, 696, 1, 932, 661, 0, 1122, 501, 0, 1122, 330, 1, 1122, 151, 0, 858, -29, 0, 596, -29, 1, 485, -29, 0, 248, 6, 0, 123, 41, 1, 123, 313, 1, 230, 261, 0, 455, 209, 0, 571, 209, 1, 676, 209, 0, 782, 267, 0, 782, 324, 1, 782, 372, 0, 709, 419, 0, 600, 432, 1, 537, 440, 1, 298, 470, 0, 106, 632, 0, 106, 797, 1, 106, 975, 0, 350, 1147, 0, 602, 1147, 1, 701, 1147, 0, 919, 1117, 0}}},
+		't':      {advance: 979, contours: [][]int16{{563, 1438, 1, 563, 1120, 1, 932, 1120, 1, 932, 864, 1, 563, 864, 1, 563, 389, 1, 563, 311, 0, 625, 256, 0, 717, 256, 1, 901, 256, 1, 901, 0, 1, 594, 0, 1, 382, 0, 0, 205, 177, 0, 205, 389, 1, 205, 864, 1, 27, 864, 1, 27, 1120, 1, 205, 1120, 1, 205, 1438, 1}}},
+		'u':      {advance: 1458, contours: [][]int16{{160, 436, 1, 160, 1120, 1, 520, 1120, 1, 520, 1008, 1, 520, 917, 0, 518, 642, 0, 518, 596, 1, 518, 461, 0, 532, 342, 0, 549, 315, 1, 571, 280, 0, 642, 242, 0, 688, 242, 1, 800, 242, 0, 928, 414, 0, 928, 567, 1, 928, 1120, 1, 1286, 1120, 1, 1286, 0, 1, 928, 0, 1, 928, 162, 1, 847, 64, 0, 666, -29, 0, 557, -29, 1, 363, -29, 0, 160, 209, 0}}},
+		'v':      {advance: 1335, contours: [][]int16{{31, 1120, 1, 389, 1120, 1, 668, 346, 1, 946, 1120, 1, 1305, 1120, 1, 864, 0, 1, 471, 0, 1}}},
+		'w':      {advance: 1892, contours: [][]int16{{72, 1120, 1, 420, 1120, 1, 608, 348, 1, 797, 1120, 1, 1096, 1120, 1, 1284, 356, 1, 1473, 1120, 1, 1821, 1120, 1, 1526, 0, 1, 1135, 0, 1, 946, 770, 1, 758, 0, 1, 367, 0, 1}}},
+		'x':      {advance: 1321, contours: [][]int16{{455, 573, 1, 51, 1120, 1, 430, 1120, 1, 659, 788, 1, 891, 1120, 1, 1270, 1120, 1, 866, 575, 1, 1290, 0, 1, 911, 0, 1, 659, 354, 1, 410, 0, 1, 31, 0, 1}}},
+		'y':      {advance: 1335, contours: [][]int16{{25, 1120, 1, 383, 1120, 1, 684, 360, 1, 940, 1120, 1, 1298, 1120, 1, 827, -106, 1, 756, -293, 0, 567, -442, 0, 412, -442, 1, 205, -442, 1, 205, -207, 1, 317, -207, 1, 408, -207, 0, 491, -149, 0, 514, -74, 1, 524, -43, 1}}},
+		'z':      {advance: 1192, contours: [][]int16{{117, 1120, 1, 1094, 1120, 1, 1094, 870, 1, 504, 256, 1, 1094, 256, 1, 1094, 0, 1, 92, 0, 1, 92, 250, 1, 682, 864, 1, 117, 864, 1}}},
+		'{':      {advance: 1458, contours: [][]int16{{1202, -109, 1, 1202, -334, 1, 985, -334, 1, 767, -334, 0, 567, -158, 0, 567, 35, 1, 567, 227, 1, 567, 377, 0, 459, 494, 0, 317, 494, 1, 256, 494, 1, 256, 717, 1, 317, 717, 1, 459, 717, 0, 567, 833, 0, 567, 983, 1, 567, 1188, 1, 567, 1381, 0, 767, 1556, 0, 985, 1556, 1, 1202, 1556, 1, 1202, 1331, 1, 1133, 1331, 1, 992, 1331, 0, 907, 1244, 0, 907, 1102, 1, 907, 936, 1, 907, 779, 0, 817, 637, 0, 707, 612, 1, 818, 585, 0, 907, 443, 0, 907, 287, 1, 907, 121, 1, 907, -22, 0, 992, -109, 0, 1133, -109, 1}}},
+		'|':      {advance: 748, contours: [][]int16{{487, 1565, 1, 487, -483, 1, 260, -483, 1, 260, 1565, 1}}},
+		'}':      {advance: 1458, contours: [][]int16{{256, -109, 1, 326, -109, 1, 466, -109, 0, 551, -22, 0, 551, 121, 1, 551, 287, 1, 551, 443, 0, 641, 585, 0, 752, 612, 1, 641, 637, 0, 551, 779, 0, 551, 936, 1, 551, 1102, 1, 551, 1244, 0, 466, 1331, 0, 326, 1331, 1, 256, 1331, 1, 256, 1556, 1, 473, 1556, 1, 691, 1556, 0, 891, 1381, 0, 891, 1188, 1, 891, 983, 1, 891, 833, 0, 999, 717, 0, 1141, 717, 1, 1202, 717, 1, 1202, 494, 1, 1141, 494, 1, 999, 494, 0, 891, 377, 0, 891, 227, 1, 891, 35, 1, 891, -158, 0, 691, -334, 0, 473, -334, 1, 256, -334, 1}}},
+		'~':      {advance: 1716, contours: [][]int16{{1499, 850, 1, 1499, 606, 1, 1393, 526, 0, 1214, 457, 0, 1118, 457, 1, 1011, 457, 0, 868, 515, 1, 854, 521, 0, 846, 524, 1, 839, 527, 0, 824, 533, 1, 669, 594, 0, 575, 594, 1, 487, 594, 0, 315, 517, 0, 217, 434, 1, 217, 678, 1, 324, 758, 0, 502, 827, 0, 598, 827, 1, 705, 827, 0, 848, 769, 1, 863, 763, 0, 870, 760, 1, 877, 757, 0, 892, 751, 1, 1047, 690, 0, 1141, 690, 1, 1227, 690, 0, 1396, 765, 0}}},
+		'\u00a0': {advance: 713},
+		'¡':      {advance: 934, contours: [][]int16{{287, -373, 1, 287, 200, 1, 338, 618, 1, 596, 618, 1, 647, 200, 1, 647, -373, 1}, {287, 764, 1, 287, 1120, 1, 647, 1120, 1, 647, 764, 1}}},
+		'¢':      {advance: 1425, contours: [][]int16{{702, 858, 1, 624, 814, 0, 547, 667, 0, 547, 559, 1, 547, 450, 0, 624, 302, 0, 702, 260, 1}, {1161, 1085, 1, 1161, 793, 1, 1087, 841, 0, 944, 891, 0, 879, 891, 1, 864, 891, 1, 864, 228, 1, 953, 229, 0, 1104, 279, 0, 1161, 326, 1, 1161, 33, 1, 1078, 3, 0, 932, -29, 0, 874, -29, 1, 864, -29, 1, 864, -313, 1, 702, -313, 1, 702, -25, 1, 440, 15, 0, 174, 317, 0, 174, 575, 1, 174, 819, 0, 444, 1110, 0, 702, 1145, 1, 702, 1432, 1, 864, 1432, 1, 865, 1145, 1, 936, 1142, 0, 1083, 1113, 0}}},
+		'£':      {advance: 1425, contours: [][]int16{{1243, 1466, 1, 1243, 1180, 1, 1173, 1219, 0, 1025, 1257, 0, 948, 1257, 1, 830, 1257, 0, 717, 1132, 0, 717, 1001, 1, 717, 831, 1, 1090, 831, 1, 1090, 592, 1, 717, 592, 1, 717, 266, 1, 1255, 266, 1, 1255, 0, 1, 125, 0, 1, 125, 266, 1, 352, 266, 1, 352, 592, 1, 158, 592, 1, 158, 831, 1, 352, 831, 1, 352, 1001, 1, 352, 1272, 0, 606, 1520, 0, 881, 1520, 1, 973, 1520, 0, 1154, 1493, 0}}},
+		'¤':      {advance: 1303, contours: [][]int16{{434, 268, 1, 227, 61, 1, 74, 215, 1, 281, 422, 1, 253, 471, 0, 225, 578, 0, 225, 641, 1, 225, 704, 0, 255, 812, 0, 285, 858, 1, 76, 1063, 1, 229, 1217, 1, 436, 1010, 1, 484, 1040, 0, 592, 1069, 0, 653, 1069, 1, 707, 1069, 0, 815, 1042, 0, 872, 1014, 1, 1079, 1221, 1, 1231, 1067, 1, 1024, 860, 1, 1053, 805, 0, 1081, 695, 0, 1081, 641, 1, 1081, 578, 0, 1052, 473, 0, 1022, 426, 1, 1229, 219, 1, 1075, 66, 1, 868, 272, 1, 822, 242, 0, 716, 213, 0, 653, 213, 1, 595, 213, 0, 487, 240, 0}, {653, 422, 1, 744, 422, 0, 872, 549, 0, 872, 641, 1, 872, 733, 0, 745, 860, 0, 653, 860, 1, 562, 860, 0, 434, 733, 0, 434, 641, 1, 434, 548, 0, 560, 422, 0}}},
+		'¥':      {advance: 1425, contours: [][]int16{{1358, 416, 1, 903, 416, 1, 903, 0, 1, 522, 0, 1, 522, 416, 1, 68, 416, 1, 68, 610, 1, 522, 610, 1, 522, 676, 1, 473, 762, 1, 68, 762, 1, 68, 954, 1, 360, 954, 1, 25, 1493, 1, 424, 1493, 1, 713, 1032, 1, 1001, 1493, 1, 1401, 1493, 1, 1065, 954, 1, 1358, 954, 1, 1358, 762, 1, 952, 762, 1, 903, 676, 1, 903, 610, 1, 1358, 610, 1}}},
+		'¦':      {advance: 748, contours: [][]int16{{487, 1432, 1, 487, 674, 1, 260, 674, 1, 260, 1432, 1}, {487, 408, 1, 487, -350, 1, 260, -350, 1, 260, 408, 1}}},
+		'§':      {advance: 1024, contours: [][]int16{{885, 1462, 1, 885, 1235, 1, 786, 1274, 0, 628, 1313, 0, 571, 1313, 1, 496, 1313, 0, 420, 1264, 0, 420, 1217, 1, 420, 1150, 0, 608, 1071, 1, 634, 1060, 0, 647, 1055, 1, 857, 966, 0, 1016, 793, 0, 1016, 668, 1, 1016, 551, 0, 903, 392, 0, 786, 344, 1, 863, 303, 0, 938, 190, 0, 938, 117, 1, 938, -28, 0, 696, -195, 0, 483, -195, 1, 398, -195, 0, 217, -166, 0, 115, -137, 1, 115, 100, 1, 230, 59, 0, 412, 16, 0, 469, 16, 1, 534, 16, 0, 612, 66, 0, 612, 106, 1, 612, 176, 0, 432, 250, 1, 396, 264, 0, 377, 272, 1, 174, 359, 0, 14, 538, 0, 14, 668, 1, 14, 772, 0, 125, 926, 0, 238, 977, 1, 163, 1028, 0, 98, 1139, 0, 98, 1214, 1, 98, 1358, 0, 327, 1520, 0, 528, 1520, 1, 612, 1520, 0, 792, 1491, 0}, {434, 856, 1, 366, 828, 0, 299, 752, 0, 299, 702, 1, 299, 635, 0, 422, 537, 0, 604, 471, 1, 669, 494, 0, 739, 573, 0, 739, 625, 1, 739, 692, 0, 601, 798, 0}}},
+		'¨':      {advance: 1024, contours: [][]int16{{197, 1585, 1, 432, 1585, 1, 432, 1339, 1, 197, 1339, 1}, {592, 1585, 1, 827, 1585, 1, 827, 1339, 1, 592, 1339, 1}}},
+		'©':      {advance: 2048, contours: [][]int16{{1323, 1126, 1, 1323, 911, 1, 1266, 948, 0, 1155, 983, 0, 1098, 983, 1, 985, 983, 0, 858, 855, 0, 858, 741, 1, 858, 626, 0, 984, 500, 0, 1098, 500, 1, 1162, 500, 0, 1277, 536, 0, 1323, 571, 1, 1323, 358, 1, 1258, 336, 0, 1127, 313, 0, 1065, 313, 1, 854, 313, 0, 600, 547, 0, 600, 741, 1, 600, 936, 0, 854, 1169, 0, 1065, 1169, 1, 1134, 1169, 0, 1262, 1148, 0}, {1024, 1331, 1, 903, 1331, 0, 695, 1244, 0, 608, 1157, 1, 521, 1070, 0, 434, 863, 0, 434, 741, 1, 434, 620, 0, 521, 413, 0, 608, 326, 1, 694, 240, 0, 903, 154, 0, 1024, 154, 1, 1147, 154, 0, 1353, 239, 0, 1440, 326, 1, 1527, 413, 0, 1614, 620, 0, 1614, 741, 1, 1614, 863, 0, 1527, 1070, 0, 1440, 1157, 1, 1352, 1245, 0, 1145, 1331, 0}, {1024, 1485, 1, 1176, 1485, 0, 1439, 1375, 0, 1548, 1266, 1, 1657, 1157, 0, 1765, 895, 0, 1765, 741, 1, 1765, 589, 0, 1657, 328, 0, 1548, 219, 1, 1439, 110, 0, 1176, 0, 0, 1024, 0, 1, 872, 0, 0, 609, 110, 0, 500, 219, 1, 391, 328, 0, 283, 589, 0, 283, 741, 1, 283, 895, 0, 391, 1157, 0, 500, 1266, 1, 609, 1375, 0, 872, 1485, 0}}},
+		'ª':      {advance: 1155, contours: [][]int16{{176, 573, 1, 989, 573, 1, 989, 373, 1, 176, 373, 1}, {643, 1081, 1, 510, 1081, 0, 406, 1029, 0, 406, 967, 1, 406, 916, 0, 472, 858, 0, 530, 858, 1, 619, 858, 0, 733, 972, 0, 733, 1059, 1, 733, 1081, 1}, {1001, 1165, 1, 1001, 717, 1, 756, 717, 1, 756, 844, 1, 701, 768, 0, 561, 696, 0, 467, 696, 1, 322, 696, 0, 158, 830, 0, 158, 946, 1, 158, 1087, 0, 368, 1219, 0, 594, 1219, 1, 731, 1219, 1, 731, 1239, 1, 731, 1295, 0, 642, 1354, 0, 557, 1354, 1, 470, 1354, 0, 304, 1319, 0, 225, 1284, 1, 225, 1464, 1, 317, 1492, 0, 486, 1520, 0, 561, 1520, 1, 785, 1520, 0, 1001, 1345, 0}}},
+		'«':      {advance: 1323, contours: [][]int16{{651, 1063, 1, 651, 821, 1, 358, 600, 1, 651, 379, 1, 651, 137, 1, 158, 506, 1, 158, 692, 1}, {1130, 1063, 1, 1130, 821, 1, 838, 600, 1, 1130, 379, 1, 1130, 137, 1, 637, 506, 1, 637, 692, 1}}},
+		'¬':      {advance: 1716, contours: [][]int16{{217, 909, 1, 1499, 909, 1, 1499, 287, 1, 1264, 287, 1, 1264, 672, 1, 217, 672, 1}}},
+		'\u00ad': {advance: 850, contours: [][]int16{{111, 735, 1, 739, 735, 1, 739, 444, 1, 111, 444, 1}}},
+		'®':      {advance: 2048, contours: [][]int16{{1024, 1331, 1, 903, 1331, 0, 695, 1244, 0, 608, 1157, 1, 521, 1070, 0, 434, 863, 0, 434, 741, 1, 434, 620, 0, 521, 413, 0, 608, 326, 1, 694, 240, 0, 903, 154, 0, 1024, 154, 1, 1147, 154, 0, 1353, 239, 0, 1440, 326, 1, 1527, 413, 0, 1614, 620, 0, 1614, 741, 1, 1614, 863, 0, 1527, 1070, 0, 1440, 1157, 1, 1352, 1245, 0, 1145, 1331, 0}, {967, 1036, 1, 932, 1036, 1, 932, 829, 1, 967, 829, 1, 1045, 829, 0, 1124, 882, 0, 1124, 934, 1, 1124, 986, 0, 1047, 1036, 0}, {1004, 1174, 1, 1180, 1174, 0, 1354, 1055, 0, 1354, 934, 1, 1354, 848, 0, 1249, 736, 0, 1153, 719, 1, 1194, 697, 0, 1265, 617, 0, 1294, 559, 1, 1405, 338, 1, 1176, 338, 1, 1069, 551, 1, 1031, 629, 0, 973, 694, 0, 944, 694, 1, 932, 694, 1, 932, 338, 1, 719, 338, 1, 719, 1174, 1}, {1024, 1485, 1, 1176, 1485, 0, 1439, 1375, 0, 1548, 1266, 1, 1657, 1157, 0, 1765, 895, 0, 1765, 741, 1, 1765, 589, 0, 1657, 328, 0, 1548, 219, 1, 1439, 110, 0, 1176, 0, 0, 1024, 0, 1, 872, 0, 0, 609, 110, 0, 500, 219, 1, 391, 328, 0, 283, 589, 0, 283, 741, 1, 283, 895, 0, 391, 1157, 0, 500, 1266, 1, 609, 1375, 0, 872, 1485, 0}}},
+		'¯':      {advance: 1024, contours: [][]int16{{197, 1556, 1, 827, 1556, 1, 827, 1368, 1, 197, 1368, 1}}},
+		'°':      {advance: 1024, contours: [][]int16{{512, 1372, 1, 440, 1372, 0, 340, 1272, 0, 340, 1200, 1, 340, 1128, 0, 439, 1030, 0, 512, 1030, 1, 584, 1030, 0, 684, 1129, 0, 684, 1200, 1, 684, 1272, 0, 583, 1372, 0}, {512, 1534, 1, 578, 1534, 0, 700, 1483, 0, 748, 1436, 1, 795, 1388, 0, 844, 1268, 0, 844, 1200, 1, 844, 1133, 0, 795, 1012, 0, 750, 967, 1, 702, 919, 0, 578, 868, 0, 510, 868, 1, 369, 868, 0, 178, 1059, 0, 178, 1200, 1, 178, 1341, 0, 371, 1534, 0}}},
+		'±':      {advance: 1716, contours: [][]int16{{977, 1284, 1, 977, 930, 1, 1499, 930, 1, 1499, 694, 1, 977, 694, 1, 977, 340, 1, 739, 340, 1, 739, 694, 1, 217, 694, 1, 217, 930, 1, 739, 930, 1, 739, 1284, 1}, {217, 238, 1, 1499, 238, 1, 1499, 0, 1, 217, 0, 1}}},
+		'²':      {advance: 897, contours: [][]int16{{412, 836, 1, 782, 836, 1, 782, 668, 1, 109, 668, 1, 109, 821, 1, 422, 1087, 1, 483, 1140, 0, 535, 1220, 0, 535, 1260, 1, 535, 1310, 0, 462, 1372, 0, 403, 1372, 1, 341, 1372, 0, 199, 1327, 0, 115, 1280, 1, 115, 1466, 1, 202, 1493, 0, 365, 1520, 0, 440, 1520, 1, 598, 1520, 0, 778, 1391, 0, 778, 1280, 1, 778, 1208, 0, 707, 1087, 0, 606, 1001, 1}}},
+		'³':      {advance: 897, contours: [][]int16{{592, 1120, 1, 684, 1102, 0, 786, 992, 0, 786, 911, 1, 786, 782, 0, 588, 653, 0, 387, 653, 1, 306, 653, 0, 158, 676, 0, 90, 698, 1, 90, 872, 1, 156, 836, 0, 284, 799, 0, 344, 799, 1, 439, 799, 0, 543, 863, 0, 543, 922, 1, 543, 986, 0, 436, 1047, 0, 322, 1047, 1, 248, 1047, 1, 248, 1184, 1, 332, 1184, 1, 430, 1184, 0, 520, 1231, 0, 520, 1282, 1, 520, 1327, 0, 442, 1372, 0, 362, 1372, 1, 310, 1372, 0, 187, 1346, 0, 117, 1319, 1, 117, 1485, 1, 182, 1502, 0, 333, 1520, 0, 420, 1520, 1, 587, 1520, 0, 764, 1408, 0, 764, 1303, 1, 764, 1234, 0, 674, 1138, 0}}},
+		'´':      {advance: 1024, contours: [][]int16{{647, 1638, 1, 930, 1638, 1, 561, 1262, 1, 365, 1262, 1}}},
+		'µ':      {advance: 1507, contours: [][]int16{{174, -428, 1, 174, 1120, 1, 535, 1120, 1, 535, 469, 1, 535, 353, 0, 635, 240, 0, 737, 240, 1, 840, 240, 0, 940, 353, 0, 940, 469, 1, 940, 1120, 1, 1300, 1120, 1, 1300, 371, 1, 1300, 300, 0, 1333, 244, 0, 1372, 244, 1, 1390, 244, 0, 1423, 254, 0, 1442, 266, 1, 1442, 16, 1, 1389, -7, 0, 1296, -29, 0, 1251, -29, 1, 1162, -29, 0, 1049, 46, 0, 1014, 129, 1, 967, 50, 0, 832, -29, 0, 743, -29, 1, 669, -29, 0, 565, 18, 0, 535, 66, 1, 535, -428, 1}}},
+		'¶':      {advance: 1303, contours: [][]int16{{604, 1493, 1, 1124, 1493, 1, 1124, -197, 1, 934, -197, 1, 934, 1346, 1, 745, 1346, 1, 745, -197, 1, 555, -197, 1, 555, 649, 1, 351, 674, 0, 129, 893, 0, 129, 1071, 1, 129, 1261, 0, 389, 1493, 0}}},
+		'·':      {advance: 778, contours: [][]int16{{209, 905, 1, 569, 905, 1, 569, 518, 1, 209, 518, 1}}},
+		'¸':      {advance: 1024, contours: [][]int16{{602, 0, 1, 660, -62, 0, 715, -168, 0, 715, -215, 1, 715, -310, 0, 592, -401, 0, 465, -401, 1, 417, -401, 0, 315, -388, 0, 263, -375, 1, 262, -223, 1, 312, -239, 0, 395, -254, 0, 428, -254, 1, 486, -254, 0, 551, -208, 0, 551, -168, 1, 551, -142, 0, 508, -60, 0, 463, 0, 1}}},
+		'¹':      {advance: 897, contours: [][]int16{{141, 825, 1, 348, 825, 1, 348, 1346, 1, 123, 1294, 1, 123, 1454, 1, 352, 1503, 1, 578, 1503, 1, 578, 825, 1, 782, 825, 1, 782, 668, 1, 141, 668, 1}}},
+		'º':      {advance: 1155, contours: [][]int16{{578, 1520, 1, 791, 1520, 0, 1038, 1298, 0, 1038, 1108, 1, 1038, 918, 0, 792, 698, 0, 578, 698, 1, 364, 698, 0, 117, 918, 0, 117, 1108, 1, 117, 1298, 0, 364, 1520, 0}, {166, 573, 1, 989, 573, 1, 989, 373, 1, 166, 373, 1}, {578, 1350, 1, 494, 1350, 0, 403, 1224, 0, 403, 1108, 1, 403, 992, 0, 494, 868, 0, 578, 868, 1, 661, 868, 0, 752, 992, 0, 752, 1108, 1, 752, 1224, 0, 661, 1350, 0}}},
+		'»':      {advance: 1323, contours: [][]int16{{672, 1063, 1, 1165, 692, 1, 1165, 506, 1, 672, 137, 1, 672, 379, 1, 965, 600, 1, 672, 821, 1}, {193, 1063, 1, 684, 692, 1, 684, 506, 1, 193, 137, 1, 193, 379, 1, 485, 600, 1, 193, 821, 1}}},
+		'¼':      {advance: 2120, contours: [][]int16{{1593, 640, 1, 1593, 640, 0, 1354, 317, 1, 1593, 317, 1}, {1575, 835, 1, 1575, 835, 0, 1823, 835, 1, 1823, 317, 1, 1960, 317, 1, 1960, 162, 1, 1823, 162, 1, 1823, 0, 1, 1593, 0, 1, 1593, 162, 1, 1202, 162, 1, 1202, 330, 1}, {734, -29, 1, 510, -29, 1, 1444, 1520, 1, 1668, 1520, 1}, {118, 825, 1, 325, 825, 1, 325, 1346, 1, 100, 1294, 1, 100, 1454, 1, 329, 1503, 1, 555, 1503, 1, 555, 825, 1, 759, 825, 1, 759, 668, 1, 118, 668, 1}}},
+		'½':      {advance: 2120, contours: [][]int16{{734, -29, 1, 510, -29, 1, 1444, 1520, 1, 1668, 1520, 1}, {1651, 168, 1, 2021, 168, 1, 2021, 0, 1, 1348, 0, 1, 1348, 153, 1, 1661, 419, 1, 1722, 472, 0, 1774, 552, 0, 1774, 592, 1, 1774, 642, 0, 1701, 704, 0, 1642, 704, 1, 1580, 704, 0, 1438, 659, 0, 1354, 612, 1, 1354, 798, 1, 1441, 825, 0, 1604, 852, 0, 1679, 852, 1, 1837, 852, 0, 2017, 723, 0, 2017, 612, 1, 2017, 540, 0, 1946, 419, 0, 1845, 333, 1}, {118, 825, 1, 325, 825, 1, 325, 1346, 1, 100, 1294, 1, 100, 1454, 1, 329, 1503, 1, 555, 1503, 1, 555, 825, 1, 759, 825, 1, 759, 668, 1, 118, 668, 1}}},
+		'¾':      {advance: 2120, contours: [][]int16{{1593, 640, 1, 1593, 640, 0, 1354, 317, 1, 1593, 317, 1}, {1575, 835, 1, 1575, 835, 0, 1823, 835, 1, 1823, 317, 1, 1960, 317, 1, 1960, 162, 1, 1823, 162, 1, 1823, 0, 1, 1593, 0, 1, 1593, 162, 1, 1202, 162, 1, 1202, 330, 1}, {734, -29, 1, 510, -29, 1, 1444, 1520, 1, 1668, 1520, 1}, {606, 1120, 1, 698, 1102, 0, 800, 992, 0, 800, 911, 1, 800, 782, 0, 602, 653, 0, 401, 653, 1, 320, 653, 0, 172, 676, 0, 104, 698, 1, 104, 872, 1, 170, 836, 0, 298, 799, 0, 358, 799, 1, 453, 799, 0, 557, 863, 0, 557, 922, 1, 557, 986, 0, 450, 1047, 0, 336, 1047, 1, 262, 1047, 1, 262, 1184, 1, 346, 1184, 1, 444, 1184, 0, 534, 1231, 0, 534, 1282, 1, 534, 1327, 0, 456, 1372, 0, 376, 1372, 1, 324, 1372, 0, 201, 1346, 0, 131, 1319, 1, 131, 1485, 1, 196, 1502, 0, 347, 1520, 0, 434, 1520, 1, 601, 1520, 0, 778, 1408, 0, 778, 1303, 1, 778, 1234, 0, 688, 1138, 0}}},
+		'¿':      {advance: 1188, contours: [][]int16{{487, 614, 1, 848, 614, 1, 848, 565, 1, 848, 484, 0, 783, 358, 0, 674, 258, 1, 610, 200, 1, 554, 149, 0, 502, 57, 0, 502, 10, 1, 502, -60, 0, 598, -140, 0, 684, -140, 1, 765, -140, 0, 954, -72, 0, 1055, -6, 1, 1055, -320, 1, 936, -362, 0, 733, -402, 0, 641, -402, 1, 397, -402, 0, 141, -203, 0, 141, -13, 1, 141, 86, 0, 219, 241, 0, 313, 329, 1, 377, 387, 1, 445, 448, 0, 487, 524, 0, 487, 569, 1}, {848, 764, 1, 487, 764, 1, 487, 1120, 1, 848, 1120, 1}}},
+		'À':      {advance: 1585, contours: [][]int16{{1094, 272, 1, 492, 272, 1, 397, 0, 1, 10, 0, 1, 563, 1493, 1, 1022, 1493, 1, 1575, 0, 1, 1188, 0, 1}, {588, 549, 1, 997, 549, 1, 793, 1143, 1}, {717, 1899, 1, 915, 1635, 1, 719, 1635, 1, 434, 1899, 1}}},
+		'Á':      {advance: 1585, contours: [][]int16{{1094, 272, 1, 492, 272, 1, 397, 0, 1, 10, 0, 1, 563, 1493, 1, 1022, 1493, 1, 1575, 0, 1, 1188, 0, 1}, {588, 549, 1, 997, 549, 1, 793, 1143, 1}, {819, 1899, 1, 1102, 1899, 1, 817, 1635, 1, 621, 1635, 1}}},
+		'Â':      {advance: 1585, contours: [][]int16{{1094, 272, 1, 492, 272, 1, 397, 0, 1, 10, 0, 1, 563, 1493, 1, 1022, 1493, 1, 1575, 0, 1, 1188, 0, 1}, {588, 549, 1, 997, 549, 1, 793, 1143, 1}, {638, 1899, 1, 946, 1899, 1, 1169, 1635, 1, 991, 1635, 1, 792, 1796, 1, 593, 1635, 1, 415, 1635, 1}}},
+		'Ã':      {advance: 1585, contours: [][]int16{{1094, 272, 1, 492, 272, 1, 397, 0, 1, 10, 0, 1, 563, 1493, 1, 1022, 1493, 1, 1575, 0, 1, 1188, 0, 1}, {588, 549, 1, 997, 549, 1, 793, 1143, 1}, {794, 1690, 1, 738, 1725, 1, 735, 1727, 0, 728, 1731, 1, 683, 1757, 0, 655, 1757, 1, 623, 1757, 0, 583, 1697, 0, 583, 1647, 1, 583, 1641, 1, 444, 1641, 1, 444, 1646, 0, 446, 1666, 0, 446, 1671, 1, 446, 1777, 0, 553, 1907, 0, 640, 1907, 1, 677, 1907, 0, 751, 1882, 0, 790, 1858, 1, 849, 1819, 1, 870, 1805, 0, 909, 1790, 0, 925, 1790, 1, 962, 1790, 0, 1001, 1850, 0, 1001, 1907, 1, 1140, 1907, 1, 1140, 1901, 0, 1138, 1881, 0, 1138, 1876, 1, 1138, 1770, 0, 1031, 1641, 0, 944, 1641, 1, 906, 1641, 0, 836, 1663, 0}}},
+		'Ä':      {advance: 1585, contours: [][]int16{{1094, 272, 1, 492, 272, 1, 397, 0, 1, 10, 0, 1, 563, 1493, 1, 1022, 1493, 1, 1575, 0, 1, 1188, 0, 1}, {588, 549, 1, 997, 549, 1, 793, 1143, 1}, {471, 1899, 1, 706, 1899, 1, 706, 1653, 1, 471, 1653, 1}, {866, 1899, 1, 1101, 1899, 1, 1101, 1653, 1, 866, 1653, 1}}},
+		'Å':      {advance: 1585, contours: [][]int16{{1032, 1464, 1, 1575, 0, 1, 1188, 0, 1, 1094, 272, 1, 492, 272, 1, 397, 0, 1, 10, 0, 1, 553, 1464, 1, 530, 1498, 0, 508, 1573, 0, 508, 1616, 1, 508, 1733, 0, 675, 1901, 0, 793, 1901, 1, 909, 1901, 0, 1077, 1733, 0, 1077, 1616, 1, 1077, 1569, 0, 1055, 1493, 0}, {662, 1616, 1, 662, 1562, 0, 739, 1485, 0, 793, 1485, 1, 847, 1485, 0, 924, 1562, 0, 924, 1616, 1, 924, 1670, 0, 846, 1747, 0, 793, 1747, 1, 739, 1747, 0, 662, 1670, 0}, {588, 549, 1, 997, 549, 1, 793, 1143, 1}}},
+		'Æ':      {advance: 2222, contours: [][]int16{{891, 1237, 1, 635, 627, 1, 1012, 627, 1, 1012, 1237, 1}, {625, 1493, 1, 2050, 1493, 1, 2050, 1202, 1, 1397, 1202, 1, 1397, 924, 1, 2011, 924, 1, 2011, 633, 1, 1397, 633, 1, 1397, 291, 1, 2073, 291, 1, 2073, 0, 1, 1012, 0, 1, 1012, 350, 1, 518, 350, 1, 371, 0, 1, 0, 0, 1}}},
+		'Ç':      {advance: 1503, contours: [][]int16{{1372, 82, 1, 1266, 27, 0, 1036, -29, 0, 911, -29, 1, 538, -29, 0, 102, 388, 0, 102, 745, 1, 102, 1103, 0, 538, 1520, 0, 911, 1520, 1, 1036, 1520, 0, 1266, 1464, 0, 1372, 1409, 1, 1372, 1100, 1, 1265, 1173, 0, 1057, 1241, 0, 942, 1241, 1, 736, 1241, 0, 500, 977, 0, 500, 745, 1, 500, 514, 0, 736, 250, 0, 942, 250, 1, 1057, 250, 0, 1265, 318, 0, 1372, 391, 1}, {973, 0, 1, 1031, -62, 0, 1086, -168, 0, 1086, -215, 1, 1086, -310, 0, 963, -401, 0, 836, -401, 1, 788, -401, 0, 686, -388, 0, 634, -375, 1, 633, -223, 1, 683, -239, 0, 766, -254, 0, 799, -254, 1, 857, -254, 0, 922, -208, 0, 922, -168, 1, 922, -142, 0, 879, -60, 0, 834, 0, 1}}},
+		'È':      {advance: 1399, contours: [][]int16{{188, 1493, 1, 1227, 1493, 1, 1227, 1202, 1, 573, 1202, 1, 573, 924, 1, 1188, 924, 1, 1188, 633, 1, 573, 633, 1, 573, 291, 1, 1249, 291, 1, 1249, 0, 1, 188, 0, 1}, {641, 1899, 1, 839, 1635, 1, 643, 1635, 1, 358, 1899, 1}}},
+		'É':      {advance: 1399, contours: [][]int16{{188, 1493, 1, 1227, 1493, 1, 1227, 1202, 1, 573, 1202, 1, 573, 924, 1, 1188, 924, 1, 1188, 633, 1, 573, 633, 1, 573, 291, 1, 1249, 291, 1, 1249, 0, 1, 188, 0, 1}, {743, 1899, 1, 1026, 1899, 1, 741, 1635, 1, 545, 1635, 1}}},
+		'Ê':      {advance: 1399, contours: [][]int16{{188, 1493, 1, 1227, 1493, 1, 1227, 1202, 1, 573, 1202, 1, 573, 924, 1, 1188, 924, 1, 1188, 633, 1, 573, 633, 1, 573, 291, 1, 1249, 291, 1, 1249, 0, 1, 188, 0, 1}, {538, 1899, 1, 846, 1899, 1, 1069, 1635, 1, 891, 1635, 1, 692, 1796, 1, 493, 1635, 1, 315, 1635, 1}}},
+		'Ë':      {advance: 1399, contours: [][]int16{{188, 1493, 1, 1227, 1493, 1, 1227, 1202, 1, 573, 1202, 1, 573, 924, 1, 1188, 924, 1, 1188, 633, 1, 573, 633, 1, 573, 291, 1, 1249, 291, 1, 1249, 0, 1, 188, 0, 1}, {377, 1899, 1, 612, 1899, 1, 612, 1653, 1, 377, 1653, 1}, {772, 1899, 1, 1007, 1899, 1, 1007, 1653, 1, 772, 1653, 1}}},
+		'Ì':      {advance: 762, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 0, 1, 188, 0, 1}, {305, 1899, 1, 503, 1635, 1, 307, 1635, 1, 22, 1899, 1}}},
+		'Í':      {advance: 762, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 0, 1, 188, 0, 1}, {407, 1899, 1, 690, 1899, 1, 405, 1635, 1, 209, 1635, 1}}},
+		'Î':      {advance: 762, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 0, 1, 188, 0, 1}, {226, 1899, 1, 534, 1899, 1, 757, 1635, 1, 579, 1635, 1, 380, 1796, 1, 181, 1635, 1, 3, 1635, 1}}},
+		'Ï':      {advance: 762, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 0, 1, 188, 0, 1}, {65, 1899, 1, 300, 1899, 1, 300, 1653, 1, 65, 1653, 1}, {460, 1899, 1, 695, 1899, 1, 695, 1653, 1, 460, 1653, 1}}},
+		'Ð':      {advance: 1716, contours: [][]int16{{592, 1202, 1, 592, 881, 1, 827, 881, 1, 827, 621, 1, 592, 621, 1, 592, 291, 1, 729, 291, 1, 965, 291, 0, 1214, 525, 0, 1214, 748, 1, 1214, 970, 0, 966, 1202, 0, 729, 1202, 1}, {207, 1493, 1, 612, 1493, 1, 953, 1493, 0, 1285, 1396, 0, 1405, 1280, 1, 1509, 1179, 0, 1612, 915, 0, 1612, 748, 1, 1612, 579, 0, 1509, 314, 0, 1405, 213, 1, 1284, 97, 0, 948, 0, 0, 612, 0, 1, 207, 0, 1, 207, 621, 1, 33, 621, 1, 33, 881, 1, 207, 881, 1}}},
+		'Ñ':      {advance: 1714, contours: [][]int16{{188, 1493, 1, 618, 1493, 1, 1161, 469, 1, 1161, 1493, 1, 1526, 1493, 1, 1526, 0, 1, 1096, 0, 1, 553, 1024, 1, 553, 0, 1, 188, 0, 1}, {823, 1684, 1, 767, 1719, 1, 764, 1721, 0, 757, 1725, 1, 712, 1751, 0, 684, 1751, 1, 652, 1751, 0, 612, 1691, 0, 612, 1641, 1, 612, 1635, 1, 473, 1635, 1, 473, 1640, 0, 475, 1660, 0, 475, 1665, 1, 475, 1771, 0, 582, 1901, 0, 669, 1901, 1, 706, 1901, 0, 780, 1876, 0, 819, 1852, 1, 878, 1813, 1, 899, 1799, 0, 938, 1784, 0, 954, 1784, 1, 991, 1784, 0, 1030, 1844, 0, 1030, 1901, 1, 1169, 1901, 1, 1169, 1895, 0, 1167, 1875, 0, 1167, 1870, 1, 1167, 1764, 0, 1060, 1635, 0, 973, 1635, 1, 935, 1635, 0, 865, 1657, 0}}},
+		'Ò':      {advance: 1741, contours: [][]int16{{870, 1241, 1, 694, 1241, 0, 500, 981, 0, 500, 745, 1, 500, 510, 0, 694, 250, 0, 870, 250, 1, 1047, 250, 0, 1241, 510, 0, 1241, 745, 1, 1241, 981, 0, 1047, 1241, 0}, {870, 1520, 1, 1230, 1520, 0, 1638, 1108, 0, 1638, 745, 1, 1638, 383, 0, 1230, -29, 0, 870, -29, 1, 511, -29, 0, 102, 383, 0, 102, 745, 1, 102, 1108, 0, 511, 1520, 0}, {795, 1899, 1, 993, 1635, 1, 797, 1635, 1, 512, 1899, 1}}},
+		'Ó':      {advance: 1741, contours: [][]int16{{870, 1241, 1, 694, 1241, 0, 500, 981, 0, 500, 745, 1, 500, 510, 0, 694, 250, 0, 870, 250, 1, 1047, 250, 0, 1241, 510, 0, 1241, 745, 1, 1241, 981, 0, 1047, 1241, 0}, {870, 1520, 1, 1230, 1520, 0, 1638, 1108, 0, 1638, 745, 1, 1638, 383, 0, 1230, -29, 0, 870, -29, 1, 511, -29, 0, 102, 383, 0, 102, 745, 1, 102, 1108, 0, 511, 1520, 0}, {897, 1899, 1, 1180, 1899, 1, 895, 1635, 1, 699, 1635, 1}}},
+		'Ô':      {advance: 1741, contours: [][]int16{{870, 1241, 1, 694, 1241, 0, 500, 981, 0, 500, 745, 1, 500, 510, 0, 694, 250, 0, 870, 250, 1, 1047, 250, 0, 1241, 510, 0, 1241, 745, 1, 1241, 981, 0, 1047, 1241, 0}, {870, 1520, 1, 1230, 1520, 0, 1638, 1108, 0, 1638, 745, 1, 1638, 383, 0, 1230, -29, 0, 870, -29, 1, 511, -29, 0, 102, 383, 0, 102, 745, 1, 102, 1108, 0, 511, 1520, 0}, {692, 1899, 1, 1000, 1899, 1, 1223, 1635, 1, 1045, 1635, 1, 846, 1796, 1, 647, 1635, 1, 469, 1635, 1}}},
+		'Õ':      {advance: 1741, contours: [][]int16{{870, 1241, 1, 694, 1241, 0, 500, 981, 0, 500, 745, 1, 500, 510, 0, 694, 250, 0, 870, 250, 1, 1047, 250, 0, 1241, 510, 0, 1241, 745, 1, 1241, 981, 0, 1047, 1241, 0}, {870, 1520, 1, 1230, 1520, 0, 1638, 1108, 0, 1638, 745, 1, 1638, 383, 0, 1230, -29, 0, 870, -29, 1, 511, -29, 0, 102, 383, 0, 102, 745, 1, 102, 1108, 0, 511, 1520, 0}, {873, 1684, 1, 817, 1719, 1, 814, 1721, 0, 807, 1725, 1, 762, 1751, 0, 734, 1751, 1, 702, 1751, 0, 662, 1691, 0, 662, 1641, 1, 662, 1635, 1, 523, 1635, 1, 523, 1640, 0, 525, 1660, 0, 525, 1665, 1, 525, 1771, 0, 632, 1901, 0, 719, 1901, 1, 756, 1901, 0, 830, 1876, 0, 869, 1852, 1, 928, 1813, 1, 949, 1799, 0, 988, 1784, 0, 1004, 1784, 1, 1041, 1784, 0, 1080, 1844, 0, 1080, 1901, 1, 1219, 1901, 1, 1219, 1895, 0, 1217, 1875, 0, 1217, 1870, 1, 1217, 1764, 0, 1110, 1635, 0, 1023, 1635, 1, 985, 1635, 0, 915, 1657, 0}}},
+		'Ö':      {advance: 1741, contours: [][]int16{{870, 1241, 1, 694, 1241, 0, 500, 981, 0, 500, 745, 1, 500, 510, 0, 694, 250, 0, 870, 250, 1, 1047, 250, 0, 1241, 510, 0, 1241, 745, 1, 1241, 981, 0, 1047, 1241, 0}, {870, 1520, 1, 1230, 1520, 0, 1638, 1108, 0, 1638, 745, 1, 1638, 383, 0, 1230, -29, 0, 870, -29, 1, 511, -29, 0, 102, 383, 0, 102, 745, 1, 102, 1108, 0, 511, 1520, 0}, {555, 1899, 1, 790, 1899, 1, 790, 1653, 1, 555, 1653, 1}, {950, 1899, 1, 1185, 1899, 1, 1185, 1653, 1, 950, 1653, 1}}},
+		'×':      {advance: 1716, contours: [][]int16{{1460, 1075, 1, 1026, 641, 1, 1460, 209, 1, 1292, 41, 1, 858, 473, 1, 424, 41, 1, 256, 209, 1, 690, 641, 1, 256, 1075, 1, 424, 1243, 1, 858, 809, 1, 1292, 1243, 1}}},
+		'Ø':      {advance: 1741, contours: [][]int16{{604, 371, 1, 656, 309, 0, 787, 250, 0, 870, 250, 1, 1047, 250, 0, 1241, 510, 0, 1241, 745, 1, 1241, 813, 0, 1226, 930, 0, 1210, 979, 1}, {1133, 1126, 1, 1082, 1184, 0, 952, 1241, 0, 870, 1241, 1, 694, 1241, 0, 500, 981, 0, 500, 745, 1, 500, 681, 0, 514, 568, 0, 528, 522, 1}, {250, 244, 1, 176, 344, 0, 102, 594, 0, 102, 745, 1, 102, 1108, 0, 511, 1520, 0, 870, 1520, 1, 1024, 1520, 0, 1272, 1445, 0, 1374, 1368, 1, 1573, 1567, 1, 1686, 1452, 1, 1485, 1253, 1, 1562, 1154, 0, 1638, 899, 0, 1638, 745, 1, 1638, 383, 0, 1230, -29, 0, 870, -29, 1, 717, -29, 0, 462, 50, 0, 360, 129, 1, 158, -74, 1, 45, 39, 1}}},
+		'Ù':      {advance: 1663, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 598, 1, 573, 413, 0, 694, 254, 0, 831, 254, 1, 969, 254, 0, 1090, 413, 0, 1090, 598, 1, 1090, 1493, 1, 1475, 1493, 1, 1475, 598, 1, 1475, 281, 0, 1157, -29, 0, 831, -29, 1, 506, -29, 0, 188, 281, 0, 188, 598, 1}, {756, 1899, 1, 954, 1635, 1, 758, 1635, 1, 473, 1899, 1}}},
+		'Ú':      {advance: 1663, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 598, 1, 573, 413, 0, 694, 254, 0, 831, 254, 1, 969, 254, 0, 1090, 413, 0, 1090, 598, 1, 1090, 1493, 1, 1475, 1493, 1, 1475, 598, 1, 1475, 281, 0, 1157, -29, 0, 831, -29, 1, 506, -29, 0, 188, 281, 0, 188, 598, 1}, {858, 1899, 1, 1141, 1899, 1, 856, 1635, 1, 660, 1635, 1}}},
+		'Û':      {advance: 1663, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 598, 1, 573, 413, 0, 694, 254, 0, 831, 254, 1, 969, 254, 0, 1090, 413, 0, 1090, 598, 1, 1090, 1493, 1, 1475, 1493, 1, 1475, 598, 1, 1475, 281, 0, 1157, -29, 0, 831, -29, 1, 506, -29, 0, 188, 281, 0, 188, 598, 1}, {678, 1899, 1, 986, 1899, 1, 1209, 1635, 1, 1031, 1635, 1, 832, 1796, 1, 633, 1635, 1, 455, 1635, 1}}},
+		'Ü':      {advance: 1663, contours: [][]int16{{188, 1493, 1, 573, 1493, 1, 573, 598, 1, 573, 413, 0, 694, 254, 0, 831, 254, 1, 969, 254, 0, 1090, 413, 0, 1090, 598, 1, 1090, 1493, 1, 1475, 1493, 1, 1475, 598, 1, 1475, 281, 0, 1157, -29, 0, 831, -29, 1, 506, -29, 0, 188, 281, 0, 188, 598, 1}, {517, 1899, 1, 752, 1899, 1, 752, 1653, 1, 517, 1653, 1}, {912, 1899, 1, 1147, 1899, 1, 1147, 1653, 1, 912, 1653, 1}}},
+		'Ý':      {advance: 1483, contours: [][]int16{{-20, 1493, 1, 401, 1493, 1, 741, 961, 1, 1081, 1493, 1, 1503, 1493, 1, 934, 629, 1, 934, 0, 1, 549, 0, 1, 549, 629, 1}, {768, 1899, 1, 1051, 1899, 1, 766, 1635, 1, 570, 1635, 1}}},
+		'Þ':      {advance: 1511, contours: [][]int16{{573, 258, 1, 573, 0, 1, 188, 0, 1, 188, 1493, 1, 573, 1493, 1, 573, 1233, 1, 827, 1233, 1, 1112, 1233, 0, 1417, 980, 0, 1417, 745, 1, 1417, 511, 0, 1112, 258, 0, 827, 258, 1}, {573, 956, 1, 573, 537, 1, 786, 537, 1, 898, 537, 0, 1020, 646, 0, 1020, 745, 1, 1020, 846, 0, 898, 956, 0, 786, 956, 1}}},
+		'ß':      {advance: 1473, contours: [][]int16{{172, 1114, 1, 172, 1336, 0, 442, 1556, 0, 715, 1556, 1, 977, 1556, 0, 1245, 1332, 0, 1245, 1114, 1, 1245, 1043, 1, 1094, 1033, 0, 950, 955, 0, 950, 881, 1, 950, 844, 0, 999, 787, 0, 1092, 735, 1, 1161, 698, 1, 1277, 634, 0, 1384, 465, 0, 1384, 348, 1, 1384, 159, 0, 1155, -29, 0, 924, -29, 1, 859, -29, 0, 721, -4, 0, 647, 20, 1, 647, 264, 1, 703, 237, 0, 818, 209, 0, 872, 209, 1, 944, 209, 0, 1032, 281, 0, 1032, 338, 1, 1032, 385, 0, 977, 453, 0, 879, 508, 1, 809, 547, 1, 721, 596, 0, 637, 731, 0, 637, 821, 1, 637, 937, 0, 776, 1095, 0, 921, 1145, 1, 920, 1230, 0, 824, 1319, 0, 733, 1319, 1, 632, 1319, 0, 530, 1209, 0, 530, 1100, 1, 530, 0, 1, 172, 0, 1}}},
+		'à':      {advance: 1382, contours: [][]int16{{674, 504, 1, 562, 504, 0, 449, 428, 0, 449, 354, 1, 449, 286, 0, 540, 209, 0, 621, 209, 1, 722, 209, 0, 860, 354, 0, 860, 463, 1, 860, 504, 1}, {1221, 639, 1, 1221, 0, 1, 860, 0, 1, 860, 166, 1, 788, 64, 0, 608, -29, 0, 479, -29, 1, 305, -29, 0, 88, 174, 0, 88, 336, 1, 88, 533, 0, 359, 717, 0, 649, 717, 1, 860, 717, 1, 860, 745, 1, 860, 830, 0, 726, 909, 0, 584, 909, 1, 469, 909, 0, 271, 863, 0, 186, 817, 1, 186, 1090, 1, 301, 1118, 0, 533, 1147, 0, 649, 1147, 1, 952, 1147, 0, 1221, 908, 0}, {563, 1638, 1, 845, 1262, 1, 649, 1262, 1, 280, 1638, 1}}},
+		'á':      {advance: 1382, contours: [][]int16{{674, 504, 1, 562, 504, 0, 449, 428, 0, 449, 354, 1, 449, 286, 0, 540, 209, 0, 621, 209, 1, 722, 209, 0, 860, 354, 0, 860, 463, 1, 860, 504, 1}, {1221, 639, 1, 1221, 0, 1, 860, 0, 1, 860, 166, 1, 788, 64, 0, 608, -29, 0, 479, -29, 1, 305, -29, 0, 88, 174, 0, 88, 336, 1, 88, 533, 0, 359, 717, 0, 649, 717, 1, 860, 717, 1, 860, 745, 1, 860, 830, 0, 726, 909, 0, 584, 909, 1, 469, 909, 0, 271, 863, 0, 186, 817, 1, 186, 1090, 1, 301, 1118, 0, 533, 1147, 0, 649, 1147, 1, 952, 1147, 0, 1221, 908, 0}, {833, 1638, 1, 1116, 1638, 1, 747, 1262, 1, 551, 1262, 1}}},
+		'â':      {advance: 1382, contours: [][]int16{{674, 504, 1, 562, 504, 0, 449, 428, 0, 449, 354, 1, 449, 286, 0, 540, 209, 0, 621, 209, 1, 722, 209, 0, 860, 354, 0, 860, 463, 1, 860, 504, 1}, {1221, 639, 1, 1221, 0, 1, 860, 0, 1, 860, 166, 1, 788, 64, 0, 608, -29, 0, 479, -29, 1, 305, -29, 0, 88, 174, 0, 88, 336, 1, 88, 533, 0, 359, 717, 0, 649, 717, 1, 860, 717, 1, 860, 745, 1, 860, 830, 0, 726, 909, 0, 584, 909, 1, 469, 909, 0, 271, 863, 0, 186, 817, 1, 186, 1090, 1, 301, 1118, 0, 533, 1147, 0, 649, 1147, 1, 952, 1147, 0, 1221, 908, 0}, {577, 1638, 1, 819, 1638, 1, 1075, 1262, 1, 897, 1262, 1, 698, 1487, 1, 499, 1262, 1, 321, 1262, 1}}},
+		'ã':      {advance: 1382, contours: [][]int16{{674, 504, 1, 562, 504, 0, 449, 428, 0, 449, 354, 1, 449, 286, 0, 540, 209, 0, 621, 209, 1, 722, 209, 0, 860, 354, 0, 860, 463, 1, 860, 504, 1}, {1221, 639, 1, 1221, 0, 1, 860, 0, 1, 860, 166, 1, 788, 64, 0, 608, -29, 0, 479, -29, 1, 305, -29, 0, 88, 174, 0, 88, 336, 1, 88, 533, 0, 359, 717, 0, 649, 717, 1, 860, 717, 1, 860, 745, 1, 860, 830, 0, 726, 909, 0, 584, 909, 1, 469, 909, 0, 271, 863, 0, 186, 817, 1, 186, 1090, 1, 301, 1118, 0, 533, 1147, 0, 649, 1147, 1, 952, 1147, 0, 1221, 908, 0}, {700, 1364, 1, 645, 1401, 1, 641, 1403, 0, 635, 1407, 1, 588, 1438, 0, 563, 1438, 1, 527, 1438, 0, 489, 1376, 0, 489, 1317, 1, 489, 1309, 1, 350, 1309, 1, 350, 1445, 0, 453, 1593, 0, 546, 1593, 1, 582, 1593, 0, 655, 1566, 0, 696, 1536, 1, 757, 1493, 1, 779, 1478, 0, 816, 1462, 0, 831, 1462, 1, 867, 1462, 0, 907, 1526, 0, 907, 1583, 1, 907, 1591, 1, 1046, 1591, 1, 1046, 1455, 0, 943, 1307, 0, 850, 1307, 1, 814, 1307, 0, 747, 1331, 0}}},
+		'ä':      {advance: 1382, contours: [][]int16{{674, 504, 1, 562, 504, 0, 449, 428, 0, 449, 354, 1, 449, 286, 0, 540, 209, 0, 621, 209, 1, 722, 209, 0, 860, 354, 0, 860, 463, 1, 860, 504, 1}, {1221, 639, 1, 1221, 0, 1, 860, 0, 1, 860, 166, 1, 788, 64, 0, 608, -29, 0, 479, -29, 1, 305, -29, 0, 88, 174, 0, 88, 336, 1, 88, 533, 0, 359, 717, 0, 649, 717, 1, 860, 717, 1, 860, 745, 1, 860, 830, 0, 726, 909, 0, 584, 909, 1, 469, 909, 0, 271, 863, 0, 186, 817, 1, 186, 1090, 1, 301, 1118, 0, 533, 1147, 0, 649, 1147, 1, 952, 1147, 0, 1221, 908, 0}, {383, 1585, 1, 618, 1585, 1, 618, 1339, 1, 383, 1339, 1}, {778, 1585, 1, 1013, 1585, 1, 1013, 1339, 1, 778, 1339, 1}}},
+		'å':      {advance: 1382, contours: [][]int16{{674, 504, 1, 562, 504, 0, 449, 428, 0, 449, 354, 1, 449, 286, 0, 540, 209, 0, 621, 209, 1, 722, 209, 0, 860, 354, 0, 860, 463, 1, 860, 504, 1}, {1221, 639, 1, 1221, 0, 1, 860, 0, 1, 860, 166, 1, 788, 64, 0, 608, -29, 0, 479, -29, 1, 305, -29, 0, 88, 174, 0, 88, 336, 1, 88, 533, 0, 359, 717, 0, 649, 717, 1, 860, 717, 1, 860, 745, 1, 860, 830, 0, 726, 909, 0, 584, 909, 1, 469, 909, 0, 271, 863, 0, 186, 817, 1, 186, 1090, 1, 301, 1118, 0, 533, 1147, 0, 649, 1147, 1, 952, 1147, 0, 1221, 908, 0}, {567, 1534, 1, 567, 1479, 0, 644, 1403, 0, 698, 1403, 1, 753, 1403, 0, 829, 1480, 0, 829, 1534, 1, 829, 1588, 0, 752, 1665, 0, 698, 1665, 1, 643, 1665, 0, 567, 1588, 0}, {413, 1534, 1, 413, 1652, 0, 580, 1819, 0, 698, 1819, 1, 816, 1819, 0, 983, 1652, 0, 983, 1534, 1, 983, 1416, 0, 816, 1249, 0, 698, 1249, 1, 580, 1249, 0, 413, 1416, 0}}},
+		'æ':      {advance: 2146, contours: [][]int16{{1679, 682, 1, 1679, 784, 0, 1560, 909, 0, 1464, 909, 1, 1361, 909, 0, 1233, 792, 0, 1217, 682, 1}, {674, 504, 1, 562, 504, 0, 449, 428, 0, 449, 354, 1, 449, 286, 0, 540, 209, 0, 621, 209, 1, 722, 209, 0, 860, 354, 0, 860, 463, 1, 860, 504, 1}, {186, 1090, 1, 305, 1118, 0, 528, 1147, 0, 625, 1147, 1, 775, 1147, 0, 992, 1070, 0, 1063, 991, 1, 1140, 1068, 0, 1344, 1147, 0, 1466, 1147, 1, 1731, 1147, 0, 2048, 829, 0, 2048, 563, 1, 2048, 461, 1, 1210, 461, 1, 1224, 335, 0, 1379, 209, 0, 1520, 209, 1, 1633, 209, 0, 1870, 276, 0, 1995, 344, 1, 1995, 68, 1, 1868, 20, 0, 1613, -29, 0, 1487, -29, 1, 1308, -29, 0, 1043, 78, 0, 971, 178, 1, 870, 71, 0, 647, -29, 0, 508, -29, 1, 314, -29, 0, 88, 168, 0, 88, 336, 1, 88, 533, 0, 359, 717, 0, 649, 717, 1, 860, 717, 1, 860, 745, 1, 860, 830, 0, 726, 909, 0, 584, 909, 1, 469, 909, 0, 271, 863, 0, 186, 817, 1}}},
+		'ç':      {advance: 1214, contours: [][]int16{{1077, 1085, 1, 1077, 793, 1, 1004, 843, 0, 857, 891, 0, 778, 891, 1, 628, 891, 0, 461, 716, 0, 461, 559, 1, 461, 402, 0, 628, 227, 0, 778, 227, 1, 862, 227, 0, 1013, 277, 0, 1077, 326, 1, 1077, 33, 1, 993, 2, 0, 820, -29, 0, 733, -29, 1, 430, -29, 0, 88, 282, 0, 88, 559, 1, 88, 836, 0, 430, 1147, 0, 733, 1147, 1, 821, 1147, 0, 992, 1116, 0}, {786, 0, 1, 844, -62, 0, 899, -168, 0, 899, -215, 1, 899, -310, 0, 776, -401, 0, 649, -401, 1, 601, -401, 0, 499, -388, 0, 447, -375, 1, 446, -223, 1, 496, -239, 0, 579, -254, 0, 612, -254, 1, 670, -254, 0, 735, -208, 0, 735, -168, 1, 735, -142, 0, 692, -60, 0, 647, 0, 1}}},
+		'è':      {advance: 1389, contours: [][]int16{{1290, 563, 1, 1290, 461, 1, 453, 461, 1, 466, 335, 0, 622, 209, 0, 762, 209, 1, 875, 209, 0, 1112, 276, 0, 1237, 344, 1, 1237, 68, 1, 1110, 20, 0, 856, -29, 0, 729, -29, 1, 425, -29, 0, 88, 280, 0, 88, 559, 1, 88, 833, 0, 419, 1147, 0, 709, 1147, 1, 973, 1147, 0, 1290, 829, 0}, {922, 682, 1, 922, 784, 0, 803, 909, 0, 707, 909, 1, 603, 909, 0, 473, 792, 0, 457, 682, 1}, {594, 1638, 1, 876, 1262, 1, 680, 1262, 1, 311, 1638, 1}}},
+		'é':      {advance: 1389, contours: [][]int16{{1290, 563, 1, 1290, 461, 1, 453, 461, 1, 466, 335, 0, 622, 209, 0, 762, 209, 1, 875, 209, 0, 1112, 276, 0, 1237, 344, 1, 1237, 68, 1, 1110, 20, 0, 856, -29, 0, 729, -29, 1, 425, -29, 0, 88, 280, 0, 88, 559, 1, 88, 833, 0, 419, 1147, 0, 709, 1147, 1, 973, 1147, 0, 1290, 829, 0}, {922, 682, 1, 922, 784, 0, 803, 909, 0, 707, 909, 1, 603, 909, 0, 473, 792, 0, 457, 682, 1}, {864, 1638, 1, 1147, 1638, 1, 778, 1262, 1, 582, 1262, 1}}},
+		'ê':      {advance: 1389, contours: [][]int16{{1290, 563, 1, 1290, 461, 1, 453, 461, 1, 466, 335, 0, 622, 209, 0, 762, 209, 1, 875, 209, 0, 1112, 276, 0, 1237, 344, 1, 1237, 68, 1, 1110, 20, 0, 856, -29, 0, 729, -29, 1, 425, -29, 0, 88, 280, 0, 88, 559, 1, 88, 833, 0, 419, 1147, 0, 709, 1147, 1, 973, 1147, 0, 1290, 829, 0}, {922, 682, 1, 922, 784, 0, 803, 909, 0, 707, 909, 1, 603, 909, 0, 473, 792, 0, 457, 682, 1}, {608, 1638, 1, 850, 1638, 1, 1106, 1262, 1, 928, 1262, 1, 729, 1487, 1, 530, 1262, 1, 352, 1262, 1}}},
+		'ë':      {advance: 1389, contours: [][]int16{{1290, 563, 1, 1290, 461, 1, 453, 461, 1, 466, 335, 0, 622, 209, 0, 762, 209, 1, 875, 209, 0, 1112, 276, 0, 1237, 344, 1, 1237, 68, 1, 1110, 20, 0, 856, -29, 0, 729, -29, 1, 425, -29, 0, 88, 280, 0, 88, 559, 1, 88, 833, 0, 419, 1147, 0, 709, 1147, 1, 973, 1147, 0, 1290, 829, 0}, {922, 682, 1, 922, 784, 0, 803, 909, 0, 707, 909, 1, 603, 909, 0, 473, 792, 0, 457, 682, 1}, {414, 1585, 1, 649, 1585, 1, 649, 1339, 1, 414, 1339, 1}, {809, 1585, 1, 1044, 1585, 1, 1044, 1339, 1, 809, 1339, 1}}},
+		'ì':      {advance: 702, contours: [][]int16{{172, 1120, 1, 530, 1120, 1, 530, 0, 1, 172, 0, 1}, {240, 1638, 1, 522, 1262, 1, 326, 1262, 1, -43, 1638, 1}}},
+		'í':      {advance: 702, contours: [][]int16{{172, 1120, 1, 530, 1120, 1, 530, 0, 1, 172, 0, 1}, {510, 1638, 1, 793, 1638, 1, 424, 1262, 1, 228, 1262, 1}}},
+		'î':      {advance: 702, contours: [][]int16{{172, 1120, 1, 530, 1120, 1, 530, 0, 1, 172, 0, 1}, {229, 1638, 1, 471, 1638, 1, 727, 1262, 1, 549, 1262, 1, 350, 1487, 1, 151, 1262, 1, -27, 1262, 1}}},
+		'ï':      {advance: 702, contours: [][]int16{{172, 1120, 1, 530, 1120, 1, 530, 0, 1, 172, 0, 1}, {35, 1585, 1, 270, 1585, 1, 270, 1339, 1, 35, 1339, 1}, {430, 1585, 1, 665, 1585, 1, 665, 1339, 1, 430, 1339, 1}}},
+		'ð':      {advance: 1407, contours: [][]int16{{920, 743, 1, 865, 770, 0, 757, 797, 0, 705, 797, 1, 588, 797, 0, 461, 664, 0, 461, 543, 1, 461, 395, 0, 591, 227, 0, 705, 227, 1, 822, 227, 0, 946, 398, 0, 946, 559, 1, 946, 604, 0, 933, 696, 0}, {1096, 1100, 1, 1213, 964, 0, 1319, 707, 0, 1319, 559, 1, 1319, 283, 0, 994, -29, 0, 705, -29, 1, 415, -29, 0, 88, 283, 0, 88, 559, 1, 88, 790, 0, 389, 1055, 0, 653, 1055, 1, 699, 1055, 0, 777, 1042, 0, 813, 1028, 1, 623, 1247, 1, 250, 1128, 1, 213, 1257, 1, 520, 1354, 1, 332, 1556, 1, 684, 1556, 1, 795, 1440, 1, 1171, 1554, 1, 1206, 1425, 1, 891, 1329, 1}}},
+		'ñ':      {advance: 1458, contours: [][]int16{{1298, 682, 1, 1298, 0, 1, 938, 0, 1, 938, 111, 1, 938, 522, 1, 938, 667, 0, 925, 777, 0, 909, 803, 1, 888, 838, 0, 816, 877, 0, 770, 877, 1, 658, 877, 0, 530, 704, 0, 530, 551, 1, 530, 0, 1, 172, 0, 1, 172, 1120, 1, 530, 1120, 1, 530, 956, 1, 611, 1054, 0, 793, 1147, 0, 903, 1147, 1, 1097, 1147, 0, 1298, 909, 0}, {756, 1364, 1, 701, 1401, 1, 697, 1403, 0, 691, 1407, 1, 644, 1438, 0, 619, 1438, 1, 583, 1438, 0, 545, 1376, 0, 545, 1317, 1, 545, 1309, 1, 406, 1309, 1, 406, 1445, 0, 509, 1593, 0, 602, 1593, 1, 638, 1593, 0, 711, 1566, 0, 752, 1536, 1, 813, 1493, 1, 835, 1478, 0, 872, 1462, 0, 887, 1462, 1, 923, 1462, 0, 963, 1526, 0, 963, 1583, 1, 963, 1591, 1, 1102, 1591, 1, 1102, 1455, 0, 999, 1307, 0, 906, 1307, 1, 870, 1307, 0, 803, 1331, 0}}},
+		'ò':      {advance: 1407, contours: [][]int16{{705, 891, 1, 586, 891, 0, 461, 720, 0, 461, 559, 1, 461, 398, 0, 586, 227, 0, 705, 227, 1, 822, 227, 0, 946, 398, 0, 946, 559, 1, 946, 720, 0, 822, 891, 0}, {705, 1147, 1, 994, 1147, 0, 1319, 835, 0, 1319, 559, 1, 1319, 283, 0, 994, -29, 0, 705, -29, 1, 415, -29, 0, 88, 283, 0, 88, 559, 1, 88, 835, 0, 415, 1147, 0}, {592, 1638, 1, 874, 1262, 1, 678, 1262, 1, 309, 1638, 1}}},
+		'ó':      {advance: 1407, contours: [][]int16{{705, 891, 1, 586, 891, 0, 461, 720, 0, 461, 559, 1, 461, 398, 0, 586, 227, 0, 705, 227, 1, 822, 227, 0, 946, 398, 0, 946, 559, 1, 946, 720, 0, 822, 891, 0}, {705, 1147, 1, 994, 1147, 0, 1319, 835, 0, 1319, 559, 1, 1319, 283, 0, 994, -29, 0, 705, -29, 1, 415, -29, 0, 88, 283, 0, 88, 559, 1, 88, 835, 0, 415, 1147, 0}, {862, 1638, 1, 1145, 1638, 1, 776, 1262, 1, 580, 1262, 1}}},
+		'ô':      {advance: 1407, contours: [][]int16{{705, 891, 1, 586, 891, 0, 461, 720, 0, 461, 559, 1, 461, 398, 0, 586, 227, 0, 705, 227, 1, 822, 227, 0, 946, 398, 0, 946, 559, 1, 946, 720, 0, 822, 891, 0}, {705, 1147, 1, 994, 1147, 0, 1319, 835, 0, 1319, 559, 1, 1319, 283, 0, 994, -29, 0, 705, -29, 1, 415, -29, 0, 88, 283, 0, 88, 559, 1, 88, 835, 0, 415, 1147, 0}, {582, 1638, 1, 824, 1638, 1, 1080, 1262, 1, 902, 1262, 1, 703, 1487, 1, 504, 1262, 1, 326, 1262, 1}}},
+		'õ':      {advance: 1407, contours: [][]int16{{705, 891, 1, 586, 891, 0, 461, 720, 0, 461, 559, 1, 461, 398, 0, 586, 227, 0, 705, 227, 1, 822, 227, 0, 946, 398, 0, 946, 559, 1, 946, 720, 0, 822, 891, 0}, {705, 1147, 1, 994, 1147, 0, 1319, 835, 0, 1319, 559, 1, 1319, 283, 0, 994, -29, 0, 705, -29, 1, 415, -29, 0, 88, 283, 0, 88, 559, 1, 88, 835, 0, 415, 1147, 0}, {704, 1364, 1, 649, 1401, 1, 645, 1403, 0, 639, 1407, 1, 592, 1438, 0, 567, 1438, 1, 531, 1438, 0, 493, 1376, 0, 493, 1317, 1, 493, 1309, 1, 354, 1309, 1, 354, 1445, 0, 457, 1593, 0, 550, 1593, 1, 586, 1593, 0, 659, 1566, 0, 700, 1536, 1, 761, 1493, 1, 783, 1478, 0, 820, 1462, 0, 835, 1462, 1, 871, 1462, 0, 911, 1526, 0, 911, 1583, 1, 911, 1591, 1, 1050, 1591, 1, 1050, 1455, 0, 947, 1307, 0, 854, 1307, 1, 818, 1307, 0, 751, 1331, 0}}},
+		'ö':      {advance: 1407, contours: [][]int16{{705, 891, 1, 586, 891, 0, 461, 720, 0, 461, 559, 1, 461, 398, 0, 586, 227, 0, 705, 227, 1, 822, 227, 0, 946, 398, 0, 946, 559, 1, 946, 720, 0, 822, 891, 0}, {705, 1147, 1, 994, 1147, 0, 1319, 835, 0, 1319, 559, 1, 1319, 283, 0, 994, -29, 0, 705, -29, 1, 415, -29, 0, 88, 283, 0, 88, 559, 1, 88, 835, 0, 415, 1147, 0}, {387, 1585, 1, 622, 1585, 1, 622, 1339, 1, 387, 1339, 1}, {782, 1585, 1, 1017, 1585, 1, 1017, 1339, 1, 782, 1339, 1}}},
+		'÷':      {advance: 1716, contours: [][]int16{{705, 395, 1, 1012, 395, 1, 1012, 86, 1, 705, 86, 1}, {705, 1198, 1, 1012, 1198, 1, 1012, 889, 1, 705, 889, 1}, {217, 760, 1, 1499, 760, 1, 1499, 524, 1, 217, 524, 1}}},
+		'ø':      {advance: 1407, contours: [][]int16{{856, 836, 1, 827, 864, 0, 752, 891, 0, 705, 891, 1, 586, 891, 0, 461, 720, 0, 461, 559, 1, 461, 518, 0, 468, 453, 0, 475, 426, 1}, {547, 287, 1, 578, 257, 0, 657, 227, 0, 705, 227, 1, 822, 227, 0, 946, 398, 0, 946, 559, 1, 946, 602, 0, 939, 669, 0, 932, 698, 1}, {223, 158, 1, 156, 236, 0, 88, 436, 0, 88, 559, 1, 88, 835, 0, 415, 1147, 0, 705, 1147, 1, 811, 1147, 0, 990, 1103, 0, 1065, 1059, 1, 1212, 1217, 1, 1321, 1116, 1, 1180, 967, 1, 1250, 887, 0, 1319, 685, 0, 1319, 559, 1, 1319, 283, 0, 994, -29, 0, 705, -29, 1, 597, -29, 0, 415, 16, 0, 338, 61, 1, 190, -94, 1, 78, 0, 1}}},
+		'ù':      {advance: 1458, contours: [][]int16{{160, 436, 1, 160, 1120, 1, 520, 1120, 1, 520, 1008, 1, 520, 917, 0, 518, 642, 0, 518, 596, 1, 518, 461, 0, 532, 342, 0, 549, 315, 1, 571, 280, 0, 642, 242, 0, 688, 242, 1, 800, 242, 0, 928, 414, 0, 928, 567, 1, 928, 1120, 1, 1286, 1120, 1, 1286, 0, 1, 928, 0, 1, 928, 162, 1, 847, 64, 0, 666, -29, 0, 557, -29, 1, 363, -29, 0, 160, 209, 0}, {619, 1638, 1, 901, 1262, 1, 705, 1262, 1, 336, 1638, 1}}},
+		'ú':      {advance: 1458, contours: [][]int16{{160, 436, 1, 160, 1120, 1, 520, 1120, 1, 520, 1008, 1, 520, 917, 0, 518, 642, 0, 518, 596, 1, 518, 461, 0, 532, 342, 0, 549, 315, 1, 571, 280, 0, 642, 242, 0, 688, 242, 1, 800, 242, 0, 928, 414, 0, 928, 567, 1, 928, 1120, 1, 1286, 1120, 1, 1286, 0, 1, 928, 0, 1, 928, 162, 1, 847, 64, 0, 666, -29, 0, 557, -29, 1, 363, -29, 0, 160, 209, 0}, {889, 1638, 1, 1172, 1638, 1, 803, 1262, 1, 607, 1262, 1}}},
+		'û':      {advance: 1458, contours: [][]int16{{160, 436, 1, 160, 1120, 1, 520, 1120, 1, 520, 1008, 1, 520, 917, 0, 518, 642, 0, 518, 596, 1, 518, 461, 0, 532, 342, 0, 549, 315, 1, 571, 280, 0, 642, 242, 0, 688, 242, 1, 800, 242, 0, 928, 414, 0, 928, 567, 1, 928, 1120, 1, 1286, 1120, 1, 1286, 0, 1, 928, 0, 1, 928, 162, 1, 847, 64, 0, 666, -29, 0, 557, -29, 1, 363, -29, 0, 160, 209, 0}, {603, 1638, 1, 845, 1638, 1, 1101, 1262, 1, 923, 1262, 1, 724, 1487, 1, 525, 1262, 1, 347, 1262, 1}}},
+		'ü':      {advance: 1458, contours: [][]int16{{160, 436, 1, 160, 1120, 1, 520, 1120, 1, 520, 1008, 1, 520, 917, 0, 518, 642, 0, 518, 596, 1, 518, 461, 0, 532, 342, 0, 549, 315, 1, 571, 280, 0, 642, 242, 0, 688, 242, 1, 800, 242, 0, 928, 414, 0, 928, 567, 1, 928, 1120, 1, 1286, 1120, 1, 1286, 0, 1, 928, 0, 1, 928, 162, 1, 847, 64, 0, 666, -29, 0, 557, -29, 1, 363, -29, 0, 160, 209, 0}, {409, 1585, 1, 644, 1585, 1, 644, 1339, 1, 409, 1339, 1}, {804, 1585, 1, 1039, 1585, 1, 1039, 1339, 1, 804, 1339, 1}}},
+		'ý':      {advance: 1335, contours: [][]int16{{25, 1120, 1, 383, 1120, 1, 684, 360, 1, 940, 1120, 1, 1298, 1120, 1, 827, -106, 1, 756, -293, 0, 567, -442, 0, 412, -442, 1, 205, -442, 1, 205, -207, 1, 317, -207, 1, 408, -207, 0, 491, -149, 0, 514, -74, 1, 524, -43, 1}, {803, 1638, 1, 1086, 1638, 1, 717, 1262, 1, 521, 1262, 1}}},
+		'þ':      {advance: 1466, contours: [][]int16{{530, 162, 1, 530, -426, 1, 172, -426, 1, 172, 1556, 1, 530, 1556, 1, 530, 956, 1, 604, 1054, 0, 784, 1147, 0, 901, 1147, 1, 1108, 1147, 0, 1374, 818, 0, 1374, 559, 1, 1374, 300, 0, 1108, -29, 0, 901, -29, 1, 784, -29, 0, 604, 64, 0}, {768, 887, 1, 653, 887, 0, 530, 718, 0, 530, 559, 1, 530, 400, 0, 653, 231, 0, 768, 231, 1, 883, 231, 0, 1004, 399, 0, 1004, 559, 1, 1004, 719, 0, 883, 887, 0}}},
+		'ÿ':      {advance: 1335, contours: [][]int16{{25, 1120, 1, 383, 1120, 1, 684, 360, 1, 940, 1120, 1, 1298, 1120, 1, 827, -106, 1, 756, -293, 0, 567, -442, 0, 412, -442, 1, 205, -442, 1, 205, -207, 1, 317, -207, 1, 408, -207, 0, 491, -149, 0, 514, -74, 1, 524, -43, 1}, {353, 1585, 1, 588, 1585, 1, 588, 1339, 1, 353, 1339, 1}, {748, 1585, 1, 983, 1585, 1, 983, 1339, 1, 748, 1339, 1}}},
+		'–':      {advance: 1024, contours: [][]int16{{110, 690, 1, 914, 690, 1, 914, 432, 1, 110, 432, 1}}},
+		'—':      {advance: 2048, contours: [][]int16{{110, 690, 1, 1938, 690, 1, 1938, 432, 1, 110, 432, 1}}},
+		'‘':      {advance: 778, contours: [][]int16{{551, 856, 1, 211, 856, 1, 211, 1141, 1, 438, 1493, 1, 651, 1493, 1, 551, 1141, 1}}},
+		'’':      {advance: 778, contours: [][]int16{{229, 1493, 1, 569, 1493, 1, 569, 1208, 1, 342, 856, 1, 129, 856, 1, 229, 1208, 1}}},
+		'“':      {advance: 1346, contours: [][]int16{{1057, 856, 1, 717, 856, 1, 717, 1139, 1, 944, 1493, 1, 1157, 1493, 1, 1057, 1139, 1}, {551, 856, 1, 211, 856, 1, 211, 1141, 1, 438, 1493, 1, 651, 1493, 1, 551, 1141, 1}}},
+		'”':      {advance: 1346, contours: [][]int16{{289, 1493, 1, 629, 1493, 1, 629, 1208, 1, 401, 856, 1, 188, 856, 1, 289, 1208, 1}, {795, 1493, 1, 1135, 1493, 1, 1135, 1206, 1, 907, 856, 1, 694, 856, 1, 795, 1206, 1}}},
+		'•':      {advance: 1309, contours: [][]int16{{295, 762, 1, 295, 836, 0, 348, 966, 0, 399, 1016, 1, 452, 1067, 0, 582, 1120, 0, 655, 1120, 1, 728, 1120, 0, 859, 1066, 0, 909, 1016, 1, 961, 964, 0, 1014, 835, 0, 1014, 762, 1, 1014, 688, 0, 960, 557, 0, 909, 506, 1, 858, 455, 0, 727, 401, 0, 653, 401, 1, 580, 401, 0, 450, 455, 0, 399, 506, 1, 349, 557, 0, 295, 688, 0}}},
+		'…':      {advance: 2048, contours: [][]int16{{1526, 387, 1, 1886, 387, 1, 1886, 0, 1, 1526, 0, 1}, {162, 387, 1, 522, 387, 1, 522, 0, 1, 162, 0, 1}, {844, 387, 1, 1204, 387, 1, 1204, 0, 1, 844, 0, 1}}},
+	},
+}
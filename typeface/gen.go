@@ -0,0 +1,300 @@
+//go:build ignore
+
+// This program extracts the glyph outlines used by the typeface package from
+// DejaVu Sans TrueType files and writes them to dejavu.go. Run it with
+// go generate in the typeface directory.
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+)
+
+// runes are the characters kept from the fonts: ASCII, Latin-1 and the
+// punctuation that's common in card text
+var runes = func() []rune {
+	var rs []rune
+	for r := rune(' '); r <= '~'; r++ {
+		rs = append(rs, r)
+	}
+	for r := rune(0xa0); r <= 0xff; r++ {
+		rs = append(rs, r)
+	}
+	return append(rs, '‘', '’', '“', '”', '–', '—', '…', '•')
+}()
+
+type point struct {
+	x, y float64
+	on   bool
+}
+
+type font struct {
+	data      []byte
+	tables    map[string][]byte
+	locaLong  bool
+	numGlyphs int
+}
+
+func (f *font) u16(b []byte, offset int) int { return int(binary.BigEndian.Uint16(b[offset:])) }
+func (f *font) i16(b []byte, offset int) int { return int(int16(binary.BigEndian.Uint16(b[offset:]))) }
+func (f *font) u32(b []byte, offset int) int { return int(binary.BigEndian.Uint32(b[offset:])) }
+
+func parse(data []byte) (*font, error) {
+	f := &font{data: data, tables: make(map[string][]byte)}
+	numTables := f.u16(data, 4)
+	for i := 0; i < numTables; i++ {
+		entry := data[12+16*i:]
+		tag := string(entry[:4])
+		offset, length := f.u32(entry, 8), f.u32(entry, 12)
+		f.tables[tag] = data[offset : offset+length]
+	}
+	for _, tag := range []string{"head", "hhea", "hmtx", "maxp", "loca", "glyf", "cmap"} {
+		if _, exists := f.tables[tag]; !exists {
+			return nil, fmt.Errorf("the font has no %s table", tag)
+		}
+	}
+	f.locaLong = f.i16(f.tables["head"], 50) == 1
+	f.numGlyphs = f.u16(f.tables["maxp"], 4)
+	return f, nil
+}
+
+// glyphIndex looks up the rune in the Unicode BMP cmap subtable
+func (f *font) glyphIndex(r rune) int {
+	cmap := f.tables["cmap"]
+	for i := 0; i < f.u16(cmap, 2); i++ {
+		platform, encoding, offset := f.u16(cmap, 4+8*i), f.u16(cmap, 6+8*i), f.u32(cmap, 8+8*i)
+		if platform != 3 || encoding != 1 || f.u16(cmap, offset) != 4 {
+			continue
+		}
+		sub := cmap[offset:]
+		segCount := f.u16(sub, 6) / 2
+		ends, starts := 14, 16+2*segCount
+		deltas, rangeOffsets := starts+2*segCount, starts+4*segCount
+		for s := 0; s < segCount; s++ {
+			if int(r) > f.u16(sub, ends+2*s) {
+				continue
+			}
+			if int(r) < f.u16(sub, starts+2*s) {
+				return 0
+			}
+			rangeOffset := f.u16(sub, rangeOffsets+2*s)
+			if rangeOffset == 0 {
+				return (int(r) + f.i16(sub, deltas+2*s)) & 0xffff
+			}
+			at := rangeOffsets + 2*s + rangeOffset + 2*(int(r)-f.u16(sub, starts+2*s))
+			if index := f.u16(sub, at); index != 0 {
+				return (index + f.i16(sub, deltas+2*s)) & 0xffff
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+func (f *font) advance(index int) int {
+	numMetrics := f.u16(f.tables["hhea"], 34)
+	if index >= numMetrics {
+		index = numMetrics - 1
+	}
+	return f.u16(f.tables["hmtx"], 4*index)
+}
+
+// contours returns the outline of the glyph, with the components of
+// composite glyphs moved into place
+func (f *font) contours(index int) ([][]point, error) {
+	loca := f.tables["loca"]
+	var start, end int
+	if f.locaLong {
+		start, end = f.u32(loca, 4*index), f.u32(loca, 4*index+4)
+	} else {
+		start, end = 2*f.u16(loca, 2*index), 2*f.u16(loca, 2*index+2)
+	}
+	if start == end {
+		return nil, nil
+	}
+	glyph := f.tables["glyf"][start:end]
+	numContours := f.i16(glyph, 0)
+	if numContours < 0 {
+		return f.composite(glyph[10:])
+	}
+
+	var endPoints []int
+	for i := 0; i < numContours; i++ {
+		endPoints = append(endPoints, f.u16(glyph, 10+2*i))
+	}
+	numPoints := endPoints[len(endPoints)-1] + 1
+	at := 10 + 2*numContours
+	at += 2 + f.u16(glyph, at)
+
+	flags := make([]byte, 0, numPoints)
+	for len(flags) < numPoints {
+		flag := glyph[at]
+		at++
+		flags = append(flags, flag)
+		if flag&8 != 0 {
+			for repeat := int(glyph[at]); repeat > 0; repeat-- {
+				flags = append(flags, flag)
+			}
+			at++
+		}
+	}
+	coordinates := func(short, same byte) []int {
+		values := make([]int, numPoints)
+		value := 0
+		for i, flag := range flags {
+			switch {
+			case flag&short != 0:
+				delta := int(glyph[at])
+				at++
+				if flag&same == 0 {
+					delta = -delta
+				}
+				value += delta
+			case flag&same == 0:
+				value += f.i16(glyph, at)
+				at += 2
+			}
+			values[i] = value
+		}
+		return values
+	}
+	xs := coordinates(2, 16)
+	ys := coordinates(4, 32)
+
+	var contours [][]point
+	first := 0
+	for _, last := range endPoints {
+		var contour []point
+		for i := first; i <= last; i++ {
+			contour = append(contour, point{float64(xs[i]), float64(ys[i]), flags[i]&1 != 0})
+		}
+		contours = append(contours, contour)
+		first = last + 1
+	}
+	return contours, nil
+}
+
+func (f *font) composite(b []byte) ([][]point, error) {
+	var contours [][]point
+	at := 0
+	for {
+		flags, index := f.u16(b, at), f.u16(b, at+2)
+		at += 4
+		var dx, dy float64
+		if flags&1 != 0 {
+			dx, dy = float64(f.i16(b, at)), float64(f.i16(b, at+2))
+			at += 4
+		} else {
+			dx, dy = float64(int8(b[at])), float64(int8(b[at+1]))
+			at += 2
+		}
+		if flags&2 == 0 {
+			return nil, fmt.Errorf("composite glyphs placed by point numbers aren't supported")
+		}
+		a, bb, c, d := 1.0, 0.0, 0.0, 1.0
+		f2dot14 := func(offset int) float64 { return float64(f.i16(b, offset)) / 16384 }
+		switch {
+		case flags&8 != 0:
+			a = f2dot14(at)
+			d = a
+			at += 2
+		case flags&0x40 != 0:
+			a, d = f2dot14(at), f2dot14(at+2)
+			at += 4
+		case flags&0x80 != 0:
+			a, bb, c, d = f2dot14(at), f2dot14(at+2), f2dot14(at+4), f2dot14(at+6)
+			at += 8
+		}
+		components, err := f.contours(index)
+		if err != nil {
+			return nil, err
+		}
+		for _, component := range components {
+			var moved []point
+			for _, p := range component {
+				moved = append(moved, point{a*p.x + c*p.y + dx, bb*p.x + d*p.y + dy, p.on})
+			}
+			contours = append(contours, moved)
+		}
+		if flags&0x20 == 0 {
+			return contours, nil
+		}
+	}
+}
+
+func writeFace(out *bytes.Buffer, name, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	f, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	head, hhea := f.tables["head"], f.tables["hhea"]
+	fmt.Fprintf(out, "var %s = &Face{\n", name)
+	fmt.Fprintf(out, "unitsPerEm: %d,\nascent: %d,\ndescent: %d,\n", f.u16(head, 18), f.i16(hhea, 4), f.i16(hhea, 6))
+	fmt.Fprintf(out, "glyphs: map[rune]*glyph{\n")
+	sorted := append([]rune(nil), runes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, r := range sorted {
+		index := f.glyphIndex(r)
+		if index == 0 {
+			continue
+		}
+		contours, err := f.contours(index)
+		if err != nil {
+			return fmt.Errorf("%s: %U: %v", path, r, err)
+		}
+		fmt.Fprintf(out, "%q: {advance: %d", r, f.advance(index))
+		if len(contours) > 0 {
+			fmt.Fprintf(out, ", contours: [][]int16{")
+			for _, contour := range contours {
+				fmt.Fprintf(out, "{")
+				for _, p := range contour {
+					on := 0
+					if p.on {
+						on = 1
+					}
+					fmt.Fprintf(out, "%d, %d, %d, ", int(p.x), int(p.y), on)
+				}
+				fmt.Fprintf(out, "}, ")
+			}
+			fmt.Fprintf(out, "}")
+		}
+		fmt.Fprintf(out, "},\n")
+	}
+	fmt.Fprintf(out, "},\n}\n\n")
+	return nil
+}
+
+func main() {
+	regular := flag.String("regular", "/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf", "DejaVu Sans")
+	bold := flag.String("bold", "/usr/share/fonts/truetype/dejavu/DejaVuSans-Bold.ttf", "DejaVu Sans Bold")
+	output := flag.String("o", "dejavu.go", "The file to write")
+	flag.Parse()
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by gen.go from the DejaVu Sans fonts; DO NOT EDIT.\n")
+	fmt.Fprintf(&out, "// The fonts' license is in LICENSE-DejaVu.\n\npackage typeface\n\n")
+	fmt.Fprintf(&out, "// Each contour is the x, y and on-curve flag of its points in font units\n\n")
+	if err := writeFace(&out, "Regular", *regular); err != nil {
+		log.Fatal(err)
+	}
+	if err := writeFace(&out, "Bold", *bold); err != nil {
+		log.Fatal(err)
+	}
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,174 @@
+package typeface
+
+import (
+	"image"
+	"math"
+)
+
+// rasterizer fills outlines by accumulating the area each edge covers in
+// each pixel, which gives exact antialiasing for outlines that don't
+// overlap, as glyphs don't
+type rasterizer struct {
+	bounds        image.Rectangle
+	width, height int
+	// area holds a row of width+2 cells for each pixel row; the running sum
+	// of a row is the coverage of each pixel
+	area []float64
+}
+
+func (r *rasterizer) reset(bounds image.Rectangle) {
+	r.bounds = bounds
+	r.width, r.height = bounds.Dx(), bounds.Dy()
+	r.area = make([]float64, (r.width+2)*r.height)
+}
+
+// addContour adds the edges of a glyph contour in font units, placed with
+// its origin at x and y and scaled to pixels
+func (r *rasterizer) addContour(contour []int16, x, y, scale float64) {
+	n := len(contour) / 3
+	if n < 2 {
+		return
+	}
+	type point struct {
+		x, y float64
+		on   bool
+	}
+	points := make([]point, n)
+	for i := range points {
+		points[i] = point{
+			x:  x + float64(contour[3*i])*scale - float64(r.bounds.Min.X),
+			y:  y - float64(contour[3*i+1])*scale - float64(r.bounds.Min.Y),
+			on: contour[3*i+2] != 0,
+		}
+	}
+
+	// a contour can start on a control point, in which case it starts
+	// between the first two points
+	start := -1
+	for i, p := range points {
+		if p.on {
+			start = i
+			break
+		}
+	}
+	var first point
+	if start >= 0 {
+		first = points[start]
+	} else {
+		start = 0
+		first = point{(points[0].x + points[1].x) / 2, (points[0].y + points[1].y) / 2, true}
+	}
+
+	current := first
+	var control *point
+	for i := 1; i <= n; i++ {
+		p := points[(start+i)%n]
+		if i == n {
+			p = first
+		}
+		switch {
+		case p.on && control == nil:
+			r.line(current.x, current.y, p.x, p.y)
+			current = p
+		case p.on:
+			r.quad(current.x, current.y, control.x, control.y, p.x, p.y)
+			current, control = p, nil
+		case control == nil:
+			c := p
+			control = &c
+		default:
+			// two control points in a row have an implied point between them
+			mid := point{(control.x + p.x) / 2, (control.y + p.y) / 2, true}
+			r.quad(current.x, current.y, control.x, control.y, mid.x, mid.y)
+			c := p
+			current, control = mid, &c
+		}
+	}
+	if control != nil {
+		r.quad(current.x, current.y, control.x, control.y, first.x, first.y)
+	}
+}
+
+// quad adds a quadratic curve as enough lines that the difference can't be
+// seen
+func (r *rasterizer) quad(x0, y0, cx, cy, x1, y1 float64) {
+	length := math.Hypot(cx-x0, cy-y0) + math.Hypot(x1-cx, y1-cy)
+	steps := int(math.Min(16, math.Max(1, length/2)))
+	px, py := x0, y0
+	for i := 1; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		u := 1 - t
+		x := u*u*x0 + 2*u*t*cx + t*t*x1
+		y := u*u*y0 + 2*u*t*cy + t*t*y1
+		r.line(px, py, x, y)
+		px, py = x, y
+	}
+}
+
+// line adds the area to the right of the edge, positive for edges going
+// down and negative for edges going up
+func (r *rasterizer) line(x0, y0, x1, y1 float64) {
+	if y0 == y1 {
+		return
+	}
+	dir := 1.0
+	if y0 > y1 {
+		dir = -1
+		x0, y0, x1, y1 = x1, y1, x0, y0
+	}
+	dxdy := (x1 - x0) / (y1 - y0)
+	clamp := func(x float64) float64 { return math.Max(0, math.Min(float64(r.width), x)) }
+	stride := r.width + 2
+
+	for row := int(math.Max(0, math.Floor(y0))); row < r.height && float64(row) < y1; row++ {
+		top, bottom := math.Max(float64(row), y0), math.Min(float64(row+1), y1)
+		xa, xb := clamp(x0+dxdy*(top-y0)), clamp(x0+dxdy*(bottom-y0))
+		d := (bottom - top) * dir
+		cells := r.area[row*stride : (row+1)*stride]
+
+		left, right := math.Min(xa, xb), math.Max(xa, xb)
+		leftFloor := math.Floor(left)
+		li := int(leftFloor)
+		ri := int(math.Ceil(right))
+		if ri <= li+1 {
+			// the edge is within one pixel of this row
+			mid := (xa+xb)/2 - leftFloor
+			cells[li] += d * (1 - mid)
+			cells[li+1] += d * mid
+			continue
+		}
+		s := 1 / (right - left)
+		leftFrac := left - leftFloor
+		a0 := 0.5 * s * (1 - leftFrac) * (1 - leftFrac)
+		rightFrac := right - float64(ri) + 1
+		am := 0.5 * s * rightFrac * rightFrac
+		cells[li] += d * a0
+		if ri == li+2 {
+			cells[li+1] += d * (1 - a0 - am)
+		} else {
+			a1 := s * (1.5 - leftFrac)
+			cells[li+1] += d * (a1 - a0)
+			for xi := li + 2; xi < ri-1; xi++ {
+				cells[xi] += d * s
+			}
+			a2 := a1 + float64(ri-li-3)*s
+			cells[ri-1] += d * (1 - a2 - am)
+		}
+		cells[ri] += d * am
+	}
+}
+
+// mask is the coverage of each pixel of the bounds
+func (r *rasterizer) mask() *image.Alpha {
+	mask := image.NewAlpha(r.bounds)
+	stride := r.width + 2
+	for row := 0; row < r.height; row++ {
+		sum := 0.0
+		for column := 0; column < r.width; column++ {
+			sum += r.area[row*stride+column]
+			coverage := math.Min(1, math.Abs(sum))
+			mask.Pix[row*mask.Stride+column] = uint8(coverage*255 + 0.5)
+		}
+	}
+	return mask
+}
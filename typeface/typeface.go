@@ -0,0 +1,150 @@
+/*
+Package typeface draws text into images without anything outside the
+standard library. It has the outlines of DejaVu Sans and DejaVu Sans Bold
+for ASCII, Latin-1 and common punctuation, and fills them with
+antialiasing. Sizes are in pixels.
+*/
+package typeface
+
+//go:generate go run gen.go
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"unicode/utf8"
+)
+
+// Face is a typeface's glyphs and metrics
+type Face struct {
+	unitsPerEm int
+	ascent     int
+	descent    int
+	glyphs     map[rune]*glyph
+}
+
+type glyph struct {
+	advance int
+	// contours are the x, y and on-curve flag of each point in font units,
+	// with y up
+	contours [][]int16
+}
+
+// glyph returns the glyph of the rune, or a question mark for runes the
+// face doesn't have
+func (face *Face) glyph(r rune) *glyph {
+	if g, exists := face.glyphs[r]; exists {
+		return g
+	}
+	if r == '\t' || r == '\n' {
+		return face.glyphs[' ']
+	}
+	return face.glyphs['?']
+}
+
+// Ascent is the height above the baseline of the tallest glyphs at the size
+func (face *Face) Ascent(size float64) float64 {
+	return float64(face.ascent) * size / float64(face.unitsPerEm)
+}
+
+// Descent is the depth below the baseline of the lowest glyphs at the size
+func (face *Face) Descent(size float64) float64 {
+	return float64(-face.descent) * size / float64(face.unitsPerEm)
+}
+
+// Width is the width of the text in pixels at the size
+func (face *Face) Width(text string, size float64) float64 {
+	total := 0
+	for _, r := range text {
+		total += face.glyph(r).advance
+	}
+	return float64(total) * size / float64(face.unitsPerEm)
+}
+
+// Wrap breaks the text into lines no wider than the width. Words longer
+// than a line are broken where they reach the edge.
+func (face *Face) Wrap(text string, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if len(line) > 0 {
+				candidate = line + " " + word
+			}
+			if face.Width(candidate, size) <= width {
+				line = candidate
+				continue
+			}
+			if len(line) > 0 {
+				lines = append(lines, line)
+			}
+			for face.Width(word, size) > width {
+				split := face.fit(word, size, width)
+				lines = append(lines, word[:split])
+				word = word[split:]
+			}
+			line = word
+		}
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// fit returns the length of the longest start of the word that fits in the
+// width, which is at least one character
+func (face *Face) fit(word string, size, width float64) int {
+	_, first := utf8.DecodeRuneInString(word)
+	end := first
+	for end < len(word) {
+		_, n := utf8.DecodeRuneInString(word[end:])
+		if face.Width(word[:end+n], size) > width {
+			break
+		}
+		end += n
+	}
+	return end
+}
+
+// Truncate shortens the text to fit in the width, ending it with an
+// ellipsis when anything is removed
+func (face *Face) Truncate(text string, size, width float64) string {
+	if face.Width(text, size) <= width {
+		return text
+	}
+	const ellipsis = "…"
+	text = strings.TrimRight(text, " ")
+	for len(text) > 0 && face.Width(text+ellipsis, size) > width {
+		_, n := utf8.DecodeLastRuneInString(text)
+		text = strings.TrimRight(text[:len(text)-n], " ")
+	}
+	return text + ellipsis
+}
+
+// Draw draws the text with its baseline starting at x and y, where y grows
+// down the image as usual
+func (face *Face) Draw(dst draw.Image, x, y, size float64, c color.Color, text string) {
+	scale := size / float64(face.unitsPerEm)
+	bounds := image.Rect(
+		int(x)-1, int(y-face.Ascent(size))-1,
+		int(x+face.Width(text, size))+2, int(y+face.Descent(size))+2,
+	).Intersect(dst.Bounds())
+	if bounds.Empty() {
+		return
+	}
+
+	var r rasterizer
+	r.reset(bounds)
+	penX := x
+	for _, ch := range text {
+		g := face.glyph(ch)
+		for _, contour := range g.contours {
+			r.addContour(contour, penX, y, scale)
+		}
+		penX += float64(g.advance) * scale
+	}
+	draw.DrawMask(dst, bounds, image.NewUniform(c), image.Point{}, r.mask(), bounds.Min, draw.Over)
+}
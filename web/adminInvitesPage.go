@@ -0,0 +1,97 @@
+package web
+
+import (
+	"log"
+	"net/http"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+type AdminInvitesPage struct {
+	pageTitle  string
+	userInfo   UserInfo
+	CSRFToken  string
+	SiteURL    string
+	Invites    []*data.Invite
+	NewInvite  *data.Invite
+	InviteOnly bool
+}
+
+func (page AdminInvitesPage) PageTitle() string {
+	return page.pageTitle
+}
+func (page AdminInvitesPage) UserInfo() UserInfo {
+	return page.userInfo
+}
+
+// requireRole only allows users logged in with a session that have been
+// granted the role. Users that are required to use two-factor authentication
+// must enroll before they can continue.
+func requireRole(role string) HttpMiddleware {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userInfo, authenticated := UserInfoFromRequest(r)
+			if !authenticated || userInfo.IsTokenAuthenticated() {
+				http.Redirect(w, r, "/login", http.StatusSeeOther)
+				return
+			}
+			user, err := data.GetUserByUsername(userInfo.Username)
+			if data.IsDatabaseUnavailable(err) {
+				writeDataError(w, err)
+				return
+			}
+			if err != nil || !user.HasRole(role) {
+				http.NotFound(w, r)
+				return
+			}
+			if requiresTwoFactor(user) && !user.HasTwoFactor() {
+				http.Redirect(w, r, "/account/2fa", http.StatusSeeOther)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ShowAdminInvitesPage lists the invite codes and allows admins to create
+// new codes
+func ShowAdminInvitesPage(w http.ResponseWriter, r *http.Request) {
+	userInfo, _ := UserInfoFromRequest(r)
+	session := sessionManager.SessionStart(w, r)
+	page := AdminInvitesPage{
+		pageTitle:  "Invites",
+		userInfo:   userInfo,
+		CSRFToken:  csrfToken(session),
+		SiteURL:    siteURL,
+		InviteOnly: registrationMode == RegistrationInvite,
+	}
+
+	switch r.Method {
+	case "GET":
+	case "POST":
+		if !validCSRFToken(r, session) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		invite, err := data.CreateInvite(userInfo.Username)
+		if err != nil {
+			log.Printf("Unable to create invite\n\t%v", err)
+			writeDataError(w, err)
+			return
+		}
+		page.NewInvite = invite
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	invites, err := data.GetInvites()
+	if err != nil {
+		log.Printf("Unable to retrieve invites\n\t%v", err)
+		writeDataError(w, err)
+		return
+	}
+	page.Invites = invites
+
+	ShowTemplateInMainLayout(w, r, "adminInvites", page)
+}
@@ -0,0 +1,452 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+const (
+	// apiDefaultLimit is the number of items in a page when the limit
+	// isn't given
+	apiDefaultLimit = 50
+	apiMaxLimit     = 200
+)
+
+// apiResponse is the body of a successful API response. Data is an object
+// or a list, and lists have Pagination.
+type apiResponse struct {
+	Data       interface{}    `json:"data"`
+	Pagination *PaginationDto `json:"pagination,omitempty"`
+}
+
+// apiError is an error with the status and code that's sent to the client
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func newAPIError(status int, code string, format string, a ...interface{}) *apiError {
+	return &apiError{
+		status:  status,
+		code:    code,
+		message: fmt.Sprintf(format, a...),
+	}
+}
+
+// writeAPIError writes the error as an ErrorDto
+func writeAPIError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.status, ErrorDto{
+		Error: ErrorDetailDto{
+			Status:  err.status,
+			Code:    err.code,
+			Message: err.message,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		log.Printf("Unable to encode API response\n\t%v", err)
+		http.Error(w, `{"error":{"status":500,"code":"internal_error","message":"Unable to encode the response"}}`, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	buf.WriteTo(w)
+}
+
+// isAPIRequest determines if the response should be JSON, either because
+// the path is within the API or the client prefers JSON over HTML
+func isAPIRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, "/api/") || prefersJSON(r)
+}
+
+// prefersJSON determines if the Accept header ranks application/json above
+// text/html. Pages that can also be JSON use this to choose the response.
+func prefersJSON(r *http.Request) bool {
+	jsonQuality, htmlQuality := 0.0, 0.0
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil {
+			quality = q
+		}
+		switch mediaType {
+		case "application/json":
+			jsonQuality = max(jsonQuality, quality)
+		case "text/html":
+			htmlQuality = max(htmlQuality, quality)
+		}
+	}
+	return jsonQuality > 0 && jsonQuality > htmlQuality
+}
+
+// apiFields are the fields requested with the fields query parameter, or
+// nil for all of them. An error is returned for fields the DTO doesn't have.
+func apiFields(r *http.Request, dto interface{}) ([]string, *apiError) {
+	value := strings.TrimSpace(r.URL.Query().Get("fields"))
+	if len(value) == 0 {
+		return nil, nil
+	}
+	available := jsonFieldNames(reflect.TypeOf(dto))
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		found := false
+		for _, name := range available {
+			found = found || name == field
+		}
+		if !found {
+			return nil, newAPIError(http.StatusBadRequest, "invalid_parameter",
+				"unknown field %q. Fields are %s", field, strings.Join(available, ", "))
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// selectedFields is a DTO limited to some of its fields, which are written
+// in the order of the DTO
+type selectedFields struct {
+	dto    interface{}
+	fields []string
+}
+
+func (selected selectedFields) MarshalJSON() ([]byte, error) {
+	content, err := json.Marshal(selected.dto)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(content, &values); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	for _, name := range jsonFieldNames(reflect.TypeOf(selected.dto)) {
+		if !contains(selected.fields, name) {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(values[name])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// withFields limits the DTO to the fields when any were requested
+func withFields(dto interface{}, fields []string) interface{} {
+	if fields == nil {
+		return dto
+	}
+	return selectedFields{dto: dto, fields: fields}
+}
+
+// apiPage reads the offset and limit query parameters
+func apiPage(r *http.Request) (offset int, limit int, err *apiError) {
+	query := r.URL.Query()
+	offset, limit = 0, apiDefaultLimit
+	if value := query.Get("offset"); len(value) > 0 {
+		n, parseErr := strconv.Atoi(value)
+		if parseErr != nil || n < 0 {
+			return 0, 0, newAPIError(http.StatusBadRequest, "invalid_parameter", "offset must be a number of at least 0")
+		}
+		offset = n
+	}
+	if value := query.Get("limit"); len(value) > 0 {
+		n, parseErr := strconv.Atoi(value)
+		if parseErr != nil || n < 1 || n > apiMaxLimit {
+			return 0, 0, newAPIError(http.StatusBadRequest, "invalid_parameter", "limit must be a number from 1 to %d", apiMaxLimit)
+		}
+		limit = n
+	}
+	return offset, limit, nil
+}
+
+// writeMiniatureList writes the page of the miniatures requested by the
+// offset, limit and fields query parameters
+func writeMiniatureList(w http.ResponseWriter, r *http.Request, minis []*data.Miniature) {
+	offset, limit, err := apiPage(r)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	fields, err := apiFields(r, MiniatureDto{})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	items := []interface{}{}
+	for i := offset; i < len(minis) && i < offset+limit; i++ {
+		items = append(items, withFields(newMiniatureDto(minis[i]), fields))
+	}
+	pagination := &PaginationDto{
+		Offset: offset,
+		Limit:  limit,
+		Total:  len(minis),
+	}
+	if offset+limit < len(minis) {
+		query := r.URL.Query()
+		query.Set("offset", strconv.Itoa(offset+limit))
+		query.Set("limit", strconv.Itoa(limit))
+		next := (&url.URL{Path: r.URL.Path, RawQuery: query.Encode()}).String()
+		pagination.Next = &next
+	}
+	writeJSON(w, http.StatusOK, apiResponse{Data: items, Pagination: pagination})
+}
+
+// queryValues returns the values of the query parameter, which can be
+// repeated or separated with commas
+func queryValues(r *http.Request, name string) []string {
+	var values []string
+	for _, value := range r.URL.Query()[name] {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); len(v) > 0 {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+func queryInt(r *http.Request, name string) (int, *apiError) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if len(value) == 0 {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, newAPIError(http.StatusBadRequest, "invalid_parameter", "%s must be a number of at least 0", name)
+	}
+	return n, nil
+}
+
+// ShowAPISets lists the sets
+func ShowAPISets(w http.ResponseWriter, r *http.Request) {
+	fields, err := apiFields(r, MiniatureSetDto{})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	sets, _ := data.GetMiniatureSets()
+	items := []interface{}{}
+	for _, miniSet := range sets {
+		items = append(items, withFields(newMiniatureSetDto(miniSet), fields))
+	}
+	writeJSON(w, http.StatusOK, apiResponse{Data: items})
+}
+
+// ShowAPISetMiniatures lists the miniatures in the set with the code in the
+// path
+func ShowAPISetMiniatures(w http.ResponseWriter, r *http.Request) {
+	miniSet, err := data.GetMiniatureSetByID(PathParam(r, "code"))
+	if err != nil {
+		writeAPIError(w, newAPIError(http.StatusNotFound, "not_found", "no set with code %s", PathParam(r, "code")))
+		return
+	}
+	writeMiniatureList(w, r, data.SearchMiniatures(data.MiniatureFilter{Sets: []string{miniSet.ID()}}))
+}
+
+// miniatureFilterFromQuery reads the filter of a miniature list from the
+// query parameters. Invalid costs are left out of the filter, and the error
+// is the first of them in the order they're listed.
+func miniatureFilterFromQuery(r *http.Request) (filter data.MiniatureFilter, firstErr *apiError) {
+	filter = data.MiniatureFilter{
+		Text:     r.URL.Query().Get("q"),
+		Sets:     queryValues(r, "set"),
+		Aspects:  queryValues(r, "aspect"),
+		Lineages: queryValues(r, "lineage"),
+		Rarities: queryValues(r, "rarity"),
+	}
+	for _, cost := range []struct {
+		name  string
+		value *int
+	}{
+		{"minSpawnCost", &filter.MinSpawnCost},
+		{"maxSpawnCost", &filter.MaxSpawnCost},
+		{"minAspectCost", &filter.MinAspectCost},
+		{"maxAspectCost", &filter.MaxAspectCost},
+	} {
+		n, err := queryInt(r, cost.name)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		*cost.value = n
+	}
+	return filter, firstErr
+}
+
+// miniatureSortFromQuery reads the sort and order query parameters, which
+// default to the order of the catalog
+func miniatureSortFromQuery(r *http.Request) (key string, descending bool, err *apiError) {
+	key = r.URL.Query().Get("sort")
+	if len(key) == 0 {
+		key = "number"
+	}
+	if !contains(data.MiniatureSortKeys, key) {
+		return "", false, newAPIError(http.StatusBadRequest, "invalid_parameter",
+			"sort must be one of %s", strings.Join(data.MiniatureSortKeys, ", "))
+	}
+	switch order := r.URL.Query().Get("order"); order {
+	case "", "asc":
+	case "desc":
+		descending = true
+	default:
+		return "", false, newAPIError(http.StatusBadRequest, "invalid_parameter", "order must be asc or desc")
+	}
+	return key, descending, nil
+}
+
+// ShowAPIMiniatures lists the miniatures selected by the query parameters
+func ShowAPIMiniatures(w http.ResponseWriter, r *http.Request) {
+	filter, err := miniatureFilterFromQuery(r)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	key, descending, err := miniatureSortFromQuery(r)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	minis := data.SearchMiniatures(filter)
+	data.SortMiniatures(minis, key, descending)
+	writeMiniatureList(w, r, minis)
+}
+
+// ShowAPIMiniature shows the miniature with the ID in the path
+func ShowAPIMiniature(w http.ResponseWriter, r *http.Request) {
+	mini, err := data.GetMiniatureByID(PathParam(r, "id"))
+	if err != nil {
+		writeAPIError(w, newAPIError(http.StatusNotFound, "not_found", "no miniature with ID %s", PathParam(r, "id")))
+		return
+	}
+	fields, fieldsErr := apiFields(r, MiniatureDto{})
+	if fieldsErr != nil {
+		writeAPIError(w, fieldsErr)
+		return
+	}
+	writeJSON(w, http.StatusOK, apiResponse{Data: withFields(newMiniatureDto(mini), fields)})
+}
+
+// exampleSetCode is the code of the first set with miniatures, which the
+// API check uses
+func exampleSetCode() string {
+	for _, mini := range data.GetMiniatures() {
+		return mini.SetCode()
+	}
+	return ""
+}
+
+// exampleMiniatureID is the ID of the first miniature, which the API check
+// uses
+func exampleMiniatureID() string {
+	for _, mini := range data.GetMiniatures() {
+		return mini.ID()
+	}
+	return ""
+}
+
+var setCodeParam = APIParam{Name: "code", Description: "The code of the set, such as B", Example: exampleSetCode}
+
+var miniatureFilterParams = []APIParam{
+	{Name: "q", Description: "Text in the name, lineage or abilities"},
+	{Name: "set", Description: "Set codes", List: true},
+	{Name: "aspect", List: true},
+	{Name: "lineage", List: true},
+	{Name: "rarity", List: true},
+	{Name: "minSpawnCost", Type: "integer"},
+	{Name: "maxSpawnCost", Type: "integer"},
+	{Name: "minAspectCost", Type: "integer"},
+	{Name: "maxAspectCost", Type: "integer"},
+	{Name: "sort", Description: "The stat to order by. Miniatures without a number for it are last.", Enum: data.MiniatureSortKeys},
+	{Name: "order", Enum: []string{"asc", "desc"}},
+}
+
+var apiSetsDoc = APIDoc{
+	Summary:  "Lists the sets",
+	Tag:      "Catalog",
+	Response: []MiniatureSetDto{},
+	Fields:   true,
+}
+
+var apiSetMiniaturesDoc = APIDoc{
+	Summary:  "Lists the miniatures of a set by collector number",
+	Tag:      "Catalog",
+	Params:   []APIParam{setCodeParam},
+	Response: []MiniatureDto{},
+	Paged:    true,
+	Fields:   true,
+}
+
+var apiMiniaturesDoc = APIDoc{
+	Summary:      "Lists the miniatures matching every filter, ordered by set and collector number unless sorted",
+	Description:  "The filters that take names can be repeated or separated with commas.",
+	Tag:          "Catalog",
+	Params:       miniatureFilterParams,
+	Response:     []MiniatureDto{},
+	Paged:        true,
+	Fields:       true,
+	ExampleQuery: "minSpawnCost=1&sort=power&order=desc",
+}
+
+var apiMiniatureDoc = APIDoc{
+	Summary:  "Shows a miniature",
+	Tag:      "Catalog",
+	Params:   []APIParam{{Name: "id", Description: "The ID of the miniature, such as ashen_knight", Example: exampleMiniatureID}},
+	Response: MiniatureDto{},
+	Fields:   true,
+}
+
+// showAPINotFound responds to unknown paths within the API
+func showAPINotFound(w http.ResponseWriter, r *http.Request) {
+	writeAPIError(w, newAPIError(http.StatusNotFound, "not_found", "no resource at %s", r.URL.Path))
+}
+
+// showAPIMethodNotAllowed responds to API requests with a method the route
+// doesn't accept. The router sets the Allow header.
+func showAPIMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeAPIError(w, newAPIError(http.StatusMethodNotAllowed, "method_not_allowed", "%s is not allowed. Use %s", r.Method, w.Header().Get("Allow")))
+}
@@ -0,0 +1,117 @@
+package web
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+// MiniatureSetDto is a set in the API
+type MiniatureSetDto struct {
+	Code           string `json:"code"`
+	Name           string `json:"name"`
+	MiniatureCount int    `json:"miniatureCount" doc:"The number of miniatures of the set in the catalog"`
+}
+
+// MiniatureDto is a miniature in the API. Stats are numbers, or null when
+// the catalog has something other than a number.
+type MiniatureDto struct {
+	ID              string  `json:"id" doc:"The name in lowercase with underscores"`
+	Name            string  `json:"name"`
+	Set             string  `json:"set" doc:"The code of the set"`
+	CollectorNumber *int    `json:"collectorNumber"`
+	Rarity          string  `json:"rarity"`
+	Aspect          string  `json:"aspect"`
+	Lineage         string  `json:"lineage"`
+	SpawnCost       *int    `json:"spawnCost"`
+	AspectCost      *int    `json:"aspectCost"`
+	Power           *int    `json:"power"`
+	Defense         *int    `json:"defense"`
+	Life            *int    `json:"life"`
+	Abilities       string  `json:"abilities" doc:"The abilities as they're written in the catalog"`
+	FlavorText      string  `json:"flavorText"`
+	NextID          *string `json:"nextId" doc:"The ID of the next miniature in the set"`
+	PrevID          *string `json:"prevId" doc:"The ID of the previous miniature in the set"`
+}
+
+// PaginationDto describes the page of a list response. Next is the URL of
+// the next page, or null on the last page.
+type PaginationDto struct {
+	Offset int     `json:"offset"`
+	Limit  int     `json:"limit"`
+	Total  int     `json:"total" doc:"The total number of matching items"`
+	Next   *string `json:"next" doc:"The URL of the next page"`
+}
+
+// ErrorDto is the body of every API error response
+type ErrorDto struct {
+	Error ErrorDetailDto `json:"error"`
+}
+
+// ErrorDetailDto describes an error. Code is a stable identifier, such as
+// not_found, and the message is for people.
+type ErrorDetailDto struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code" doc:"A stable identifier of the error, such as not_found"`
+	Message string `json:"message"`
+}
+
+func newMiniatureSetDto(miniSet *data.MiniatureSet) MiniatureSetDto {
+	minis, _ := data.GetMiniaturesBySet(miniSet.ID())
+	return MiniatureSetDto{
+		Code:           miniSet.ID(),
+		Name:           miniSet.Name(),
+		MiniatureCount: len(minis),
+	}
+}
+
+func newMiniatureDto(mini *data.Miniature) MiniatureDto {
+	return MiniatureDto{
+		ID:              mini.ID(),
+		Name:            mini.Name(),
+		Set:             mini.SetCode(),
+		CollectorNumber: statValue(mini.CollectorNumber()),
+		Rarity:          strings.TrimSpace(mini.Rarity()),
+		Aspect:          strings.TrimSpace(mini.Aspect()),
+		Lineage:         strings.TrimSpace(mini.Lineage()),
+		SpawnCost:       statValue(mini.SpawnCost()),
+		AspectCost:      statValue(mini.AspectCost()),
+		Power:           statValue(mini.Power()),
+		Defense:         statValue(mini.Defense()),
+		Life:            statValue(mini.Life()),
+		Abilities:       strings.TrimSpace(mini.Abilities()),
+		FlavorText:      strings.TrimSpace(mini.FlavorText()),
+		NextID:          optionalString(mini.NextMiniID()),
+		PrevID:          optionalString(mini.PrevMiniID()),
+	}
+}
+
+func statValue(value string) *int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func optionalString(value string) *string {
+	if len(value) == 0 {
+		return nil
+	}
+	return &value
+}
+
+// jsonFieldNames returns the JSON names of the fields of the struct type in
+// order
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if len(name) > 0 && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
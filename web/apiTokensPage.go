@@ -0,0 +1,86 @@
+package web
+
+import (
+	"log"
+	"net/http"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+type APITokensPage struct {
+	pageTitle string
+	userInfo  UserInfo
+	Tokens    []*data.APIToken
+	Scopes    []string
+	CSRFToken string
+	NewSecret string
+	Error     string
+}
+
+func (page APITokensPage) PageTitle() string {
+	return page.pageTitle
+}
+func (page APITokensPage) UserInfo() UserInfo {
+	return page.userInfo
+}
+
+// ShowAPITokensPage allows a logged in user to create and revoke their
+// personal API tokens. Tokens cannot be managed with an API token.
+func ShowAPITokensPage(w http.ResponseWriter, r *http.Request) {
+	userInfo, authenticated := UserInfoFromRequest(r)
+	if !authenticated || userInfo.IsTokenAuthenticated() {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	session := sessionManager.SessionStart(w, r)
+
+	page := APITokensPage{
+		pageTitle: "API Tokens",
+		userInfo:  userInfo,
+		Scopes:    data.APITokenScopes,
+		CSRFToken: csrfToken(session),
+	}
+
+	switch r.Method {
+	case "GET":
+	case "POST":
+		if !validCSRFToken(r, session) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.PostFormValue("action") {
+		case "create":
+			secret, _, err := data.CreateAPIToken(userInfo.Username, r.PostFormValue("name"), r.PostForm["scope"])
+			if err != nil {
+				page.Error = err.Error()
+			} else {
+				page.NewSecret = secret
+			}
+		case "revoke":
+			err := data.RevokeAPIToken(userInfo.Username, r.PostFormValue("id"))
+			if err != nil && err != data.ErrAPITokenNotFound {
+				log.Printf("Unable to revoke API token for %s\n\t%v", userInfo.Username, err)
+				writeDataError(w, err)
+				return
+			}
+			http.Redirect(w, r, "/account/tokens", http.StatusSeeOther)
+			return
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	tokens, err := data.GetAPITokensByUsername(userInfo.Username)
+	if err != nil {
+		log.Printf("Unable to retrieve API tokens for %s\n\t%v", userInfo.Username, err)
+		writeDataError(w, err)
+		return
+	}
+	page.Tokens = tokens
+
+	ShowTemplateInMainLayout(w, r, "apiTokens", page)
+}
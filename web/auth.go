@@ -6,6 +6,29 @@ import (
 
 type UserInfo struct {
 	Username string
+
+	// Scopes limits what the user is allowed to do when authenticated with
+	// an API token. Users authenticated with a session have nil scopes
+	// since they can do everything their account allows.
+	Scopes []string
+}
+
+// HasScope determines if the user has been granted the scope
+func (userInfo UserInfo) HasScope(scope string) bool {
+	if userInfo.Scopes == nil {
+		return len(userInfo.Username) > 0
+	}
+	for _, s := range userInfo.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTokenAuthenticated determines if the user was authenticated by an API token
+func (userInfo UserInfo) IsTokenAuthenticated() bool {
+	return userInfo.Scopes != nil
 }
 
 func UserInfoFromRequest(r *http.Request) (UserInfo, bool) {
@@ -3,6 +3,7 @@ package web
 const (
 	AuthUserToken       RequestTokenType = "authUser"
 	SessionRequestToken RequestTokenType = "session"
+	GraphQLViewerToken  RequestTokenType = "graphqlViewer"
 )
 
 type RequestTokenType string
@@ -0,0 +1,314 @@
+package web
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+const (
+	// compareSessionKey is the session value with the IDs of the miniatures
+	// chosen for the compare page, separated with commas
+	compareSessionKey = "compareIDs"
+
+	// maxCompared is the most miniatures the compare page shows
+	maxCompared = 4
+)
+
+// comparedIDs returns the miniatures chosen for the compare page in the
+// session, which may be nil
+func comparedIDs(session Session) []string {
+	if session == nil {
+		return nil
+	}
+	value, _ := session.Get(compareSessionKey).(string)
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if len(id) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// sessionFromRequest returns the session of the request without starting
+// one, or nil
+func sessionFromRequest(r *http.Request) Session {
+	session, _ := r.Context().Value(SessionRequestToken).(Session)
+	return session
+}
+
+// compareURL is the compare page for the miniatures
+func compareURL(ids []string) string {
+	path, _ := router.URL("compare")
+	if len(ids) == 0 {
+		return path
+	}
+	return path + "?" + url.Values{"ids": {strings.Join(ids, ",")}}.Encode()
+}
+
+// CompareCell is the value of a stat for one miniature. Best is set when the
+// stats differ and the value is the best of them, such as the lowest cost.
+type CompareCell struct {
+	Value string
+	Best  bool
+}
+
+// CompareRow is a stat of every compared miniature
+type CompareRow struct {
+	Label   string
+	Cells   []CompareCell
+	Differs bool
+}
+
+// ComparedAbility is an ability of a compared miniature. Shared abilities
+// are the same on every miniature, changed abilities are on other miniatures
+// with different text, such as Ravage 1 and Ravage 2, common abilities are
+// the same on some of the other miniatures and the rest are unique.
+type ComparedAbility struct {
+	Text string
+	Kind string
+}
+
+// ComparedMiniature is a column of the compare page
+type ComparedMiniature struct {
+	*data.Miniature
+	URL       string
+	RemoveURL string
+	Abilities []ComparedAbility
+}
+
+type ComparePage struct {
+	pageTitle  string
+	userInfo   UserInfo
+	CSRFToken  string
+	Miniatures []ComparedMiniature
+	Rows       []CompareRow
+	// NotFound are the requested IDs that aren't in the catalog
+	NotFound []string
+	// Dropped is the number of miniatures left out after the first four
+	Dropped int
+	// InSession is set when the page shows the list kept in the session,
+	// which can be changed with the forms on the page
+	InSession bool
+	ProxyURL  string
+}
+
+func (page ComparePage) PageTitle() string {
+	return page.pageTitle
+}
+func (page ComparePage) UserInfo() UserInfo {
+	return page.userInfo
+}
+
+// compareStats are the rows of the compare page. Better is 1 when a higher
+// value is better, -1 when lower is better and 0 for values that can't be
+// ranked.
+var compareStats = []struct {
+	label  string
+	value  func(mini *data.Miniature) string
+	better int
+}{
+	{"Set", func(mini *data.Miniature) string { return setName(mini.SetCode()) }, 0},
+	{"Collector Number", (*data.Miniature).CollectorNumber, 0},
+	{"Rarity", (*data.Miniature).Rarity, 0},
+	{"Aspect", (*data.Miniature).Aspect, 0},
+	{"Lineage", (*data.Miniature).Lineage, 0},
+	{"Spawn Cost", (*data.Miniature).SpawnCost, -1},
+	{"Aspect Cost", (*data.Miniature).AspectCost, -1},
+	{"Power", (*data.Miniature).Power, 1},
+	{"Defense", (*data.Miniature).Defense, 1},
+	{"Life", (*data.Miniature).Life, 1},
+}
+
+func newCompareRow(label string, minis []*data.Miniature, value func(mini *data.Miniature) string, better int) CompareRow {
+	row := CompareRow{Label: label}
+	first := strings.TrimSpace(value(minis[0]))
+	best, ranked := 0, false
+	for _, mini := range minis {
+		v := strings.TrimSpace(value(mini))
+		row.Cells = append(row.Cells, CompareCell{Value: emptyToDash(v)})
+		row.Differs = row.Differs || !strings.EqualFold(v, first)
+		// values that aren't numbers, such as a missing cost, aren't ranked
+		if n, err := strconv.Atoi(v); err == nil && better != 0 {
+			if !ranked || n*better > best*better {
+				best = n
+			}
+			ranked = true
+		}
+	}
+	if !row.Differs || !ranked {
+		return row
+	}
+	for i, mini := range minis {
+		if n, err := strconv.Atoi(strings.TrimSpace(value(mini))); err == nil && n == best {
+			row.Cells[i].Best = true
+		}
+	}
+	return row
+}
+
+// compareAbilities sorts the abilities of each miniature into shared,
+// changed, common and unique
+func compareAbilities(minis []*data.Miniature) [][]ComparedAbility {
+	// the number of miniatures with each text, and the texts of each name
+	texts := make(map[string]int)
+	names := make(map[string]map[string]bool)
+	for _, mini := range minis {
+		seenText := make(map[string]bool)
+		for _, ability := range mini.AbilityList() {
+			text, name := strings.ToLower(ability.Text()), strings.ToLower(ability.Name())
+			if !seenText[text] {
+				texts[text]++
+				seenText[text] = true
+			}
+			if names[name] == nil {
+				names[name] = make(map[string]bool)
+			}
+			names[name][text] = true
+		}
+	}
+
+	var compared [][]ComparedAbility
+	for _, mini := range minis {
+		var abilities []ComparedAbility
+		for _, ability := range mini.AbilityList() {
+			text, name := strings.ToLower(ability.Text()), strings.ToLower(ability.Name())
+			kind := "unique"
+			switch {
+			case texts[text] == len(minis):
+				kind = "shared"
+			case len(names[name]) > 1:
+				kind = "changed"
+			case texts[text] > 1:
+				kind = "common"
+			}
+			abilities = append(abilities, ComparedAbility{Text: ability.Text(), Kind: kind})
+		}
+		compared = append(compared, abilities)
+	}
+	return compared
+}
+
+func newComparePage(r *http.Request, ids []string, inSession bool) ComparePage {
+	userInfo, _ := UserInfoFromRequest(r)
+	page := ComparePage{
+		pageTitle: "Compare",
+		userInfo:  userInfo,
+		InSession: inSession,
+	}
+
+	var minis []*data.Miniature
+	var shownIDs []string
+	for _, id := range ids {
+		mini, err := data.GetMiniatureByID(id)
+		if err != nil {
+			page.NotFound = append(page.NotFound, id)
+			continue
+		}
+		if contains(shownIDs, mini.ID()) {
+			continue
+		}
+		if len(minis) == maxCompared {
+			page.Dropped++
+			continue
+		}
+		minis = append(minis, mini)
+		shownIDs = append(shownIDs, mini.ID())
+	}
+	abilities := compareAbilities(minis)
+	var names []string
+	for i, mini := range minis {
+		miniURL, _ := router.URL("miniature", "id", mini.ID())
+		var others []string
+		for _, id := range shownIDs {
+			if id != mini.ID() {
+				others = append(others, id)
+			}
+		}
+		page.Miniatures = append(page.Miniatures, ComparedMiniature{
+			Miniature: mini,
+			URL:       miniURL,
+			RemoveURL: compareURL(others),
+			Abilities: abilities[i],
+		})
+		names = append(names, mini.Name())
+	}
+	if len(minis) > 0 {
+		for _, stat := range compareStats {
+			page.Rows = append(page.Rows, newCompareRow(stat.label, minis, stat.value, stat.better))
+		}
+	}
+	if len(names) > 0 {
+		page.ProxyURL = proxySheetURL(shownIDs)
+		page.pageTitle = "Compare " + strings.Join(names, ", ")
+	}
+	return page
+}
+
+// ShowComparePage shows the miniatures in the ids query parameter side by
+// side. Without ids it redirects to the miniatures chosen in the session so
+// the URL can be shared. POST requests change the list in the session.
+func ShowComparePage(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		sessionIDs := comparedIDs(sessionFromRequest(r))
+		ids := lowerAll(queryValues(r, "ids"))
+		if len(ids) == 0 && len(sessionIDs) > 0 {
+			http.Redirect(w, r, compareURL(sessionIDs), http.StatusSeeOther)
+			return
+		}
+		inSession := strings.Join(ids, ",") == strings.Join(sessionIDs, ",")
+		page := newComparePage(r, ids, inSession)
+		if inSession {
+			page.CSRFToken = csrfToken(sessionManager.SessionStart(w, r))
+		}
+		ShowTemplateInMainLayout(w, r, "compare", page)
+	case "POST":
+		session := sessionManager.SessionStart(w, r)
+		if !validCSRFToken(r, session) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		ids := comparedIDs(session)
+		id := strings.ToLower(strings.TrimSpace(r.PostFormValue("id")))
+		redirect := compareURL(nil)
+		switch r.PostFormValue("action") {
+		case "add":
+			mini, err := data.GetMiniatureByID(id)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if !contains(ids, mini.ID()) && len(ids) < maxCompared {
+				ids = append(ids, mini.ID())
+			}
+			// adding keeps the user on the miniature so they can go on
+			// choosing
+			redirect, _ = router.URL("miniature", "id", mini.ID())
+		case "remove":
+			var remaining []string
+			for _, existing := range ids {
+				if existing != id {
+					remaining = append(remaining, existing)
+				}
+			}
+			ids = remaining
+		case "clear":
+			ids = nil
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := session.Set(compareSessionKey, strings.Join(ids, ",")); err != nil {
+			log.Printf("Unable to save the compared miniatures\n\t%v", err)
+			writeDataError(w, err)
+			return
+		}
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+	}
+}
@@ -0,0 +1,34 @@
+package web
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+const csrfSessionKey = "csrfToken"
+
+// csrfToken returns the token that must be included as the "csrf" form value
+// in any form that changes state. The token is created and stored in the
+// session the first time it's requested.
+func csrfToken(session Session) string {
+	if token, ok := session.Get(csrfSessionKey).(string); ok && len(token) > 0 {
+		return token
+	}
+	token, err := generateRandomString(32)
+	if err != nil {
+		return ""
+	}
+	session.Set(csrfSessionKey, token)
+	return token
+}
+
+// validCSRFToken determines if the request contains the CSRF token stored in
+// the session
+func validCSRFToken(r *http.Request, session Session) bool {
+	expected, ok := session.Get(csrfSessionKey).(string)
+	if !ok || len(expected) == 0 {
+		return false
+	}
+	actual := r.PostFormValue("csrf")
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) == 1
+}
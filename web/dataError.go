@@ -0,0 +1,23 @@
+package web
+
+import (
+	"net/http"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+// databaseRetryAfterSeconds is how long clients are asked to wait before
+// retrying when the database is unavailable
+const databaseRetryAfterSeconds = "30"
+
+// writeDataError responds to a failed data operation. When the database
+// can't be reached the response is a 503 so clients know to try again later,
+// otherwise it's a 500.
+func writeDataError(w http.ResponseWriter, err error) {
+	if data.IsDatabaseUnavailable(err) {
+		w.Header().Set("Retry-After", databaseRetryAfterSeconds)
+		http.Error(w, "The site is temporarily unavailable. Please try again shortly.", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusInternalServerError)
+}
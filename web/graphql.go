@@ -0,0 +1,106 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"jaredpearson.com/dbweb/graphql"
+)
+
+// graphqlMaxBodySize is the largest request body accepted by the GraphQL
+// endpoint
+const graphqlMaxBodySize = 1 << 20
+
+// graphqlLimits bound the depth and complexity of GraphQL queries
+var graphqlLimits graphql.Limits
+
+// GraphQLRequestDto is the body of a POST to the GraphQL endpoint
+type GraphQLRequestDto struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty" doc:"The operation to run when the query has more than one"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+var apiGraphQLDoc = APIDoc{
+	Summary:     "Runs a GraphQL query",
+	Description: "GET requests can't run mutations. The schema is available through introspection.",
+	Tag:         "GraphQL",
+	Params: []APIParam{
+		{Name: "query", Required: true},
+		{Name: "operationName"},
+		{Name: "variables", Description: "The values of the variables as a JSON object"},
+	},
+	Request:      GraphQLRequestDto{},
+	Response:     graphql.Response{},
+	Raw:          true,
+	OptionalAuth: true,
+	ExampleQuery: "query=%7B__typename%7D",
+}
+
+// writeGraphQLError responds with a request that couldn't be run in the
+// GraphQL error format
+func writeGraphQLError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, &graphql.Response{Errors: []*graphql.Error{{Message: message}}})
+}
+
+// ShowAPIGraphQL runs a GraphQL query against the catalog. GET requests take
+// the query from the URL and can't run mutations. POST requests must be JSON,
+// which a form on another site can't send.
+func ShowAPIGraphQL(w http.ResponseWriter, r *http.Request) {
+	schema, err := CatalogSchema()
+	if err != nil {
+		log.Printf("Unable to create the GraphQL schema\n\t%v", err)
+		writeGraphQLError(w, http.StatusInternalServerError, "The GraphQL schema is unavailable")
+		return
+	}
+
+	var body GraphQLRequestDto
+	if r.Method == http.MethodPost {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != "application/json" {
+			writeGraphQLError(w, http.StatusUnsupportedMediaType, "The request body must be application/json")
+			return
+		}
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, graphqlMaxBodySize))
+		decoder.UseNumber()
+		if err := decoder.Decode(&body); err != nil {
+			writeGraphQLError(w, http.StatusBadRequest, "The request body is not a valid GraphQL request")
+			return
+		}
+	} else {
+		body.Query = r.URL.Query().Get("query")
+		body.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); len(strings.TrimSpace(variables)) > 0 {
+			decoder := json.NewDecoder(strings.NewReader(variables))
+			decoder.UseNumber()
+			if err := decoder.Decode(&body.Variables); err != nil {
+				writeGraphQLError(w, http.StatusBadRequest, "The variables parameter must be a JSON object")
+				return
+			}
+		}
+	}
+	if len(strings.TrimSpace(body.Query)) == 0 {
+		writeGraphQLError(w, http.StatusBadRequest, "A query is required")
+		return
+	}
+
+	userInfo, _ := UserInfoFromRequest(r)
+	viewer := &graphqlViewer{userInfo: userInfo}
+	response := schema.Execute(graphql.Request{
+		Query:         body.Query,
+		OperationName: body.OperationName,
+		Variables:     body.Variables,
+		Context:       context.WithValue(r.Context(), GraphQLViewerToken, viewer),
+		QueryOnly:     r.Method != http.MethodPost,
+	}, graphqlLimits)
+
+	status := http.StatusOK
+	if response.Rejected() {
+		status = http.StatusBadRequest
+	}
+	writeJSON(w, status, response)
+}
@@ -0,0 +1,472 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"jaredpearson.com/dbweb/data"
+	"jaredpearson.com/dbweb/graphql"
+)
+
+// graphqlViewer is the user making a GraphQL request
+type graphqlViewer struct {
+	userInfo UserInfo
+
+	lock   sync.Mutex
+	owned  map[string]int
+	loaded bool
+}
+
+func viewerFromContext(ctx context.Context) *graphqlViewer {
+	if viewer, ok := ctx.Value(GraphQLViewerToken).(*graphqlViewer); ok {
+		return viewer
+	}
+	return &graphqlViewer{}
+}
+
+// canReadCollection determines if the viewer may see their collection. It's
+// false for anonymous users.
+func (viewer *graphqlViewer) canReadCollection() bool {
+	return viewer.userInfo.HasScope(data.ScopeCollectionRead)
+}
+
+// ownedCounts returns the number of copies the viewer owns of each
+// miniature, loading the collection the first time
+func (viewer *graphqlViewer) ownedCounts() (map[string]int, error) {
+	viewer.lock.Lock()
+	defer viewer.lock.Unlock()
+	if viewer.loaded {
+		return viewer.owned, nil
+	}
+	entries, err := data.GetCollection(viewer.userInfo.Username)
+	if err != nil {
+		return nil, err
+	}
+	viewer.owned = make(map[string]int)
+	for _, entry := range entries {
+		viewer.owned[entry.MiniatureID()] = entry.Count()
+	}
+	viewer.loaded = true
+	return viewer.owned, nil
+}
+
+// setOwned updates the cached count after a mutation
+func (viewer *graphqlViewer) setOwned(miniatureID string, count int) {
+	viewer.lock.Lock()
+	defer viewer.lock.Unlock()
+	if viewer.loaded {
+		viewer.owned[miniatureID] = count
+	}
+}
+
+var errCollectionScope = errors.New("the collection:read scope is required to see a collection")
+
+var (
+	catalogSchemaOnce sync.Once
+	catalogSchema     *graphql.Schema
+	catalogSchemaErr  error
+)
+
+// CatalogSchema returns the GraphQL schema of the catalog and the viewer's
+// collection
+func CatalogSchema() (*graphql.Schema, error) {
+	catalogSchemaOnce.Do(func() {
+		catalogSchema, catalogSchemaErr = newCatalogSchema()
+	})
+	return catalogSchema, catalogSchemaErr
+}
+
+// pageArgs are the offset and limit arguments of the fields that return a
+// page of a list
+func pageArgs() []*graphql.Argument {
+	return []*graphql.Argument{
+		{Name: "offset", Description: "The number of items to skip", Type: graphql.Int, DefaultValue: 0},
+		{Name: "limit", Description: fmt.Sprintf("The number of items to return, from 1 to %d", apiMaxLimit), Type: graphql.Int, DefaultValue: apiDefaultLimit},
+	}
+}
+
+// pageComplexity is the cost of a field with a limit, which is the cost of
+// its selection set for each item
+func pageComplexity(args map[string]interface{}, childComplexity int) int {
+	limit, _ := args["limit"].(int)
+	limit = min(max(limit, 1), apiMaxLimit)
+	if ids, ok := args["ids"].([]interface{}); ok {
+		limit = min(limit, len(ids))
+	}
+	return 1 + limit*childComplexity
+}
+
+// page returns the items selected by the offset and limit arguments
+func page[T any](items []T, args map[string]interface{}) ([]T, error) {
+	offset, _ := args["offset"].(int)
+	limit, _ := args["limit"].(int)
+	if offset < 0 {
+		return nil, errors.New("offset must be at least 0")
+	}
+	if limit < 1 || limit > apiMaxLimit {
+		return nil, fmt.Errorf("limit must be from 1 to %d", apiMaxLimit)
+	}
+	if offset >= len(items) {
+		return []T{}, nil
+	}
+	return items[offset:min(offset+limit, len(items))], nil
+}
+
+func stringList(value interface{}) []string {
+	var values []string
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// statResolver resolves a stat of a miniature as a number, or null when the
+// catalog has something else
+func statResolver(stat func(mini *data.Miniature) string) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if n := statValue(stat(p.Source.(*data.Miniature))); n != nil {
+			return *n, nil
+		}
+		return nil, nil
+	}
+}
+
+func miniatureResolver(id func(mini *data.Miniature) string) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		mini, err := data.GetMiniatureByID(id(p.Source.(*data.Miniature)))
+		if err != nil {
+			return nil, nil
+		}
+		return mini, nil
+	}
+}
+
+func stringResolver(value func(source interface{}) string) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return strings.TrimSpace(value(p.Source)), nil
+	}
+}
+
+func lineageMiniatures(name string) []*data.Miniature {
+	return data.SearchMiniatures(data.MiniatureFilter{Lineages: []string{name}})
+}
+
+func findLineage(name string) (string, bool) {
+	for _, lineage := range data.GetLineages() {
+		if strings.EqualFold(lineage, strings.TrimSpace(name)) {
+			return lineage, true
+		}
+	}
+	return "", false
+}
+
+func findAbility(name string) (string, bool) {
+	for _, ability := range data.GetAbilityNames() {
+		if strings.EqualFold(ability, strings.TrimSpace(name)) {
+			return ability, true
+		}
+	}
+	return "", false
+}
+
+func newCatalogSchema() (*graphql.Schema, error) {
+	setType := &graphql.Object{Name: "MiniatureSet", Description: "A set of miniatures that were released together"}
+	miniatureType := &graphql.Object{Name: "Miniature", Description: "A miniature in the catalog"}
+	miniatureAbilityType := &graphql.Object{Name: "MiniatureAbility", Description: "An ability as it's written for a miniature, such as \"Ravage 1\""}
+	lineageType := &graphql.Object{Name: "Lineage", Description: "A lineage shared by miniatures, such as Knight"}
+	abilityType := &graphql.Object{Name: "Ability", Description: "An ability shared by miniatures, such as Ravage"}
+	userType := &graphql.Object{Name: "User", Description: "The user making the request"}
+	collectionEntryType := &graphql.Object{Name: "CollectionEntry", Description: "The number of copies of a miniature that the user owns"}
+	miniatureList := graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(miniatureType)))
+
+	setType.
+		AddField(&graphql.Field{Name: "code", Type: graphql.NewNonNull(graphql.ID),
+			Resolve: stringResolver(func(s interface{}) string { return s.(*data.MiniatureSet).ID() })}).
+		AddField(&graphql.Field{Name: "name", Type: graphql.NewNonNull(graphql.String),
+			Resolve: stringResolver(func(s interface{}) string { return s.(*data.MiniatureSet).Name() })}).
+		AddField(&graphql.Field{Name: "miniatureCount", Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				minis, _ := data.GetMiniaturesBySet(p.Source.(*data.MiniatureSet).ID())
+				return len(minis), nil
+			}}).
+		AddField(&graphql.Field{Name: "miniatures", Description: "The miniatures of the set by collector number", Type: miniatureList,
+			Args: pageArgs(), Complexity: pageComplexity,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				minis := data.SearchMiniatures(data.MiniatureFilter{Sets: []string{p.Source.(*data.MiniatureSet).ID()}})
+				return page(minis, p.Args)
+			}})
+
+	miniatureType.
+		AddField(&graphql.Field{Name: "id", Type: graphql.NewNonNull(graphql.ID),
+			Resolve: stringResolver(func(s interface{}) string { return s.(*data.Miniature).ID() })}).
+		AddField(&graphql.Field{Name: "name", Type: graphql.NewNonNull(graphql.String),
+			Resolve: stringResolver(func(s interface{}) string { return s.(*data.Miniature).Name() })}).
+		AddField(&graphql.Field{Name: "set", Type: setType,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if miniSet, exists := p.Source.(*data.Miniature).Set(); exists {
+					return miniSet, nil
+				}
+				return nil, nil
+			}}).
+		AddField(&graphql.Field{Name: "collectorNumber", Type: graphql.Int,
+			Resolve: statResolver((*data.Miniature).CollectorNumber)}).
+		AddField(&graphql.Field{Name: "rarity", Type: graphql.NewNonNull(graphql.String),
+			Resolve: stringResolver(func(s interface{}) string { return s.(*data.Miniature).Rarity() })}).
+		AddField(&graphql.Field{Name: "aspect", Type: graphql.NewNonNull(graphql.String),
+			Resolve: stringResolver(func(s interface{}) string { return s.(*data.Miniature).Aspect() })}).
+		AddField(&graphql.Field{Name: "lineage", Type: lineageType,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if lineage := strings.TrimSpace(p.Source.(*data.Miniature).Lineage()); len(lineage) > 0 {
+					return lineage, nil
+				}
+				return nil, nil
+			}}).
+		AddField(&graphql.Field{Name: "spawnCost", Type: graphql.Int, Resolve: statResolver((*data.Miniature).SpawnCost)}).
+		AddField(&graphql.Field{Name: "aspectCost", Type: graphql.Int, Resolve: statResolver((*data.Miniature).AspectCost)}).
+		AddField(&graphql.Field{Name: "power", Type: graphql.Int, Resolve: statResolver((*data.Miniature).Power)}).
+		AddField(&graphql.Field{Name: "defense", Type: graphql.Int, Resolve: statResolver((*data.Miniature).Defense)}).
+		AddField(&graphql.Field{Name: "life", Type: graphql.Int, Resolve: statResolver((*data.Miniature).Life)}).
+		AddField(&graphql.Field{Name: "abilityText", Description: "The abilities as they're written in the catalog", Type: graphql.NewNonNull(graphql.String),
+			Resolve: stringResolver(func(s interface{}) string { return s.(*data.Miniature).Abilities() })}).
+		AddField(&graphql.Field{Name: "abilities", Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(miniatureAbilityType))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*data.Miniature).AbilityList(), nil
+			}}).
+		AddField(&graphql.Field{Name: "flavorText", Type: graphql.NewNonNull(graphql.String),
+			Resolve: stringResolver(func(s interface{}) string { return s.(*data.Miniature).FlavorText() })}).
+		AddField(&graphql.Field{Name: "next", Description: "The next miniature in the set", Type: miniatureType,
+			Resolve: miniatureResolver((*data.Miniature).NextMiniID)}).
+		AddField(&graphql.Field{Name: "prev", Description: "The previous miniature in the set", Type: miniatureType,
+			Resolve: miniatureResolver((*data.Miniature).PrevMiniID)}).
+		AddField(&graphql.Field{Name: "owned", Description: "The number of copies the user owns, or null for anonymous requests", Type: graphql.Int,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				viewer := viewerFromContext(p.Context)
+				if !viewer.canReadCollection() {
+					return nil, nil
+				}
+				owned, err := viewer.ownedCounts()
+				if err != nil {
+					return nil, err
+				}
+				return owned[p.Source.(*data.Miniature).ID()], nil
+			}})
+
+	miniatureAbilityType.
+		AddField(&graphql.Field{Name: "name", Type: graphql.NewNonNull(graphql.String),
+			Resolve: stringResolver(func(s interface{}) string { return s.(data.Ability).Name() })}).
+		AddField(&graphql.Field{Name: "text", Type: graphql.NewNonNull(graphql.String),
+			Resolve: stringResolver(func(s interface{}) string { return s.(data.Ability).Text() })}).
+		AddField(&graphql.Field{Name: "ability", Type: graphql.NewNonNull(abilityType),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if name, exists := findAbility(p.Source.(data.Ability).Name()); exists {
+					return name, nil
+				}
+				return p.Source.(data.Ability).Name(), nil
+			}})
+
+	lineageType.
+		AddField(&graphql.Field{Name: "name", Type: graphql.NewNonNull(graphql.String),
+			Resolve: stringResolver(func(s interface{}) string { return s.(string) })}).
+		AddField(&graphql.Field{Name: "miniatureCount", Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return len(lineageMiniatures(p.Source.(string))), nil
+			}}).
+		AddField(&graphql.Field{Name: "miniatures", Type: miniatureList, Args: pageArgs(), Complexity: pageComplexity,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return page(lineageMiniatures(p.Source.(string)), p.Args)
+			}})
+
+	abilityType.
+		AddField(&graphql.Field{Name: "name", Type: graphql.NewNonNull(graphql.String),
+			Resolve: stringResolver(func(s interface{}) string { return s.(string) })}).
+		AddField(&graphql.Field{Name: "miniatureCount", Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return len(data.GetMiniaturesWithAbility(p.Source.(string))), nil
+			}}).
+		AddField(&graphql.Field{Name: "miniatures", Type: miniatureList, Args: pageArgs(), Complexity: pageComplexity,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return page(data.GetMiniaturesWithAbility(p.Source.(string)), p.Args)
+			}})
+
+	collectionArgs := pageArgs()
+	userType.
+		AddField(&graphql.Field{Name: "username", Type: graphql.NewNonNull(graphql.String),
+			Resolve: stringResolver(func(s interface{}) string { return s.(*graphqlViewer).userInfo.Username })}).
+		AddField(&graphql.Field{Name: "collection", Description: "The miniatures the user owns by miniature ID", Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(collectionEntryType))),
+			Args: collectionArgs, Complexity: pageComplexity,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				viewer := p.Source.(*graphqlViewer)
+				if !viewer.canReadCollection() {
+					return nil, errCollectionScope
+				}
+				entries, err := data.GetCollection(viewer.userInfo.Username)
+				if err != nil {
+					return nil, err
+				}
+				return page(entries, p.Args)
+			}}).
+		AddField(&graphql.Field{Name: "totalOwned", Description: "The number of miniatures the user owns, counting every copy", Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				viewer := p.Source.(*graphqlViewer)
+				if !viewer.canReadCollection() {
+					return nil, errCollectionScope
+				}
+				owned, err := viewer.ownedCounts()
+				if err != nil {
+					return nil, err
+				}
+				total := 0
+				for _, count := range owned {
+					total += count
+				}
+				return total, nil
+			}})
+
+	collectionEntryType.
+		AddField(&graphql.Field{Name: "miniature", Type: graphql.NewNonNull(miniatureType),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				mini, err := data.GetMiniatureByID(p.Source.(*data.CollectionEntry).MiniatureID())
+				if err != nil {
+					return nil, fmt.Errorf("miniature %s is no longer in the catalog", p.Source.(*data.CollectionEntry).MiniatureID())
+				}
+				return mini, nil
+			}}).
+		AddField(&graphql.Field{Name: "count", Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*data.CollectionEntry).Count(), nil
+			}})
+
+	miniaturesArgs := append([]*graphql.Argument{
+		{Name: "ids", Description: "Only the miniatures with these IDs, such as the miniatures of a warband", Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+		{Name: "q", Description: "Text in the name, lineage or abilities", Type: graphql.String},
+		{Name: "set", Description: "Set codes", Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		{Name: "aspect", Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		{Name: "lineage", Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		{Name: "rarity", Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		{Name: "minSpawnCost", Type: graphql.Int},
+		{Name: "maxSpawnCost", Type: graphql.Int},
+	}, pageArgs()...)
+
+	queryType := &graphql.Object{Name: "Query"}
+	queryType.
+		AddField(&graphql.Field{Name: "sets", Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(setType))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return data.GetMiniatureSets()
+			}}).
+		AddField(&graphql.Field{Name: "set", Type: setType,
+			Args: []*graphql.Argument{{Name: "code", Type: graphql.NewNonNull(graphql.ID)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				miniSet, err := data.GetMiniatureSetByID(p.Args["code"].(string))
+				if err != nil {
+					return nil, nil
+				}
+				return miniSet, nil
+			}}).
+		AddField(&graphql.Field{Name: "miniature", Type: miniatureType,
+			Args: []*graphql.Argument{{Name: "id", Type: graphql.NewNonNull(graphql.ID)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				mini, err := data.GetMiniatureByID(p.Args["id"].(string))
+				if err != nil {
+					return nil, nil
+				}
+				return mini, nil
+			}}).
+		AddField(&graphql.Field{Name: "miniatures", Description: "The miniatures matching every argument, ordered by set and collector number", Type: miniatureList,
+			Args: miniaturesArgs, Complexity: pageComplexity,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				text, _ := p.Args["q"].(string)
+				minSpawnCost, _ := p.Args["minSpawnCost"].(int)
+				maxSpawnCost, _ := p.Args["maxSpawnCost"].(int)
+				minis := data.SearchMiniatures(data.MiniatureFilter{
+					Text:         text,
+					Sets:         stringList(p.Args["set"]),
+					Aspects:      stringList(p.Args["aspect"]),
+					Lineages:     stringList(p.Args["lineage"]),
+					Rarities:     stringList(p.Args["rarity"]),
+					MinSpawnCost: minSpawnCost,
+					MaxSpawnCost: maxSpawnCost,
+				})
+				if ids, given := p.Args["ids"].([]interface{}); given {
+					wanted := make(map[string]bool)
+					for _, id := range stringList(ids) {
+						wanted[strings.ToLower(id)] = true
+					}
+					var selected []*data.Miniature
+					for _, mini := range minis {
+						if wanted[mini.ID()] {
+							selected = append(selected, mini)
+						}
+					}
+					minis = selected
+				}
+				return page(minis, p.Args)
+			}}).
+		AddField(&graphql.Field{Name: "lineages", Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(lineageType))),
+			Args: pageArgs(), Complexity: pageComplexity,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return page(data.GetLineages(), p.Args)
+			}}).
+		AddField(&graphql.Field{Name: "lineage", Type: lineageType,
+			Args: []*graphql.Argument{{Name: "name", Type: graphql.NewNonNull(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if lineage, exists := findLineage(p.Args["name"].(string)); exists {
+					return lineage, nil
+				}
+				return nil, nil
+			}}).
+		AddField(&graphql.Field{Name: "abilities", Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(abilityType))),
+			Args: pageArgs(), Complexity: pageComplexity,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return page(data.GetAbilityNames(), p.Args)
+			}}).
+		AddField(&graphql.Field{Name: "ability", Type: abilityType,
+			Args: []*graphql.Argument{{Name: "name", Type: graphql.NewNonNull(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if ability, exists := findAbility(p.Args["name"].(string)); exists {
+					return ability, nil
+				}
+				return nil, nil
+			}}).
+		AddField(&graphql.Field{Name: "viewer", Description: "The user making the request, or null for anonymous requests", Type: userType,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				viewer := viewerFromContext(p.Context)
+				if len(viewer.userInfo.Username) == 0 {
+					return nil, nil
+				}
+				return viewer, nil
+			}})
+
+	mutationType := &graphql.Object{Name: "Mutation"}
+	mutationType.AddField(&graphql.Field{
+		Name:        "setOwnedCount",
+		Description: "Sets the number of copies of a miniature the user owns. A count of 0 removes it from the collection.",
+		Type:        graphql.NewNonNull(collectionEntryType),
+		Args: []*graphql.Argument{
+			{Name: "miniatureId", Type: graphql.NewNonNull(graphql.ID)},
+			{Name: "count", Type: graphql.NewNonNull(graphql.Int)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			viewer := viewerFromContext(p.Context)
+			if len(viewer.userInfo.Username) == 0 {
+				return nil, errors.New("you must be logged in to change a collection")
+			}
+			if !viewer.userInfo.HasScope(data.ScopeCollectionWrite) {
+				return nil, errors.New("the collection:write scope is required to change a collection")
+			}
+			entry, err := data.SetOwnedCount(viewer.userInfo.Username, p.Args["miniatureId"].(string), p.Args["count"].(int))
+			if err != nil {
+				return nil, err
+			}
+			viewer.setOwned(entry.MiniatureID(), entry.Count())
+			return entry, nil
+		},
+	})
+
+	return graphql.NewSchema(queryType, mutationType)
+}
@@ -1,29 +1,143 @@
 package web
 
 import (
+	"fmt"
+	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"jaredpearson.com/dbweb/data"
 )
 
+type LoginPage struct {
+	pageTitle      string
+	userInfo       UserInfo
+	Providers      []*OIDCProvider
+	CSRFToken      string
+	Username       string
+	Error          string
+	Message        string
+	CanRegister    bool
+	RequiresInvite bool
+}
+
+func (page LoginPage) PageTitle() string {
+	return page.pageTitle
+}
+func (page LoginPage) UserInfo() UserInfo {
+	return page.userInfo
+}
+
+func newLoginPage(r *http.Request, errorMessage string) LoginPage {
+	userInfo, _ := UserInfoFromRequest(r)
+	page := LoginPage{
+		pageTitle:      "Login",
+		userInfo:       userInfo,
+		Providers:      oidcProviders,
+		Error:          errorMessage,
+		CanRegister:    registrationMode != RegistrationClosed,
+		RequiresInvite: registrationMode == RegistrationInvite,
+	}
+	if session, ok := r.Context().Value(SessionRequestToken).(Session); ok {
+		page.CSRFToken = csrfToken(session)
+	}
+	return page
+}
+
+// ShowLoginPage shows the login form and logs in local accounts with a
+// username and password
 func ShowLoginPage(w http.ResponseWriter, r *http.Request) {
 	session := sessionManager.SessionStart(w, r)
-	// TODO build login page for now just hardcode
 
-	username := r.URL.Query()["username"]
-	if len(username) != 1 {
-		// TODO redirect to a login page instead
-		http.Redirect(w, r, "/", http.StatusSeeOther)
-		return
+	switch r.Method {
+	case "GET":
+		page := newLoginPage(r, "")
+		page.CSRFToken = csrfToken(session)
+		ShowTemplateInMainLayout(w, r, "login", page)
+	case "POST":
+		if !validCSRFToken(r, session) {
+			page := newLoginPage(r, "Your login session has expired. Please try again.")
+			page.CSRFToken = csrfToken(session)
+			ShowTemplateInMainLayout(w, r, "login", page)
+			return
+		}
+		username := strings.TrimSpace(r.PostFormValue("username"))
+		showError := func(status int, message string) {
+			page := newLoginPage(r, message)
+			page.CSRFToken = csrfToken(session)
+			page.Username = username
+			ShowTemplateInMainLayoutWithStatus(w, r, status, "login", page)
+		}
+
+		user, err := data.AuthenticateUser(username, r.PostFormValue("password"))
+		if err == data.ErrInvalidCredentials {
+			showError(http.StatusUnauthorized, "Invalid username or password")
+			return
+		} else if err != nil {
+			log.Printf("Unable to authenticate %s\n\t%v", username, err)
+			writeDataError(w, err)
+			return
+		}
+
+		if !user.IsVerified() {
+			// the email is sent again in case it was lost, but not on every
+			// attempt so the login can't be used to flood the address
+			retryAfter, err := allowEmail(r, user.Email())
+			if err != nil || retryAfter > 0 {
+				if err != nil {
+					log.Printf("Unable to check the email rate limit\n\t%v", err)
+				}
+				showError(http.StatusForbidden, "Your email address has not been verified. Follow the link in the verification email to verify it.")
+				return
+			}
+			if err := sendVerificationEmail(user); err != nil {
+				log.Printf("Unable to send verification email to %s\n\t%v", username, err)
+			}
+			showError(http.StatusForbidden, "Your email address has not been verified. A new verification email has been sent.")
+			return
+		}
+
+		completeLogin(w, r, session, user)
+	default:
+		http.NotFound(w, r)
 	}
+}
+
+// loginRateLimitMiddleware throttles failed login attempts by IP address
+// and by account. Failures are recorded when the login responds with 401
+// Unauthorized.
+func loginRateLimitMiddleware() HttpMiddleware {
+	blocked := func(w http.ResponseWriter, r *http.Request, retryAfter time.Duration) {
+		page := newLoginPage(r, fmt.Sprintf("Too many failed attempts. Try again in %s.", retryAfter.Round(time.Second)))
+		page.Username = r.PostFormValue("username")
+		if session, err := sessionManager.ReadSession(r); err == nil && session != nil {
+			page.CSRFToken = csrfToken(session)
+		}
+		ShowTemplateInMainLayoutWithStatus(w, r, http.StatusTooManyRequests, "login", page)
+	}
+	byIP := rateLimitMiddleware(loginIPLimiter, func(r *http.Request) string {
+		if r.Method != "POST" {
+			return ""
+		}
+		return "ip:" + clientIP(r)
+	}, blocked)
+	byAccount := rateLimitMiddleware(loginAccountLimiter, func(r *http.Request) string {
+		username := strings.TrimSpace(r.PostFormValue("username"))
+		if r.Method != "POST" || len(username) == 0 {
+			return ""
+		}
+		return data.LoginAttemptsKeyForUsername(username)
+	}, blocked)
+	return ChainMiddleware(byIP, byAccount)
+}
 
-	user, err := data.GetUserByUsername(strings.TrimSpace(username[0]))
-	if err != nil {
-		// TODO redirect to a login page instead
-		http.Redirect(w, r, "/", http.StatusSeeOther)
-		return
+// ShowLogoutPage removes the user from the session
+func ShowLogoutPage(w http.ResponseWriter, r *http.Request) {
+	session, err := sessionManager.ReadSession(r)
+	if err == nil && session != nil {
+		session.Delete(pendingUsernameSessionKey)
+		session.Delete("username")
 	}
-	session.Set("username", user.Username())
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
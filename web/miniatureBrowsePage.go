@@ -0,0 +1,371 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+// browsePageSize is the number of miniatures on each page of the browse page
+const browsePageSize = 50
+
+// browseColumns are the columns of the browse page in the order they're
+// shown. Columns without a key can't be sorted.
+var browseColumns = []struct {
+	key   string
+	label string
+}{
+	{"name", "Name"},
+	{"number", "#"},
+	{"", "Aspect"},
+	{"", "Lineage"},
+	{"", "Rarity"},
+	{"spawnCost", "Spawn"},
+	{"aspectCost", "Aspect Cost"},
+	{"power", "Power"},
+	{"defense", "Defense"},
+	{"life", "Life"},
+}
+
+// browseState is everything the URL of the browse page can choose. Each link
+// on the page is the state with one change.
+type browseState struct {
+	filter     data.MiniatureFilter
+	sort       string
+	descending bool
+	page       int
+}
+
+// browseStateFromQuery reads the state from the query parameters, which are
+// the same as the API's. Values that can't be used are ignored.
+func browseStateFromQuery(r *http.Request) browseState {
+	state := browseState{sort: "number", page: 1}
+	state.filter, _ = miniatureFilterFromQuery(r)
+	if key, descending, err := miniatureSortFromQuery(r); err == nil {
+		state.sort, state.descending = key, descending
+	}
+	if page, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && page > 1 {
+		state.page = page
+	}
+	return state
+}
+
+// url builds the URL of the browse page for the state. Defaults are left out
+// so every state has one URL.
+func (state browseState) url() string {
+	query := url.Values{}
+	if text := strings.TrimSpace(state.filter.Text); len(text) > 0 {
+		query.Set("q", text)
+	}
+	for name, values := range map[string][]string{
+		"set":     state.filter.Sets,
+		"aspect":  state.filter.Aspects,
+		"lineage": state.filter.Lineages,
+		"rarity":  state.filter.Rarities,
+	} {
+		for _, value := range values {
+			query.Add(name, value)
+		}
+	}
+	for name, value := range map[string]int{
+		"minSpawnCost":  state.filter.MinSpawnCost,
+		"maxSpawnCost":  state.filter.MaxSpawnCost,
+		"minAspectCost": state.filter.MinAspectCost,
+		"maxAspectCost": state.filter.MaxAspectCost,
+	} {
+		if value > 0 {
+			query.Set(name, strconv.Itoa(value))
+		}
+	}
+	if state.sort != "number" {
+		query.Set("sort", state.sort)
+	}
+	if state.descending {
+		query.Set("order", "desc")
+	}
+	if state.page > 1 {
+		query.Set("page", strconv.Itoa(state.page))
+	}
+	path, _ := router.URL("miniatures")
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// toggle adds the value to the list or removes it when it's there already
+func toggle(values []string, value string) []string {
+	var toggled []string
+	found := false
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			found = true
+		} else {
+			toggled = append(toggled, v)
+		}
+	}
+	if !found {
+		toggled = append(toggled, value)
+	}
+	return toggled
+}
+
+// FacetLink is a value of a facet on the browse page. The URL chooses the
+// value, or removes it when it's selected.
+type FacetLink struct {
+	Label    string
+	Count    int
+	Selected bool
+	URL      string
+}
+
+// Facet is a group of values on the browse page. ClearURL removes every
+// value of the facet when any are selected.
+type Facet struct {
+	Name     string
+	Values   []FacetLink
+	ClearURL string
+}
+
+// SortLink is a column heading on the browse page. Choosing the column
+// again reverses the order. The URL is empty when the column can't be
+// sorted.
+type SortLink struct {
+	Label      string
+	URL        string
+	Selected   bool
+	Descending bool
+}
+
+// PageLink is a link to a page of results. Gap is set when the pages
+// before it aren't linked.
+type PageLink struct {
+	Number  int
+	URL     string
+	Current bool
+	Gap     bool
+}
+
+type MiniatureBrowsePage struct {
+	pageTitle string
+	userInfo  UserInfo
+	// Query, SpawnCost and AspectCost fill the search form, and Hidden
+	// keeps the chosen facets when it's submitted
+	Query       string
+	SpawnCost   [2]string
+	AspectCost  [2]string
+	Hidden      map[string][]string
+	Facets      []Facet
+	Columns     []SortLink
+	Miniatures  []*data.Miniature
+	Total       int
+	First       int
+	Last        int
+	Pages       []PageLink
+	PrevPageURL string
+	NextPageURL string
+	ClearURL    string
+}
+
+func (page MiniatureBrowsePage) PageTitle() string {
+	return page.pageTitle
+}
+func (page MiniatureBrowsePage) UserInfo() UserInfo {
+	return page.userInfo
+}
+
+// listFacet builds the links of a facet whose values are chosen from a list
+func listFacet(state browseState, name string, values []data.FacetValue, selected func(f *data.MiniatureFilter) *[]string, label func(value string) string) Facet {
+	facet := Facet{Name: name}
+	chosen := *selected(&state.filter)
+	for _, value := range values {
+		next := state
+		next.page = 1
+		*selected(&next.filter) = toggle(chosen, value.Value)
+		facet.Values = append(facet.Values, FacetLink{
+			Label:    label(value.Value),
+			Count:    value.Count,
+			Selected: contains(lowerAll(chosen), strings.ToLower(value.Value)),
+			URL:      next.url(),
+		})
+	}
+	// a chosen value without matches is still shown so it can be removed
+	for _, value := range chosen {
+		found := false
+		for _, link := range values {
+			found = found || strings.EqualFold(link.Value, value)
+		}
+		if !found {
+			next := state
+			next.page = 1
+			*selected(&next.filter) = toggle(chosen, value)
+			facet.Values = append(facet.Values, FacetLink{Label: label(value), Selected: true, URL: next.url()})
+		}
+	}
+	if len(chosen) > 0 {
+		next := state
+		next.page = 1
+		*selected(&next.filter) = nil
+		facet.ClearURL = next.url()
+	}
+	return facet
+}
+
+// rangeFacet builds the links of a cost, where each value chooses the range
+// of just that cost
+func rangeFacet(state browseState, name string, values []data.FacetValue, bounds func(f *data.MiniatureFilter) (*int, *int)) Facet {
+	facet := Facet{Name: name}
+	low, high := bounds(&state.filter)
+	for _, value := range values {
+		cost, _ := strconv.Atoi(value.Value)
+		selected := *low == cost && *high == cost
+		next := state
+		next.page = 1
+		nextMin, nextMax := bounds(&next.filter)
+		if selected {
+			*nextMin, *nextMax = 0, 0
+		} else {
+			*nextMin, *nextMax = cost, cost
+		}
+		facet.Values = append(facet.Values, FacetLink{
+			Label:    value.Value,
+			Count:    value.Count,
+			Selected: selected || (*low > 0 || *high > 0) && cost >= *low && (*high == 0 || cost <= *high),
+			URL:      next.url(),
+		})
+	}
+	if *low > 0 || *high > 0 {
+		next := state
+		next.page = 1
+		nextMin, nextMax := bounds(&next.filter)
+		*nextMin, *nextMax = 0, 0
+		facet.ClearURL = next.url()
+	}
+	return facet
+}
+
+func lowerAll(values []string) []string {
+	var lower []string
+	for _, v := range values {
+		lower = append(lower, strings.ToLower(v))
+	}
+	return lower
+}
+
+func setName(code string) string {
+	if miniSet, err := data.GetMiniatureSetByID(code); err == nil {
+		return miniSet.Name()
+	}
+	return code
+}
+
+func rangeValue(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func newMiniatureBrowsePage(r *http.Request, state browseState) MiniatureBrowsePage {
+	userInfo, _ := UserInfoFromRequest(r)
+	minis := data.SearchMiniatures(state.filter)
+	data.SortMiniatures(minis, state.sort, state.descending)
+
+	page := MiniatureBrowsePage{
+		pageTitle:  "Miniatures",
+		userInfo:   userInfo,
+		Query:      strings.TrimSpace(state.filter.Text),
+		SpawnCost:  [2]string{rangeValue(state.filter.MinSpawnCost), rangeValue(state.filter.MaxSpawnCost)},
+		AspectCost: [2]string{rangeValue(state.filter.MinAspectCost), rangeValue(state.filter.MaxAspectCost)},
+		Hidden: map[string][]string{
+			"set":     state.filter.Sets,
+			"aspect":  state.filter.Aspects,
+			"lineage": state.filter.Lineages,
+			"rarity":  state.filter.Rarities,
+		},
+		Total:    len(minis),
+		ClearURL: browseState{sort: "number", page: 1}.url(),
+	}
+	if state.sort != "number" || state.descending {
+		page.Hidden["sort"] = []string{state.sort}
+	}
+	if state.descending {
+		page.Hidden["order"] = []string{"desc"}
+	}
+
+	facets := data.GetMiniatureFacets(state.filter)
+	identity := func(value string) string { return value }
+	page.Facets = []Facet{
+		listFacet(state, "Set", facets.Sets, func(f *data.MiniatureFilter) *[]string { return &f.Sets }, setName),
+		listFacet(state, "Aspect", facets.Aspects, func(f *data.MiniatureFilter) *[]string { return &f.Aspects }, identity),
+		listFacet(state, "Rarity", facets.Rarities, func(f *data.MiniatureFilter) *[]string { return &f.Rarities }, identity),
+		rangeFacet(state, "Spawn Cost", facets.SpawnCosts, func(f *data.MiniatureFilter) (*int, *int) { return &f.MinSpawnCost, &f.MaxSpawnCost }),
+		rangeFacet(state, "Aspect Cost", facets.AspectCosts, func(f *data.MiniatureFilter) (*int, *int) { return &f.MinAspectCost, &f.MaxAspectCost }),
+		listFacet(state, "Lineage", facets.Lineages, func(f *data.MiniatureFilter) *[]string { return &f.Lineages }, identity),
+	}
+
+	for _, column := range browseColumns {
+		if len(column.key) == 0 {
+			page.Columns = append(page.Columns, SortLink{Label: column.label})
+			continue
+		}
+		next := state
+		next.page = 1
+		next.sort = column.key
+		next.descending = state.sort == column.key && !state.descending
+		page.Columns = append(page.Columns, SortLink{
+			Label:      column.label,
+			URL:        next.url(),
+			Selected:   state.sort == column.key,
+			Descending: state.sort == column.key && state.descending,
+		})
+	}
+
+	pageCount := max((len(minis)+browsePageSize-1)/browsePageSize, 1)
+	current := min(state.page, pageCount)
+	start := (current - 1) * browsePageSize
+	page.Miniatures = minis[start:min(start+browsePageSize, len(minis))]
+	if len(page.Miniatures) > 0 {
+		page.First, page.Last = start+1, start+len(page.Miniatures)
+	}
+	pageURL := func(number int) string {
+		next := state
+		next.page = number
+		return next.url()
+	}
+	if pageCount > 1 {
+		for number := 1; number <= pageCount; number++ {
+			// the first, last and nearby pages are linked
+			if number == 1 || number == pageCount || (number >= current-2 && number <= current+2) {
+				gap := len(page.Pages) > 0 && page.Pages[len(page.Pages)-1].Number < number-1
+				page.Pages = append(page.Pages, PageLink{Number: number, URL: pageURL(number), Current: number == current, Gap: gap})
+			}
+		}
+	}
+	if current > 1 {
+		page.PrevPageURL = pageURL(current - 1)
+	}
+	if current < pageCount {
+		page.NextPageURL = pageURL(current + 1)
+	}
+	if current > 1 {
+		page.pageTitle = fmt.Sprintf("Miniatures - Page %d", current)
+	}
+	return page
+}
+
+// ShowMiniatureBrowsePage lists the miniatures chosen with the facets in the
+// query parameters. Clients that prefer JSON get the API's list, which takes
+// the same parameters.
+func ShowMiniatureBrowsePage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Vary", "Accept")
+	if prefersJSON(r) {
+		ShowAPIMiniatures(w, r)
+		return
+	}
+	ShowTemplateInMainLayout(w, r, "miniatures", newMiniatureBrowsePage(r, browseStateFromQuery(r)))
+}
@@ -1,7 +1,6 @@
 package web
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
 
@@ -28,6 +27,13 @@ type MiniatureDetailPage struct {
 	Rarity          string
 	NextMiniURL     string
 	PrevMiniURL     string
+	ProxyURL        string
+	// CSRFToken, CompareCount, InCompare and CompareFull show the add to
+	// compare form
+	CSRFToken    string
+	CompareCount int
+	InCompare    bool
+	CompareFull  bool
 }
 
 func (page MiniatureDetailPage) UserInfo() UserInfo {
@@ -62,11 +68,12 @@ func newMiniatureDetailPage(r *http.Request, miniature *data.Miniature) (page Mi
 	} else {
 		page.Set = "Unknown"
 	}
+	page.ProxyURL = proxySheetURL([]string{miniature.ID()})
 	if len(miniature.NextMiniID()) > 0 {
-		page.NextMiniURL = fmt.Sprintf("/miniature/" + miniature.NextMiniID())
+		page.NextMiniURL, _ = router.URL("miniature", "id", miniature.NextMiniID())
 	}
 	if len(miniature.PrevMiniID()) > 0 {
-		page.PrevMiniURL = fmt.Sprintf("/miniature/" + miniature.PrevMiniID())
+		page.PrevMiniURL, _ = router.URL("miniature", "id", miniature.PrevMiniID())
 	}
 	return
 }
@@ -78,28 +85,27 @@ func emptyToDash(value string) string {
 	return strings.TrimSpace(value)
 }
 
+// ShowMiniatureDetailPage shows the miniature with the ID in the path. Clients
+// that prefer JSON get the same response as the API.
 func ShowMiniatureDetailPage(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "GET" {
-		http.NotFound(w, r)
+	w.Header().Set("Vary", "Accept")
+	if prefersJSON(r) {
+		ShowAPIMiniature(w, r)
 		return
 	}
-
-	pathParts := strings.Split(r.URL.Path[1:], "/")
-	if len(pathParts) <= 1 {
-		http.NotFound(w, r)
-		return
-	}
-
-	// assume that the second part of the path is the mini ID
-	miniID := pathParts[1]
-
-	m, err := data.GetMiniatureByID(miniID)
+	m, err := data.GetMiniatureByID(PathParam(r, "id"))
 	if err != nil {
-		http.NotFound(w, r)
+		ShowNotFoundPage(w, r)
 		return
 	}
 
 	pageModel := newMiniatureDetailPage(r, m)
+	session := sessionManager.SessionStart(w, r)
+	compared := comparedIDs(session)
+	pageModel.CSRFToken = csrfToken(session)
+	pageModel.CompareCount = len(compared)
+	pageModel.InCompare = contains(compared, m.ID())
+	pageModel.CompareFull = len(compared) >= maxCompared
 
 	ShowTemplateInMainLayout(w, r, "miniDetail", pageModel)
 }
@@ -0,0 +1,400 @@
+package web
+
+// OpenID Connect authorization code flow with PKCE. Providers are listed in
+// auth.oidcProviders of the config file or in the environment:
+//
+//	OIDC_PROVIDERS=google,local
+//	OIDC_GOOGLE_ISSUER=https://accounts.google.com
+//	OIDC_GOOGLE_CLIENT_ID=...
+//	OIDC_GOOGLE_CLIENT_SECRET=...
+//	OIDC_GOOGLE_REDIRECT_URL=http://localhost:8080/login/oidc/google/callback
+//	OIDC_GOOGLE_DISPLAY_NAME=Google (optional)
+//	OIDC_GOOGLE_SCOPES=openid email profile (optional)
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	oidcClockSkew = 2 * time.Minute
+
+	oidcSessionState    = "oidcState"
+	oidcSessionNonce    = "oidcNonce"
+	oidcSessionVerifier = "oidcVerifier"
+	oidcSessionProvider = "oidcProvider"
+	oidcSessionInvite   = "oidcInvite"
+)
+
+// OIDCProviderConfig contains the settings for a single OpenID Connect provider
+type OIDCProviderConfig struct {
+	Name         string
+	DisplayName  string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type oidcDiscoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+type oidcJSONWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type oidcJSONWebKeySet struct {
+	Keys []oidcJSONWebKey `json:"keys"`
+}
+
+// OIDCClaims are the claims from a validated ID token
+type OIDCClaims struct {
+	Issuer            string       `json:"iss"`
+	Subject           string       `json:"sub"`
+	Audience          oidcAudience `json:"aud"`
+	AuthorizedParty   string       `json:"azp"`
+	Expiry            int64        `json:"exp"`
+	IssuedAt          int64        `json:"iat"`
+	Nonce             string       `json:"nonce"`
+	Email             string       `json:"email"`
+	EmailVerified     bool         `json:"email_verified"`
+	PreferredUsername string       `json:"preferred_username"`
+}
+
+// oidcAudience handles the "aud" claim which can either be a single string
+// or an array of strings.
+type oidcAudience []string
+
+func (aud *oidcAudience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*aud = oidcAudience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+	*aud = oidcAudience(multiple)
+	return nil
+}
+
+func (aud oidcAudience) contains(value string) bool {
+	for _, a := range aud {
+		if a == value {
+			return true
+		}
+	}
+	return false
+}
+
+// OIDCProvider performs the authorization code flow against a single issuer.
+// The discovery document and signing keys are fetched lazily and cached.
+type OIDCProvider struct {
+	config     OIDCProviderConfig
+	httpClient *http.Client
+	lock       sync.Mutex
+	discovery  *oidcDiscoveryDocument
+	keys       map[string]*rsa.PublicKey
+}
+
+func NewOIDCProvider(config OIDCProviderConfig) *OIDCProvider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	if len(config.DisplayName) == 0 {
+		config.DisplayName = config.Name
+	}
+	return &OIDCProvider{
+		config:     config,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (provider *OIDCProvider) Name() string {
+	return provider.config.Name
+}
+func (provider *OIDCProvider) DisplayName() string {
+	return provider.config.DisplayName
+}
+
+// getDiscovery retrieves the discovery document from the issuer. The issuer
+// in the document must match the configured issuer.
+func (provider *OIDCProvider) getDiscovery() (*oidcDiscoveryDocument, error) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	if provider.discovery != nil {
+		return provider.discovery, nil
+	}
+
+	discoveryURL := strings.TrimSuffix(provider.config.Issuer, "/") + "/.well-known/openid-configuration"
+	var doc oidcDiscoveryDocument
+	if err := provider.getJSON(discoveryURL, &doc); err != nil {
+		return nil, fmt.Errorf("unable to retrieve discovery document for %s: %v", provider.config.Name, err)
+	}
+	if doc.Issuer != provider.config.Issuer {
+		return nil, fmt.Errorf("issuer mismatch for %s: expected %s, discovered %s", provider.config.Name, provider.config.Issuer, doc.Issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JwksURI == "" {
+		return nil, fmt.Errorf("discovery document for %s is missing required endpoints", provider.config.Name)
+	}
+	provider.discovery = &doc
+	return provider.discovery, nil
+}
+
+// getKey returns the signing key with the given ID. The key set is refreshed
+// when the key is not known, which handles key rotation by the issuer.
+func (provider *OIDCProvider) getKey(kid string) (*rsa.PublicKey, error) {
+	provider.lock.Lock()
+	key, exists := provider.keys[kid]
+	provider.lock.Unlock()
+	if exists {
+		return key, nil
+	}
+
+	discovery, err := provider.getDiscovery()
+	if err != nil {
+		return nil, err
+	}
+	var keySet oidcJSONWebKeySet
+	if err := provider.getJSON(discovery.JwksURI, &keySet); err != nil {
+		return nil, fmt.Errorf("unable to retrieve signing keys for %s: %v", provider.config.Name, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range keySet.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		publicKey, err := jwk.rsaPublicKey()
+		if err != nil {
+			log.Printf("Ignoring invalid signing key %s from %s\n\t%v", jwk.Kid, provider.config.Name, err)
+			continue
+		}
+		keys[jwk.Kid] = publicKey
+	}
+
+	provider.lock.Lock()
+	provider.keys = keys
+	provider.lock.Unlock()
+
+	key, exists = keys[kid]
+	if !exists {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+	return key, nil
+}
+
+func (jwk oidcJSONWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > int64(^uint32(0)>>1) {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
+
+func (provider *OIDCProvider) getJSON(url string, v interface{}) error {
+	resp, err := provider.httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+// AuthCodeURL builds the URL of the authorization endpoint the user agent
+// should be redirected to.
+func (provider *OIDCProvider) AuthCodeURL(state, nonce, codeChallenge string) (string, error) {
+	discovery, err := provider.getDiscovery()
+	if err != nil {
+		return "", err
+	}
+	authURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", provider.config.ClientID)
+	query.Set("redirect_uri", provider.config.RedirectURL)
+	query.Set("scope", strings.Join(provider.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+// Exchange trades the authorization code for tokens and returns the
+// validated claims of the ID token.
+func (provider *OIDCProvider) Exchange(code, codeVerifier, nonce string) (*OIDCClaims, error) {
+	discovery, err := provider.getDiscovery()
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", provider.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	req, err := http.NewRequest("POST", discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(provider.config.ClientID), url.QueryEscape(provider.config.ClientSecret))
+
+	resp, err := provider.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tokenResponse struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&tokenResponse); err != nil {
+		return nil, fmt.Errorf("invalid token response from %s: %v", provider.config.Name, err)
+	}
+	if resp.StatusCode != http.StatusOK || tokenResponse.Error != "" {
+		return nil, fmt.Errorf("token request to %s failed: %s %s", provider.config.Name, tokenResponse.Error, tokenResponse.ErrorDescription)
+	}
+	if tokenResponse.IDToken == "" {
+		return nil, fmt.Errorf("token response from %s did not include an ID token", provider.config.Name)
+	}
+	return provider.VerifyIDToken(tokenResponse.IDToken, nonce)
+}
+
+// VerifyIDToken validates the signature and claims of the raw ID token.
+// Only RS256 signed tokens are accepted.
+func (provider *OIDCProvider) VerifyIDToken(rawIDToken, nonce string) (*OIDCClaims, error) {
+	parts := strings.Split(rawIDToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed ID token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed ID token header: %v", err)
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("unsupported ID token signing algorithm: %s", header.Alg)
+	}
+
+	key, err := provider.getKey(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed ID token signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, errors.New("invalid ID token signature")
+	}
+
+	var claims OIDCClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed ID token claims: %v", err)
+	}
+
+	now := time.Now()
+	switch {
+	case claims.Issuer != provider.config.Issuer:
+		return nil, fmt.Errorf("unexpected ID token issuer: %s", claims.Issuer)
+	case !claims.Audience.contains(provider.config.ClientID):
+		return nil, errors.New("ID token was not issued for this client")
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != provider.config.ClientID:
+		return nil, errors.New("ID token authorized party does not match this client")
+	case now.Add(-oidcClockSkew).After(time.Unix(claims.Expiry, 0)):
+		return nil, errors.New("ID token has expired")
+	case now.Add(oidcClockSkew).Before(time.Unix(claims.IssuedAt, 0)):
+		return nil, errors.New("ID token was issued in the future")
+	case claims.Nonce != nonce:
+		return nil, errors.New("ID token nonce does not match")
+	case claims.Subject == "":
+		return nil, errors.New("ID token is missing the subject")
+	}
+	return &claims, nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// generateRandomString returns a URL safe random string with n bytes of entropy
+func generateRandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// pkceChallenge creates the S256 code challenge for the verifier
+func pkceChallenge(verifier string) string {
+	digest := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+var oidcProviders []*OIDCProvider
+
+func getOIDCProvider(name string) (*OIDCProvider, bool) {
+	for _, provider := range oidcProviders {
+		if provider.Name() == name {
+			return provider, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,209 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+// ShowOIDCLoginPage starts the OpenID Connect login by redirecting to the
+// provider in the path
+func ShowOIDCLoginPage(w http.ResponseWriter, r *http.Request) {
+	provider, exists := getOIDCProvider(PathParam(r, "provider"))
+	if !exists {
+		ShowNotFoundPage(w, r)
+		return
+	}
+	startOIDCLogin(w, r, provider)
+}
+
+// ShowOIDCCallbackPage completes the OpenID Connect login when the provider
+// redirects back
+func ShowOIDCCallbackPage(w http.ResponseWriter, r *http.Request) {
+	provider, exists := getOIDCProvider(PathParam(r, "provider"))
+	if !exists {
+		ShowNotFoundPage(w, r)
+		return
+	}
+	completeOIDCLogin(w, r, provider)
+}
+
+func startOIDCLogin(w http.ResponseWriter, r *http.Request, provider *OIDCProvider) {
+	state, err := generateRandomString(32)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	nonce, err := generateRandomString(32)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	verifier, err := generateRandomString(32)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	authURL, err := provider.AuthCodeURL(state, nonce, pkceChallenge(verifier))
+	if err != nil {
+		log.Printf("Unable to start OIDC login with %s\n\t%v", provider.Name(), err)
+		ShowTemplateInMainLayout(w, r, "login", newLoginPage(r, fmt.Sprintf("%s is currently unavailable", provider.DisplayName())))
+		return
+	}
+
+	session := sessionManager.SessionStart(w, r)
+	session.Set(oidcSessionProvider, provider.Name())
+	session.Set(oidcSessionState, state)
+	session.Set(oidcSessionNonce, nonce)
+	session.Set(oidcSessionVerifier, verifier)
+	// an invite allows a new account to be created when registration
+	// requires one
+	session.Set(oidcSessionInvite, strings.TrimSpace(r.URL.Query().Get("invite")))
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+func completeOIDCLogin(w http.ResponseWriter, r *http.Request, provider *OIDCProvider) {
+	session, err := sessionManager.ReadSession(r)
+	if err != nil || session == nil {
+		ShowTemplateInMainLayout(w, r, "login", newLoginPage(r, "Your login session has expired. Please try again."))
+		return
+	}
+
+	expectedProvider, _ := session.Get(oidcSessionProvider).(string)
+	expectedState, _ := session.Get(oidcSessionState).(string)
+	nonce, _ := session.Get(oidcSessionNonce).(string)
+	verifier, _ := session.Get(oidcSessionVerifier).(string)
+	inviteCode, _ := session.Get(oidcSessionInvite).(string)
+
+	// the state is single use regardless of the outcome
+	session.Delete(oidcSessionProvider)
+	session.Delete(oidcSessionState)
+	session.Delete(oidcSessionNonce)
+	session.Delete(oidcSessionVerifier)
+	session.Delete(oidcSessionInvite)
+
+	query := r.URL.Query()
+	if len(expectedState) == 0 || expectedProvider != provider.Name() || query.Get("state") != expectedState {
+		ShowTemplateInMainLayout(w, r, "login", newLoginPage(r, "Your login session has expired. Please try again."))
+		return
+	}
+	if errorCode := query.Get("error"); len(errorCode) > 0 {
+		log.Printf("OIDC login with %s returned an error: %s %s", provider.Name(), errorCode, query.Get("error_description"))
+		ShowTemplateInMainLayout(w, r, "login", newLoginPage(r, fmt.Sprintf("Login with %s was not completed", provider.DisplayName())))
+		return
+	}
+
+	claims, err := provider.Exchange(query.Get("code"), verifier, nonce)
+	if err != nil {
+		log.Printf("Unable to complete OIDC login with %s\n\t%v", provider.Name(), err)
+		ShowTemplateInMainLayout(w, r, "login", newLoginPage(r, fmt.Sprintf("Login with %s failed", provider.DisplayName())))
+		return
+	}
+
+	currentUsername, _ := session.Get("username").(string)
+	user, err := findOrLinkOIDCUser(provider, claims, currentUsername, inviteCode)
+	if err == errOIDCRegistrationClosed {
+		ShowTemplateInMainLayout(w, r, "login", newLoginPage(r, fmt.Sprintf("There is no account linked to your %s login", provider.DisplayName())))
+		return
+	} else if err == data.ErrInviteInvalid {
+		ShowTemplateInMainLayout(w, r, "login", newLoginPage(r, "The invite code is invalid or has already been used"))
+		return
+	} else if err != nil {
+		log.Printf("Unable to link OIDC identity from %s\n\t%v", provider.Name(), err)
+		ShowTemplateInMainLayout(w, r, "login", newLoginPage(r, fmt.Sprintf("Login with %s failed", provider.DisplayName())))
+		return
+	}
+
+	completeLogin(w, r, session, user)
+}
+
+// errOIDCRegistrationClosed is returned when an identity that isn't linked to
+// a user would need a new account but registration doesn't allow it
+var errOIDCRegistrationClosed = errors.New("registration is closed")
+
+// findOrLinkOIDCUser returns the user for the identity in the claims. When the
+// identity has not been seen before, it is linked to the currently logged in
+// user or to the verified user with the same verified email address.
+// Otherwise a new user is created when registration is open or an invite is
+// presented.
+func findOrLinkOIDCUser(provider *OIDCProvider, claims *OIDCClaims, currentUsername, inviteCode string) (data.User, error) {
+	user, err := data.GetUserByIdentity(provider.Name(), claims.Subject)
+	if err == nil {
+		return user, nil
+	}
+	if _, ok := err.(*data.ErrUserNotFound); !ok {
+		return nil, err
+	}
+
+	username := currentUsername
+	if len(username) == 0 && claims.EmailVerified && len(claims.Email) > 0 {
+		existing, err := data.GetUserByEmail(claims.Email)
+		if err == nil {
+			// an unverified account may have been registered by anyone
+			if !existing.IsVerified() {
+				return nil, fmt.Errorf("email address %s belongs to the unverified user %s", claims.Email, existing.Username())
+			}
+			username = existing.Username()
+		} else if _, ok := err.(*data.ErrUserNotFound); !ok {
+			return nil, err
+		}
+	}
+	if len(username) == 0 {
+		if username, err = addOIDCUser(provider, claims, inviteCode); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = data.LinkUserIdentity(username, provider.Name(), claims.Subject); err != nil {
+		return nil, err
+	}
+	return data.GetUserByUsername(username)
+}
+
+// addOIDCUser creates the user for an identity that isn't linked to any user,
+// redeeming the invite when registration requires one
+func addOIDCUser(provider *OIDCProvider, claims *OIDCClaims, inviteCode string) (string, error) {
+	var email string
+	if claims.EmailVerified {
+		email = claims.Email
+	}
+	username := oidcUsername(provider, claims)
+	if _, err := data.GetUserByUsername(username); err == nil {
+		// someone else chose the email address as their username
+		username = provider.Name() + ":" + claims.Subject
+	}
+
+	switch registrationMode {
+	case RegistrationOpen:
+	case RegistrationInvite:
+		if len(inviteCode) == 0 {
+			return "", errOIDCRegistrationClosed
+		}
+		if err := data.RedeemInvite(inviteCode, username); err != nil {
+			return "", err
+		}
+	default:
+		return "", errOIDCRegistrationClosed
+	}
+
+	if err := data.AddUser(username, email); err != nil {
+		if registrationMode == RegistrationInvite {
+			data.ReleaseInvite(inviteCode, username)
+		}
+		return "", err
+	}
+	return username, nil
+}
+
+func oidcUsername(provider *OIDCProvider, claims *OIDCClaims) string {
+	if claims.EmailVerified && len(claims.Email) > 0 {
+		return claims.Email
+	}
+	return provider.Name() + ":" + claims.Subject
+}
@@ -0,0 +1,251 @@
+package web
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OIDCStubIssuer is a minimal OpenID Connect issuer intended for local
+// development. It signs ID tokens for whatever username is entered on the
+// authorize page and accepts any client ID and secret. Never expose it
+// publicly.
+type OIDCStubIssuer struct {
+	issuer string
+	key    *rsa.PrivateKey
+	keyID  string
+	lock   sync.Mutex
+	codes  map[string]oidcStubCode
+}
+
+type oidcStubCode struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	username      string
+	expires       time.Time
+}
+
+// NewOIDCStubIssuer creates an issuer with a freshly generated signing key.
+// The issuer should be the URL the stub is served from, e.g. http://localhost:9000
+func NewOIDCStubIssuer(issuer string) (*OIDCStubIssuer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := generateRandomString(8)
+	if err != nil {
+		return nil, err
+	}
+	return &OIDCStubIssuer{
+		issuer: strings.TrimSuffix(issuer, "/"),
+		key:    key,
+		keyID:  keyID,
+		codes:  make(map[string]oidcStubCode),
+	}, nil
+}
+
+func (stub *OIDCStubIssuer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/.well-known/openid-configuration":
+		stub.serveDiscovery(w, r)
+	case "/jwks":
+		stub.serveKeys(w, r)
+	case "/authorize":
+		stub.serveAuthorize(w, r)
+	case "/token":
+		stub.serveToken(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (stub *OIDCStubIssuer) serveDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeStubJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                stub.issuer,
+		"authorization_endpoint":                stub.issuer + "/authorize",
+		"token_endpoint":                        stub.issuer + "/token",
+		"jwks_uri":                              stub.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (stub *OIDCStubIssuer) serveKeys(w http.ResponseWriter, r *http.Request) {
+	publicKey := stub.key.PublicKey
+	writeStubJSON(w, http.StatusOK, oidcJSONWebKeySet{
+		Keys: []oidcJSONWebKey{{
+			Kid: stub.keyID,
+			Kty: "RSA",
+			Alg: "RS256",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+}
+
+var oidcStubAuthorizeTemplate = template.Must(template.New("authorize").Parse(`<!DOCTYPE html>
+<html>
+<body>
+    <h1>Stub OpenID Connect Issuer</h1>
+    <form method="POST" action="/authorize?{{.}}">
+        <label for="username">Username</label>
+        <input type="text" id="username" name="username" value="user@dreamblade.com" />
+        <button type="submit">Sign in</button>
+    </form>
+</body>
+</html>`))
+
+func (stub *OIDCStubIssuer) serveAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || !redirectURI.IsAbs() {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "only the authorization code flow with S256 PKCE is supported", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == "GET" {
+		oidcStubAuthorizeTemplate.Execute(w, template.URL(r.URL.RawQuery))
+		return
+	}
+
+	username := strings.TrimSpace(r.PostFormValue("username"))
+	if len(username) == 0 {
+		http.Error(w, "username is required", http.StatusBadRequest)
+		return
+	}
+
+	code, err := generateRandomString(32)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	stub.lock.Lock()
+	stub.codes[code] = oidcStubCode{
+		clientID:      query.Get("client_id"),
+		redirectURI:   redirectURI.String(),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		username:      username,
+		expires:       time.Now().Add(time.Minute),
+	}
+	stub.lock.Unlock()
+
+	callbackQuery := redirectURI.Query()
+	callbackQuery.Set("code", code)
+	callbackQuery.Set("state", query.Get("state"))
+	redirectURI.RawQuery = callbackQuery.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (stub *OIDCStubIssuer) serveToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.PostFormValue("grant_type") != "authorization_code" {
+		writeStubJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	clientID := r.PostFormValue("client_id")
+	if basicClientID, _, ok := r.BasicAuth(); ok {
+		clientID, _ = url.QueryUnescape(basicClientID)
+	}
+
+	code := r.PostFormValue("code")
+	stub.lock.Lock()
+	grant, exists := stub.codes[code]
+	delete(stub.codes, code)
+	stub.lock.Unlock()
+
+	if !exists ||
+		time.Now().After(grant.expires) ||
+		grant.clientID != clientID ||
+		grant.redirectURI != r.PostFormValue("redirect_uri") ||
+		pkceChallenge(r.PostFormValue("code_verifier")) != grant.codeChallenge {
+		writeStubJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	idToken, err := stub.sign(map[string]interface{}{
+		"iss":                stub.issuer,
+		"sub":                "stub|" + grant.username,
+		"aud":                clientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(5 * time.Minute).Unix(),
+		"nonce":              grant.nonce,
+		"email":              grant.username,
+		"email_verified":     true,
+		"preferred_username": grant.username,
+	})
+	if err != nil {
+		log.Printf("Stub issuer failed to sign ID token\n\t%v", err)
+		writeStubJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeStubJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": code,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func (stub *OIDCStubIssuer) sign(claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": stub.keyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, stub.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func writeStubJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// StartOIDCStubIssuer runs a stub issuer on the given port until the process
+// exits. It only listens on the loopback interface since it signs a token for
+// anyone.
+func StartOIDCStubIssuer(port string) {
+	issuer := "http://localhost:" + port
+	stub, err := NewOIDCStubIssuer(issuer)
+	if err != nil {
+		log.Fatalf("Unable to create stub issuer\n\t%v", err)
+	}
+	log.Printf("Stub OIDC issuer started at %s", issuer)
+	log.Fatal(http.ListenAndServe("127.0.0.1:"+port, stub))
+}
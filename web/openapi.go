@@ -0,0 +1,432 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIDoc describes an API route in the OpenAPI document served at
+// /api/openapi.json. The schemas are generated from the DTO types the
+// handlers write, and CheckAPI confirms the handlers still match.
+type APIDoc struct {
+	Summary     string
+	Description string
+	Tag         string
+	// Params describes the path parameters in the pattern and the query
+	// parameters of GET requests
+	Params []APIParam
+	// Request is the DTO of the JSON body of the other methods, if any
+	Request interface{}
+	// Response is the DTO in the data of a successful response. A slice of
+	// the DTO is a list.
+	Response interface{}
+	// Paged lists take the offset and limit parameters and have pagination
+	Paged bool
+	// Fields is set when the fields parameter can limit the fields of the
+	// Response DTO
+	Fields bool
+	// Raw responses, including errors, are the Response rather than being
+	// within data
+	Raw bool
+	// OptionalAuth is set when authenticated requests can get more
+	OptionalAuth bool
+	// ExampleQuery is the query string used when the route is checked
+	ExampleQuery string
+}
+
+// APIParam is a path or query parameter of an API route
+type APIParam struct {
+	Name        string
+	Description string
+	// Type is the JSON schema type, which defaults to string
+	Type string
+	// List parameters can be repeated or separated with commas
+	List     bool
+	Required bool
+	// Enum lists the values a string parameter accepts
+	Enum []string
+	// Example returns a value of a path parameter to check the route with
+	Example func() string
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Servers    []openAPIServer                         `json:"servers,omitempty"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*jsonSchema           `json:"schemas"`
+	SecuritySchemes map[string]openAPISecurityScheme `json:"securitySchemes"`
+}
+
+type openAPISecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme"`
+	Description string `json:"description,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *openAPIBody                `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+	Security    []map[string][]string       `json:"security,omitempty"`
+}
+
+type openAPIParameter struct {
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Explode     *bool       `json:"explode,omitempty"`
+	Schema      *jsonSchema `json:"schema"`
+}
+
+type openAPIBody struct {
+	Required bool                        `json:"required,omitempty"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *jsonSchema `json:"schema"`
+}
+
+// jsonSchema is the part of the OpenAPI schema object that the DTOs need.
+// An empty schema allows any value.
+type jsonSchema struct {
+	Ref         string                 `json:"$ref,omitempty"`
+	AllOf       []*jsonSchema          `json:"allOf,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Nullable    bool                   `json:"nullable,omitempty"`
+	Enum        []string               `json:"enum,omitempty"`
+	Minimum     *int                   `json:"minimum,omitempty"`
+	Maximum     *int                   `json:"maximum,omitempty"`
+	Default     interface{}            `json:"default,omitempty"`
+	Items       *jsonSchema            `json:"items,omitempty"`
+	Properties  map[string]*jsonSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	// AdditionalProperties is false or the schema of the values of a map
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+}
+
+const componentSchemaPrefix = "#/components/schemas/"
+
+// newOpenAPIDocument describes the routes of the router that have an APIDoc
+func newOpenAPIDocument(router *Router) *openAPIDocument {
+	doc := &openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    openAPIInfo{Title: "Dreamblade Web API", Version: "1"},
+		Paths:   make(map[string]map[string]*openAPIOperation),
+		Components: openAPIComponents{
+			Schemas: make(map[string]*jsonSchema),
+			SecuritySchemes: map[string]openAPISecurityScheme{
+				"apiToken": {Type: "http", Scheme: "bearer", Description: "A personal API token created at /account/tokens"},
+			},
+		},
+	}
+	for _, route := range router.routes {
+		if route.doc == nil {
+			continue
+		}
+		path := strings.ReplaceAll(route.pattern, "...}", "}")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*openAPIOperation)
+		}
+		for _, method := range route.methods {
+			doc.Paths[path][strings.ToLower(method)] = doc.operation(route, method)
+		}
+	}
+	return doc
+}
+
+func (doc *openAPIDocument) operation(route *Route, method string) *openAPIOperation {
+	apiDoc := route.doc
+	operation := &openAPIOperation{
+		OperationID: route.name,
+		Summary:     apiDoc.Summary,
+		Description: apiDoc.Description,
+		Responses:   make(map[string]*openAPIResponse),
+	}
+	if len(route.methods) > 1 {
+		operation.OperationID += method[:1] + strings.ToLower(method[1:])
+	}
+	if len(apiDoc.Tag) > 0 {
+		operation.Tags = []string{apiDoc.Tag}
+	}
+	if apiDoc.OptionalAuth {
+		operation.Security = []map[string][]string{{}, {"apiToken": {}}}
+	}
+
+	for _, segment := range route.segments {
+		if len(segment.param) > 0 {
+			param := apiDoc.param(segment.param)
+			operation.Parameters = append(operation.Parameters, openAPIParameter{
+				Name:        segment.param,
+				In:          "path",
+				Description: param.Description,
+				Required:    true,
+				Schema:      param.schema(),
+			})
+		}
+	}
+	if method == "GET" {
+		for _, param := range apiDoc.queryParams(route) {
+			operation.Parameters = append(operation.Parameters, openAPIParameter{
+				Name:        param.Name,
+				In:          "query",
+				Description: param.Description,
+				Required:    param.Required,
+				Schema:      param.schema(),
+			})
+		}
+		if apiDoc.Fields {
+			explode := false
+			operation.Parameters = append(operation.Parameters, openAPIParameter{
+				Name:        "fields",
+				In:          "query",
+				Description: "The fields to include, separated with commas. The other fields are left out.",
+				Explode:     &explode,
+				Schema: &jsonSchema{Type: "array", Items: &jsonSchema{
+					Type: "string",
+					Enum: jsonFieldNames(elemType(reflect.TypeOf(apiDoc.Response))),
+				}},
+			})
+		}
+	} else if apiDoc.Request != nil {
+		operation.RequestBody = &openAPIBody{
+			Required: true,
+			Content:  jsonContent(doc.schemaFor(reflect.TypeOf(apiDoc.Request))),
+		}
+	}
+
+	operation.Responses["200"] = &openAPIResponse{
+		Description: "Success",
+		Content:     jsonContent(doc.responseSchema(apiDoc)),
+	}
+	errorSchema := doc.schemaFor(reflect.TypeOf(ErrorDto{}))
+	if apiDoc.Raw {
+		errorSchema = doc.schemaFor(reflect.TypeOf(apiDoc.Response))
+	}
+	operation.Responses["default"] = &openAPIResponse{
+		Description: "An error",
+		Content:     jsonContent(errorSchema),
+	}
+	return operation
+}
+
+// responseSchema is the body of a successful response, which has the
+// Response DTO in data unless the route is raw
+func (doc *openAPIDocument) responseSchema(apiDoc *APIDoc) *jsonSchema {
+	data := doc.schemaFor(reflect.TypeOf(apiDoc.Response))
+	if apiDoc.Raw {
+		return data
+	}
+	schema := &jsonSchema{
+		Type:                 "object",
+		Properties:           map[string]*jsonSchema{"data": data},
+		Required:             []string{"data"},
+		AdditionalProperties: false,
+	}
+	if apiDoc.Paged {
+		schema.Properties["pagination"] = doc.schemaFor(reflect.TypeOf(PaginationDto{}))
+		schema.Required = append(schema.Required, "pagination")
+	}
+	return schema
+}
+
+func (apiDoc *APIDoc) param(name string) APIParam {
+	for _, param := range apiDoc.Params {
+		if param.Name == name {
+			return param
+		}
+	}
+	return APIParam{Name: name}
+}
+
+// queryParams are the parameters that aren't in the path, including offset
+// and limit for paged lists
+func (apiDoc *APIDoc) queryParams(route *Route) []APIParam {
+	var params []APIParam
+	for _, param := range apiDoc.Params {
+		inPath := false
+		for _, segment := range route.segments {
+			inPath = inPath || segment.param == param.Name
+		}
+		if !inPath {
+			params = append(params, param)
+		}
+	}
+	if apiDoc.Paged {
+		params = append(params,
+			APIParam{Name: "offset", Type: "integer", Description: "The number of items to skip"},
+			APIParam{Name: "limit", Type: "integer", Description: "The number of items in the page"})
+	}
+	return params
+}
+
+func (param APIParam) schema() *jsonSchema {
+	schema := &jsonSchema{Type: param.Type, Enum: param.Enum}
+	if len(schema.Type) == 0 {
+		schema.Type = "string"
+	}
+	switch param.Name {
+	case "offset":
+		schema.Minimum, schema.Default = intPtr(0), 0
+	case "limit":
+		schema.Minimum, schema.Maximum, schema.Default = intPtr(1), intPtr(apiMaxLimit), apiDefaultLimit
+	}
+	if param.List {
+		return &jsonSchema{Type: "array", Items: schema}
+	}
+	return schema
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func jsonContent(schema *jsonSchema) map[string]openAPIMediaType {
+	return map[string]openAPIMediaType{"application/json": {Schema: schema}}
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
+
+// schemaName is the name of the component of a DTO, which leaves out the
+// Dto suffix
+func schemaName(t reflect.Type) string {
+	name := strings.TrimSuffix(t.Name(), "Dto")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// schemaFor generates the schema of the type from its JSON encoding. DTOs
+// are added to the components and referenced, and other structs are
+// described where they're used.
+func (doc *openAPIDocument) schemaFor(t reflect.Type) *jsonSchema {
+	if t == nil {
+		return &jsonSchema{Nullable: true}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := doc.schemaFor(t.Elem())
+		if len(schema.Ref) > 0 {
+			// properties beside a $ref are ignored
+			return &jsonSchema{AllOf: []*jsonSchema{schema}, Nullable: true}
+		}
+		nullable := *schema
+		nullable.Nullable = true
+		return &nullable
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: doc.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: doc.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return &jsonSchema{Type: "string", Format: "date-time"}
+		}
+		if !strings.HasSuffix(t.Name(), "Dto") {
+			return doc.objectSchema(t)
+		}
+		name := schemaName(t)
+		if _, exists := doc.Components.Schemas[name]; !exists {
+			// the name is taken first in case the DTO refers to itself
+			doc.Components.Schemas[name] = nil
+			doc.Components.Schemas[name] = doc.objectSchema(t)
+		}
+		return &jsonSchema{Ref: componentSchemaPrefix + name}
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	}
+	// an interface can be any value
+	return &jsonSchema{Nullable: true}
+}
+
+// objectSchema describes the exported fields of the struct. Fields without
+// omitempty are required and the description is the doc tag of the field.
+func (doc *openAPIDocument) objectSchema(t reflect.Type) *jsonSchema {
+	schema := &jsonSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema),
+		AdditionalProperties: false,
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		if len(field.PkgPath) > 0 || tag[0] == "-" {
+			continue
+		}
+		name := tag[0]
+		if len(name) == 0 {
+			name = field.Name
+		}
+		property := doc.schemaFor(field.Type)
+		if description := field.Tag.Get("doc"); len(description) > 0 && len(property.Ref) == 0 {
+			property.Description = description
+		}
+		schema.Properties[name] = property
+		if !contains(tag[1:], "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPIJSON []byte
+)
+
+var openAPIDoc = APIDoc{
+	Summary:  "This OpenAPI document",
+	Raw:      true,
+	Response: map[string]interface{}{},
+}
+
+// ShowOpenAPIDocument responds with the OpenAPI document of the API
+func ShowOpenAPIDocument(w http.ResponseWriter, r *http.Request) {
+	openAPIOnce.Do(func() {
+		doc := newOpenAPIDocument(router)
+		if len(siteURL) > 0 {
+			doc.Servers = []openAPIServer{{URL: siteURL}}
+		}
+		openAPIJSON, _ = json.Marshal(doc)
+	})
+	writeJSON(w, http.StatusOK, json.RawMessage(openAPIJSON))
+}
@@ -0,0 +1,198 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// CheckAPI requests each documented API route from the catalog that's
+// loaded and compares the responses with the OpenAPI document. It returns a
+// description of each difference and of each API route without an APIDoc.
+func CheckAPI() []string {
+	router = newServerRouter()
+	doc := newOpenAPIDocument(router)
+
+	var problems []string
+	for _, route := range router.routes {
+		if route.doc == nil {
+			if strings.HasPrefix(route.pattern, "/api/") {
+				problems = append(problems, fmt.Sprintf("%s has no APIDoc", route.pattern))
+			}
+			continue
+		}
+		if !route.allows("GET") {
+			continue
+		}
+		operation := doc.Paths[strings.ReplaceAll(route.pattern, "...}", "}")]["get"]
+
+		values, missing := route.doc.examples(route)
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s has no example for %s", route.pattern, missing))
+			continue
+		}
+		query, _ := url.ParseQuery(route.doc.ExampleQuery)
+		if route.doc.Paged && len(query.Get("limit")) == 0 {
+			// a limit of 1 gives a page with a next link
+			query.Set("limit", "1")
+		}
+		problems = append(problems, doc.checkRequest(route.buildPath(values), query,
+			http.StatusOK, operation.Responses["200"].Content["application/json"].Schema)...)
+
+		// a missing resource is an error in the documented format
+		for name := range values {
+			unknown := make(map[string]string)
+			for k, v := range values {
+				unknown[k] = v
+			}
+			unknown[name] = "does-not-exist"
+			problems = append(problems, doc.checkRequest(route.buildPath(unknown), nil,
+				http.StatusNotFound, operation.Responses["default"].Content["application/json"].Schema)...)
+		}
+	}
+	return problems
+}
+
+// examples returns the example values of the path parameters and the names
+// of the parameters without an example
+func (apiDoc *APIDoc) examples(route *Route) (values map[string]string, missing []string) {
+	values = make(map[string]string)
+	for _, segment := range route.segments {
+		if len(segment.param) == 0 {
+			continue
+		}
+		param := apiDoc.param(segment.param)
+		if param.Example == nil || len(param.Example()) == 0 {
+			missing = append(missing, segment.param)
+			continue
+		}
+		values[segment.param] = param.Example()
+	}
+	return values, missing
+}
+
+// buildPath builds the path of the route from the parameter values
+func (route *Route) buildPath(values map[string]string) string {
+	var parts []string
+	for _, segment := range route.segments {
+		if len(segment.param) > 0 {
+			parts = append(parts, url.PathEscape(values[segment.param])+segment.suffix)
+		} else {
+			parts = append(parts, segment.literal)
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// checkRequest sends a GET for the path to the router and compares the
+// response with the status and schema
+func (doc *openAPIDocument) checkRequest(path string, query url.Values, status int, schema *jsonSchema) []string {
+	target := path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("GET", target, nil))
+
+	prefix := "GET " + target
+	if recorder.Code != status {
+		return []string{fmt.Sprintf("%s: expected status %d but was %d", prefix, status, recorder.Code)}
+	}
+	if mediaType, _, err := mime.ParseMediaType(recorder.Header().Get("Content-Type")); err != nil || mediaType != "application/json" {
+		return []string{fmt.Sprintf("%s: expected application/json but was %q", prefix, recorder.Header().Get("Content-Type"))}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(recorder.Body.Bytes()))
+	decoder.UseNumber()
+	var body interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return []string{fmt.Sprintf("%s: invalid JSON: %v", prefix, err)}
+	}
+	var problems []string
+	for _, problem := range doc.validate(schema, body, "body") {
+		problems = append(problems, prefix+": "+problem)
+	}
+	return problems
+}
+
+// validate checks the decoded JSON value against the schema
+func (doc *openAPIDocument) validate(schema *jsonSchema, value interface{}, path string) []string {
+	if len(schema.Ref) > 0 {
+		return doc.validate(doc.Components.Schemas[strings.TrimPrefix(schema.Ref, componentSchemaPrefix)], value, path)
+	}
+	if value == nil {
+		if schema.Nullable || len(schema.Type) == 0 && len(schema.AllOf) == 0 {
+			return nil
+		}
+		return []string{path + " is null"}
+	}
+	var problems []string
+	for _, s := range schema.AllOf {
+		problems = append(problems, doc.validate(s, value, path)...)
+	}
+
+	mismatch := func() []string {
+		return append(problems, fmt.Sprintf("%s should be %s but was %T", path, schema.Type, value))
+	}
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch()
+		}
+		for _, name := range schema.Required {
+			if _, exists := object[name]; !exists {
+				problems = append(problems, fmt.Sprintf("%s.%s is missing", path, name))
+			}
+		}
+		var names []string
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, exists := schema.Properties[name]; exists {
+				problems = append(problems, doc.validate(property, object[name], path+"."+name)...)
+			} else if additional, ok := schema.AdditionalProperties.(*jsonSchema); ok {
+				problems = append(problems, doc.validate(additional, object[name], path+"."+name)...)
+			} else if schema.AdditionalProperties == false {
+				problems = append(problems, fmt.Sprintf("%s.%s is not in the schema", path, name))
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return mismatch()
+		}
+		for i, item := range items {
+			problems = append(problems, doc.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return mismatch()
+		}
+		if len(schema.Enum) > 0 && !contains(schema.Enum, s) {
+			problems = append(problems, fmt.Sprintf("%s is %q, which isn't one of %s", path, s, strings.Join(schema.Enum, ", ")))
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if _, err := n.Int64(); !ok || err != nil {
+			return mismatch()
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return mismatch()
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return mismatch()
+		}
+	}
+	return problems
+}
@@ -0,0 +1,18 @@
+package web
+
+import (
+	"testing"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+// TestAPIMatchesOpenAPIDocument fails when a handler's response drifts from
+// the OpenAPI document, such as when a DTO changes without its schema
+func TestAPIMatchesOpenAPIDocument(t *testing.T) {
+	if err := data.LoadMiniatures("testdata/miniatures.csv"); err != nil {
+		t.Fatal(err)
+	}
+	for _, problem := range CheckAPI() {
+		t.Error(problem)
+	}
+}
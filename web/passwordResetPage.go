@@ -0,0 +1,193 @@
+package web
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"jaredpearson.com/dbweb/data"
+	"jaredpearson.com/dbweb/mail"
+)
+
+const passwordResetTTL = time.Hour
+
+type PasswordResetPage struct {
+	pageTitle string
+	userInfo  UserInfo
+	CSRFToken string
+	Token     string
+	Error     string
+	Sent      bool
+}
+
+func (page PasswordResetPage) PageTitle() string {
+	return page.pageTitle
+}
+func (page PasswordResetPage) UserInfo() UserInfo {
+	return page.userInfo
+}
+
+// ShowForgotPasswordPage emails a password reset link. The same response is
+// shown whether or not an account exists with the address so the page can't
+// be used to discover registered addresses.
+func ShowForgotPasswordPage(w http.ResponseWriter, r *http.Request) {
+	userInfo, _ := UserInfoFromRequest(r)
+	session := sessionManager.SessionStart(w, r)
+	page := PasswordResetPage{
+		pageTitle: "Forgot Password",
+		userInfo:  userInfo,
+		CSRFToken: csrfToken(session),
+	}
+
+	switch r.Method {
+	case "GET":
+	case "POST":
+		if !validCSRFToken(r, session) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		email := strings.TrimSpace(r.PostFormValue("email"))
+		if len(email) > 0 {
+			// every address is limited, known or not, so the limit doesn't
+			// reveal which addresses are registered
+			retryAfter, err := allowEmail(r, email)
+			if err != nil {
+				log.Printf("Unable to check the email rate limit\n\t%v", err)
+				writeDataError(w, err)
+				return
+			}
+			if retryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				page.Error = fmt.Sprintf("Too many reset links have been requested. Try again in %s.", retryAfter.Round(time.Second))
+				ShowTemplateInMainLayoutWithStatus(w, r, http.StatusTooManyRequests, "forgotPassword", page)
+				return
+			}
+			// send in the background so the response time is the same for
+			// known and unknown addresses
+			go sendPasswordResetEmail(email)
+		}
+		page.Sent = true
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	ShowTemplateInMainLayout(w, r, "forgotPassword", page)
+}
+
+func sendPasswordResetEmail(email string) {
+	user, err := data.GetUserByEmail(email)
+	if err != nil {
+		if _, ok := err.(*data.ErrUserNotFound); !ok {
+			log.Printf("Unable to find user for password reset\n\t%v", err)
+		}
+		return
+	}
+
+	// only the newest link works so the tokens don't pile up
+	if err := data.DeleteUserTokens(user.Username(), data.UserTokenResetPassword); err != nil {
+		log.Printf("Unable to remove the password reset tokens of %s\n\t%v", user.Username(), err)
+		return
+	}
+	token, err := data.CreateUserToken(user.Username(), data.UserTokenResetPassword, passwordResetTTL)
+	if err != nil {
+		log.Printf("Unable to create password reset token for %s\n\t%v", user.Username(), err)
+		return
+	}
+	link := fmt.Sprintf("%s/password/reset?token=%s", siteURL, url.QueryEscape(token))
+	err = mailSender.Send(mail.Message{
+		To:      user.Email(),
+		Subject: "Reset your Dreamblade Catalog password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Someone asked to reset the password for your account. Follow the link below to choose a new password. The link expires in 1 hour and can only be used once.\n\n"+
+			"%s\n\n"+
+			"If you did not ask to reset your password, you can ignore this email.\n",
+			user.Username(), link),
+	})
+	if err != nil {
+		log.Printf("Unable to send password reset email to %s\n\t%v", user.Username(), err)
+	}
+}
+
+// ShowResetPasswordPage changes the password of the user with the token
+// from the password reset email. All of the user's sessions are logged out
+// once the password has been changed.
+func ShowResetPasswordPage(w http.ResponseWriter, r *http.Request) {
+	userInfo, _ := UserInfoFromRequest(r)
+	session := sessionManager.SessionStart(w, r)
+	page := PasswordResetPage{
+		pageTitle: "Reset Password",
+		userInfo:  userInfo,
+		CSRFToken: csrfToken(session),
+	}
+
+	switch r.Method {
+	case "GET":
+		page.Token = r.URL.Query().Get("token")
+		if !data.IsUserTokenValid(data.UserTokenResetPassword, page.Token) {
+			page.Token = ""
+			page.Error = "The password reset link is invalid or has expired."
+		}
+	case "POST":
+		if !validCSRFToken(r, session) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		page.Token = r.PostFormValue("token")
+		password := r.PostFormValue("password")
+		if password != r.PostFormValue("confirmPassword") {
+			page.Error = "The passwords do not match"
+		} else if len([]rune(password)) < data.MinPasswordLength {
+			page.Error = fmt.Sprintf("Password must be at least %d characters", data.MinPasswordLength)
+		} else if err := resetPassword(page.Token, password); err == data.ErrUserTokenInvalid {
+			page.Token = ""
+			page.Error = "The password reset link is invalid or has expired."
+		} else if err != nil {
+			log.Printf("Unable to reset password\n\t%v", err)
+			writeDataError(w, err)
+			return
+		} else {
+			// the session of this request may have been one of the user's
+			// sessions that were removed, so the login form needs a new one
+			session, err := sessionManager.RestartSession(w, r)
+			if err != nil {
+				log.Printf("Unable to start a new session\n\t%v", err)
+				writeDataError(w, err)
+				return
+			}
+			loginPage := newLoginPage(r, "")
+			loginPage.CSRFToken = csrfToken(session)
+			loginPage.Message = "Your password has been changed. Please login with your new password."
+			ShowTemplateInMainLayout(w, r, "login", loginPage)
+			return
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	ShowTemplateInMainLayout(w, r, "resetPassword", page)
+}
+
+func resetPassword(token, password string) error {
+	username, err := data.ConsumeUserToken(data.UserTokenResetPassword, token)
+	if err != nil {
+		return err
+	}
+	if err := data.SetUserPassword(username, password); err != nil {
+		return err
+	}
+	if err := data.DeleteUserTokens(username, data.UserTokenResetPassword); err != nil {
+		return err
+	}
+	// following the emailed link proves the user owns the address
+	if err := data.MarkUserVerified(username); err != nil {
+		return err
+	}
+	return sessionManager.DestroyUserSessions(username)
+}
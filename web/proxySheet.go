@@ -0,0 +1,253 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"jaredpearson.com/dbweb/data"
+	"jaredpearson.com/dbweb/pdf"
+)
+
+const (
+	// proxyCardWidth and proxyCardHeight are the size of a proxy in points,
+	// which is the size of a stat card
+	proxyCardWidth  = 180
+	proxyCardHeight = 252
+	// proxyColumns and proxyRows are the number of proxies on each page,
+	// which fit on both Letter and A4
+	proxyColumns = 3
+	proxyRows    = 3
+	// maxProxyCards is the most proxies in one document
+	maxProxyCards = 90
+	// proxyPadding is the space between the edge of a proxy and its text
+	proxyPadding = 9
+	// cutMarkGap and cutMarkLength place the cut marks in the margin beside
+	// each edge of the proxies
+	cutMarkGap    = 3
+	cutMarkLength = 10
+)
+
+var proxyPaperSizes = map[string]pdf.Size{
+	"letter": pdf.Letter,
+	"a4":     pdf.A4,
+}
+
+var (
+	proxyGray      = pdf.Color{R: 0.45, G: 0.45, B: 0.45}
+	proxyLightGray = pdf.Color{R: 0.85, G: 0.85, B: 0.85}
+	proxyShade     = pdf.Color{R: 0.94, G: 0.94, B: 0.94}
+)
+
+// newProxySheet lays out a proxy of each miniature, in order, on as many
+// pages as they need
+func newProxySheet(minis []*data.Miniature, size pdf.Size) *pdf.Document {
+	doc := pdf.New(size, "Dreamblade Proxies")
+	left := (size.Width - proxyColumns*proxyCardWidth) / 2
+	bottom := (size.Height - proxyRows*proxyCardHeight) / 2
+	top := bottom + proxyRows*proxyCardHeight
+
+	var page *pdf.Page
+	for i, mini := range minis {
+		slot := i % (proxyColumns * proxyRows)
+		if slot == 0 {
+			page = doc.AddPage()
+			drawCutMarks(page, left, bottom)
+		}
+		x := left + float64(slot%proxyColumns)*proxyCardWidth
+		y := top - float64(slot/proxyColumns+1)*proxyCardHeight
+		drawProxy(page, x, y, mini)
+	}
+	return doc
+}
+
+// drawCutMarks draws short lines in the margins in line with the edges of
+// the proxies, which are cut from mark to mark
+func drawCutMarks(page *pdf.Page, left, bottom float64) {
+	right := left + proxyColumns*proxyCardWidth
+	top := bottom + proxyRows*proxyCardHeight
+	for column := 0; column <= proxyColumns; column++ {
+		x := left + float64(column)*proxyCardWidth
+		page.Line(x, top+cutMarkGap, x, top+cutMarkGap+cutMarkLength, 0.5, pdf.Black)
+		page.Line(x, bottom-cutMarkGap, x, bottom-cutMarkGap-cutMarkLength, 0.5, pdf.Black)
+	}
+	for row := 0; row <= proxyRows; row++ {
+		y := bottom + float64(row)*proxyCardHeight
+		page.Line(left-cutMarkGap, y, left-cutMarkGap-cutMarkLength, y, 0.5, pdf.Black)
+		page.Line(right+cutMarkGap, y, right+cutMarkGap+cutMarkLength, y, 0.5, pdf.Black)
+	}
+}
+
+// textBlock is wrapped text drawn in one font
+type textBlock struct {
+	lines []string
+	font  pdf.Font
+	size  float64
+}
+
+func (block textBlock) height() float64 {
+	return float64(len(block.lines)) * block.size * 1.2
+}
+
+// fitText wraps the abilities and flavor text to the width, shrinking them
+// until they fit in the height. Text that doesn't fit at the smallest size
+// is cut off.
+func fitText(abilities, flavor string, width, height float64) (textBlock, textBlock) {
+	const smallest = 5
+	for size := 8.0; ; size -= 0.5 {
+		abilityBlock := textBlock{pdf.Wrap(abilities, pdf.Helvetica, size, width), pdf.Helvetica, size}
+		flavorBlock := textBlock{pdf.Wrap(flavor, pdf.HelveticaOblique, size-1, width), pdf.HelveticaOblique, size - 1}
+		gap := 0.0
+		if len(abilityBlock.lines) > 0 && len(flavorBlock.lines) > 0 {
+			gap = size
+		}
+		if abilityBlock.height()+gap+flavorBlock.height() <= height {
+			return abilityBlock, flavorBlock
+		}
+		if size > smallest {
+			continue
+		}
+		// the abilities matter more than the flavor text
+		flavorBlock.lines = nil
+		fit := int(height / (size * 1.2))
+		if len(abilityBlock.lines) > fit {
+			abilityBlock.lines = abilityBlock.lines[:fit]
+			last := len(abilityBlock.lines) - 1
+			if last >= 0 {
+				abilityBlock.lines[last] = pdf.Truncate(abilityBlock.lines[last]+" …", pdf.Helvetica, size, width)
+			}
+		}
+		return abilityBlock, flavorBlock
+	}
+}
+
+// drawProxy draws the proxy of the miniature with its bottom left corner at
+// x and y
+func drawProxy(page *pdf.Page, x, y float64, mini *data.Miniature) {
+	page.Rect(x, y, proxyCardWidth, proxyCardHeight, 0.25, proxyLightGray)
+	left := x + proxyPadding
+	width := float64(proxyCardWidth - 2*proxyPadding)
+	line := y + proxyCardHeight - proxyPadding
+
+	nameSize := 12.0
+	for nameSize > 8 && pdf.HelveticaBold.Width(mini.Name(), nameSize) > width {
+		nameSize -= 0.5
+	}
+	line -= nameSize
+	page.Text(left, line, pdf.HelveticaBold, nameSize, pdf.Black, pdf.Truncate(mini.Name(), pdf.HelveticaBold, nameSize, width))
+
+	line -= 10
+	details := fmt.Sprintf("%s #%s", setName(mini.SetCode()), emptyToDash(mini.CollectorNumber()))
+	if rarity := strings.TrimSpace(mini.Rarity()); len(rarity) > 0 {
+		details += " - " + rarity
+	}
+	page.Text(left, line, pdf.Helvetica, 7, proxyGray, pdf.Truncate(details, pdf.Helvetica, 7, width))
+
+	line -= 11
+	var kind []string
+	for _, value := range []string{mini.Aspect(), mini.Lineage()} {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			kind = append(kind, value)
+		}
+	}
+	page.Text(left, line, pdf.HelveticaOblique, 8, pdf.Black, pdf.Truncate(strings.Join(kind, " "), pdf.HelveticaOblique, 8, width))
+
+	line -= 12
+	costs := fmt.Sprintf("Spawn %s   Aspect %s", emptyToDash(mini.SpawnCost()), emptyToDash(mini.AspectCost()))
+	page.Text(left, line, pdf.HelveticaBold, 8, pdf.Black, costs)
+
+	line -= 6
+	page.Line(left, line, left+width, line, 0.5, proxyLightGray)
+
+	// the stats are in three boxes across the card
+	const boxHeight, boxGap = 26, 6
+	boxWidth := (width - 2*boxGap) / 3
+	line -= 4 + boxHeight
+	for i, stat := range []struct{ label, value string }{
+		{"POWER", mini.Power()},
+		{"DEFENSE", mini.Defense()},
+		{"LIFE", mini.Life()},
+	} {
+		boxLeft := left + float64(i)*(boxWidth+boxGap)
+		page.FillRect(boxLeft, line, boxWidth, boxHeight, proxyShade)
+		centre := func(font pdf.Font, size float64, text string) float64 {
+			return boxLeft + (boxWidth-font.Width(text, size))/2
+		}
+		page.Text(centre(pdf.Helvetica, 5.5, stat.label), line+boxHeight-8, pdf.Helvetica, 5.5, proxyGray, stat.label)
+		value := emptyToDash(stat.value)
+		page.Text(centre(pdf.HelveticaBold, 13, value), line+4, pdf.HelveticaBold, 13, pdf.Black, value)
+	}
+
+	var abilities []string
+	for _, ability := range mini.AbilityList() {
+		abilities = append(abilities, ability.Text())
+	}
+	textTop := line - 8
+	textBottom := y + proxyPadding
+	abilityBlock, flavorBlock := fitText(strings.Join(abilities, "\n"), strings.TrimSpace(mini.FlavorText()), width, textTop-textBottom)
+
+	line = textTop
+	for _, text := range abilityBlock.lines {
+		line -= abilityBlock.size * 1.2
+		page.Text(left, line+abilityBlock.size*0.2, abilityBlock.font, abilityBlock.size, pdf.Black, text)
+	}
+	// the flavor text is at the bottom of the card
+	line = textBottom + flavorBlock.height()
+	for _, text := range flavorBlock.lines {
+		line -= flavorBlock.size * 1.2
+		page.Text(left, line+flavorBlock.size*0.2, flavorBlock.font, flavorBlock.size, proxyGray, text)
+	}
+}
+
+// proxySheetURL is the proxy sheet of the miniatures
+func proxySheetURL(ids []string) string {
+	path, _ := router.URL("proxies")
+	return path + "?" + url.Values{"ids": {strings.Join(ids, ",")}}.Encode()
+}
+
+// ShowProxySheet responds with a PDF of printable proxies of the miniatures
+// in the ids parameter. An ID can be repeated for more than one copy, such
+// as for a warband with two of a miniature.
+func ShowProxySheet(w http.ResponseWriter, r *http.Request) {
+	ids := lowerAll(queryValues(r, "ids"))
+	if len(ids) == 0 {
+		http.Error(w, "Choose the miniatures with the ids parameter", http.StatusBadRequest)
+		return
+	}
+	if len(ids) > maxProxyCards {
+		http.Error(w, fmt.Sprintf("At most %d proxies can be printed at once", maxProxyCards), http.StatusBadRequest)
+		return
+	}
+	paper := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("paper")))
+	if len(paper) == 0 {
+		paper = "letter"
+	}
+	size, exists := proxyPaperSizes[paper]
+	if !exists {
+		http.Error(w, "The paper must be letter or a4", http.StatusBadRequest)
+		return
+	}
+
+	var minis []*data.Miniature
+	for _, id := range ids {
+		mini, err := data.GetMiniatureByID(id)
+		if err != nil {
+			ShowNotFoundPage(w, r)
+			return
+		}
+		minis = append(minis, mini)
+	}
+
+	var body bytes.Buffer
+	if _, err := newProxySheet(minis, size).WriteTo(&body); err != nil {
+		log.Printf("Unable to write the proxy sheet\n\t%v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `inline; filename="proxies.pdf"`)
+	w.Write(body.Bytes())
+}
@@ -0,0 +1,378 @@
+package web
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+// BackoffPolicy determines how long a key is blocked after failed attempts.
+// After FreeAttempts failures, each failure doubles the delay before the next
+// attempt is allowed, starting at BaseDelay and capped at MaxDelay. Once
+// LockoutThreshold failures have occurred, the key is locked out for
+// LockoutDuration. Failures are forgotten when there hasn't been a failure
+// for ResetAfter.
+type BackoffPolicy struct {
+	FreeAttempts     int
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	ResetAfter       time.Duration
+}
+
+func (policy BackoffPolicy) delay(failures int) time.Duration {
+	if failures <= policy.FreeAttempts {
+		return 0
+	}
+	exponent := float64(failures - policy.FreeAttempts - 1)
+	delay := time.Duration(float64(policy.BaseDelay) * math.Pow(2, exponent))
+	if delay > policy.MaxDelay || delay <= 0 {
+		return policy.MaxDelay
+	}
+	return delay
+}
+
+// AttemptStore stores the failed attempts for each key
+type AttemptStore interface {
+	Get(key string) (data.LoginAttempts, error)
+	Put(key string, attempts data.LoginAttempts) error
+	Delete(key string) error
+}
+
+// memoryAttemptStore keeps attempts in memory. Entries older than maxAge are
+// pruned as new entries are added.
+type memoryAttemptStore struct {
+	lock     sync.Mutex
+	maxAge   time.Duration
+	attempts map[string]data.LoginAttempts
+}
+
+func newMemoryAttemptStore(maxAge time.Duration) *memoryAttemptStore {
+	return &memoryAttemptStore{
+		maxAge:   maxAge,
+		attempts: make(map[string]data.LoginAttempts),
+	}
+}
+
+func (store *memoryAttemptStore) Get(key string) (data.LoginAttempts, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	return store.attempts[key], nil
+}
+func (store *memoryAttemptStore) Put(key string, attempts data.LoginAttempts) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	now := time.Now()
+	for k, a := range store.attempts {
+		if now.Sub(a.LastFailure) > store.maxAge && now.After(a.LockedUntil) {
+			delete(store.attempts, k)
+		}
+	}
+	store.attempts[key] = attempts
+	return nil
+}
+func (store *memoryAttemptStore) Delete(key string) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	delete(store.attempts, key)
+	return nil
+}
+
+// dataAttemptStore keeps attempts in the database so they are shared between
+// processes and can be cleared from the command line
+type dataAttemptStore struct{}
+
+func (store dataAttemptStore) Get(key string) (data.LoginAttempts, error) {
+	return data.GetLoginAttempts(key)
+}
+func (store dataAttemptStore) Put(key string, attempts data.LoginAttempts) error {
+	return data.SaveLoginAttempts(key, attempts)
+}
+func (store dataAttemptStore) Delete(key string) error {
+	return data.DeleteLoginAttempts(key)
+}
+
+// LockoutListener is notified when a key is locked out
+type LockoutListener func(r *http.Request, key string, attempts data.LoginAttempts)
+
+// RateLimiter tracks failed attempts by key and determines when the next
+// attempt is allowed
+type RateLimiter struct {
+	lock      sync.Mutex
+	policy    BackoffPolicy
+	store     AttemptStore
+	onLockout LockoutListener
+	// pending holds the attempts by key that are allowed but not finished.
+	// They count as failures until they're released so that attempts sent
+	// at the same time can't all be allowed before any of them fail.
+	pending map[string]*pendingAttempts
+}
+
+type pendingAttempts struct {
+	count   int
+	started time.Time
+}
+
+func NewRateLimiter(policy BackoffPolicy, store AttemptStore, onLockout LockoutListener) *RateLimiter {
+	return &RateLimiter{
+		policy:    policy,
+		store:     store,
+		onLockout: onLockout,
+		pending:   make(map[string]*pendingAttempts),
+	}
+}
+
+// Reserve starts an attempt for the key when one is allowed now, returning
+// zero. Otherwise it returns how long until the next attempt is allowed.
+// Release must be called when a reserved attempt is finished.
+func (limiter *RateLimiter) Reserve(key string) (time.Duration, error) {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	attempts, err := limiter.store.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	failures, lastFailure := attempts.Failures, attempts.LastFailure
+	if now.Sub(lastFailure) > limiter.policy.ResetAfter && now.After(attempts.LockedUntil) {
+		failures, lastFailure = 0, time.Time{}
+	}
+	pending := limiter.pending[key]
+	if pending != nil {
+		failures += pending.count
+		if pending.started.After(lastFailure) {
+			lastFailure = pending.started
+		}
+	}
+
+	allowedAt := lastFailure.Add(limiter.policy.delay(failures))
+	if attempts.LockedUntil.After(allowedAt) {
+		allowedAt = attempts.LockedUntil
+	}
+	if now.Before(allowedAt) {
+		return allowedAt.Sub(now), nil
+	}
+
+	if pending == nil {
+		pending = &pendingAttempts{}
+		limiter.pending[key] = pending
+	}
+	pending.count++
+	pending.started = now
+	return 0, nil
+}
+
+// Release finishes an attempt started by Reserve. Fail should be called
+// first when the attempt failed.
+func (limiter *RateLimiter) Release(key string) {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+	if pending := limiter.pending[key]; pending != nil {
+		pending.count--
+		if pending.count <= 0 {
+			delete(limiter.pending, key)
+		}
+	}
+}
+
+// Attempt counts an attempt for the key that's limited whatever its outcome,
+// such as sending an email. It returns how long until the next attempt is
+// allowed when the attempt isn't allowed now, and zero when it is.
+func (limiter *RateLimiter) Attempt(r *http.Request, key string) (time.Duration, error) {
+	retryAfter, err := limiter.Reserve(key)
+	if err != nil || retryAfter > 0 {
+		return retryAfter, err
+	}
+	defer limiter.Release(key)
+	return 0, limiter.Fail(r, key)
+}
+
+// Fail records a failed attempt for the key. The lockout listener is
+// notified when the failure causes the key to be locked out.
+func (limiter *RateLimiter) Fail(r *http.Request, key string) error {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	attempts, err := limiter.store.Get(key)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if now.Sub(attempts.LastFailure) > limiter.policy.ResetAfter && now.After(attempts.LockedUntil) {
+		attempts = data.LoginAttempts{}
+	}
+
+	attempts.Failures++
+	attempts.LastFailure = now
+	lockedOut := false
+	if limiter.policy.LockoutThreshold > 0 &&
+		attempts.Failures%limiter.policy.LockoutThreshold == 0 {
+		attempts.LockedUntil = now.Add(limiter.policy.LockoutDuration)
+		lockedOut = true
+	}
+	if err = limiter.store.Put(key, attempts); err != nil {
+		return err
+	}
+
+	if lockedOut && limiter.onLockout != nil {
+		limiter.onLockout(r, key, attempts)
+	}
+	return nil
+}
+
+// Reset forgets the failed attempts for the key
+func (limiter *RateLimiter) Reset(key string) error {
+	return limiter.store.Delete(key)
+}
+
+// RateLimitKeyFunc determines the key to track a request by. Requests with
+// an empty key are not rate limited.
+type RateLimitKeyFunc func(r *http.Request) string
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	recorder.status = status
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+// rateLimitMiddleware rejects requests while the key of the request is
+// blocked by the limiter. Otherwise an attempt is reserved for the key while
+// the request is handled and, when the handler responds with 401
+// Unauthorized, a failure is recorded for the key.
+// The blocked handler writes the response for rejected requests; when it's
+// nil a plain 429 response is written.
+func rateLimitMiddleware(limiter *RateLimiter, keyFunc RateLimitKeyFunc, blocked func(w http.ResponseWriter, r *http.Request, retryAfter time.Duration)) HttpMiddleware {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := keyFunc(r)
+			if len(key) == 0 {
+				handler.ServeHTTP(w, r)
+				return
+			}
+
+			retryAfter, err := limiter.Reserve(key)
+			if err != nil {
+				log.Printf("Unable to check rate limit for %s\n\t%v", key, err)
+				writeDataError(w, err)
+				return
+			}
+			if retryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				if blocked != nil {
+					blocked(w, r, retryAfter)
+				} else {
+					http.Error(w, "Too many attempts. Try again later.", http.StatusTooManyRequests)
+				}
+				return
+			}
+
+			defer limiter.Release(key)
+
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			handler.ServeHTTP(recorder, r)
+			if recorder.status == http.StatusUnauthorized {
+				if err := limiter.Fail(r, key); err != nil {
+					log.Printf("Unable to record failed attempt for %s\n\t%v", key, err)
+				}
+			}
+		})
+	}
+}
+
+// clientIP determines the IP address of the client. The last address in
+// X-Forwarded-For is used when trustProxyHeaders is enabled, which should only
+// be set when the site is behind a reverse proxy that sets the header.
+func clientIP(r *http.Request) string {
+	if trustProxyHeaders {
+		if forwarded := r.Header.Get("X-Forwarded-For"); len(forwarded) > 0 {
+			addresses := strings.Split(forwarded, ",")
+			return strings.TrimSpace(addresses[len(addresses)-1])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// auditLockout records an audit entry when a key is locked out
+func auditLockout(r *http.Request, key string, attempts data.LoginAttempts) {
+	entry := data.AuditEntry{
+		Event:      data.AuditLoginLockout,
+		RemoteAddr: clientIP(r),
+		Detail:     fmt.Sprintf("%s locked out until %s after %d failed attempts", key, attempts.LockedUntil.Format(time.RFC3339), attempts.Failures),
+	}
+	if strings.HasPrefix(key, "account:") {
+		entry.Username = strings.TrimPrefix(key, "account:")
+	}
+	if err := data.AddAuditEntry(entry); err != nil {
+		log.Printf("Unable to add audit entry for lockout of %s\n\t%v", key, err)
+	}
+	log.Print(entry.Detail)
+}
+
+var trustProxyHeaders bool
+
+var (
+	loginIPPolicy = BackoffPolicy{
+		FreeAttempts:     10,
+		BaseDelay:        time.Second,
+		MaxDelay:         5 * time.Minute,
+		LockoutThreshold: 100,
+		LockoutDuration:  time.Hour,
+		ResetAfter:       time.Hour,
+	}
+	loginAccountPolicy = BackoffPolicy{
+		FreeAttempts:     3,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Minute,
+		LockoutThreshold: 10,
+		LockoutDuration:  15 * time.Minute,
+		ResetAfter:       time.Hour,
+	}
+	// emailPolicy limits the emails that can be sent to an address or
+	// requested from an IP address, such as password reset links
+	emailPolicy = BackoffPolicy{
+		FreeAttempts: 3,
+		BaseDelay:    time.Minute,
+		MaxDelay:     time.Hour,
+		ResetAfter:   24 * time.Hour,
+	}
+
+	loginIPLimiter      = NewRateLimiter(loginIPPolicy, newMemoryAttemptStore(loginIPPolicy.ResetAfter), auditLockout)
+	loginAccountLimiter = NewRateLimiter(loginAccountPolicy, dataAttemptStore{}, auditLockout)
+	emailIPLimiter      = NewRateLimiter(emailPolicy, newMemoryAttemptStore(emailPolicy.ResetAfter), nil)
+	emailAddressLimiter = NewRateLimiter(emailPolicy, dataAttemptStore{}, nil)
+)
+
+// emailAddressKey is the key that emails sent to the address are limited by
+func emailAddressKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// allowEmail counts an email sent to the address for the request and
+// determines if it's allowed, returning how long until the next one is when
+// it isn't
+func allowEmail(r *http.Request, email string) (time.Duration, error) {
+	retryAfter, err := emailIPLimiter.Attempt(r, "ip:"+clientIP(r))
+	if err != nil || retryAfter > 0 {
+		return retryAfter, err
+	}
+	return emailAddressLimiter.Attempt(r, emailAddressKey(email))
+}
@@ -0,0 +1,134 @@
+package web
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+type RegisterPage struct {
+	pageTitle      string
+	userInfo       UserInfo
+	Providers      []*OIDCProvider
+	CSRFToken      string
+	RequiresInvite bool
+	Username       string
+	Email          string
+	InviteCode     string
+	Error          string
+	Registered     bool
+}
+
+func (page RegisterPage) PageTitle() string {
+	return page.pageTitle
+}
+func (page RegisterPage) UserInfo() UserInfo {
+	return page.userInfo
+}
+
+// ShowRegisterPage allows new users to register when registration is open or
+// when they have an invite code. The new user must verify their email
+// address before they are able to login.
+func ShowRegisterPage(w http.ResponseWriter, r *http.Request) {
+	if registrationMode == RegistrationClosed {
+		http.NotFound(w, r)
+		return
+	}
+
+	userInfo, _ := UserInfoFromRequest(r)
+	session := sessionManager.SessionStart(w, r)
+	page := RegisterPage{
+		pageTitle:      "Register",
+		userInfo:       userInfo,
+		Providers:      oidcProviders,
+		CSRFToken:      csrfToken(session),
+		RequiresInvite: registrationMode == RegistrationInvite,
+		InviteCode:     r.URL.Query().Get("invite"),
+	}
+
+	switch r.Method {
+	case "GET":
+	case "POST":
+		if !validCSRFToken(r, session) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		page.Username = strings.TrimSpace(r.PostFormValue("username"))
+		page.Email = strings.TrimSpace(r.PostFormValue("email"))
+		page.InviteCode = strings.TrimSpace(r.PostFormValue("invite"))
+		password := r.PostFormValue("password")
+
+		if password != r.PostFormValue("confirmPassword") {
+			page.Error = "The passwords do not match"
+		} else if err := registerUser(page.Username, page.Email, password, page.InviteCode); err != nil {
+			page.Error = err.Error()
+		} else {
+			page.Registered = true
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	ShowTemplateInMainLayout(w, r, "register", page)
+}
+
+// registerUser adds the user and sends the verification email. In invite
+// mode, the invite is redeemed before the user is added so that an invite
+// can only be used once.
+func registerUser(username, email, password, inviteCode string) error {
+	if registrationMode == RegistrationInvite {
+		if err := data.RedeemInvite(inviteCode, username); err != nil {
+			return err
+		}
+	}
+
+	if err := data.RegisterUser(username, email, password); err != nil {
+		if registrationMode == RegistrationInvite {
+			data.ReleaseInvite(inviteCode, username)
+		}
+		return err
+	}
+
+	user, err := data.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+	if err := sendVerificationEmail(user); err != nil {
+		// the user can request another email by attempting to login
+		log.Printf("Unable to send verification email to %s\n\t%v", username, err)
+	}
+	return nil
+}
+
+// ShowVerifyEmailPage verifies the email address of the user with the token
+// sent in the verification email
+func ShowVerifyEmailPage(w http.ResponseWriter, r *http.Request) {
+	session := sessionManager.SessionStart(w, r)
+	page := newLoginPage(r, "")
+	page.CSRFToken = csrfToken(session)
+
+	username, err := data.ConsumeUserToken(data.UserTokenVerifyEmail, r.URL.Query().Get("token"))
+	if err == data.ErrUserTokenInvalid {
+		page.Error = "The verification link is invalid or has expired. Login to receive a new link."
+		ShowTemplateInMainLayout(w, r, "login", page)
+		return
+	} else if err != nil {
+		log.Printf("Unable to verify email\n\t%v", err)
+		writeDataError(w, err)
+		return
+	}
+
+	if err := data.MarkUserVerified(username); err != nil {
+		log.Printf("Unable to mark %s as verified\n\t%v", username, err)
+		writeDataError(w, err)
+		return
+	}
+	data.DeleteUserTokens(username, data.UserTokenVerifyEmail)
+
+	page.Message = "Your email address has been verified. You can now login."
+	page.Username = username
+	ShowTemplateInMainLayout(w, r, "login", page)
+}
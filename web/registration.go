@@ -0,0 +1,61 @@
+package web
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"jaredpearson.com/dbweb/data"
+	"jaredpearson.com/dbweb/mail"
+)
+
+// RegistrationMode determines who is allowed to register for an account
+type RegistrationMode string
+
+const (
+	// RegistrationClosed only allows accounts to be created from the command line
+	RegistrationClosed RegistrationMode = "closed"
+	// RegistrationOpen allows anyone to register
+	RegistrationOpen RegistrationMode = "open"
+	// RegistrationInvite requires an invite code to register
+	RegistrationInvite RegistrationMode = "invite"
+
+	emailVerificationTTL = 24 * time.Hour
+)
+
+// determineSiteURL determines the URL used in links sent by email,
+// defaulting to localhost
+func determineSiteURL(siteURL, port string) string {
+	if len(siteURL) == 0 {
+		return "http://localhost:" + port
+	}
+	return strings.TrimSuffix(siteURL, "/")
+}
+
+// sendVerificationEmail emails a link the user must follow to verify their
+// email address
+func sendVerificationEmail(user data.User) error {
+	// only the newest link works so the tokens don't pile up
+	if err := data.DeleteUserTokens(user.Username(), data.UserTokenVerifyEmail); err != nil {
+		return err
+	}
+	token, err := data.CreateUserToken(user.Username(), data.UserTokenVerifyEmail, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+	link := fmt.Sprintf("%s/register/verify?token=%s", siteURL, url.QueryEscape(token))
+	return mailSender.Send(mail.Message{
+		To:      user.Email(),
+		Subject: "Verify your Dreamblade Catalog account",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Please verify your email address by following the link below. The link expires in 24 hours.\n\n"+
+			"%s\n\n"+
+			"If you did not register for an account, you can ignore this email.\n",
+			user.Username(), link),
+	})
+}
+
+var registrationMode RegistrationMode
+var siteURL string
+var mailSender mail.Sender
@@ -0,0 +1,330 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// PathParamsToken is the request context key of the path parameters matched
+// by the Router. See PathParam.
+const PathParamsToken RequestTokenType = "pathParams"
+
+// Router sends each request to the route matching its path and method.
+// Patterns are made of literal segments and named parameters, such as
+// "/miniature/{id}". A parameter can be followed by a literal suffix within
+// its segment, such as "/miniature/{id}.png". A parameter ending with "..."
+// matches the rest of the path, such as "/static/{path...}".
+//
+// Paths don't end with a slash. GET requests for a path ending with a slash
+// are redirected to the path without it; see ServeHTTP. Requests that match
+// the path of a route but none of its methods get a 405 with an Allow header.
+// Requests that match no route are handled by NotFound.
+type Router struct {
+	routes       []*Route
+	routesByName map[string]*Route
+	// NotFound handles the requests that match no route
+	NotFound http.Handler
+	// MethodNotAllowed handles the requests that match a route but none of
+	// its methods. The Allow header is set before it's called.
+	MethodNotAllowed http.Handler
+}
+
+// Route is a pattern, the methods it accepts and the handler of the
+// requests that match
+type Route struct {
+	name     string
+	pattern  string
+	segments []routeSegment
+	methods  []string
+	handler  http.Handler
+	router   *Router
+	// doc describes the route in the OpenAPI document
+	doc *APIDoc
+}
+
+type routeSegment struct {
+	// literal is the text of the segment when it isn't a parameter
+	literal string
+	param   string
+	// suffix is the text after the parameter within the segment
+	suffix string
+	rest   bool
+}
+
+func NewRouter() *Router {
+	return &Router{
+		routesByName: make(map[string]*Route),
+		NotFound:     http.NotFoundHandler(),
+		MethodNotAllowed: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		}),
+	}
+}
+
+// Handle adds a route for the pattern that accepts any method until Methods
+// is called. Patterns are checked in the order they're added. It panics if
+// the pattern is invalid since routes are only added when the server starts.
+func (router *Router) Handle(pattern string, handler http.Handler) *Route {
+	segments, err := parsePattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	route := &Route{
+		pattern:  pattern,
+		segments: segments,
+		handler:  handler,
+		router:   router,
+	}
+	router.routes = append(router.routes, route)
+	return route
+}
+
+// HandleFunc adds a route for the pattern with the function as the handler
+func (router *Router) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) *Route {
+	return router.Handle(pattern, http.HandlerFunc(handler))
+}
+
+// Methods limits the route to the methods. A GET route also handles HEAD.
+func (route *Route) Methods(methods ...string) *Route {
+	for _, method := range methods {
+		route.methods = append(route.methods, strings.ToUpper(method))
+	}
+	return route
+}
+
+// Name names the route so its URL can be built with Router.URL
+func (route *Route) Name(name string) *Route {
+	if _, exists := route.router.routesByName[name]; exists {
+		panic(fmt.Sprintf("route already exists with name %s", name))
+	}
+	route.name = name
+	route.router.routesByName[name] = route
+	return route
+}
+
+// Doc describes the route in the OpenAPI document. Only routes with a doc
+// are in the document.
+func (route *Route) Doc(doc APIDoc) *Route {
+	route.doc = &doc
+	return route
+}
+
+func parsePattern(pattern string) ([]routeSegment, error) {
+	if !strings.HasPrefix(pattern, "/") {
+		return nil, fmt.Errorf("route pattern must start with /: %s", pattern)
+	}
+	if pattern == "/" {
+		return nil, nil
+	}
+	if strings.HasSuffix(pattern, "/") {
+		return nil, fmt.Errorf("route pattern must not end with /: %s", pattern)
+	}
+	var segments []routeSegment
+	parts := strings.Split(pattern[1:], "/")
+	for i, part := range parts {
+		end := strings.Index(part, "}")
+		if !strings.HasPrefix(part, "{") || end < 0 {
+			if len(part) == 0 {
+				return nil, fmt.Errorf("route pattern has an empty segment: %s", pattern)
+			}
+			segments = append(segments, routeSegment{literal: part})
+			continue
+		}
+		name, suffix := part[1:end], part[end+1:]
+		rest := strings.HasSuffix(name, "...")
+		name = strings.TrimSuffix(name, "...")
+		if len(name) == 0 {
+			return nil, fmt.Errorf("route pattern has a parameter without a name: %s", pattern)
+		}
+		if rest && (i != len(parts)-1 || len(suffix) > 0) {
+			return nil, fmt.Errorf("route pattern has a ... parameter before the end: %s", pattern)
+		}
+		segments = append(segments, routeSegment{param: name, suffix: suffix, rest: rest})
+	}
+	return segments, nil
+}
+
+// match determines if the path segments match the route and returns the
+// values of the parameters
+func (route *Route) match(parts []string) (map[string]string, bool) {
+	params := make(map[string]string)
+	for i, segment := range route.segments {
+		if i >= len(parts) {
+			return nil, false
+		}
+		if segment.rest {
+			params[segment.param] = strings.Join(parts[i:], "/")
+			return params, true
+		}
+		if len(segment.param) > 0 {
+			value, hasSuffix := strings.CutSuffix(parts[i], segment.suffix)
+			if len(value) == 0 || !hasSuffix {
+				return nil, false
+			}
+			params[segment.param] = value
+		} else if segment.literal != parts[i] {
+			return nil, false
+		}
+	}
+	return params, len(parts) == len(route.segments)
+}
+
+func (route *Route) allows(method string) bool {
+	if len(route.methods) == 0 {
+		return true
+	}
+	for _, m := range route.methods {
+		if m == method || (m == "GET" && method == "HEAD") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPath splits the path into its unescaped segments. Escaped slashes
+// stay within their segment.
+func splitPath(escapedPath string) ([]string, bool) {
+	trimmed := strings.TrimPrefix(escapedPath, "/")
+	if len(trimmed) == 0 {
+		return nil, true
+	}
+	var parts []string
+	for _, part := range strings.Split(trimmed, "/") {
+		unescaped, err := url.PathUnescape(part)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, unescaped)
+	}
+	return parts, true
+}
+
+func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	escapedPath := r.URL.EscapedPath()
+	if len(escapedPath) > 1 && strings.HasSuffix(escapedPath, "/") {
+		if r.Method == "GET" || r.Method == "HEAD" {
+			trimmed := strings.TrimRight(escapedPath, "/")
+			if parts, ok := splitPath(trimmed); ok && router.find(parts) != nil {
+				// the raw path keeps escaped slashes within their segment
+				target := url.URL{Path: "/" + strings.Join(parts, "/"), RawPath: trimmed, RawQuery: r.URL.RawQuery}
+				http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
+				return
+			}
+		}
+		router.NotFound.ServeHTTP(w, r)
+		return
+	}
+
+	parts, ok := splitPath(escapedPath)
+	if !ok {
+		router.NotFound.ServeHTTP(w, r)
+		return
+	}
+	var allowed []string
+	for _, route := range router.routes {
+		params, matches := route.match(parts)
+		if !matches {
+			continue
+		}
+		if !route.allows(r.Method) {
+			allowed = append(allowed, route.methods...)
+			continue
+		}
+		r = r.WithContext(context.WithValue(r.Context(), PathParamsToken, params))
+		if r.Method == "HEAD" && len(route.methods) > 0 {
+			// handlers only check for GET. The server still leaves out the body.
+			r.Method = "GET"
+		}
+		route.handler.ServeHTTP(w, r)
+		return
+	}
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", allowHeader(allowed))
+		router.MethodNotAllowed.ServeHTTP(w, r)
+		return
+	}
+	router.NotFound.ServeHTTP(w, r)
+}
+
+// find returns the first route matching the path segments regardless of the
+// method
+func (router *Router) find(parts []string) *Route {
+	for _, route := range router.routes {
+		if _, matches := route.match(parts); matches {
+			return route
+		}
+	}
+	return nil
+}
+
+// allowHeader lists the methods for the Allow header, adding HEAD when GET
+// is allowed
+func allowHeader(allowed []string) string {
+	methods := make(map[string]bool)
+	for _, method := range allowed {
+		methods[method] = true
+		if method == "GET" {
+			methods["HEAD"] = true
+		}
+	}
+	var names []string
+	for method := range methods {
+		names = append(names, method)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// URL builds the path of the named route. The params are pairs of parameter
+// names and values, such as URL("miniature", "id", "ashen_knight"). Values
+// are escaped except for the slashes of a "..." parameter.
+func (router *Router) URL(name string, params ...string) (string, error) {
+	route, exists := router.routesByName[name]
+	if !exists {
+		return "", fmt.Errorf("no route named %s", name)
+	}
+	if len(params)%2 != 0 {
+		return "", fmt.Errorf("route %s needs pairs of parameter names and values: %v", name, params)
+	}
+	values := make(map[string]string)
+	for i := 0; i < len(params); i += 2 {
+		values[params[i]] = params[i+1]
+	}
+
+	var parts []string
+	for _, segment := range route.segments {
+		if len(segment.param) == 0 {
+			parts = append(parts, segment.literal)
+			continue
+		}
+		value, exists := values[segment.param]
+		if !exists || len(value) == 0 {
+			return "", fmt.Errorf("route %s needs a value for %s", name, segment.param)
+		}
+		delete(values, segment.param)
+		if segment.rest {
+			var escaped []string
+			for _, part := range strings.Split(value, "/") {
+				escaped = append(escaped, url.PathEscape(part))
+			}
+			parts = append(parts, strings.Join(escaped, "/"))
+		} else {
+			parts = append(parts, url.PathEscape(value)+segment.suffix)
+		}
+	}
+	for param := range values {
+		return "", fmt.Errorf("route %s has no parameter %s", name, param)
+	}
+	return "/" + strings.Join(parts, "/"), nil
+}
+
+// PathParam returns the value of the parameter in the path of the route
+// that matched the request, or an empty string
+func PathParam(r *http.Request, name string) string {
+	params, _ := r.Context().Value(PathParamsToken).(map[string]string)
+	return params[name]
+}
@@ -0,0 +1,158 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testRouter has a route of each kind. Each handler writes its name, the
+// values of its parameters and the method it was called with.
+func testRouter() *Router {
+	router := NewRouter()
+	handler := func(name string, params ...string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			body := []string{name}
+			for _, param := range params {
+				body = append(body, PathParam(r, param))
+			}
+			w.Write([]byte(strings.Join(append(body, r.Method), " ")))
+		}
+	}
+	router.HandleFunc("/", handler("home")).Methods("GET").Name("home")
+	router.HandleFunc("/miniature/{id}.png", handler("png", "id")).Methods("GET").Name("png")
+	router.HandleFunc("/miniature/{id}", handler("miniature", "id")).Methods("GET").Name("miniature")
+	router.HandleFunc("/miniature/{id}", handler("update", "id")).Methods("POST")
+	router.HandleFunc("/sets/{set}/minis/{id}", handler("setMiniature", "set", "id")).Methods("GET").Name("setMiniature")
+	router.HandleFunc("/api/items", handler("items")).Methods("GET", "POST")
+	router.HandleFunc("/static/{path...}", handler("static", "path")).Name("static")
+	return router
+}
+
+func TestRouterServeHTTP(t *testing.T) {
+	router := testRouter()
+	tests := []struct {
+		method   string
+		target   string
+		status   int
+		body     string
+		allow    string
+		location string
+	}{
+		// matching
+		{"GET", "/", 200, "home GET", "", ""},
+		{"GET", "/miniature/ashen_knight", 200, "miniature ashen_knight GET", "", ""},
+		{"GET", "/miniature/ashen%20knight", 200, "miniature ashen knight GET", "", ""},
+		{"GET", "/miniature/a%2Fb", 200, "miniature a/b GET", "", ""},
+		{"GET", "/miniature/ashen_knight.png", 200, "png ashen_knight GET", "", ""},
+		{"GET", "/miniature/.png", 200, "miniature .png GET", "", ""},
+		{"GET", "/miniature/ashen_knight?page=2", 200, "miniature ashen_knight GET", "", ""},
+		{"POST", "/miniature/ashen_knight", 200, "update ashen_knight POST", "", ""},
+		{"GET", "/sets/A/minis/ember_sprite", 200, "setMiniature A ember_sprite GET", "", ""},
+		{"GET", "/static/css/site.css", 200, "static css/site.css GET", "", ""},
+		{"DELETE", "/static/site.css", 200, "static site.css DELETE", "", ""},
+
+		// HEAD is handled as a GET
+		{"HEAD", "/miniature/ashen_knight", 200, "miniature ashen_knight GET", "", ""},
+		{"HEAD", "/static/site.css", 200, "static site.css HEAD", "", ""},
+
+		// no route
+		{"GET", "/nowhere", 404, "", "", ""},
+		{"GET", "/miniature", 404, "", "", ""},
+		{"GET", "/sets/A/minis", 404, "", "", ""},
+		{"GET", "/sets/A/minis/ember_sprite/more", 404, "", "", ""},
+		{"GET", "/static", 404, "", "", ""},
+
+		// a route for the path but not the method
+		{"DELETE", "/miniature/ashen_knight", 405, "", "GET, HEAD, POST", ""},
+		{"PUT", "/api/items", 405, "", "GET, HEAD, POST", ""},
+		{"POST", "/", 405, "", "GET, HEAD", ""},
+
+		// trailing slashes
+		{"GET", "/miniature/ashen_knight/", 301, "", "", "/miniature/ashen_knight"},
+		{"GET", "/miniature/ashen_knight//", 301, "", "", "/miniature/ashen_knight"},
+		{"HEAD", "/api/items/?page=2", 301, "", "", "/api/items?page=2"},
+		{"GET", "/miniature/ashen%20knight/", 301, "", "", "/miniature/ashen%20knight"},
+		{"GET", "/miniature/a%2Fb/", 301, "", "", "/miniature/a%2Fb"},
+		{"DELETE", "/static/site.css/", 404, "", "", ""},
+		{"POST", "/miniature/ashen_knight/", 404, "", "", ""},
+		{"GET", "/nowhere/", 404, "", "", ""},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(test.method, test.target, nil))
+		name := test.method + " " + test.target
+		if w.Code != test.status {
+			t.Errorf("%s: got status %d, want %d", name, w.Code, test.status)
+			continue
+		}
+		if test.status == 200 && w.Body.String() != test.body {
+			t.Errorf("%s: got body %q, want %q", name, w.Body.String(), test.body)
+		}
+		if allow := w.Header().Get("Allow"); allow != test.allow {
+			t.Errorf("%s: got Allow %q, want %q", name, allow, test.allow)
+		}
+		if location := w.Header().Get("Location"); location != test.location {
+			t.Errorf("%s: got Location %q, want %q", name, location, test.location)
+		}
+	}
+}
+
+func TestRouterURL(t *testing.T) {
+	router := testRouter()
+	tests := []struct {
+		name   string
+		params []string
+		url    string
+	}{
+		{"home", nil, "/"},
+		{"miniature", []string{"id", "ashen_knight"}, "/miniature/ashen_knight"},
+		{"miniature", []string{"id", "ashen knight"}, "/miniature/ashen%20knight"},
+		{"miniature", []string{"id", "a/b"}, "/miniature/a%2Fb"},
+		{"png", []string{"id", "ashen_knight"}, "/miniature/ashen_knight.png"},
+		{"setMiniature", []string{"set", "A", "id", "ember_sprite"}, "/sets/A/minis/ember_sprite"},
+		{"static", []string{"path", "css/site v2.css"}, "/static/css/site%20v2.css"},
+	}
+	for _, test := range tests {
+		url, err := router.URL(test.name, test.params...)
+		if err != nil {
+			t.Errorf("%s %v: %v", test.name, test.params, err)
+			continue
+		}
+		if url != test.url {
+			t.Errorf("%s %v: got %s, want %s", test.name, test.params, url, test.url)
+		}
+	}
+
+	for _, test := range []struct {
+		name   string
+		params []string
+	}{
+		{"nowhere", nil},
+		{"miniature", nil},
+		{"miniature", []string{"id", ""}},
+		{"miniature", []string{"id"}},
+		{"miniature", []string{"id", "ashen_knight", "page", "2"}},
+	} {
+		if url, err := router.URL(test.name, test.params...); err == nil {
+			t.Errorf("%s %v: got %s, want an error", test.name, test.params, url)
+		}
+	}
+}
+
+func TestParsePatternErrors(t *testing.T) {
+	for _, pattern := range []string{
+		"",
+		"miniature",
+		"/miniature/",
+		"/miniature//{id}",
+		"/miniature/{}",
+		"/static/{path...}/more",
+		"/static/{path...}.css",
+	} {
+		if _, err := parsePattern(pattern); err == nil {
+			t.Errorf("%q: expected an error", pattern)
+		}
+	}
+}
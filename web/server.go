@@ -4,19 +4,37 @@ import (
 	"context"
 	"log"
 	"net/http"
-	"os"
+	"strconv"
+	"strings"
 
 	"jaredpearson.com/dbweb/data"
+	"jaredpearson.com/dbweb/graphql"
+	"jaredpearson.com/dbweb/mail"
 )
 
-func determinePort() string {
-	port := os.Getenv("PORT")
-	if len(port) != 0 {
-		return port
-	}
-
-	// default port
-	return "8080"
+// ServerConfig contains the settings of the web server
+type ServerConfig struct {
+	Port int
+	// TemplatePath is the directory containing the templates. Defaults to
+	// the "templates" directory within the current working directory.
+	TemplatePath string
+	// DevMode parses the templates again when they change
+	DevMode bool
+	// StaticPath is a directory of assets that replace or add to the
+	// embedded static assets
+	StaticPath string
+	// CardCachePath is the directory where stat card images are kept
+	CardCachePath string
+	// SiteURL is used in links sent by email. Defaults to localhost.
+	SiteURL string
+	// TrustProxyHeaders uses X-Forwarded-For to determine the client address
+	TrustProxyHeaders     bool
+	RegistrationMode      RegistrationMode
+	RequireTwoFactorRoles []string
+	OIDCProviders         []OIDCProviderConfig
+	Mail                  mail.Config
+	// GraphQLLimits bound the depth and complexity of GraphQL queries
+	GraphQLLimits graphql.Limits
 }
 
 type HomePageData struct {
@@ -33,6 +51,11 @@ func (page HomePageData) UserInfo() UserInfo {
 }
 
 func showHome(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Vary", "Accept")
+	if prefersJSON(r) {
+		ShowAPISets(w, r)
+		return
+	}
 	userInfo, _ := UserInfoFromRequest(r)
 	sets, err := data.GetMiniatureSets()
 	if err != nil {
@@ -75,7 +98,7 @@ func fillUserMiddleware() HttpMiddleware {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			s := r.Context().Value(SessionRequestToken)
 			if s != nil {
-				username := s.(Session).Get("username").(string)
+				username, _ := s.(Session).Get("username").(string)
 				if len(username) > 0 {
 					// TODO attempt to load the user name
 					user := UserInfo{
@@ -90,33 +113,149 @@ func fillUserMiddleware() HttpMiddleware {
 	}
 }
 
+// fillUserFromAPITokenMiddleware authenticates requests that contain an
+// "Authorization: Bearer" header with a personal API token and populates the
+// user information in the Request context. The scopes of the token are
+// included in the UserInfo. Requests with an invalid token are rejected.
+func fillUserFromAPITokenMiddleware() HttpMiddleware {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization := r.Header.Get("Authorization")
+			if !strings.HasPrefix(authorization, "Bearer ") {
+				handler.ServeHTTP(w, r)
+				return
+			}
+
+			token, err := data.AuthenticateAPIToken(strings.TrimSpace(authorization[len("Bearer "):]))
+			if err != nil {
+				if err != data.ErrAPITokenNotFound {
+					log.Printf("Unable to authenticate API token\n\t%v", err)
+					if data.IsDatabaseUnavailable(err) {
+						writeDataError(w, err)
+						return
+					}
+				}
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			user := UserInfo{
+				Username: token.Username(),
+				Scopes:   token.Scopes(),
+			}
+			newContext := context.WithValue(r.Context(), AuthUserToken, user)
+			handler.ServeHTTP(w, r.WithContext(newContext))
+		})
+	}
+}
+
 var sessionManager *SessionManager
 
+// router has the routes of the server. Templates use it to build URLs.
+var router *Router
+
+// newServerRouter adds a named route for each page of the server
+func newServerRouter() *Router {
+	r := NewRouter()
+	r.NotFound = http.HandlerFunc(ShowNotFoundPage)
+	r.MethodNotAllowed = http.HandlerFunc(showMethodNotAllowed)
+	r.HandleFunc("/", showHome).Methods("GET").Name("home")
+	r.Handle("/login", loginRateLimitMiddleware()(http.HandlerFunc(ShowLoginPage))).Methods("GET", "POST").Name("login")
+	r.Handle("/login/2fa", twoFactorRateLimitMiddleware()(http.HandlerFunc(ShowTwoFactorLoginPage))).Methods("GET", "POST").Name("twoFactorLogin")
+	r.HandleFunc("/login/oidc/{provider}", ShowOIDCLoginPage).Methods("GET").Name("oidcLogin")
+	r.HandleFunc("/login/oidc/{provider}/callback", ShowOIDCCallbackPage).Methods("GET").Name("oidcCallback")
+	r.HandleFunc("/logout", ShowLogoutPage).Name("logout")
+	r.HandleFunc("/register", ShowRegisterPage).Methods("GET", "POST").Name("register")
+	r.HandleFunc("/register/verify", ShowVerifyEmailPage).Methods("GET").Name("verifyEmail")
+	r.HandleFunc("/password/forgot", ShowForgotPasswordPage).Methods("GET", "POST").Name("forgotPassword")
+	r.HandleFunc("/password/reset", ShowResetPasswordPage).Methods("GET", "POST").Name("resetPassword")
+	r.Handle("/account/2fa", twoFactorRateLimitMiddleware()(http.HandlerFunc(ShowTwoFactorSettingsPage))).Methods("GET", "POST").Name("twoFactorSettings")
+	r.HandleFunc("/account/tokens", ShowAPITokensPage).Methods("GET", "POST").Name("apiTokens")
+	r.Handle("/admin/invites", requireRole(data.RoleAdmin)(http.HandlerFunc(ShowAdminInvitesPage))).Methods("GET", "POST").Name("adminInvites")
+	r.HandleFunc("/miniatures", ShowMiniatureBrowsePage).Methods("GET").Name("miniatures")
+	r.HandleFunc("/miniature/{id}.png", ShowStatCardPNG).Methods("GET").Name("miniatureCardPNG")
+	r.HandleFunc("/miniature/{id}.svg", ShowStatCardSVG).Methods("GET").Name("miniatureCardSVG")
+	r.HandleFunc("/miniature/{id}", ShowMiniatureDetailPage).Methods("GET").Name("miniature")
+	r.HandleFunc("/compare", ShowComparePage).Methods("GET", "POST").Name("compare")
+	r.HandleFunc("/proxies.pdf", ShowProxySheet).Methods("GET").Name("proxies")
+	r.HandleFunc("/set/{code}", ShowSetDetailPage).Methods("GET").Name("set")
+	r.HandleFunc("/static/{path...}", ShowStaticAsset).Methods("GET").Name("static")
+	r.HandleFunc("/api/v1/sets", ShowAPISets).Methods("GET").Name("apiSets").Doc(apiSetsDoc)
+	r.HandleFunc("/api/v1/sets/{code}/miniatures", ShowAPISetMiniatures).Methods("GET").Name("apiSetMiniatures").Doc(apiSetMiniaturesDoc)
+	r.HandleFunc("/api/v1/miniatures", ShowAPIMiniatures).Methods("GET").Name("apiMiniatures").Doc(apiMiniaturesDoc)
+	r.HandleFunc("/api/v1/miniatures/{id}", ShowAPIMiniature).Methods("GET").Name("apiMiniature").Doc(apiMiniatureDoc)
+	r.HandleFunc("/api/graphql", ShowAPIGraphQL).Methods("GET", "POST").Name("apiGraphQL").Doc(apiGraphQLDoc)
+	r.HandleFunc("/api/openapi.json", ShowOpenAPIDocument).Methods("GET").Name("apiOpenAPI").Doc(openAPIDoc)
+	return r
+}
+
+// ShowNotFoundPage responds with a 404 and a page linking back to the
+// catalog, or an error body for the API
+func ShowNotFoundPage(w http.ResponseWriter, r *http.Request) {
+	if isAPIRequest(r) {
+		showAPINotFound(w, r)
+		return
+	}
+	userInfo, _ := UserInfoFromRequest(r)
+	ShowTemplateInMainLayoutWithStatus(w, r, http.StatusNotFound, "notFound", NewMainLayoutData("Page Not Found", userInfo))
+}
+
+func showMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	if isAPIRequest(r) {
+		showAPIMethodNotAllowed(w, r)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
+
 func initializeSessionManager() {
-	sessionProvider := NewMongoDbSessionProvider(
-		data.GetMongoSession,
-		data.GetMongoDbName(),
-		"sessions",
-	)
-	sessionProvider.InitializeMongoDb()
+	sessionProvider := NewStoreSessionProvider(data.OpenStore, sessionCollectionName)
+	if err := sessionProvider.InitializeStore(); err != nil {
+		// the index is created the next time the server starts
+		log.Printf("Unable to initialize the session store\n\t%v", err)
+	}
 	sessionManager, _ = NewSessionManager("dbsession", sessionProvider)
 }
 
-func ServerStart() {
+func ServerStart(config ServerConfig) {
+	port := strconv.Itoa(config.Port)
+	var err error
+	staticFiles, err = loadStaticAssets(config.StaticPath)
+	if err != nil {
+		log.Fatalf("Unable to load static assets\n\t%v", err)
+	}
+	templates, err = newTemplateRegistry(determineTemplateDir(config.TemplatePath), config.DevMode)
+	if err != nil {
+		log.Fatalf("Unable to load templates\n\t%v", err)
+	}
+	trustProxyHeaders = config.TrustProxyHeaders
+	registrationMode = config.RegistrationMode
+	siteURL = determineSiteURL(config.SiteURL, port)
+	mailSender = mail.NewSender(config.Mail)
+	requiredTwoFactorRoles = config.RequireTwoFactorRoles
+	oidcProviders = nil
+	for _, providerConfig := range config.OIDCProviders {
+		oidcProviders = append(oidcProviders, NewOIDCProvider(providerConfig))
+	}
+	graphqlLimits = config.GraphQLLimits
+	statCards = &cardCache{dir: config.CardCachePath}
+	if _, err := CatalogSchema(); err != nil {
+		log.Fatalf("Unable to create the GraphQL schema\n\t%v", err)
+	}
 	initializeSessionManager()
 
 	fillSession := fillRequestSession(sessionManager)
 	fillUser := fillUserMiddleware()
-	mwChain := ChainMiddleware(fillSession, fillUser)
+	fillUserFromAPIToken := fillUserFromAPITokenMiddleware()
+	mwChain := ChainMiddleware(fillSession, fillUser, fillUserFromAPIToken)
 
-	http.Handle("/", mwChain(http.HandlerFunc(showHome)))
-	http.Handle("/login", mwChain(http.HandlerFunc(ShowLoginPage)))
-	http.Handle("/miniature/", mwChain(http.HandlerFunc(ShowMiniatureDetailPage)))
-	http.Handle("/set/", mwChain(http.HandlerFunc(ShowSetDetailPage)))
+	router = newServerRouter()
 
-	port := determinePort()
 	log.Printf("Server started on %s", port)
-
-	// TODO fix issue with missing pages being sent to home
-	log.Fatal(http.ListenAndServe(":"+port, nil))
+	// static assets don't need the session or user
+	mux := http.NewServeMux()
+	mux.Handle("/static/", router)
+	mux.Handle("/", mwChain(router))
+	log.Fatal(http.ListenAndServe(":"+port, mux))
 }
@@ -15,9 +15,11 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
-	"github.com/globalsign/mgo"
 	"github.com/globalsign/mgo/bson"
+
+	"jaredpearson.com/dbweb/data"
 )
 
 type Session interface {
@@ -30,112 +32,203 @@ type Session interface {
 type SessionProvider interface {
 	InitializeSession(sid string) (Session, error)
 	ReadSession(sid string) (Session, error)
+	// DestroySessionsWithValue removes every session where the key is set
+	// to the value
+	DestroySessionsWithValue(key string, value interface{}) error
+	// RenewSession moves the values of the session to a new session with the
+	// ID and removes the old session
+	RenewSession(session Session, sid string) (Session, error)
+	DestroySession(sid string) error
 }
 
-// MongoDbSession is a web session backed by MongoDb
-type MongoDbSession struct {
-	provider  *MongoDbSessionProvider
+// StoreSession is a web session kept in a data store
+type StoreSession struct {
+	provider  *StoreSessionProvider
 	sessionID string
 	data      map[string]interface{}
 }
 
-func (session *MongoDbSession) SessionID() string {
+func (session *StoreSession) SessionID() string {
 	return session.sessionID
 }
-func (session *MongoDbSession) Set(key string, value interface{}) error {
+func (session *StoreSession) Set(key string, value interface{}) error {
 	session.data[key] = value
 	return session.provider.UpdateSession(session)
 }
-func (session *MongoDbSession) Get(key string) interface{} {
+func (session *StoreSession) Get(key string) interface{} {
 	return session.data[key]
 }
-func (session *MongoDbSession) Delete(key string) error {
+func (session *StoreSession) Delete(key string) error {
 	delete(session.data, key)
-	return nil
+	return session.provider.UpdateSession(session)
 }
 
-type CreateMongoDbSession func() *mgo.Session
+const (
+	sessionCollectionName = "sessions"
 
-// MongoDbSessionProvider is a SessionProvider that's backed by MongoDb.
-type MongoDbSessionProvider struct {
-	lock                 sync.Mutex
-	createMongoDbSession CreateMongoDbSession
-	databaseName         string
-	collectionName       string
+	// sessionDocCurrentVersion is the version of session documents written by
+	// StoreSessionProvider. See the migrations registered in init.
+	sessionDocCurrentVersion = 2
+)
+
+func init() {
+	data.RegisterMigration(data.Migration{
+		Collection:  sessionCollectionName,
+		FromVersion: 1,
+		Description: "record when the session was last updated",
+		Upgrade: func(doc bson.M) error {
+			doc["updated"] = time.Now().UTC()
+			return nil
+		},
+	})
 }
 
-func NewMongoDbSessionProvider(
-	createMongoDbSession CreateMongoDbSession,
-	databaseName,
-	collectionName string) *MongoDbSessionProvider {
-	return &MongoDbSessionProvider{
-		createMongoDbSession: createMongoDbSession,
-		databaseName:         databaseName,
-		collectionName:       collectionName,
+// OpenStoreFunc opens the store sessions are kept in
+type OpenStoreFunc func() (data.Store, error)
+
+// StoreSessionProvider is a SessionProvider that keeps sessions in a data
+// store, which is either MongoDB or the embedded file store.
+type StoreSessionProvider struct {
+	lock           sync.Mutex
+	openStore      OpenStoreFunc
+	collectionName string
+}
+
+func NewStoreSessionProvider(openStore OpenStoreFunc, collectionName string) *StoreSessionProvider {
+	return &StoreSessionProvider{
+		openStore:      openStore,
+		collectionName: collectionName,
 	}
 }
 
-func (provider *MongoDbSessionProvider) InitializeMongoDb() (err error) {
-	session := provider.createMongoDbSession()
-	defer session.Close()
+func (provider *StoreSessionProvider) InitializeStore() (err error) {
+	store, err := provider.openStore()
+	if err != nil {
+		return
+	}
+	defer store.Close()
 
-	err = session.DB(provider.databaseName).C(provider.collectionName).EnsureIndex(mgo.Index{
+	err = store.C(provider.collectionName).EnsureIndex(data.Index{
 		Key: []string{"sid"},
 	})
 	return
 }
 
-func (provider *MongoDbSessionProvider) InitializeSession(sid string) (Session, error) {
+func (provider *StoreSessionProvider) InitializeSession(sid string) (Session, error) {
 	provider.lock.Lock()
 	defer provider.lock.Unlock()
-	session := &MongoDbSession{
+	session := &StoreSession{
 		sessionID: sid,
 		provider:  provider,
 		data:      make(map[string]interface{}),
 	}
 	return session, nil
 }
-func (provider *MongoDbSessionProvider) ReadSession(sid string) (Session, error) {
-	session := provider.createMongoDbSession()
-	defer session.Close()
+func (provider *StoreSessionProvider) ReadSession(sid string) (Session, error) {
+	store, err := provider.openStore()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
 
-	var sessionData map[string]interface{}
+	var sessionDoc bson.M
 
-	collection := session.DB(provider.databaseName).C(provider.collectionName)
+	collection := store.C(provider.collectionName)
 
 	query := bson.M{"sid": sid}
 
-	err := collection.Find(query).One(&sessionData)
+	err = collection.Find(query).One(&sessionDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	originalVersion := sessionDoc["version"]
+	changed, err := data.UpgradeDocument(provider.collectionName, sessionDoc)
 	if err != nil {
 		return nil, err
 	}
+	if changed {
+		// best effort; the session is written again on the next change
+		collection.Update(bson.M{"sid": sid, "version": originalVersion}, sessionDoc)
+	}
 
-	// assume v1 of session data doc
-	data := sessionData["data"].(map[string]interface{})
+	sessionData, _ := sessionDoc["data"].(bson.M)
+	if sessionData == nil {
+		sessionData = bson.M{}
+	}
 
-	return &MongoDbSession{
+	return &StoreSession{
 		sessionID: sid,
 		provider:  provider,
-		data:      data,
+		data:      map[string]interface{}(sessionData),
 	}, nil
 }
-func (provider *MongoDbSessionProvider) UpdateSession(session *MongoDbSession) error {
+func (provider *StoreSessionProvider) UpdateSession(session *StoreSession) error {
 	provider.lock.Lock()
 	defer provider.lock.Unlock()
 
-	mongoSession := provider.createMongoDbSession()
-	defer mongoSession.Close()
+	store, err := provider.openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
 
 	doc := make(map[string]interface{})
 	doc["sid"] = session.SessionID()
-	doc["version"] = 1
+	doc["version"] = sessionDocCurrentVersion
+	doc["updated"] = time.Now().UTC()
 	doc["data"] = session.data
 
 	query := bson.M{"sid": session.SessionID()}
 
-	collection := mongoSession.DB(provider.databaseName).C(provider.collectionName)
-	_, err := collection.Upsert(query, doc)
+	return store.C(provider.collectionName).Upsert(query, doc)
+}
+
+func (provider *StoreSessionProvider) DestroySessionsWithValue(key string, value interface{}) error {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+
+	store, err := provider.openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	_, err = store.C(provider.collectionName).RemoveAll(bson.M{"data." + key: value})
+	return err
+}
+
+func (provider *StoreSessionProvider) RenewSession(session Session, sid string) (Session, error) {
+	renewed := &StoreSession{
+		sessionID: sid,
+		provider:  provider,
+		data:      make(map[string]interface{}),
+	}
+	if old, ok := session.(*StoreSession); ok {
+		for key, value := range old.data {
+			renewed.data[key] = value
+		}
+	}
+	if err := provider.UpdateSession(renewed); err != nil {
+		return nil, err
+	}
+	if err := provider.DestroySession(session.SessionID()); err != nil {
+		return nil, err
+	}
+	return renewed, nil
+}
+
+func (provider *StoreSessionProvider) DestroySession(sid string) error {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+
+	store, err := provider.openStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
 
+	_, err = store.C(provider.collectionName).RemoveAll(bson.M{"sid": sid})
 	return err
 }
 
@@ -170,6 +263,11 @@ func (manager *SessionManager) generateSessionID() string {
 func (manager *SessionManager) createNewSession(w http.ResponseWriter, r *http.Request) (session Session) {
 	sid := manager.generateSessionID()
 	session, _ = manager.provider.InitializeSession(sid)
+	manager.setCookie(w, sid)
+	return session
+}
+
+func (manager *SessionManager) setCookie(w http.ResponseWriter, sid string) {
 	cookie := http.Cookie{
 		Name:     manager.cookieName,
 		Value:    url.QueryEscape(sid),
@@ -178,7 +276,21 @@ func (manager *SessionManager) createNewSession(w http.ResponseWriter, r *http.R
 		MaxAge:   3600,
 	}
 	http.SetCookie(w, &cookie)
-	return session
+}
+
+// RenewSession gives the session a new ID, keeping its values. This is called
+// when the user logs in so that a session ID known before then, such as one
+// set by someone else, isn't logged in.
+func (manager *SessionManager) RenewSession(w http.ResponseWriter, session Session) (Session, error) {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	renewed, err := manager.provider.RenewSession(session, manager.generateSessionID())
+	if err != nil {
+		return nil, err
+	}
+	manager.setCookie(w, renewed.SessionID())
+	return renewed, nil
 }
 
 // SessionStart should be called by the client to initialize a new session or
@@ -190,6 +302,14 @@ func (manager *SessionManager) SessionStart(w http.ResponseWriter, r *http.Reque
 
 	// attempt to read the session from the request
 	session, err = manager.ReadSession(r)
+	if data.IsDatabaseUnavailable(err) {
+		// keep the cookie so the user is still logged in once the database
+		// is available again
+		cookie, _ := r.Cookie(manager.cookieName)
+		sid, _ := url.QueryUnescape(cookie.Value)
+		session, _ = manager.provider.InitializeSession(sid)
+		return
+	}
 	if err != nil || session == nil {
 		// create a new session if we didn't find one
 		session = manager.createNewSession(w, r)
@@ -209,6 +329,29 @@ func (manager *SessionManager) ReadSession(r *http.Request) (session Session, er
 	return
 }
 
+// RestartSession removes the session of the request, if there is one, and
+// starts a new session without any values
+func (manager *SessionManager) RestartSession(w http.ResponseWriter, r *http.Request) (Session, error) {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	if cookie, err := r.Cookie(manager.cookieName); err == nil && cookie.Value != "" {
+		sid, _ := url.QueryUnescape(cookie.Value)
+		if err := manager.provider.DestroySession(sid); err != nil {
+			return nil, err
+		}
+	}
+	return manager.createNewSession(w, r), nil
+}
+
+// DestroyUserSessions removes all of the sessions the user is logged in with
+func (manager *SessionManager) DestroyUserSessions(username string) error {
+	if err := manager.provider.DestroySessionsWithValue(pendingUsernameSessionKey, username); err != nil {
+		return err
+	}
+	return manager.provider.DestroySessionsWithValue("username", username)
+}
+
 // HasSession determines if a session has already been associated to the request
 func (manager *SessionManager) HasSession(r *http.Request) bool {
 	manager.lock.Lock()
@@ -3,7 +3,6 @@ package web
 import (
 	"fmt"
 	"net/http"
-	"strings"
 
 	"jaredpearson.com/dbweb/data"
 )
@@ -38,24 +37,17 @@ func newSetDetailPage(r *http.Request, set data.MiniatureSet, minis []*data.Mini
 	}
 }
 
+// ShowSetDetailPage shows the miniatures in the set with the code in the
+// path. Clients that prefer JSON get the same response as the API.
 func ShowSetDetailPage(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "GET" {
-		http.NotFound(w, r)
+	w.Header().Set("Vary", "Accept")
+	if prefersJSON(r) {
+		ShowAPISetMiniatures(w, r)
 		return
 	}
-
-	pathParts := strings.Split(r.URL.Path[1:], "/")
-	if len(pathParts) <= 1 {
-		http.NotFound(w, r)
-		return
-	}
-
-	// assume that the second part of the path is the mini ID
-	miniID := pathParts[1]
-
-	s, exists := data.GetMiniatureSetByID(miniID)
+	s, exists := data.GetMiniatureSetByID(PathParam(r, "code"))
 	if exists != nil {
-		http.NotFound(w, r)
+		ShowNotFoundPage(w, r)
 		return
 	}
 
@@ -0,0 +1,391 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"jaredpearson.com/dbweb/data"
+	"jaredpearson.com/dbweb/typeface"
+)
+
+// statCardWidth and statCardHeight are the size of a stat card image in
+// pixels, which has the proportions of a printed card
+const (
+	statCardWidth  = 500
+	statCardHeight = 700
+	statCardMargin = 30
+)
+
+// statCardRevision is part of the cache key of the images. Change it along
+// with the layout so cached cards are drawn again.
+const statCardRevision = 1
+
+// aspectColors color the frame of a stat card by the miniature's aspect
+var aspectColors = map[string]color.RGBA{
+	"tooth":  {0xa8, 0x2a, 0x22, 0xff},
+	"vapor":  {0x5b, 0x4b, 0x8a, 0xff},
+	"vile":   {0x3d, 0x6e, 0x1f, 0xff},
+	"vision": {0x1f, 0x5c, 0x99, 0xff},
+}
+
+var (
+	statCardNeutral = color.RGBA{0x55, 0x55, 0x55, 0xff}
+	statCardText    = color.RGBA{0x22, 0x22, 0x22, 0xff}
+	statCardGray    = color.RGBA{0x6b, 0x6b, 0x6b, 0xff}
+	statCardWhite   = color.RGBA{0xff, 0xff, 0xff, 0xff}
+)
+
+func aspectColor(aspect string) color.RGBA {
+	if c, exists := aspectColors[strings.ToLower(strings.TrimSpace(aspect))]; exists {
+		return c
+	}
+	return statCardNeutral
+}
+
+// tint mixes the color with white, keeping the amount of the color
+func tint(c color.RGBA, amount float64) color.RGBA {
+	mix := func(v uint8) uint8 { return uint8(float64(v)*amount + 255*(1-amount)) }
+	return color.RGBA{mix(c.R), mix(c.G), mix(c.B), 0xff}
+}
+
+// cardCanvas is what a stat card is drawn on, so the PNG and SVG have the
+// same layout. Coordinates are pixels from the top left and text is placed
+// by the left end of its baseline.
+type cardCanvas interface {
+	fillRect(x, y, width, height float64, c color.RGBA)
+	text(x, y float64, face *typeface.Face, size float64, c color.RGBA, text string)
+}
+
+type rasterCanvas struct {
+	img *image.RGBA
+}
+
+func (canvas rasterCanvas) fillRect(x, y, width, height float64, c color.RGBA) {
+	rect := image.Rect(int(x), int(y), int(x+width), int(y+height))
+	draw.Draw(canvas.img, rect, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+func (canvas rasterCanvas) text(x, y float64, face *typeface.Face, size float64, c color.RGBA, text string) {
+	face.Draw(canvas.img, x, y, size, c, text)
+}
+
+type svgCanvas struct {
+	buf *bytes.Buffer
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func (canvas svgCanvas) fillRect(x, y, width, height float64, c color.RGBA) {
+	fmt.Fprintf(canvas.buf, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n", x, y, width, height, svgColor(c))
+}
+
+func (canvas svgCanvas) text(x, y float64, face *typeface.Face, size float64, c color.RGBA, text string) {
+	weight := "normal"
+	if face == typeface.Bold {
+		weight = "bold"
+	}
+	fmt.Fprintf(canvas.buf, `<text x="%.1f" y="%.1f" font-size="%g" font-weight="%s" fill="%s">%s</text>`+"\n",
+		x, y, size, weight, svgColor(c), html.EscapeString(text))
+}
+
+// shrinkToFit returns the largest size from the size down to the smallest
+// at which the text fits in the width
+func shrinkToFit(face *typeface.Face, text string, size, smallest, width float64) float64 {
+	for size > smallest && face.Width(text, size) > width {
+		size--
+	}
+	return size
+}
+
+// drawStatCard draws the card of the miniature: a frame in the color of its
+// aspect around its name, costs, stats, abilities and flavor text
+func drawStatCard(canvas cardCanvas, mini *data.Miniature) {
+	frame := aspectColor(mini.Aspect())
+	light := tint(frame, 0.15)
+	canvas.fillRect(0, 0, statCardWidth, statCardHeight, frame)
+	canvas.fillRect(12, 120, statCardWidth-24, statCardHeight-132, statCardWhite)
+
+	left := float64(statCardMargin)
+	width := float64(statCardWidth - 2*statCardMargin)
+
+	nameSize := shrinkToFit(typeface.Bold, mini.Name(), 36, 22, width)
+	canvas.text(left, 62, typeface.Bold, nameSize, statCardWhite, typeface.Bold.Truncate(mini.Name(), nameSize, width))
+	var kind []string
+	for _, value := range []string{mini.Aspect(), mini.Lineage()} {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			kind = append(kind, value)
+		}
+	}
+	canvas.text(left, 100, typeface.Regular, 20, statCardWhite, typeface.Regular.Truncate(strings.Join(kind, " "), 20, width))
+
+	details := fmt.Sprintf("%s #%s", setName(mini.SetCode()), emptyToDash(mini.CollectorNumber()))
+	if rarity := strings.TrimSpace(mini.Rarity()); len(rarity) > 0 {
+		details += " · " + rarity
+	}
+	canvas.text(left, 156, typeface.Regular, 16, statCardGray, typeface.Regular.Truncate(details, 16, width))
+
+	// the costs are side by side with the stats in boxes below them
+	costLeft := left
+	for _, cost := range []struct{ label, value string }{
+		{"Spawn", mini.SpawnCost()},
+		{"Aspect", mini.AspectCost()},
+	} {
+		text := cost.label + " " + emptyToDash(cost.value)
+		boxWidth := typeface.Bold.Width(text, 20) + 24
+		canvas.fillRect(costLeft, 172, boxWidth, 36, light)
+		canvas.text(costLeft+12, 198, typeface.Bold, 20, statCardText, text)
+		costLeft += boxWidth + 12
+	}
+
+	const boxTop, boxHeight, boxGap = 224, 92, 16
+	boxWidth := (width - 2*boxGap) / 3
+	for i, stat := range []struct{ label, value string }{
+		{"POWER", mini.Power()},
+		{"DEFENSE", mini.Defense()},
+		{"LIFE", mini.Life()},
+	} {
+		boxLeft := left + float64(i)*(boxWidth+boxGap)
+		canvas.fillRect(boxLeft, boxTop, boxWidth, boxHeight, light)
+		centre := func(face *typeface.Face, size float64, text string) float64 {
+			return boxLeft + (boxWidth-face.Width(text, size))/2
+		}
+		canvas.text(centre(typeface.Regular, 14, stat.label), boxTop+24, typeface.Regular, 14, statCardGray, stat.label)
+		value := emptyToDash(stat.value)
+		canvas.text(centre(typeface.Bold, 44, value), boxTop+76, typeface.Bold, 44, frame, value)
+	}
+
+	var abilities []string
+	for _, ability := range mini.AbilityList() {
+		abilities = append(abilities, ability.Text())
+	}
+	flavor := strings.TrimSpace(mini.FlavorText())
+	textTop, textBottom := float64(boxTop+boxHeight+24), float64(statCardHeight-statCardMargin)
+
+	// the text gets smaller until it fits. At the smallest size the flavor
+	// text is left out and the abilities are cut off if they still don't fit.
+	var abilityLines, flavorLines []string
+	size := 20.0
+	height := func() float64 {
+		h := float64(len(abilityLines))*size*1.3 + float64(len(flavorLines))*(size-2)*1.3
+		if len(abilityLines) > 0 && len(flavorLines) > 0 {
+			h += size
+		}
+		return h
+	}
+	for ; ; size-- {
+		abilityLines = typeface.Regular.Wrap(strings.Join(abilities, "\n"), size, width)
+		flavorLines = typeface.Regular.Wrap(flavor, size-2, width)
+		if height() <= textBottom-textTop || size <= 11 {
+			break
+		}
+	}
+	if height() > textBottom-textTop {
+		flavorLines = nil
+		fit := int((textBottom - textTop) / (size * 1.3))
+		if len(abilityLines) > fit && fit > 0 {
+			abilityLines = abilityLines[:fit]
+			abilityLines[fit-1] = typeface.Regular.Truncate(abilityLines[fit-1]+" …", size, width)
+		}
+	}
+	line := textTop
+	for _, text := range abilityLines {
+		line += size * 1.3
+		canvas.text(left, line-size*0.3, typeface.Regular, size, statCardText, text)
+	}
+	line = textBottom - float64(len(flavorLines))*(size-2)*1.3
+	for _, text := range flavorLines {
+		line += (size - 2) * 1.3
+		canvas.text(left, line-(size-2)*0.3, typeface.Regular, size-2, statCardGray, text)
+	}
+}
+
+// renderStatCard draws the card of the miniature as a PNG or SVG
+func renderStatCard(mini *data.Miniature, format string) ([]byte, error) {
+	var out bytes.Buffer
+	if format == "svg" {
+		fmt.Fprintf(&out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="DejaVu Sans, Verdana, sans-serif">`+"\n",
+			statCardWidth, statCardHeight, statCardWidth, statCardHeight)
+		fmt.Fprintf(&out, "<title>%s</title>\n", html.EscapeString(mini.Name()))
+		drawStatCard(svgCanvas{&out}, mini)
+		out.WriteString("</svg>\n")
+		return out.Bytes(), nil
+	}
+	img := image.NewRGBA(image.Rect(0, 0, statCardWidth, statCardHeight))
+	drawStatCard(rasterCanvas{img}, mini)
+	if err := png.Encode(&out, img); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// cardCache keeps the stat card images on disk in a directory for each
+// version of the catalog and revision of the layout. Directories of other
+// versions are removed when the catalog changes.
+type cardCache struct {
+	// lock guards the version, the renders and the saving of files but not
+	// the rendering, so different cards can be rendered at the same time
+	lock    sync.Mutex
+	dir     string
+	version string
+	// renders holds the cards being rendered by path so that requests for a
+	// card that's being rendered wait for it instead of rendering it again
+	renders map[string]*cardRender
+}
+
+type cardRender struct {
+	done    chan struct{}
+	content []byte
+	err     error
+}
+
+// statCards is the cache of the stat card images
+var statCards = &cardCache{}
+
+// catalogVersionPattern matches the cache's directories, so nothing else in
+// the directory is removed
+var cardCacheVersionPattern = regexp.MustCompile(`^[0-9a-f]{16}-[0-9]+$`)
+
+// statCardVersion is the version of the stat cards of the current catalog
+func statCardVersion() string {
+	return fmt.Sprintf("%s-%d", data.CatalogVersion(), statCardRevision)
+}
+
+// prune removes the directories of versions other than the current one. A
+// version that isn't current is from a request that started before the
+// catalog changed, so it doesn't remove the current directory.
+func (cache *cardCache) prune(version string) {
+	if cache.version == version || version != statCardVersion() {
+		return
+	}
+	cache.version = version
+	entries, err := os.ReadDir(cache.dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != version && cardCacheVersionPattern.MatchString(entry.Name()) {
+			if err := os.RemoveAll(filepath.Join(cache.dir, entry.Name())); err != nil {
+				log.Printf("Unable to remove old stat cards\n\t%v", err)
+			}
+		}
+	}
+}
+
+// get returns the cached image of the miniature, rendering and saving it
+// when it isn't cached. The image is still returned when it can't be saved.
+func (cache *cardCache) get(mini *data.Miniature, format, version string) ([]byte, error) {
+	path := filepath.Join(cache.dir, version, url.PathEscape(mini.ID())+"."+format)
+
+	cache.lock.Lock()
+	cache.prune(version)
+	if render, exists := cache.renders[path]; exists {
+		cache.lock.Unlock()
+		<-render.done
+		return render.content, render.err
+	}
+	if cache.renders == nil {
+		cache.renders = make(map[string]*cardRender)
+	}
+	render := &cardRender{done: make(chan struct{})}
+	cache.renders[path] = render
+	cache.lock.Unlock()
+
+	render.content, render.err = cache.load(mini, format, version, path)
+
+	cache.lock.Lock()
+	delete(cache.renders, path)
+	cache.lock.Unlock()
+	close(render.done)
+	return render.content, render.err
+}
+
+// load reads the image from the path, or renders and saves it there. It
+// isn't saved when the version is no longer current, since the directory of
+// the version has been removed.
+func (cache *cardCache) load(mini *data.Miniature, format, version, path string) ([]byte, error) {
+	if content, err := os.ReadFile(path); err == nil {
+		return content, nil
+	}
+	content, err := renderStatCard(mini, format)
+	if err != nil {
+		return nil, err
+	}
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	if version != cache.version {
+		return content, nil
+	}
+	if err := writeFileAtomic(path, content); err != nil {
+		log.Printf("Unable to cache the stat card\n\t%v", err)
+	}
+	return content, nil
+}
+
+// writeFileAtomic writes the file through a temporary file so a partly
+// written file is never read
+func writeFileAtomic(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(filepath.Dir(path), ".card-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), path)
+}
+
+// showStatCard responds with the stat card image of the miniature with the
+// ID in the path. The ETag is the cache version so clients only download it
+// again after the catalog or the layout changes.
+func showStatCard(w http.ResponseWriter, r *http.Request, format, contentType string) {
+	mini, err := data.GetMiniatureByID(PathParam(r, "id"))
+	if err != nil {
+		ShowNotFoundPage(w, r)
+		return
+	}
+	version := statCardVersion()
+	content, err := statCards.get(mini, format, version)
+	if err != nil {
+		log.Printf("Unable to draw the stat card of %s\n\t%v", mini.ID(), err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Header().Set("ETag", `"`+version+`"`)
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+}
+
+// ShowStatCardPNG responds with the stat card of the miniature as a PNG
+func ShowStatCardPNG(w http.ResponseWriter, r *http.Request) {
+	showStatCard(w, r, "png", "image/png")
+}
+
+// ShowStatCardSVG responds with the stat card of the miniature as an SVG
+func ShowStatCardSVG(w http.ResponseWriter, r *http.Request) {
+	showStatCard(w, r, "svg", "image/svg+xml")
+}
@@ -0,0 +1,158 @@
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+//go:embed static
+var embeddedStatic embed.FS
+
+// staticAsset is a file served under /static/
+type staticAsset struct {
+	// name is the path of the file within the static directory, such as
+	// css/site.css
+	name string
+	// fingerprintedName has a hash of the content before the extension,
+	// such as css/site.1a2b3c4d5e.css
+	fingerprintedName string
+	content           []byte
+	contentType       string
+	hash              string
+	modTime           time.Time
+}
+
+// staticAssets are the files embedded in the static directory along with
+// the files in the override directory, which replace embedded files with the
+// same name. The files are read once when the server starts.
+type staticAssets struct {
+	byName            map[string]*staticAsset
+	byFingerprintName map[string]*staticAsset
+}
+
+// staticFingerprintLength is the number of hex characters of the hash used
+// in fingerprinted names
+const staticFingerprintLength = 10
+
+func loadStaticAssets(overrideDir string) (*staticAssets, error) {
+	assets := &staticAssets{
+		byName:            make(map[string]*staticAsset),
+		byFingerprintName: make(map[string]*staticAsset),
+	}
+	embedded, err := fs.Sub(embeddedStatic, "static")
+	if err != nil {
+		return nil, err
+	}
+	// the binary's modification time stands in for the embedded files
+	modTime := time.Now()
+	if executable, err := os.Executable(); err == nil {
+		if info, err := os.Stat(executable); err == nil {
+			modTime = info.ModTime()
+		}
+	}
+	if err := assets.addFiles(embedded, modTime); err != nil {
+		return nil, err
+	}
+	if len(overrideDir) > 0 {
+		if err := assets.addFiles(os.DirFS(overrideDir), time.Time{}); err != nil {
+			return nil, fmt.Errorf("unable to read static assets from %s: %v", overrideDir, err)
+		}
+	}
+	return assets, nil
+}
+
+// addFiles adds every file in the file system. The modification time of
+// each file is used when modTime is zero.
+func (assets *staticAssets) addFiles(files fs.FS, modTime time.Time) error {
+	return fs.WalkDir(files, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+		content, err := fs.ReadFile(files, name)
+		if err != nil {
+			return err
+		}
+		fileModTime := modTime
+		if fileModTime.IsZero() {
+			if info, err := entry.Info(); err == nil {
+				fileModTime = info.ModTime()
+			}
+		}
+		assets.add(name, content, fileModTime)
+		return nil
+	})
+}
+
+func (assets *staticAssets) add(name string, content []byte, modTime time.Time) {
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])[:staticFingerprintLength]
+	ext := path.Ext(name)
+	contentType := mime.TypeByExtension(ext)
+	if len(contentType) == 0 {
+		contentType = http.DetectContentType(content)
+	}
+	asset := &staticAsset{
+		name:              name,
+		fingerprintedName: strings.TrimSuffix(name, ext) + "." + hash + ext,
+		content:           content,
+		contentType:       contentType,
+		hash:              hash,
+		modTime:           modTime,
+	}
+	if previous, exists := assets.byName[name]; exists {
+		delete(assets.byFingerprintName, previous.fingerprintedName)
+	}
+	assets.byName[name] = asset
+	assets.byFingerprintName[asset.fingerprintedName] = asset
+}
+
+// URL returns the fingerprinted URL of the asset, such as
+// /static/css/site.1a2b3c4d5e.css
+func (assets *staticAssets) URL(name string) (string, error) {
+	asset, exists := assets.byName[strings.TrimPrefix(name, "/")]
+	if !exists {
+		return "", fmt.Errorf("no static asset named %s", name)
+	}
+	return router.URL("static", "path", asset.fingerprintedName)
+}
+
+// ShowStaticAsset serves the asset in the path. Fingerprinted names are
+// cached for a year since their content never changes. The plain names are
+// also served, but must be revalidated.
+func ShowStaticAsset(w http.ResponseWriter, r *http.Request) {
+	name := PathParam(r, "path")
+	asset, fingerprinted := staticFiles.byFingerprintName[name]
+	if !fingerprinted {
+		var exists bool
+		if asset, exists = staticFiles.byName[name]; !exists {
+			http.NotFound(w, r)
+			return
+		}
+	}
+
+	if fingerprinted {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	w.Header().Set("Content-Type", asset.contentType)
+	w.Header().Set("ETag", `"`+asset.hash+`"`)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, asset.name, asset.modTime, bytes.NewReader(asset.content))
+}
+
+// staticFiles are loaded when the server starts
+var staticFiles *staticAssets
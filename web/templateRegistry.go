@@ -0,0 +1,175 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// templateRegistry holds the parsed templates. Each page in the template
+// directory is parsed on its own and with each layout in the layouts
+// directory when the server starts, so mistakes are found before the first
+// request. When reload is on, the templates are parsed again whenever the
+// files change.
+type templateRegistry struct {
+	dir    string
+	reload bool
+
+	lock      sync.RWMutex
+	templates map[string]*template.Template
+	// modTimes are the modification times of the files that were parsed
+	modTimes map[string]time.Time
+}
+
+func newTemplateRegistry(dir string, reload bool) (*templateRegistry, error) {
+	registry := &templateRegistry{
+		dir:    dir,
+		reload: reload,
+	}
+	if err := registry.load(); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// templateKey is the key of a page parsed with a layout. Pages parsed on
+// their own have an empty layout.
+func templateKey(layoutName, templateName string) string {
+	if len(layoutName) == 0 {
+		return templateName
+	}
+	return layoutName + ":" + templateName
+}
+
+func templateName(file string) string {
+	return strings.TrimSuffix(filepath.Base(file), ".html")
+}
+
+// files returns the layouts and pages in the template directory
+func (registry *templateRegistry) files() (layouts []string, pages []string, err error) {
+	layouts, err = filepath.Glob(filepath.Join(registry.dir, "layouts", "*.html"))
+	if err != nil {
+		return nil, nil, err
+	}
+	pages, err = filepath.Glob(filepath.Join(registry.dir, "*.html"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(pages) == 0 {
+		return nil, nil, fmt.Errorf("no templates found in %s", registry.dir)
+	}
+	return layouts, pages, nil
+}
+
+// load parses every template, replacing the templates parsed before. Every
+// problem is reported and the current templates are kept when there are any.
+func (registry *templateRegistry) load() error {
+	layouts, pages, err := registry.files()
+	if err != nil {
+		return err
+	}
+
+	templates := make(map[string]*template.Template)
+	var problems []error
+	for _, page := range pages {
+		t, err := template.New(filepath.Base(page)).Funcs(templateFuncs).ParseFiles(page)
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		templates[templateKey("", templateName(page))] = t
+
+		for _, layout := range layouts {
+			t, err := template.New(filepath.Base(layout)).Funcs(templateFuncs).ParseFiles(layout, page)
+			if err != nil {
+				problems = append(problems, err)
+				continue
+			}
+			if t.Lookup("layouts/"+templateName(layout)) == nil {
+				problems = append(problems, fmt.Errorf("%s does not define layouts/%s", layout, templateName(layout)))
+				continue
+			}
+			if t.Lookup("content") == nil {
+				problems = append(problems, fmt.Errorf("%s does not define content for the layouts", page))
+				continue
+			}
+			templates[templateKey(templateName(layout), templateName(page))] = t
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Join(problems...)
+	}
+
+	modTimes, err := modificationTimes(append(layouts, pages...))
+	if err != nil {
+		return err
+	}
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	registry.templates = templates
+	registry.modTimes = modTimes
+	return nil
+}
+
+func modificationTimes(files []string) (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// changed determines if any template was added, removed or modified since
+// the templates were parsed
+func (registry *templateRegistry) changed() bool {
+	layouts, pages, err := registry.files()
+	if err != nil {
+		return true
+	}
+	modTimes, err := modificationTimes(append(layouts, pages...))
+	if err != nil {
+		return true
+	}
+
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+	if len(modTimes) != len(registry.modTimes) {
+		return true
+	}
+	for file, modTime := range modTimes {
+		if previous, exists := registry.modTimes[file]; !exists || !previous.Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the page parsed with the layout, or on its own when the
+// layout is empty. The templates are parsed again first if reload is on and
+// the files have changed.
+func (registry *templateRegistry) lookup(layoutName, templateName string) (*template.Template, error) {
+	if registry.reload && registry.changed() {
+		if err := registry.load(); err != nil {
+			return nil, err
+		}
+		log.Printf("Reloaded templates from %s", registry.dir)
+	}
+
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+	t, exists := registry.templates[templateKey(layoutName, templateName)]
+	if !exists {
+		return nil, fmt.Errorf("no template named %s", templateKey(layoutName, templateName))
+	}
+	return t, nil
+}
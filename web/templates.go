@@ -1,6 +1,7 @@
 package web
 
 import (
+	"bytes"
 	"html/template"
 	"log"
 	"net/http"
@@ -9,27 +10,20 @@ import (
 )
 
 // determineTemplateDir determines the directory containing the template. This
-// will use TEMPLATE_PATH environment or a default path the "templates" directory
+// will use the configured directory or a default path the "templates" directory
 // within the current working directory.
-func determineTemplateDir() string {
-	directory := os.Getenv("TEMPLATE_PATH")
+func determineTemplateDir(directory string) string {
 	if len(directory) == 0 {
 		d, err := os.Getwd()
 		if err != nil {
 			log.Fatal(err)
 		}
 		directory = path.Join(d, "templates")
-		log.Printf("TEMPLATE_PATH not defined. Using default: %s", directory)
+		log.Printf("Template path not configured. Using default: %s", directory)
 	}
 	return directory
 }
 
-// GetTemplatePath gets the path of a template by the specific name. Usually this
-// is "templatePath + name + .html".
-func GetTemplatePath(name string) string {
-	return path.Join(templateDir, name+".html")
-}
-
 type MainLayoutData interface {
 	PageTitle() string
 	UserInfo() UserInfo
@@ -61,39 +55,78 @@ func ShowTemplateInMainLayout(
 	ShowTemplateInLayout(w, r, "main", templateName, data)
 }
 
+// ShowTemplateInMainLayoutWithStatus shows the template in the main layout
+// with a status other than 200 OK
+func ShowTemplateInMainLayoutWithStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	status int,
+	templateName string,
+	data MainLayoutData) {
+	renderTemplate(w, status, "main", templateName, data)
+}
+
 func ShowTemplateInLayout(
 	w http.ResponseWriter,
 	r *http.Request,
 	layoutName string,
 	templateName string,
 	data interface{}) {
-	layoutPath := GetTemplatePath(path.Join("layouts", layoutName))
-	contentPath := GetTemplatePath(templateName)
-	t, err := template.ParseFiles(layoutPath, contentPath)
+	renderTemplate(w, http.StatusOK, layoutName, templateName, data)
+}
+
+// ShowTemplate writes the template without a layout to the response
+func ShowTemplate(w http.ResponseWriter, r *http.Request, templateName string, data interface{}) {
+	renderTemplate(w, http.StatusOK, "", templateName, data)
+}
+
+// renderTemplate executes the template into a buffer before writing the
+// response so a failure results in a 500 rather than part of a page
+func renderTemplate(w http.ResponseWriter, status int, layoutName string, templateName string, data interface{}) {
+	t, err := templates.lookup(layoutName, templateName)
 	if err != nil {
-		log.Printf("Unable to find templates. layout:%s, template:%s\n\t%v", layoutPath, contentPath, err)
-		http.NotFound(w, r)
+		log.Printf("Unable to find template. layout:%s, template:%s\n\t%v", layoutName, templateName, err)
+		writeTemplateError(w, err)
 		return
 	}
-	err = t.ExecuteTemplate(w, "layouts/"+layoutName, data)
-	if err != nil {
-		log.Printf("Failed to execute templates. layout:%s, template:%s\n\t%v", layoutPath, contentPath, err)
-		http.NotFound(w, r)
+	name := t.Name()
+	if len(layoutName) > 0 {
+		name = "layouts/" + layoutName
+	}
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+		log.Printf("Failed to execute template. layout:%s, template:%s\n\t%v", layoutName, templateName, err)
+		writeTemplateError(w, err)
 		return
 	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	buf.WriteTo(w)
 }
 
-// ShowTemplate parses the template and writes the template with the given name to
-// the response.
-func ShowTemplate(w http.ResponseWriter, r *http.Request, templateName string, data interface{}) {
-	tp := GetTemplatePath(templateName)
-	t, err := template.ParseFiles(tp)
-	if err != nil {
-		log.Printf("Unable to find template: %s\n\t%v", tp, err)
-		http.NotFound(w, r)
-		return
+// writeTemplateError responds with a 500. The error is only shown in dev
+// mode since it may reveal details of the server.
+func writeTemplateError(w http.ResponseWriter, err error) {
+	message := http.StatusText(http.StatusInternalServerError)
+	if templates != nil && templates.reload {
+		message = err.Error()
 	}
-	t.Execute(w, data)
+	http.Error(w, message, http.StatusInternalServerError)
+}
+
+// templateFuncs are the functions available to every template
+var templateFuncs = template.FuncMap{
+	// url builds the path of a named route, such as
+	// {{url "miniature" "id" .ID}}
+	"url": func(name string, params ...string) (string, error) {
+		return router.URL(name, params...)
+	},
+	// asset returns the fingerprinted URL of a static asset, such as
+	// {{asset "css/site.css"}}
+	"asset": func(name string) (string, error) {
+		return staticFiles.URL(name)
+	},
 }
 
-var templateDir = determineTemplateDir()
+// templates are parsed when the server starts
+var templates *templateRegistry
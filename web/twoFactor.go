@@ -0,0 +1,108 @@
+package web
+
+import (
+	"log"
+	"net/http"
+
+	"jaredpearson.com/dbweb/data"
+)
+
+const (
+	// pendingUsernameSessionKey holds the user that has passed the first login
+	// step but still needs to complete two-factor authentication. The user is
+	// not considered logged in until "username" is set.
+	pendingUsernameSessionKey = "pendingUsername"
+
+	totpEnrollSecretSessionKey = "totpEnrollSecret"
+	totpIssuer                 = "Dreamblade Catalog"
+)
+
+// requiresTwoFactor determines if the policy requires the user to enroll in
+// two-factor authentication
+func requiresTwoFactor(user data.User) bool {
+	for _, role := range requiredTwoFactorRoles {
+		if user.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// completeLogin is called once the user has been authenticated by a password
+// or external identity. Users enrolled in two-factor authentication are sent
+// to the second login step and users required to enroll are sent to the
+// enrollment page. Otherwise the user is logged in.
+func completeLogin(w http.ResponseWriter, r *http.Request, session Session, user data.User) {
+	// the session ID changes once the password step has passed so an ID
+	// known before the login can't be used to finish it
+	session, err := sessionManager.RenewSession(w, session)
+	if err != nil {
+		log.Printf("Unable to renew the session of %s\n\t%v", user.Username(), err)
+		writeDataError(w, err)
+		return
+	}
+	if user.HasTwoFactor() {
+		session.Set(pendingUsernameSessionKey, user.Username())
+		http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+		return
+	}
+	if requiresTwoFactor(user) {
+		session.Set(pendingUsernameSessionKey, user.Username())
+		http.Redirect(w, r, "/account/2fa", http.StatusSeeOther)
+		return
+	}
+	logIn(w, r, session, user.Username())
+}
+
+// finishLogin logs in the user once the second login step has passed
+func finishLogin(w http.ResponseWriter, r *http.Request, session Session, username string) {
+	session, err := sessionManager.RenewSession(w, session)
+	if err != nil {
+		log.Printf("Unable to renew the session of %s\n\t%v", username, err)
+		writeDataError(w, err)
+		return
+	}
+	logIn(w, r, session, username)
+}
+
+// logIn records the user in the session which logs them in
+func logIn(w http.ResponseWriter, r *http.Request, session Session, username string) {
+	if err := loginAccountLimiter.Reset(data.LoginAttemptsKeyForUsername(username)); err != nil {
+		log.Printf("Unable to reset failed login attempts for %s\n\t%v", username, err)
+	}
+	session.Delete(pendingUsernameSessionKey)
+	session.Set("username", username)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// pendingUsername returns the user waiting on the second login step
+func pendingUsername(r *http.Request) string {
+	session, err := sessionManager.ReadSession(r)
+	if err != nil || session == nil {
+		return ""
+	}
+	username, _ := session.Get(pendingUsernameSessionKey).(string)
+	return username
+}
+
+// twoFactorRateLimitMiddleware throttles failed codes against the logged in
+// account or the account waiting on the second login step. The same limiter
+// as the password step is used so guessing codes counts toward the account
+// lockout.
+func twoFactorRateLimitMiddleware() HttpMiddleware {
+	return rateLimitMiddleware(loginAccountLimiter, func(r *http.Request) string {
+		if r.Method != "POST" {
+			return ""
+		}
+		username := pendingUsername(r)
+		if userInfo, authenticated := UserInfoFromRequest(r); authenticated && !userInfo.IsTokenAuthenticated() {
+			username = userInfo.Username
+		}
+		if len(username) == 0 {
+			return ""
+		}
+		return data.LoginAttemptsKeyForUsername(username)
+	}, nil)
+}
+
+var requiredTwoFactorRoles []string
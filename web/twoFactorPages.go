@@ -0,0 +1,219 @@
+package web
+
+import (
+	"log"
+	"net/http"
+
+	"jaredpearson.com/dbweb/data"
+	"jaredpearson.com/dbweb/totp"
+)
+
+type TwoFactorLoginPage struct {
+	pageTitle string
+	userInfo  UserInfo
+	CSRFToken string
+	Error     string
+}
+
+func (page TwoFactorLoginPage) PageTitle() string {
+	return page.pageTitle
+}
+func (page TwoFactorLoginPage) UserInfo() UserInfo {
+	return page.userInfo
+}
+
+// ShowTwoFactorLoginPage is the second login step for users enrolled in
+// two-factor authentication. Either a one-time code or a recovery code is
+// accepted.
+func ShowTwoFactorLoginPage(w http.ResponseWriter, r *http.Request) {
+	session := sessionManager.SessionStart(w, r)
+	username, _ := session.Get(pendingUsernameSessionKey).(string)
+	if len(username) == 0 {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	page := TwoFactorLoginPage{
+		pageTitle: "Two-Factor Authentication",
+		CSRFToken: csrfToken(session),
+	}
+
+	switch r.Method {
+	case "GET":
+	case "POST":
+		if !validCSRFToken(r, session) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		err := data.VerifyUserTwoFactor(username, r.PostFormValue("code"))
+		if err == nil {
+			finishLogin(w, r, session, username)
+			return
+		} else if err != data.ErrInvalidTwoFactorCode {
+			log.Printf("Unable to verify two-factor code for %s\n\t%v", username, err)
+			writeDataError(w, err)
+			return
+		}
+		page.Error = "Invalid code"
+		w.WriteHeader(http.StatusUnauthorized)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	ShowTemplateInMainLayout(w, r, "twoFactorLogin", page)
+}
+
+type TwoFactorSettingsPage struct {
+	pageTitle       string
+	userInfo        UserInfo
+	CSRFToken       string
+	Enabled         bool
+	Required        bool
+	Pending         bool
+	Secret          string
+	ProvisioningURI string
+	RecoveryCodes   []string
+	RemainingCodes  int
+	Error           string
+}
+
+func (page TwoFactorSettingsPage) PageTitle() string {
+	return page.pageTitle
+}
+func (page TwoFactorSettingsPage) UserInfo() UserInfo {
+	return page.userInfo
+}
+
+// ShowTwoFactorSettingsPage allows a user to enroll in or remove two-factor
+// authentication. Users that are required to enroll by the policy are sent
+// here during login before they are logged in.
+func ShowTwoFactorSettingsPage(w http.ResponseWriter, r *http.Request) {
+	session := sessionManager.SessionStart(w, r)
+	userInfo, authenticated := UserInfoFromRequest(r)
+	username := userInfo.Username
+	pending := false
+	if !authenticated || userInfo.IsTokenAuthenticated() {
+		username, _ = session.Get(pendingUsernameSessionKey).(string)
+		pending = true
+	}
+	if len(username) == 0 {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	user, err := data.GetUserByUsername(username)
+	if err != nil {
+		log.Printf("Unable to retrieve %s\n\t%v", username, err)
+		writeDataError(w, err)
+		return
+	}
+	// a pending user that's already enrolled must use the login step instead
+	if pending && user.HasTwoFactor() {
+		http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+		return
+	}
+
+	page := TwoFactorSettingsPage{
+		pageTitle: "Two-Factor Authentication",
+		userInfo:  userInfo,
+		CSRFToken: csrfToken(session),
+		Enabled:   user.HasTwoFactor(),
+		Required:  requiresTwoFactor(user),
+		Pending:   pending,
+	}
+
+	switch r.Method {
+	case "GET":
+	case "POST":
+		if !validCSRFToken(r, session) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.PostFormValue("action") {
+		case "enable":
+			secret, _ := session.Get(totpEnrollSecretSessionKey).(string)
+			recoveryCodes, err := data.EnableUserTwoFactor(username, secret, r.PostFormValue("code"))
+			if err == data.ErrInvalidTwoFactorCode {
+				page.Error = "Invalid code. Make sure the time on your device is correct."
+			} else if err != nil {
+				log.Printf("Unable to enable two-factor authentication for %s\n\t%v", username, err)
+				writeDataError(w, err)
+				return
+			} else {
+				session.Delete(totpEnrollSecretSessionKey)
+				if pending {
+					// enrollment completes the login
+					if session, err = sessionManager.RenewSession(w, session); err != nil {
+						log.Printf("Unable to renew the session of %s\n\t%v", username, err)
+						writeDataError(w, err)
+						return
+					}
+					session.Delete(pendingUsernameSessionKey)
+					session.Set("username", username)
+					loginAccountLimiter.Reset(data.LoginAttemptsKeyForUsername(username))
+					page.CSRFToken = csrfToken(session)
+				}
+				page.Enabled = true
+				page.RecoveryCodes = recoveryCodes
+			}
+		case "disable":
+			if pending || page.Required {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			if err := data.VerifyUserTwoFactor(username, r.PostFormValue("code")); err != nil {
+				page.Error = "Invalid code"
+				w.WriteHeader(http.StatusUnauthorized)
+				break
+			}
+			if err := data.DisableUserTwoFactor(username); err != nil {
+				log.Printf("Unable to disable two-factor authentication for %s\n\t%v", username, err)
+				writeDataError(w, err)
+				return
+			}
+			page.Enabled = false
+		case "regenerate":
+			if pending {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			if err := data.VerifyUserTwoFactor(username, r.PostFormValue("code")); err != nil {
+				page.Error = "Invalid code"
+				w.WriteHeader(http.StatusUnauthorized)
+				break
+			}
+			recoveryCodes, err := data.RegenerateUserRecoveryCodes(username)
+			if err != nil {
+				log.Printf("Unable to regenerate recovery codes for %s\n\t%v", username, err)
+				writeDataError(w, err)
+				return
+			}
+			page.RecoveryCodes = recoveryCodes
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	if page.Enabled {
+		page.RemainingCodes, _ = data.GetUserRecoveryCodeCount(username)
+	} else {
+		secret, _ := session.Get(totpEnrollSecretSessionKey).(string)
+		if len(secret) == 0 {
+			secret, err = totp.GenerateSecret()
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			session.Set(totpEnrollSecretSessionKey, secret)
+		}
+		page.Secret = secret
+		page.ProvisioningURI = totp.ProvisioningURI(totpIssuer, username, secret)
+	}
+
+	ShowTemplateInMainLayout(w, r, "twoFactorSettings", page)
+}